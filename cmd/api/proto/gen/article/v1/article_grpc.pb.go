@@ -19,13 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ArticleService_Save_FullMethodName             = "/article.v1.ArticleService/Save"
-	ArticleService_Publish_FullMethodName          = "/article.v1.ArticleService/Publish"
-	ArticleService_Withdraw_FullMethodName         = "/article.v1.ArticleService/Withdraw"
-	ArticleService_List_FullMethodName             = "/article.v1.ArticleService/List"
-	ArticleService_GetById_FullMethodName          = "/article.v1.ArticleService/GetById"
-	ArticleService_GetPublishedById_FullMethodName = "/article.v1.ArticleService/GetPublishedById"
-	ArticleService_ListPub_FullMethodName          = "/article.v1.ArticleService/ListPub"
+	ArticleService_Save_FullMethodName              = "/article.v1.ArticleService/Save"
+	ArticleService_Publish_FullMethodName           = "/article.v1.ArticleService/Publish"
+	ArticleService_Withdraw_FullMethodName          = "/article.v1.ArticleService/Withdraw"
+	ArticleService_CancelSchedule_FullMethodName    = "/article.v1.ArticleService/CancelSchedule"
+	ArticleService_Reschedule_FullMethodName        = "/article.v1.ArticleService/Reschedule"
+	ArticleService_List_FullMethodName              = "/article.v1.ArticleService/List"
+	ArticleService_GetById_FullMethodName           = "/article.v1.ArticleService/GetById"
+	ArticleService_GetPublishedById_FullMethodName  = "/article.v1.ArticleService/GetPublishedById"
+	ArticleService_BatchGetPublished_FullMethodName = "/article.v1.ArticleService/BatchGetPublished"
+	ArticleService_ListPub_FullMethodName           = "/article.v1.ArticleService/ListPub"
+	ArticleService_ListRevisions_FullMethodName     = "/article.v1.ArticleService/ListRevisions"
+	ArticleService_DiffRevisions_FullMethodName     = "/article.v1.ArticleService/DiffRevisions"
+	ArticleService_RestoreRevision_FullMethodName   = "/article.v1.ArticleService/RestoreRevision"
+	ArticleService_CreateSeries_FullMethodName      = "/article.v1.ArticleService/CreateSeries"
+	ArticleService_ReorderSeries_FullMethodName     = "/article.v1.ArticleService/ReorderSeries"
+	ArticleService_ListSeries_FullMethodName        = "/article.v1.ArticleService/ListSeries"
+	ArticleService_InviteCoAuthor_FullMethodName    = "/article.v1.ArticleService/InviteCoAuthor"
+	ArticleService_RespondInvitation_FullMethodName = "/article.v1.ArticleService/RespondInvitation"
+	ArticleService_RemoveCoAuthor_FullMethodName    = "/article.v1.ArticleService/RemoveCoAuthor"
+	ArticleService_ListCoAuthors_FullMethodName     = "/article.v1.ArticleService/ListCoAuthors"
+	ArticleService_ListInvitations_FullMethodName   = "/article.v1.ArticleService/ListInvitations"
+	ArticleService_ListUnderReview_FullMethodName   = "/article.v1.ArticleService/ListUnderReview"
+	ArticleService_Review_FullMethodName            = "/article.v1.ArticleService/Review"
+	ArticleService_Delete_FullMethodName            = "/article.v1.ArticleService/Delete"
+	ArticleService_RestoreDeleted_FullMethodName    = "/article.v1.ArticleService/RestoreDeleted"
+	ArticleService_ListDeleted_FullMethodName       = "/article.v1.ArticleService/ListDeleted"
 )
 
 // ArticleServiceClient is the client API for ArticleService service.
@@ -35,10 +54,35 @@ type ArticleServiceClient interface {
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error)
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
+	// 定时发表
+	CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleResponse, error)
+	Reschedule(ctx context.Context, in *RescheduleRequest, opts ...grpc.CallOption) (*RescheduleResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error)
 	GetPublishedById(ctx context.Context, in *GetPublishedByIdRequest, opts ...grpc.CallOption) (*GetPublishedByIdResponse, error)
+	// 批量查询已发表的帖子，给内部服务用，不会记录阅读
+	BatchGetPublished(ctx context.Context, in *BatchGetPublishedRequest, opts ...grpc.CallOption) (*BatchGetPublishedResponse, error)
 	ListPub(ctx context.Context, in *ListPubRequest, opts ...grpc.CallOption) (*ListPubResponse, error)
+	// 历史版本，只有作者本人可以操作
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error)
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error)
+	// 系列，只有作者本人可以修改
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error)
+	ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error)
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	InviteCoAuthor(ctx context.Context, in *InviteCoAuthorRequest, opts ...grpc.CallOption) (*InviteCoAuthorResponse, error)
+	RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*RespondInvitationResponse, error)
+	RemoveCoAuthor(ctx context.Context, in *RemoveCoAuthorRequest, opts ...grpc.CallOption) (*RemoveCoAuthorResponse, error)
+	ListCoAuthors(ctx context.Context, in *ListCoAuthorsRequest, opts ...grpc.CallOption) (*ListCoAuthorsResponse, error)
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	// 内容审核，给审核人员用
+	ListUnderReview(ctx context.Context, in *ListUnderReviewRequest, opts ...grpc.CallOption) (*ListUnderReviewResponse, error)
+	Review(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	// 回收站，只有创建帖子的人可以删除和恢复
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	RestoreDeleted(ctx context.Context, in *RestoreDeletedRequest, opts ...grpc.CallOption) (*RestoreDeletedResponse, error)
+	ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error)
 }
 
 type articleServiceClient struct {
@@ -76,6 +120,24 @@ func (c *articleServiceClient) Withdraw(ctx context.Context, in *WithdrawRequest
 	return out, nil
 }
 
+func (c *articleServiceClient) CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleResponse, error) {
+	out := new(CancelScheduleResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CancelSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Reschedule(ctx context.Context, in *RescheduleRequest, opts ...grpc.CallOption) (*RescheduleResponse, error) {
+	out := new(RescheduleResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Reschedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *articleServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
 	out := new(ListResponse)
 	err := c.cc.Invoke(ctx, ArticleService_List_FullMethodName, in, out, opts...)
@@ -103,6 +165,15 @@ func (c *articleServiceClient) GetPublishedById(ctx context.Context, in *GetPubl
 	return out, nil
 }
 
+func (c *articleServiceClient) BatchGetPublished(ctx context.Context, in *BatchGetPublishedRequest, opts ...grpc.CallOption) (*BatchGetPublishedResponse, error) {
+	out := new(BatchGetPublishedResponse)
+	err := c.cc.Invoke(ctx, ArticleService_BatchGetPublished_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *articleServiceClient) ListPub(ctx context.Context, in *ListPubRequest, opts ...grpc.CallOption) (*ListPubResponse, error) {
 	out := new(ListPubResponse)
 	err := c.cc.Invoke(ctx, ArticleService_ListPub_FullMethodName, in, out, opts...)
@@ -112,6 +183,150 @@ func (c *articleServiceClient) ListPub(ctx context.Context, in *ListPubRequest,
 	return out, nil
 }
 
+func (c *articleServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error) {
+	out := new(DiffRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_DiffRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error) {
+	out := new(RestoreRevisionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RestoreRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error) {
+	out := new(CreateSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CreateSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error) {
+	out := new(ReorderSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ReorderSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) InviteCoAuthor(ctx context.Context, in *InviteCoAuthorRequest, opts ...grpc.CallOption) (*InviteCoAuthorResponse, error) {
+	out := new(InviteCoAuthorResponse)
+	err := c.cc.Invoke(ctx, ArticleService_InviteCoAuthor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*RespondInvitationResponse, error) {
+	out := new(RespondInvitationResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RespondInvitation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RemoveCoAuthor(ctx context.Context, in *RemoveCoAuthorRequest, opts ...grpc.CallOption) (*RemoveCoAuthorResponse, error) {
+	out := new(RemoveCoAuthorResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RemoveCoAuthor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListCoAuthors(ctx context.Context, in *ListCoAuthorsRequest, opts ...grpc.CallOption) (*ListCoAuthorsResponse, error) {
+	out := new(ListCoAuthorsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListCoAuthors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListInvitations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListUnderReview(ctx context.Context, in *ListUnderReviewRequest, opts ...grpc.CallOption) (*ListUnderReviewResponse, error) {
+	out := new(ListUnderReviewResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListUnderReview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Review(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Review_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RestoreDeleted(ctx context.Context, in *RestoreDeletedRequest, opts ...grpc.CallOption) (*RestoreDeletedResponse, error) {
+	out := new(RestoreDeletedResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RestoreDeleted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error) {
+	out := new(ListDeletedResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListDeleted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ArticleServiceServer is the server API for ArticleService service.
 // All implementations must embed UnimplementedArticleServiceServer
 // for forward compatibility
@@ -119,10 +334,35 @@ type ArticleServiceServer interface {
 	Save(context.Context, *SaveRequest) (*SaveResponse, error)
 	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
 	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
+	// 定时发表
+	CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleResponse, error)
+	Reschedule(context.Context, *RescheduleRequest) (*RescheduleResponse, error)
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
 	GetPublishedById(context.Context, *GetPublishedByIdRequest) (*GetPublishedByIdResponse, error)
+	// 批量查询已发表的帖子，给内部服务用，不会记录阅读
+	BatchGetPublished(context.Context, *BatchGetPublishedRequest) (*BatchGetPublishedResponse, error)
 	ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error)
+	// 历史版本，只有作者本人可以操作
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error)
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error)
+	// 系列，只有作者本人可以修改
+	CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error)
+	ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error)
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	InviteCoAuthor(context.Context, *InviteCoAuthorRequest) (*InviteCoAuthorResponse, error)
+	RespondInvitation(context.Context, *RespondInvitationRequest) (*RespondInvitationResponse, error)
+	RemoveCoAuthor(context.Context, *RemoveCoAuthorRequest) (*RemoveCoAuthorResponse, error)
+	ListCoAuthors(context.Context, *ListCoAuthorsRequest) (*ListCoAuthorsResponse, error)
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	// 内容审核，给审核人员用
+	ListUnderReview(context.Context, *ListUnderReviewRequest) (*ListUnderReviewResponse, error)
+	Review(context.Context, *ReviewRequest) (*ReviewResponse, error)
+	// 回收站，只有创建帖子的人可以删除和恢复
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	RestoreDeleted(context.Context, *RestoreDeletedRequest) (*RestoreDeletedResponse, error)
+	ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error)
 	mustEmbedUnimplementedArticleServiceServer()
 }
 
@@ -139,6 +379,12 @@ func (UnimplementedArticleServiceServer) Publish(context.Context, *PublishReques
 func (UnimplementedArticleServiceServer) Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
 }
+func (UnimplementedArticleServiceServer) CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedArticleServiceServer) Reschedule(context.Context, *RescheduleRequest) (*RescheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reschedule not implemented")
+}
 func (UnimplementedArticleServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
@@ -148,9 +394,60 @@ func (UnimplementedArticleServiceServer) GetById(context.Context, *GetByIdReques
 func (UnimplementedArticleServiceServer) GetPublishedById(context.Context, *GetPublishedByIdRequest) (*GetPublishedByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPublishedById not implemented")
 }
+func (UnimplementedArticleServiceServer) BatchGetPublished(context.Context, *BatchGetPublishedRequest) (*BatchGetPublishedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetPublished not implemented")
+}
 func (UnimplementedArticleServiceServer) ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPub not implemented")
 }
+func (UnimplementedArticleServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedArticleServiceServer) CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) InviteCoAuthor(context.Context, *InviteCoAuthorRequest) (*InviteCoAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteCoAuthor not implemented")
+}
+func (UnimplementedArticleServiceServer) RespondInvitation(context.Context, *RespondInvitationRequest) (*RespondInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondInvitation not implemented")
+}
+func (UnimplementedArticleServiceServer) RemoveCoAuthor(context.Context, *RemoveCoAuthorRequest) (*RemoveCoAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCoAuthor not implemented")
+}
+func (UnimplementedArticleServiceServer) ListCoAuthors(context.Context, *ListCoAuthorsRequest) (*ListCoAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCoAuthors not implemented")
+}
+func (UnimplementedArticleServiceServer) ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedArticleServiceServer) ListUnderReview(context.Context, *ListUnderReviewRequest) (*ListUnderReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnderReview not implemented")
+}
+func (UnimplementedArticleServiceServer) Review(context.Context, *ReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Review not implemented")
+}
+func (UnimplementedArticleServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedArticleServiceServer) RestoreDeleted(context.Context, *RestoreDeletedRequest) (*RestoreDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDeleted not implemented")
+}
+func (UnimplementedArticleServiceServer) ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
 func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
 
 // UnsafeArticleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -218,6 +515,42 @@ func _ArticleService_Withdraw_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CancelSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CancelSchedule(ctx, req.(*CancelScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Reschedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Reschedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Reschedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Reschedule(ctx, req.(*RescheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArticleService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRequest)
 	if err := dec(in); err != nil {
@@ -272,6 +605,24 @@ func _ArticleService_GetPublishedById_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_BatchGetPublished_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPublishedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).BatchGetPublished(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_BatchGetPublished_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).BatchGetPublished(ctx, req.(*BatchGetPublishedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPubRequest)
 	if err := dec(in); err != nil {
@@ -290,40 +641,935 @@ func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-// ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var ArticleService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "article.v1.ArticleService",
-	HandlerType: (*ArticleServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Save",
-			Handler:    _ArticleService_Save_Handler,
-		},
-		{
-			MethodName: "Publish",
-			Handler:    _ArticleService_Publish_Handler,
-		},
-		{
-			MethodName: "Withdraw",
-			Handler:    _ArticleService_Withdraw_Handler,
-		},
-		{
-			MethodName: "List",
-			Handler:    _ArticleService_List_Handler,
-		},
-		{
-			MethodName: "GetById",
-			Handler:    _ArticleService_GetById_Handler,
-		},
-		{
-			MethodName: "GetPublishedById",
-			Handler:    _ArticleService_GetPublishedById_Handler,
-		},
-		{
-			MethodName: "ListPub",
-			Handler:    _ArticleService_ListPub_Handler,
+func _ArticleService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_DiffRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_DiffRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, req.(*DiffRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CreateSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ReorderSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ReorderSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, req.(*ReorderSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_InviteCoAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteCoAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).InviteCoAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_InviteCoAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).InviteCoAuthor(ctx, req.(*InviteCoAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RespondInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RespondInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RespondInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RespondInvitation(ctx, req.(*RespondInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RemoveCoAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCoAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RemoveCoAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RemoveCoAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RemoveCoAuthor(ctx, req.(*RemoveCoAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListCoAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCoAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListCoAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListCoAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListCoAuthors(ctx, req.(*ListCoAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListUnderReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnderReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListUnderReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListUnderReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListUnderReview(ctx, req.(*ListUnderReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Review_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Review(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Review_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Review(ctx, req.(*ReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RestoreDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RestoreDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RestoreDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RestoreDeleted(ctx, req.(*RestoreDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListDeleted(ctx, req.(*ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArticleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.ArticleService",
+	HandlerType: (*ArticleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Save",
+			Handler:    _ArticleService_Save_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _ArticleService_Publish_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _ArticleService_Withdraw_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _ArticleService_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "Reschedule",
+			Handler:    _ArticleService_Reschedule_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ArticleService_List_Handler,
+		},
+		{
+			MethodName: "GetById",
+			Handler:    _ArticleService_GetById_Handler,
+		},
+		{
+			MethodName: "GetPublishedById",
+			Handler:    _ArticleService_GetPublishedById_Handler,
+		},
+		{
+			MethodName: "BatchGetPublished",
+			Handler:    _ArticleService_BatchGetPublished_Handler,
+		},
+		{
+			MethodName: "ListPub",
+			Handler:    _ArticleService_ListPub_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _ArticleService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "DiffRevisions",
+			Handler:    _ArticleService_DiffRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _ArticleService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _ArticleService_CreateSeries_Handler,
+		},
+		{
+			MethodName: "ReorderSeries",
+			Handler:    _ArticleService_ReorderSeries_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _ArticleService_ListSeries_Handler,
+		},
+		{
+			MethodName: "InviteCoAuthor",
+			Handler:    _ArticleService_InviteCoAuthor_Handler,
+		},
+		{
+			MethodName: "RespondInvitation",
+			Handler:    _ArticleService_RespondInvitation_Handler,
+		},
+		{
+			MethodName: "RemoveCoAuthor",
+			Handler:    _ArticleService_RemoveCoAuthor_Handler,
+		},
+		{
+			MethodName: "ListCoAuthors",
+			Handler:    _ArticleService_ListCoAuthors_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _ArticleService_ListInvitations_Handler,
+		},
+		{
+			MethodName: "ListUnderReview",
+			Handler:    _ArticleService_ListUnderReview_Handler,
+		},
+		{
+			MethodName: "Review",
+			Handler:    _ArticleService_Review_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ArticleService_Delete_Handler,
+		},
+		{
+			MethodName: "RestoreDeleted",
+			Handler:    _ArticleService_RestoreDeleted_Handler,
+		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _ArticleService_ListDeleted_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "article/v1/article.proto",
+}
+
+const (
+	AttachmentService_PrepareUpload_FullMethodName   = "/article.v1.AttachmentService/PrepareUpload"
+	AttachmentService_ConfirmUpload_FullMethodName   = "/article.v1.AttachmentService/ConfirmUpload"
+	AttachmentService_Attach_FullMethodName          = "/article.v1.AttachmentService/Attach"
+	AttachmentService_ListAttachments_FullMethodName = "/article.v1.AttachmentService/ListAttachments"
+)
+
+// AttachmentServiceClient is the client API for AttachmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AttachmentServiceClient interface {
+	PrepareUpload(ctx context.Context, in *PrepareUploadRequest, opts ...grpc.CallOption) (*PrepareUploadResponse, error)
+	ConfirmUpload(ctx context.Context, in *ConfirmUploadRequest, opts ...grpc.CallOption) (*ConfirmUploadResponse, error)
+	Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error)
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+}
+
+type attachmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttachmentServiceClient(cc grpc.ClientConnInterface) AttachmentServiceClient {
+	return &attachmentServiceClient{cc}
+}
+
+func (c *attachmentServiceClient) PrepareUpload(ctx context.Context, in *PrepareUploadRequest, opts ...grpc.CallOption) (*PrepareUploadResponse, error) {
+	out := new(PrepareUploadResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_PrepareUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) ConfirmUpload(ctx context.Context, in *ConfirmUploadRequest, opts ...grpc.CallOption) (*ConfirmUploadResponse, error) {
+	out := new(ConfirmUploadResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_ConfirmUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error) {
+	out := new(AttachResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_Attach_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_ListAttachments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AttachmentServiceServer is the server API for AttachmentService service.
+// All implementations must embed UnimplementedAttachmentServiceServer
+// for forward compatibility
+type AttachmentServiceServer interface {
+	PrepareUpload(context.Context, *PrepareUploadRequest) (*PrepareUploadResponse, error)
+	ConfirmUpload(context.Context, *ConfirmUploadRequest) (*ConfirmUploadResponse, error)
+	Attach(context.Context, *AttachRequest) (*AttachResponse, error)
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	mustEmbedUnimplementedAttachmentServiceServer()
+}
+
+// UnimplementedAttachmentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAttachmentServiceServer struct {
+}
+
+func (UnimplementedAttachmentServiceServer) PrepareUpload(context.Context, *PrepareUploadRequest) (*PrepareUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareUpload not implemented")
+}
+func (UnimplementedAttachmentServiceServer) ConfirmUpload(context.Context, *ConfirmUploadRequest) (*ConfirmUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmUpload not implemented")
+}
+func (UnimplementedAttachmentServiceServer) Attach(context.Context, *AttachRequest) (*AttachResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedAttachmentServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {}
+
+// UnsafeAttachmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttachmentServiceServer will
+// result in compilation errors.
+type UnsafeAttachmentServiceServer interface {
+	mustEmbedUnimplementedAttachmentServiceServer()
+}
+
+func RegisterAttachmentServiceServer(s grpc.ServiceRegistrar, srv AttachmentServiceServer) {
+	s.RegisterService(&AttachmentService_ServiceDesc, srv)
+}
+
+func _AttachmentService_PrepareUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).PrepareUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_PrepareUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).PrepareUpload(ctx, req.(*PrepareUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_ConfirmUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).ConfirmUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_ConfirmUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).ConfirmUpload(ctx, req.(*ConfirmUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_Attach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).Attach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_Attach_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).Attach(ctx, req.(*AttachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AttachmentService_ServiceDesc is the grpc.ServiceDesc for AttachmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AttachmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.AttachmentService",
+	HandlerType: (*AttachmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PrepareUpload",
+			Handler:    _AttachmentService_PrepareUpload_Handler,
+		},
+		{
+			MethodName: "ConfirmUpload",
+			Handler:    _AttachmentService_ConfirmUpload_Handler,
+		},
+		{
+			MethodName: "Attach",
+			Handler:    _AttachmentService_Attach_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _AttachmentService_ListAttachments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "article/v1/article.proto",
+}
+
+const (
+	CitationService_SetCitations_FullMethodName  = "/article.v1.CitationService/SetCitations"
+	CitationService_ListCitations_FullMethodName = "/article.v1.CitationService/ListCitations"
+	CitationService_ListCitedBy_FullMethodName   = "/article.v1.CitationService/ListCitedBy"
+)
+
+// CitationServiceClient is the client API for CitationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CitationServiceClient interface {
+	SetCitations(ctx context.Context, in *SetCitationsRequest, opts ...grpc.CallOption) (*SetCitationsResponse, error)
+	ListCitations(ctx context.Context, in *ListCitationsRequest, opts ...grpc.CallOption) (*ListCitationsResponse, error)
+	ListCitedBy(ctx context.Context, in *ListCitedByRequest, opts ...grpc.CallOption) (*ListCitedByResponse, error)
+}
+
+type citationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCitationServiceClient(cc grpc.ClientConnInterface) CitationServiceClient {
+	return &citationServiceClient{cc}
+}
+
+func (c *citationServiceClient) SetCitations(ctx context.Context, in *SetCitationsRequest, opts ...grpc.CallOption) (*SetCitationsResponse, error) {
+	out := new(SetCitationsResponse)
+	err := c.cc.Invoke(ctx, CitationService_SetCitations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *citationServiceClient) ListCitations(ctx context.Context, in *ListCitationsRequest, opts ...grpc.CallOption) (*ListCitationsResponse, error) {
+	out := new(ListCitationsResponse)
+	err := c.cc.Invoke(ctx, CitationService_ListCitations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *citationServiceClient) ListCitedBy(ctx context.Context, in *ListCitedByRequest, opts ...grpc.CallOption) (*ListCitedByResponse, error) {
+	out := new(ListCitedByResponse)
+	err := c.cc.Invoke(ctx, CitationService_ListCitedBy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CitationServiceServer is the server API for CitationService service.
+// All implementations must embed UnimplementedCitationServiceServer
+// for forward compatibility
+type CitationServiceServer interface {
+	SetCitations(context.Context, *SetCitationsRequest) (*SetCitationsResponse, error)
+	ListCitations(context.Context, *ListCitationsRequest) (*ListCitationsResponse, error)
+	ListCitedBy(context.Context, *ListCitedByRequest) (*ListCitedByResponse, error)
+	mustEmbedUnimplementedCitationServiceServer()
+}
+
+// UnimplementedCitationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCitationServiceServer struct {
+}
+
+func (UnimplementedCitationServiceServer) SetCitations(context.Context, *SetCitationsRequest) (*SetCitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCitations not implemented")
+}
+func (UnimplementedCitationServiceServer) ListCitations(context.Context, *ListCitationsRequest) (*ListCitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCitations not implemented")
+}
+func (UnimplementedCitationServiceServer) ListCitedBy(context.Context, *ListCitedByRequest) (*ListCitedByResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCitedBy not implemented")
+}
+func (UnimplementedCitationServiceServer) mustEmbedUnimplementedCitationServiceServer() {}
+
+// UnsafeCitationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CitationServiceServer will
+// result in compilation errors.
+type UnsafeCitationServiceServer interface {
+	mustEmbedUnimplementedCitationServiceServer()
+}
+
+func RegisterCitationServiceServer(s grpc.ServiceRegistrar, srv CitationServiceServer) {
+	s.RegisterService(&CitationService_ServiceDesc, srv)
+}
+
+func _CitationService_SetCitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CitationServiceServer).SetCitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CitationService_SetCitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CitationServiceServer).SetCitations(ctx, req.(*SetCitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CitationService_ListCitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CitationServiceServer).ListCitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CitationService_ListCitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CitationServiceServer).ListCitations(ctx, req.(*ListCitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CitationService_ListCitedBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCitedByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CitationServiceServer).ListCitedBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CitationService_ListCitedBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CitationServiceServer).ListCitedBy(ctx, req.(*ListCitedByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CitationService_ServiceDesc is the grpc.ServiceDesc for CitationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CitationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.CitationService",
+	HandlerType: (*CitationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetCitations",
+			Handler:    _CitationService_SetCitations_Handler,
+		},
+		{
+			MethodName: "ListCitations",
+			Handler:    _CitationService_ListCitations_Handler,
+		},
+		{
+			MethodName: "ListCitedBy",
+			Handler:    _CitationService_ListCitedBy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "article/v1/article.proto",
+}
+
+const (
+	VisibilityService_SetVisibility_FullMethodName  = "/article.v1.VisibilityService/SetVisibility"
+	VisibilityService_GetVisibility_FullMethodName  = "/article.v1.VisibilityService/GetVisibility"
+	VisibilityService_FilterAudience_FullMethodName = "/article.v1.VisibilityService/FilterAudience"
+)
+
+// VisibilityServiceClient is the client API for VisibilityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VisibilityServiceClient interface {
+	SetVisibility(ctx context.Context, in *SetVisibilityRequest, opts ...grpc.CallOption) (*SetVisibilityResponse, error)
+	GetVisibility(ctx context.Context, in *GetVisibilityRequest, opts ...grpc.CallOption) (*GetVisibilityResponse, error)
+	// 给 feed 这种内部调用方用，从 uids 里面挑出能看这篇帖子的人
+	FilterAudience(ctx context.Context, in *FilterAudienceRequest, opts ...grpc.CallOption) (*FilterAudienceResponse, error)
+}
+
+type visibilityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVisibilityServiceClient(cc grpc.ClientConnInterface) VisibilityServiceClient {
+	return &visibilityServiceClient{cc}
+}
+
+func (c *visibilityServiceClient) SetVisibility(ctx context.Context, in *SetVisibilityRequest, opts ...grpc.CallOption) (*SetVisibilityResponse, error) {
+	out := new(SetVisibilityResponse)
+	err := c.cc.Invoke(ctx, VisibilityService_SetVisibility_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visibilityServiceClient) GetVisibility(ctx context.Context, in *GetVisibilityRequest, opts ...grpc.CallOption) (*GetVisibilityResponse, error) {
+	out := new(GetVisibilityResponse)
+	err := c.cc.Invoke(ctx, VisibilityService_GetVisibility_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visibilityServiceClient) FilterAudience(ctx context.Context, in *FilterAudienceRequest, opts ...grpc.CallOption) (*FilterAudienceResponse, error) {
+	out := new(FilterAudienceResponse)
+	err := c.cc.Invoke(ctx, VisibilityService_FilterAudience_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VisibilityServiceServer is the server API for VisibilityService service.
+// All implementations must embed UnimplementedVisibilityServiceServer
+// for forward compatibility
+type VisibilityServiceServer interface {
+	SetVisibility(context.Context, *SetVisibilityRequest) (*SetVisibilityResponse, error)
+	GetVisibility(context.Context, *GetVisibilityRequest) (*GetVisibilityResponse, error)
+	// 给 feed 这种内部调用方用，从 uids 里面挑出能看这篇帖子的人
+	FilterAudience(context.Context, *FilterAudienceRequest) (*FilterAudienceResponse, error)
+	mustEmbedUnimplementedVisibilityServiceServer()
+}
+
+// UnimplementedVisibilityServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVisibilityServiceServer struct {
+}
+
+func (UnimplementedVisibilityServiceServer) SetVisibility(context.Context, *SetVisibilityRequest) (*SetVisibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVisibility not implemented")
+}
+func (UnimplementedVisibilityServiceServer) GetVisibility(context.Context, *GetVisibilityRequest) (*GetVisibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVisibility not implemented")
+}
+func (UnimplementedVisibilityServiceServer) FilterAudience(context.Context, *FilterAudienceRequest) (*FilterAudienceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilterAudience not implemented")
+}
+func (UnimplementedVisibilityServiceServer) mustEmbedUnimplementedVisibilityServiceServer() {}
+
+// UnsafeVisibilityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VisibilityServiceServer will
+// result in compilation errors.
+type UnsafeVisibilityServiceServer interface {
+	mustEmbedUnimplementedVisibilityServiceServer()
+}
+
+func RegisterVisibilityServiceServer(s grpc.ServiceRegistrar, srv VisibilityServiceServer) {
+	s.RegisterService(&VisibilityService_ServiceDesc, srv)
+}
+
+func _VisibilityService_SetVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisibilityServiceServer).SetVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisibilityService_SetVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisibilityServiceServer).SetVisibility(ctx, req.(*SetVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisibilityService_GetVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisibilityServiceServer).GetVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisibilityService_GetVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisibilityServiceServer).GetVisibility(ctx, req.(*GetVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisibilityService_FilterAudience_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterAudienceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisibilityServiceServer).FilterAudience(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisibilityService_FilterAudience_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisibilityServiceServer).FilterAudience(ctx, req.(*FilterAudienceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VisibilityService_ServiceDesc is the grpc.ServiceDesc for VisibilityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VisibilityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.VisibilityService",
+	HandlerType: (*VisibilityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetVisibility",
+			Handler:    _VisibilityService_SetVisibility_Handler,
+		},
+		{
+			MethodName: "GetVisibility",
+			Handler:    _VisibilityService_GetVisibility_Handler,
+		},
+		{
+			MethodName: "FilterAudience",
+			Handler:    _VisibilityService_FilterAudience_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
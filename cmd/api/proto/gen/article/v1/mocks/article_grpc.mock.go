@@ -40,6 +40,106 @@ func (m *MockArticleServiceClient) EXPECT() *MockArticleServiceClientMockRecorde
 	return m.recorder
 }
 
+// BatchGetPublished mocks base method.
+func (m *MockArticleServiceClient) BatchGetPublished(ctx context.Context, in *articlev1.BatchGetPublishedRequest, opts ...grpc.CallOption) (*articlev1.BatchGetPublishedResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchGetPublished", varargs...)
+	ret0, _ := ret[0].(*articlev1.BatchGetPublishedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetPublished indicates an expected call of BatchGetPublished.
+func (mr *MockArticleServiceClientMockRecorder) BatchGetPublished(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetPublished", reflect.TypeOf((*MockArticleServiceClient)(nil).BatchGetPublished), varargs...)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleServiceClient) CancelSchedule(ctx context.Context, in *articlev1.CancelScheduleRequest, opts ...grpc.CallOption) (*articlev1.CancelScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelSchedule", varargs...)
+	ret0, _ := ret[0].(*articlev1.CancelScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceClientMockRecorder) CancelSchedule(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleServiceClient)(nil).CancelSchedule), varargs...)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleServiceClient) CreateSeries(ctx context.Context, in *articlev1.CreateSeriesRequest, opts ...grpc.CallOption) (*articlev1.CreateSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSeries", varargs...)
+	ret0, _ := ret[0].(*articlev1.CreateSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceClientMockRecorder) CreateSeries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).CreateSeries), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockArticleServiceClient) Delete(ctx context.Context, in *articlev1.DeleteRequest, opts ...grpc.CallOption) (*articlev1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*articlev1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceClientMockRecorder) Delete(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleServiceClient)(nil).Delete), varargs...)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleServiceClient) DiffRevisions(ctx context.Context, in *articlev1.DiffRevisionsRequest, opts ...grpc.CallOption) (*articlev1.DiffRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DiffRevisions", varargs...)
+	ret0, _ := ret[0].(*articlev1.DiffRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceClientMockRecorder) DiffRevisions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleServiceClient)(nil).DiffRevisions), varargs...)
+}
+
 // GetById mocks base method.
 func (m *MockArticleServiceClient) GetById(ctx context.Context, in *articlev1.GetByIdRequest, opts ...grpc.CallOption) (*articlev1.GetByIdResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +180,26 @@ func (mr *MockArticleServiceClientMockRecorder) GetPublishedById(ctx, in any, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleServiceClient)(nil).GetPublishedById), varargs...)
 }
 
+// InviteCoAuthor mocks base method.
+func (m *MockArticleServiceClient) InviteCoAuthor(ctx context.Context, in *articlev1.InviteCoAuthorRequest, opts ...grpc.CallOption) (*articlev1.InviteCoAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InviteCoAuthor", varargs...)
+	ret0, _ := ret[0].(*articlev1.InviteCoAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteCoAuthor indicates an expected call of InviteCoAuthor.
+func (mr *MockArticleServiceClientMockRecorder) InviteCoAuthor(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteCoAuthor", reflect.TypeOf((*MockArticleServiceClient)(nil).InviteCoAuthor), varargs...)
+}
+
 // List mocks base method.
 func (m *MockArticleServiceClient) List(ctx context.Context, in *articlev1.ListRequest, opts ...grpc.CallOption) (*articlev1.ListResponse, error) {
 	m.ctrl.T.Helper()
@@ -100,230 +220,775 @@ func (mr *MockArticleServiceClientMockRecorder) List(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleServiceClient)(nil).List), varargs...)
 }
 
-// ListPub mocks base method.
-func (m *MockArticleServiceClient) ListPub(ctx context.Context, in *articlev1.ListPubRequest, opts ...grpc.CallOption) (*articlev1.ListPubResponse, error) {
+// ListCoAuthors mocks base method.
+func (m *MockArticleServiceClient) ListCoAuthors(ctx context.Context, in *articlev1.ListCoAuthorsRequest, opts ...grpc.CallOption) (*articlev1.ListCoAuthorsResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "ListPub", varargs...)
-	ret0, _ := ret[0].(*articlev1.ListPubResponse)
+	ret := m.ctrl.Call(m, "ListCoAuthors", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListCoAuthorsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPub indicates an expected call of ListPub.
-func (mr *MockArticleServiceClientMockRecorder) ListPub(ctx, in any, opts ...any) *gomock.Call {
+// ListCoAuthors indicates an expected call of ListCoAuthors.
+func (mr *MockArticleServiceClientMockRecorder) ListCoAuthors(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPub), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthors", reflect.TypeOf((*MockArticleServiceClient)(nil).ListCoAuthors), varargs...)
 }
 
-// Publish mocks base method.
-func (m *MockArticleServiceClient) Publish(ctx context.Context, in *articlev1.PublishRequest, opts ...grpc.CallOption) (*articlev1.PublishResponse, error) {
+// ListDeleted mocks base method.
+func (m *MockArticleServiceClient) ListDeleted(ctx context.Context, in *articlev1.ListDeletedRequest, opts ...grpc.CallOption) (*articlev1.ListDeletedResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Publish", varargs...)
-	ret0, _ := ret[0].(*articlev1.PublishResponse)
+	ret := m.ctrl.Call(m, "ListDeleted", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListDeletedResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Publish indicates an expected call of Publish.
-func (mr *MockArticleServiceClientMockRecorder) Publish(ctx, in any, opts ...any) *gomock.Call {
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleServiceClientMockRecorder) ListDeleted(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceClient)(nil).Publish), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleServiceClient)(nil).ListDeleted), varargs...)
 }
 
-// Save mocks base method.
-func (m *MockArticleServiceClient) Save(ctx context.Context, in *articlev1.SaveRequest, opts ...grpc.CallOption) (*articlev1.SaveResponse, error) {
+// ListInvitations mocks base method.
+func (m *MockArticleServiceClient) ListInvitations(ctx context.Context, in *articlev1.ListInvitationsRequest, opts ...grpc.CallOption) (*articlev1.ListInvitationsResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Save", varargs...)
-	ret0, _ := ret[0].(*articlev1.SaveResponse)
+	ret := m.ctrl.Call(m, "ListInvitations", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListInvitationsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Save indicates an expected call of Save.
-func (mr *MockArticleServiceClientMockRecorder) Save(ctx, in any, opts ...any) *gomock.Call {
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockArticleServiceClientMockRecorder) ListInvitations(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceClient)(nil).Save), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockArticleServiceClient)(nil).ListInvitations), varargs...)
 }
 
-// Withdraw mocks base method.
-func (m *MockArticleServiceClient) Withdraw(ctx context.Context, in *articlev1.WithdrawRequest, opts ...grpc.CallOption) (*articlev1.WithdrawResponse, error) {
+// ListPub mocks base method.
+func (m *MockArticleServiceClient) ListPub(ctx context.Context, in *articlev1.ListPubRequest, opts ...grpc.CallOption) (*articlev1.ListPubResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Withdraw", varargs...)
-	ret0, _ := ret[0].(*articlev1.WithdrawResponse)
+	ret := m.ctrl.Call(m, "ListPub", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListPubResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Withdraw indicates an expected call of Withdraw.
-func (mr *MockArticleServiceClientMockRecorder) Withdraw(ctx, in any, opts ...any) *gomock.Call {
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleServiceClientMockRecorder) ListPub(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleServiceClient)(nil).Withdraw), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPub), varargs...)
 }
 
-// MockArticleServiceServer is a mock of ArticleServiceServer interface.
-type MockArticleServiceServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockArticleServiceServerMockRecorder
+// ListRevisions mocks base method.
+func (m *MockArticleServiceClient) ListRevisions(ctx context.Context, in *articlev1.ListRevisionsRequest, opts ...grpc.CallOption) (*articlev1.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRevisions", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockArticleServiceServerMockRecorder is the mock recorder for MockArticleServiceServer.
-type MockArticleServiceServerMockRecorder struct {
-	mock *MockArticleServiceServer
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceClientMockRecorder) ListRevisions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleServiceClient)(nil).ListRevisions), varargs...)
 }
 
-// NewMockArticleServiceServer creates a new mock instance.
-func NewMockArticleServiceServer(ctrl *gomock.Controller) *MockArticleServiceServer {
-	mock := &MockArticleServiceServer{ctrl: ctrl}
-	mock.recorder = &MockArticleServiceServerMockRecorder{mock}
-	return mock
+// ListSeries mocks base method.
+func (m *MockArticleServiceClient) ListSeries(ctx context.Context, in *articlev1.ListSeriesRequest, opts ...grpc.CallOption) (*articlev1.ListSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSeries", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockArticleServiceServer) EXPECT() *MockArticleServiceServerMockRecorder {
-	return m.recorder
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceClientMockRecorder) ListSeries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).ListSeries), varargs...)
 }
 
-// GetById mocks base method.
-func (m *MockArticleServiceServer) GetById(arg0 context.Context, arg1 *articlev1.GetByIdRequest) (*articlev1.GetByIdResponse, error) {
+// ListUnderReview mocks base method.
+func (m *MockArticleServiceClient) ListUnderReview(ctx context.Context, in *articlev1.ListUnderReviewRequest, opts ...grpc.CallOption) (*articlev1.ListUnderReviewResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.GetByIdResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUnderReview", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListUnderReviewResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockArticleServiceServerMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+// ListUnderReview indicates an expected call of ListUnderReview.
+func (mr *MockArticleServiceClientMockRecorder) ListUnderReview(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetById), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnderReview", reflect.TypeOf((*MockArticleServiceClient)(nil).ListUnderReview), varargs...)
 }
 
-// GetPublishedById mocks base method.
-func (m *MockArticleServiceServer) GetPublishedById(arg0 context.Context, arg1 *articlev1.GetPublishedByIdRequest) (*articlev1.GetPublishedByIdResponse, error) {
+// Publish mocks base method.
+func (m *MockArticleServiceClient) Publish(ctx context.Context, in *articlev1.PublishRequest, opts ...grpc.CallOption) (*articlev1.PublishResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPublishedById", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.GetPublishedByIdResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(*articlev1.PublishResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPublishedById indicates an expected call of GetPublishedById.
-func (mr *MockArticleServiceServerMockRecorder) GetPublishedById(arg0, arg1 any) *gomock.Call {
+// Publish indicates an expected call of Publish.
+func (mr *MockArticleServiceClientMockRecorder) Publish(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetPublishedById), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceClient)(nil).Publish), varargs...)
 }
 
-// List mocks base method.
-func (m *MockArticleServiceServer) List(arg0 context.Context, arg1 *articlev1.ListRequest) (*articlev1.ListResponse, error) {
+// RemoveCoAuthor mocks base method.
+func (m *MockArticleServiceClient) RemoveCoAuthor(ctx context.Context, in *articlev1.RemoveCoAuthorRequest, opts ...grpc.CallOption) (*articlev1.RemoveCoAuthorResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.ListResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveCoAuthor", varargs...)
+	ret0, _ := ret[0].(*articlev1.RemoveCoAuthorResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockArticleServiceServerMockRecorder) List(arg0, arg1 any) *gomock.Call {
+// RemoveCoAuthor indicates an expected call of RemoveCoAuthor.
+func (mr *MockArticleServiceClientMockRecorder) RemoveCoAuthor(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleServiceServer)(nil).List), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoAuthor", reflect.TypeOf((*MockArticleServiceClient)(nil).RemoveCoAuthor), varargs...)
 }
 
-// ListPub mocks base method.
-func (m *MockArticleServiceServer) ListPub(arg0 context.Context, arg1 *articlev1.ListPubRequest) (*articlev1.ListPubResponse, error) {
+// ReorderSeries mocks base method.
+func (m *MockArticleServiceClient) ReorderSeries(ctx context.Context, in *articlev1.ReorderSeriesRequest, opts ...grpc.CallOption) (*articlev1.ReorderSeriesResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPub", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.ListPubResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReorderSeries", varargs...)
+	ret0, _ := ret[0].(*articlev1.ReorderSeriesResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPub indicates an expected call of ListPub.
-func (mr *MockArticleServiceServerMockRecorder) ListPub(arg0, arg1 any) *gomock.Call {
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceClientMockRecorder) ReorderSeries(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPub), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).ReorderSeries), varargs...)
 }
 
-// Publish mocks base method.
-func (m *MockArticleServiceServer) Publish(arg0 context.Context, arg1 *articlev1.PublishRequest) (*articlev1.PublishResponse, error) {
+// Reschedule mocks base method.
+func (m *MockArticleServiceClient) Reschedule(ctx context.Context, in *articlev1.RescheduleRequest, opts ...grpc.CallOption) (*articlev1.RescheduleResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.PublishResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Reschedule", varargs...)
+	ret0, _ := ret[0].(*articlev1.RescheduleResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Publish indicates an expected call of Publish.
-func (mr *MockArticleServiceServerMockRecorder) Publish(arg0, arg1 any) *gomock.Call {
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockArticleServiceClientMockRecorder) Reschedule(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceServer)(nil).Publish), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockArticleServiceClient)(nil).Reschedule), varargs...)
 }
 
-// Save mocks base method.
-func (m *MockArticleServiceServer) Save(arg0 context.Context, arg1 *articlev1.SaveRequest) (*articlev1.SaveResponse, error) {
+// RespondInvitation mocks base method.
+func (m *MockArticleServiceClient) RespondInvitation(ctx context.Context, in *articlev1.RespondInvitationRequest, opts ...grpc.CallOption) (*articlev1.RespondInvitationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.SaveResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RespondInvitation", varargs...)
+	ret0, _ := ret[0].(*articlev1.RespondInvitationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Save indicates an expected call of Save.
-func (mr *MockArticleServiceServerMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+// RespondInvitation indicates an expected call of RespondInvitation.
+func (mr *MockArticleServiceClientMockRecorder) RespondInvitation(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceServer)(nil).Save), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondInvitation", reflect.TypeOf((*MockArticleServiceClient)(nil).RespondInvitation), varargs...)
 }
 
-// Withdraw mocks base method.
-func (m *MockArticleServiceServer) Withdraw(arg0 context.Context, arg1 *articlev1.WithdrawRequest) (*articlev1.WithdrawResponse, error) {
+// RestoreDeleted mocks base method.
+func (m *MockArticleServiceClient) RestoreDeleted(ctx context.Context, in *articlev1.RestoreDeletedRequest, opts ...grpc.CallOption) (*articlev1.RestoreDeletedResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Withdraw", arg0, arg1)
-	ret0, _ := ret[0].(*articlev1.WithdrawResponse)
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreDeleted", varargs...)
+	ret0, _ := ret[0].(*articlev1.RestoreDeletedResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Withdraw indicates an expected call of Withdraw.
-func (mr *MockArticleServiceServerMockRecorder) Withdraw(arg0, arg1 any) *gomock.Call {
+// RestoreDeleted indicates an expected call of RestoreDeleted.
+func (mr *MockArticleServiceClientMockRecorder) RestoreDeleted(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleServiceServer)(nil).Withdraw), arg0, arg1)
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeleted", reflect.TypeOf((*MockArticleServiceClient)(nil).RestoreDeleted), varargs...)
 }
 
-// mustEmbedUnimplementedArticleServiceServer mocks base method.
-func (m *MockArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+// RestoreRevision mocks base method.
+func (m *MockArticleServiceClient) RestoreRevision(ctx context.Context, in *articlev1.RestoreRevisionRequest, opts ...grpc.CallOption) (*articlev1.RestoreRevisionResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreRevision", varargs...)
+	ret0, _ := ret[0].(*articlev1.RestoreRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
-func (mr *MockArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceClientMockRecorder) RestoreRevision(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
-}
-
-// MockUnsafeArticleServiceServer is a mock of UnsafeArticleServiceServer interface.
-type MockUnsafeArticleServiceServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockUnsafeArticleServiceServerMockRecorder
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleServiceClient)(nil).RestoreRevision), varargs...)
+}
+
+// Review mocks base method.
+func (m *MockArticleServiceClient) Review(ctx context.Context, in *articlev1.ReviewRequest, opts ...grpc.CallOption) (*articlev1.ReviewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Review", varargs...)
+	ret0, _ := ret[0].(*articlev1.ReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockArticleServiceClientMockRecorder) Review(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockArticleServiceClient)(nil).Review), varargs...)
+}
+
+// Save mocks base method.
+func (m *MockArticleServiceClient) Save(ctx context.Context, in *articlev1.SaveRequest, opts ...grpc.CallOption) (*articlev1.SaveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Save", varargs...)
+	ret0, _ := ret[0].(*articlev1.SaveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleServiceClientMockRecorder) Save(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceClient)(nil).Save), varargs...)
+}
+
+// Withdraw mocks base method.
+func (m *MockArticleServiceClient) Withdraw(ctx context.Context, in *articlev1.WithdrawRequest, opts ...grpc.CallOption) (*articlev1.WithdrawResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Withdraw", varargs...)
+	ret0, _ := ret[0].(*articlev1.WithdrawResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockArticleServiceClientMockRecorder) Withdraw(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleServiceClient)(nil).Withdraw), varargs...)
+}
+
+// MockArticleServiceServer is a mock of ArticleServiceServer interface.
+type MockArticleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleServiceServerMockRecorder
+}
+
+// MockArticleServiceServerMockRecorder is the mock recorder for MockArticleServiceServer.
+type MockArticleServiceServerMockRecorder struct {
+	mock *MockArticleServiceServer
+}
+
+// NewMockArticleServiceServer creates a new mock instance.
+func NewMockArticleServiceServer(ctrl *gomock.Controller) *MockArticleServiceServer {
+	mock := &MockArticleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockArticleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleServiceServer) EXPECT() *MockArticleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// BatchGetPublished mocks base method.
+func (m *MockArticleServiceServer) BatchGetPublished(arg0 context.Context, arg1 *articlev1.BatchGetPublishedRequest) (*articlev1.BatchGetPublishedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetPublished", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.BatchGetPublishedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetPublished indicates an expected call of BatchGetPublished.
+func (mr *MockArticleServiceServerMockRecorder) BatchGetPublished(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetPublished", reflect.TypeOf((*MockArticleServiceServer)(nil).BatchGetPublished), arg0, arg1)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleServiceServer) CancelSchedule(arg0 context.Context, arg1 *articlev1.CancelScheduleRequest) (*articlev1.CancelScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.CancelScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceServerMockRecorder) CancelSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleServiceServer)(nil).CancelSchedule), arg0, arg1)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleServiceServer) CreateSeries(arg0 context.Context, arg1 *articlev1.CreateSeriesRequest) (*articlev1.CreateSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.CreateSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceServerMockRecorder) CreateSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).CreateSeries), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockArticleServiceServer) Delete(arg0 context.Context, arg1 *articlev1.DeleteRequest) (*articlev1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceServerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleServiceServer)(nil).Delete), arg0, arg1)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleServiceServer) DiffRevisions(arg0 context.Context, arg1 *articlev1.DiffRevisionsRequest) (*articlev1.DiffRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.DiffRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceServerMockRecorder) DiffRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleServiceServer)(nil).DiffRevisions), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockArticleServiceServer) GetById(arg0 context.Context, arg1 *articlev1.GetByIdRequest) (*articlev1.GetByIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.GetByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleServiceServerMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetById), arg0, arg1)
+}
+
+// GetPublishedById mocks base method.
+func (m *MockArticleServiceServer) GetPublishedById(arg0 context.Context, arg1 *articlev1.GetPublishedByIdRequest) (*articlev1.GetPublishedByIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedById", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.GetPublishedByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedById indicates an expected call of GetPublishedById.
+func (mr *MockArticleServiceServerMockRecorder) GetPublishedById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetPublishedById), arg0, arg1)
+}
+
+// InviteCoAuthor mocks base method.
+func (m *MockArticleServiceServer) InviteCoAuthor(arg0 context.Context, arg1 *articlev1.InviteCoAuthorRequest) (*articlev1.InviteCoAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteCoAuthor", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.InviteCoAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteCoAuthor indicates an expected call of InviteCoAuthor.
+func (mr *MockArticleServiceServerMockRecorder) InviteCoAuthor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteCoAuthor", reflect.TypeOf((*MockArticleServiceServer)(nil).InviteCoAuthor), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockArticleServiceServer) List(arg0 context.Context, arg1 *articlev1.ListRequest) (*articlev1.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleServiceServerMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleServiceServer)(nil).List), arg0, arg1)
+}
+
+// ListCoAuthors mocks base method.
+func (m *MockArticleServiceServer) ListCoAuthors(arg0 context.Context, arg1 *articlev1.ListCoAuthorsRequest) (*articlev1.ListCoAuthorsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoAuthors", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListCoAuthorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoAuthors indicates an expected call of ListCoAuthors.
+func (mr *MockArticleServiceServerMockRecorder) ListCoAuthors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthors", reflect.TypeOf((*MockArticleServiceServer)(nil).ListCoAuthors), arg0, arg1)
+}
+
+// ListDeleted mocks base method.
+func (m *MockArticleServiceServer) ListDeleted(arg0 context.Context, arg1 *articlev1.ListDeletedRequest) (*articlev1.ListDeletedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListDeletedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleServiceServerMockRecorder) ListDeleted(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleServiceServer)(nil).ListDeleted), arg0, arg1)
+}
+
+// ListInvitations mocks base method.
+func (m *MockArticleServiceServer) ListInvitations(arg0 context.Context, arg1 *articlev1.ListInvitationsRequest) (*articlev1.ListInvitationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListInvitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockArticleServiceServerMockRecorder) ListInvitations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockArticleServiceServer)(nil).ListInvitations), arg0, arg1)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleServiceServer) ListPub(arg0 context.Context, arg1 *articlev1.ListPubRequest) (*articlev1.ListPubResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListPubResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleServiceServerMockRecorder) ListPub(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPub), arg0, arg1)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleServiceServer) ListRevisions(arg0 context.Context, arg1 *articlev1.ListRevisionsRequest) (*articlev1.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceServerMockRecorder) ListRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleServiceServer)(nil).ListRevisions), arg0, arg1)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleServiceServer) ListSeries(arg0 context.Context, arg1 *articlev1.ListSeriesRequest) (*articlev1.ListSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceServerMockRecorder) ListSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).ListSeries), arg0, arg1)
+}
+
+// ListUnderReview mocks base method.
+func (m *MockArticleServiceServer) ListUnderReview(arg0 context.Context, arg1 *articlev1.ListUnderReviewRequest) (*articlev1.ListUnderReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnderReview", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListUnderReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnderReview indicates an expected call of ListUnderReview.
+func (mr *MockArticleServiceServerMockRecorder) ListUnderReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnderReview", reflect.TypeOf((*MockArticleServiceServer)(nil).ListUnderReview), arg0, arg1)
+}
+
+// Publish mocks base method.
+func (m *MockArticleServiceServer) Publish(arg0 context.Context, arg1 *articlev1.PublishRequest) (*articlev1.PublishResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.PublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockArticleServiceServerMockRecorder) Publish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceServer)(nil).Publish), arg0, arg1)
+}
+
+// RemoveCoAuthor mocks base method.
+func (m *MockArticleServiceServer) RemoveCoAuthor(arg0 context.Context, arg1 *articlev1.RemoveCoAuthorRequest) (*articlev1.RemoveCoAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCoAuthor", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.RemoveCoAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCoAuthor indicates an expected call of RemoveCoAuthor.
+func (mr *MockArticleServiceServerMockRecorder) RemoveCoAuthor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoAuthor", reflect.TypeOf((*MockArticleServiceServer)(nil).RemoveCoAuthor), arg0, arg1)
+}
+
+// ReorderSeries mocks base method.
+func (m *MockArticleServiceServer) ReorderSeries(arg0 context.Context, arg1 *articlev1.ReorderSeriesRequest) (*articlev1.ReorderSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSeries", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ReorderSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceServerMockRecorder) ReorderSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).ReorderSeries), arg0, arg1)
+}
+
+// Reschedule mocks base method.
+func (m *MockArticleServiceServer) Reschedule(arg0 context.Context, arg1 *articlev1.RescheduleRequest) (*articlev1.RescheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.RescheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockArticleServiceServerMockRecorder) Reschedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockArticleServiceServer)(nil).Reschedule), arg0, arg1)
+}
+
+// RespondInvitation mocks base method.
+func (m *MockArticleServiceServer) RespondInvitation(arg0 context.Context, arg1 *articlev1.RespondInvitationRequest) (*articlev1.RespondInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.RespondInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondInvitation indicates an expected call of RespondInvitation.
+func (mr *MockArticleServiceServerMockRecorder) RespondInvitation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondInvitation", reflect.TypeOf((*MockArticleServiceServer)(nil).RespondInvitation), arg0, arg1)
+}
+
+// RestoreDeleted mocks base method.
+func (m *MockArticleServiceServer) RestoreDeleted(arg0 context.Context, arg1 *articlev1.RestoreDeletedRequest) (*articlev1.RestoreDeletedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeleted", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.RestoreDeletedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDeleted indicates an expected call of RestoreDeleted.
+func (mr *MockArticleServiceServerMockRecorder) RestoreDeleted(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeleted", reflect.TypeOf((*MockArticleServiceServer)(nil).RestoreDeleted), arg0, arg1)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleServiceServer) RestoreRevision(arg0 context.Context, arg1 *articlev1.RestoreRevisionRequest) (*articlev1.RestoreRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.RestoreRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceServerMockRecorder) RestoreRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleServiceServer)(nil).RestoreRevision), arg0, arg1)
+}
+
+// Review mocks base method.
+func (m *MockArticleServiceServer) Review(arg0 context.Context, arg1 *articlev1.ReviewRequest) (*articlev1.ReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockArticleServiceServerMockRecorder) Review(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockArticleServiceServer)(nil).Review), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockArticleServiceServer) Save(arg0 context.Context, arg1 *articlev1.SaveRequest) (*articlev1.SaveResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.SaveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleServiceServerMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceServer)(nil).Save), arg0, arg1)
+}
+
+// Withdraw mocks base method.
+func (m *MockArticleServiceServer) Withdraw(arg0 context.Context, arg1 *articlev1.WithdrawRequest) (*articlev1.WithdrawResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.WithdrawResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockArticleServiceServerMockRecorder) Withdraw(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleServiceServer)(nil).Withdraw), arg0, arg1)
+}
+
+// mustEmbedUnimplementedArticleServiceServer mocks base method.
+func (m *MockArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+}
+
+// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
+func (mr *MockArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
+}
+
+// MockUnsafeArticleServiceServer is a mock of UnsafeArticleServiceServer interface.
+type MockUnsafeArticleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeArticleServiceServerMockRecorder
 }
 
 // MockUnsafeArticleServiceServerMockRecorder is the mock recorder for MockUnsafeArticleServiceServer.
@@ -331,26 +996,655 @@ type MockUnsafeArticleServiceServerMockRecorder struct {
 	mock *MockUnsafeArticleServiceServer
 }
 
-// NewMockUnsafeArticleServiceServer creates a new mock instance.
-func NewMockUnsafeArticleServiceServer(ctrl *gomock.Controller) *MockUnsafeArticleServiceServer {
-	mock := &MockUnsafeArticleServiceServer{ctrl: ctrl}
-	mock.recorder = &MockUnsafeArticleServiceServerMockRecorder{mock}
+// NewMockUnsafeArticleServiceServer creates a new mock instance.
+func NewMockUnsafeArticleServiceServer(ctrl *gomock.Controller) *MockUnsafeArticleServiceServer {
+	mock := &MockUnsafeArticleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeArticleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeArticleServiceServer) EXPECT() *MockUnsafeArticleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedArticleServiceServer mocks base method.
+func (m *MockUnsafeArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+}
+
+// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
+func (mr *MockUnsafeArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockUnsafeArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
+}
+
+// MockAttachmentServiceClient is a mock of AttachmentServiceClient interface.
+type MockAttachmentServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServiceClientMockRecorder
+}
+
+// MockAttachmentServiceClientMockRecorder is the mock recorder for MockAttachmentServiceClient.
+type MockAttachmentServiceClientMockRecorder struct {
+	mock *MockAttachmentServiceClient
+}
+
+// NewMockAttachmentServiceClient creates a new mock instance.
+func NewMockAttachmentServiceClient(ctrl *gomock.Controller) *MockAttachmentServiceClient {
+	mock := &MockAttachmentServiceClient{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentServiceClient) EXPECT() *MockAttachmentServiceClientMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockAttachmentServiceClient) Attach(ctx context.Context, in *articlev1.AttachRequest, opts ...grpc.CallOption) (*articlev1.AttachResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Attach", varargs...)
+	ret0, _ := ret[0].(*articlev1.AttachResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockAttachmentServiceClientMockRecorder) Attach(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockAttachmentServiceClient)(nil).Attach), varargs...)
+}
+
+// ConfirmUpload mocks base method.
+func (m *MockAttachmentServiceClient) ConfirmUpload(ctx context.Context, in *articlev1.ConfirmUploadRequest, opts ...grpc.CallOption) (*articlev1.ConfirmUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmUpload", varargs...)
+	ret0, _ := ret[0].(*articlev1.ConfirmUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmUpload indicates an expected call of ConfirmUpload.
+func (mr *MockAttachmentServiceClientMockRecorder) ConfirmUpload(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUpload", reflect.TypeOf((*MockAttachmentServiceClient)(nil).ConfirmUpload), varargs...)
+}
+
+// ListAttachments mocks base method.
+func (m *MockAttachmentServiceClient) ListAttachments(ctx context.Context, in *articlev1.ListAttachmentsRequest, opts ...grpc.CallOption) (*articlev1.ListAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAttachments", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockAttachmentServiceClientMockRecorder) ListAttachments(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockAttachmentServiceClient)(nil).ListAttachments), varargs...)
+}
+
+// PrepareUpload mocks base method.
+func (m *MockAttachmentServiceClient) PrepareUpload(ctx context.Context, in *articlev1.PrepareUploadRequest, opts ...grpc.CallOption) (*articlev1.PrepareUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrepareUpload", varargs...)
+	ret0, _ := ret[0].(*articlev1.PrepareUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareUpload indicates an expected call of PrepareUpload.
+func (mr *MockAttachmentServiceClientMockRecorder) PrepareUpload(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareUpload", reflect.TypeOf((*MockAttachmentServiceClient)(nil).PrepareUpload), varargs...)
+}
+
+// MockAttachmentServiceServer is a mock of AttachmentServiceServer interface.
+type MockAttachmentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServiceServerMockRecorder
+}
+
+// MockAttachmentServiceServerMockRecorder is the mock recorder for MockAttachmentServiceServer.
+type MockAttachmentServiceServerMockRecorder struct {
+	mock *MockAttachmentServiceServer
+}
+
+// NewMockAttachmentServiceServer creates a new mock instance.
+func NewMockAttachmentServiceServer(ctrl *gomock.Controller) *MockAttachmentServiceServer {
+	mock := &MockAttachmentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentServiceServer) EXPECT() *MockAttachmentServiceServerMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockAttachmentServiceServer) Attach(arg0 context.Context, arg1 *articlev1.AttachRequest) (*articlev1.AttachResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.AttachResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockAttachmentServiceServerMockRecorder) Attach(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockAttachmentServiceServer)(nil).Attach), arg0, arg1)
+}
+
+// ConfirmUpload mocks base method.
+func (m *MockAttachmentServiceServer) ConfirmUpload(arg0 context.Context, arg1 *articlev1.ConfirmUploadRequest) (*articlev1.ConfirmUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmUpload", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ConfirmUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmUpload indicates an expected call of ConfirmUpload.
+func (mr *MockAttachmentServiceServerMockRecorder) ConfirmUpload(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUpload", reflect.TypeOf((*MockAttachmentServiceServer)(nil).ConfirmUpload), arg0, arg1)
+}
+
+// ListAttachments mocks base method.
+func (m *MockAttachmentServiceServer) ListAttachments(arg0 context.Context, arg1 *articlev1.ListAttachmentsRequest) (*articlev1.ListAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockAttachmentServiceServerMockRecorder) ListAttachments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockAttachmentServiceServer)(nil).ListAttachments), arg0, arg1)
+}
+
+// PrepareUpload mocks base method.
+func (m *MockAttachmentServiceServer) PrepareUpload(arg0 context.Context, arg1 *articlev1.PrepareUploadRequest) (*articlev1.PrepareUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareUpload", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.PrepareUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareUpload indicates an expected call of PrepareUpload.
+func (mr *MockAttachmentServiceServerMockRecorder) PrepareUpload(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareUpload", reflect.TypeOf((*MockAttachmentServiceServer)(nil).PrepareUpload), arg0, arg1)
+}
+
+// mustEmbedUnimplementedAttachmentServiceServer mocks base method.
+func (m *MockAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAttachmentServiceServer")
+}
+
+// mustEmbedUnimplementedAttachmentServiceServer indicates an expected call of mustEmbedUnimplementedAttachmentServiceServer.
+func (mr *MockAttachmentServiceServerMockRecorder) mustEmbedUnimplementedAttachmentServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAttachmentServiceServer", reflect.TypeOf((*MockAttachmentServiceServer)(nil).mustEmbedUnimplementedAttachmentServiceServer))
+}
+
+// MockUnsafeAttachmentServiceServer is a mock of UnsafeAttachmentServiceServer interface.
+type MockUnsafeAttachmentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeAttachmentServiceServerMockRecorder
+}
+
+// MockUnsafeAttachmentServiceServerMockRecorder is the mock recorder for MockUnsafeAttachmentServiceServer.
+type MockUnsafeAttachmentServiceServerMockRecorder struct {
+	mock *MockUnsafeAttachmentServiceServer
+}
+
+// NewMockUnsafeAttachmentServiceServer creates a new mock instance.
+func NewMockUnsafeAttachmentServiceServer(ctrl *gomock.Controller) *MockUnsafeAttachmentServiceServer {
+	mock := &MockUnsafeAttachmentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeAttachmentServiceServerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUnsafeArticleServiceServer) EXPECT() *MockUnsafeArticleServiceServerMockRecorder {
+func (m *MockUnsafeAttachmentServiceServer) EXPECT() *MockUnsafeAttachmentServiceServerMockRecorder {
 	return m.recorder
 }
 
-// mustEmbedUnimplementedArticleServiceServer mocks base method.
-func (m *MockUnsafeArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+// mustEmbedUnimplementedAttachmentServiceServer mocks base method.
+func (m *MockUnsafeAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+	m.ctrl.Call(m, "mustEmbedUnimplementedAttachmentServiceServer")
 }
 
-// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
-func (mr *MockUnsafeArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+// mustEmbedUnimplementedAttachmentServiceServer indicates an expected call of mustEmbedUnimplementedAttachmentServiceServer.
+func (mr *MockUnsafeAttachmentServiceServerMockRecorder) mustEmbedUnimplementedAttachmentServiceServer() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockUnsafeArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAttachmentServiceServer", reflect.TypeOf((*MockUnsafeAttachmentServiceServer)(nil).mustEmbedUnimplementedAttachmentServiceServer))
+}
+
+// MockCitationServiceClient is a mock of CitationServiceClient interface.
+type MockCitationServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCitationServiceClientMockRecorder
+}
+
+// MockCitationServiceClientMockRecorder is the mock recorder for MockCitationServiceClient.
+type MockCitationServiceClientMockRecorder struct {
+	mock *MockCitationServiceClient
+}
+
+// NewMockCitationServiceClient creates a new mock instance.
+func NewMockCitationServiceClient(ctrl *gomock.Controller) *MockCitationServiceClient {
+	mock := &MockCitationServiceClient{ctrl: ctrl}
+	mock.recorder = &MockCitationServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCitationServiceClient) EXPECT() *MockCitationServiceClientMockRecorder {
+	return m.recorder
+}
+
+// ListCitations mocks base method.
+func (m *MockCitationServiceClient) ListCitations(ctx context.Context, in *articlev1.ListCitationsRequest, opts ...grpc.CallOption) (*articlev1.ListCitationsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCitations", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListCitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitations indicates an expected call of ListCitations.
+func (mr *MockCitationServiceClientMockRecorder) ListCitations(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitations", reflect.TypeOf((*MockCitationServiceClient)(nil).ListCitations), varargs...)
+}
+
+// ListCitedBy mocks base method.
+func (m *MockCitationServiceClient) ListCitedBy(ctx context.Context, in *articlev1.ListCitedByRequest, opts ...grpc.CallOption) (*articlev1.ListCitedByResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCitedBy", varargs...)
+	ret0, _ := ret[0].(*articlev1.ListCitedByResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitedBy indicates an expected call of ListCitedBy.
+func (mr *MockCitationServiceClientMockRecorder) ListCitedBy(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitedBy", reflect.TypeOf((*MockCitationServiceClient)(nil).ListCitedBy), varargs...)
+}
+
+// SetCitations mocks base method.
+func (m *MockCitationServiceClient) SetCitations(ctx context.Context, in *articlev1.SetCitationsRequest, opts ...grpc.CallOption) (*articlev1.SetCitationsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetCitations", varargs...)
+	ret0, _ := ret[0].(*articlev1.SetCitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCitations indicates an expected call of SetCitations.
+func (mr *MockCitationServiceClientMockRecorder) SetCitations(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCitations", reflect.TypeOf((*MockCitationServiceClient)(nil).SetCitations), varargs...)
+}
+
+// MockCitationServiceServer is a mock of CitationServiceServer interface.
+type MockCitationServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCitationServiceServerMockRecorder
+}
+
+// MockCitationServiceServerMockRecorder is the mock recorder for MockCitationServiceServer.
+type MockCitationServiceServerMockRecorder struct {
+	mock *MockCitationServiceServer
+}
+
+// NewMockCitationServiceServer creates a new mock instance.
+func NewMockCitationServiceServer(ctrl *gomock.Controller) *MockCitationServiceServer {
+	mock := &MockCitationServiceServer{ctrl: ctrl}
+	mock.recorder = &MockCitationServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCitationServiceServer) EXPECT() *MockCitationServiceServerMockRecorder {
+	return m.recorder
+}
+
+// ListCitations mocks base method.
+func (m *MockCitationServiceServer) ListCitations(arg0 context.Context, arg1 *articlev1.ListCitationsRequest) (*articlev1.ListCitationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCitations", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListCitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitations indicates an expected call of ListCitations.
+func (mr *MockCitationServiceServerMockRecorder) ListCitations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitations", reflect.TypeOf((*MockCitationServiceServer)(nil).ListCitations), arg0, arg1)
+}
+
+// ListCitedBy mocks base method.
+func (m *MockCitationServiceServer) ListCitedBy(arg0 context.Context, arg1 *articlev1.ListCitedByRequest) (*articlev1.ListCitedByResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCitedBy", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.ListCitedByResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitedBy indicates an expected call of ListCitedBy.
+func (mr *MockCitationServiceServerMockRecorder) ListCitedBy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitedBy", reflect.TypeOf((*MockCitationServiceServer)(nil).ListCitedBy), arg0, arg1)
+}
+
+// SetCitations mocks base method.
+func (m *MockCitationServiceServer) SetCitations(arg0 context.Context, arg1 *articlev1.SetCitationsRequest) (*articlev1.SetCitationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCitations", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.SetCitationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCitations indicates an expected call of SetCitations.
+func (mr *MockCitationServiceServerMockRecorder) SetCitations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCitations", reflect.TypeOf((*MockCitationServiceServer)(nil).SetCitations), arg0, arg1)
+}
+
+// mustEmbedUnimplementedCitationServiceServer mocks base method.
+func (m *MockCitationServiceServer) mustEmbedUnimplementedCitationServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCitationServiceServer")
+}
+
+// mustEmbedUnimplementedCitationServiceServer indicates an expected call of mustEmbedUnimplementedCitationServiceServer.
+func (mr *MockCitationServiceServerMockRecorder) mustEmbedUnimplementedCitationServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCitationServiceServer", reflect.TypeOf((*MockCitationServiceServer)(nil).mustEmbedUnimplementedCitationServiceServer))
+}
+
+// MockUnsafeCitationServiceServer is a mock of UnsafeCitationServiceServer interface.
+type MockUnsafeCitationServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeCitationServiceServerMockRecorder
+}
+
+// MockUnsafeCitationServiceServerMockRecorder is the mock recorder for MockUnsafeCitationServiceServer.
+type MockUnsafeCitationServiceServerMockRecorder struct {
+	mock *MockUnsafeCitationServiceServer
+}
+
+// NewMockUnsafeCitationServiceServer creates a new mock instance.
+func NewMockUnsafeCitationServiceServer(ctrl *gomock.Controller) *MockUnsafeCitationServiceServer {
+	mock := &MockUnsafeCitationServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeCitationServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeCitationServiceServer) EXPECT() *MockUnsafeCitationServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedCitationServiceServer mocks base method.
+func (m *MockUnsafeCitationServiceServer) mustEmbedUnimplementedCitationServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCitationServiceServer")
+}
+
+// mustEmbedUnimplementedCitationServiceServer indicates an expected call of mustEmbedUnimplementedCitationServiceServer.
+func (mr *MockUnsafeCitationServiceServerMockRecorder) mustEmbedUnimplementedCitationServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCitationServiceServer", reflect.TypeOf((*MockUnsafeCitationServiceServer)(nil).mustEmbedUnimplementedCitationServiceServer))
+}
+
+// MockVisibilityServiceClient is a mock of VisibilityServiceClient interface.
+type MockVisibilityServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityServiceClientMockRecorder
+}
+
+// MockVisibilityServiceClientMockRecorder is the mock recorder for MockVisibilityServiceClient.
+type MockVisibilityServiceClientMockRecorder struct {
+	mock *MockVisibilityServiceClient
+}
+
+// NewMockVisibilityServiceClient creates a new mock instance.
+func NewMockVisibilityServiceClient(ctrl *gomock.Controller) *MockVisibilityServiceClient {
+	mock := &MockVisibilityServiceClient{ctrl: ctrl}
+	mock.recorder = &MockVisibilityServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityServiceClient) EXPECT() *MockVisibilityServiceClientMockRecorder {
+	return m.recorder
+}
+
+// FilterAudience mocks base method.
+func (m *MockVisibilityServiceClient) FilterAudience(ctx context.Context, in *articlev1.FilterAudienceRequest, opts ...grpc.CallOption) (*articlev1.FilterAudienceResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterAudience", varargs...)
+	ret0, _ := ret[0].(*articlev1.FilterAudienceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterAudience indicates an expected call of FilterAudience.
+func (mr *MockVisibilityServiceClientMockRecorder) FilterAudience(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterAudience", reflect.TypeOf((*MockVisibilityServiceClient)(nil).FilterAudience), varargs...)
+}
+
+// GetVisibility mocks base method.
+func (m *MockVisibilityServiceClient) GetVisibility(ctx context.Context, in *articlev1.GetVisibilityRequest, opts ...grpc.CallOption) (*articlev1.GetVisibilityResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVisibility", varargs...)
+	ret0, _ := ret[0].(*articlev1.GetVisibilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibility indicates an expected call of GetVisibility.
+func (mr *MockVisibilityServiceClientMockRecorder) GetVisibility(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibility", reflect.TypeOf((*MockVisibilityServiceClient)(nil).GetVisibility), varargs...)
+}
+
+// SetVisibility mocks base method.
+func (m *MockVisibilityServiceClient) SetVisibility(ctx context.Context, in *articlev1.SetVisibilityRequest, opts ...grpc.CallOption) (*articlev1.SetVisibilityResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetVisibility", varargs...)
+	ret0, _ := ret[0].(*articlev1.SetVisibilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVisibility indicates an expected call of SetVisibility.
+func (mr *MockVisibilityServiceClientMockRecorder) SetVisibility(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibility", reflect.TypeOf((*MockVisibilityServiceClient)(nil).SetVisibility), varargs...)
+}
+
+// MockVisibilityServiceServer is a mock of VisibilityServiceServer interface.
+type MockVisibilityServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityServiceServerMockRecorder
+}
+
+// MockVisibilityServiceServerMockRecorder is the mock recorder for MockVisibilityServiceServer.
+type MockVisibilityServiceServerMockRecorder struct {
+	mock *MockVisibilityServiceServer
+}
+
+// NewMockVisibilityServiceServer creates a new mock instance.
+func NewMockVisibilityServiceServer(ctrl *gomock.Controller) *MockVisibilityServiceServer {
+	mock := &MockVisibilityServiceServer{ctrl: ctrl}
+	mock.recorder = &MockVisibilityServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityServiceServer) EXPECT() *MockVisibilityServiceServerMockRecorder {
+	return m.recorder
+}
+
+// FilterAudience mocks base method.
+func (m *MockVisibilityServiceServer) FilterAudience(arg0 context.Context, arg1 *articlev1.FilterAudienceRequest) (*articlev1.FilterAudienceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterAudience", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.FilterAudienceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterAudience indicates an expected call of FilterAudience.
+func (mr *MockVisibilityServiceServerMockRecorder) FilterAudience(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterAudience", reflect.TypeOf((*MockVisibilityServiceServer)(nil).FilterAudience), arg0, arg1)
+}
+
+// GetVisibility mocks base method.
+func (m *MockVisibilityServiceServer) GetVisibility(arg0 context.Context, arg1 *articlev1.GetVisibilityRequest) (*articlev1.GetVisibilityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibility", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.GetVisibilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibility indicates an expected call of GetVisibility.
+func (mr *MockVisibilityServiceServerMockRecorder) GetVisibility(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibility", reflect.TypeOf((*MockVisibilityServiceServer)(nil).GetVisibility), arg0, arg1)
+}
+
+// SetVisibility mocks base method.
+func (m *MockVisibilityServiceServer) SetVisibility(arg0 context.Context, arg1 *articlev1.SetVisibilityRequest) (*articlev1.SetVisibilityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVisibility", arg0, arg1)
+	ret0, _ := ret[0].(*articlev1.SetVisibilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVisibility indicates an expected call of SetVisibility.
+func (mr *MockVisibilityServiceServerMockRecorder) SetVisibility(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibility", reflect.TypeOf((*MockVisibilityServiceServer)(nil).SetVisibility), arg0, arg1)
+}
+
+// mustEmbedUnimplementedVisibilityServiceServer mocks base method.
+func (m *MockVisibilityServiceServer) mustEmbedUnimplementedVisibilityServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedVisibilityServiceServer")
+}
+
+// mustEmbedUnimplementedVisibilityServiceServer indicates an expected call of mustEmbedUnimplementedVisibilityServiceServer.
+func (mr *MockVisibilityServiceServerMockRecorder) mustEmbedUnimplementedVisibilityServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedVisibilityServiceServer", reflect.TypeOf((*MockVisibilityServiceServer)(nil).mustEmbedUnimplementedVisibilityServiceServer))
+}
+
+// MockUnsafeVisibilityServiceServer is a mock of UnsafeVisibilityServiceServer interface.
+type MockUnsafeVisibilityServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeVisibilityServiceServerMockRecorder
+}
+
+// MockUnsafeVisibilityServiceServerMockRecorder is the mock recorder for MockUnsafeVisibilityServiceServer.
+type MockUnsafeVisibilityServiceServerMockRecorder struct {
+	mock *MockUnsafeVisibilityServiceServer
+}
+
+// NewMockUnsafeVisibilityServiceServer creates a new mock instance.
+func NewMockUnsafeVisibilityServiceServer(ctrl *gomock.Controller) *MockUnsafeVisibilityServiceServer {
+	mock := &MockUnsafeVisibilityServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeVisibilityServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeVisibilityServiceServer) EXPECT() *MockUnsafeVisibilityServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedVisibilityServiceServer mocks base method.
+func (m *MockUnsafeVisibilityServiceServer) mustEmbedUnimplementedVisibilityServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedVisibilityServiceServer")
+}
+
+// mustEmbedUnimplementedVisibilityServiceServer indicates an expected call of mustEmbedUnimplementedVisibilityServiceServer.
+func (mr *MockUnsafeVisibilityServiceServerMockRecorder) mustEmbedUnimplementedVisibilityServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedVisibilityServiceServer", reflect.TypeOf((*MockUnsafeVisibilityServiceServer)(nil).mustEmbedUnimplementedVisibilityServiceServer))
 }
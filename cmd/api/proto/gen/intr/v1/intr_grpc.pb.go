@@ -19,12 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	InteractiveService_IncrReadCnt_FullMethodName = "/intr.v1.InteractiveService/IncrReadCnt"
-	InteractiveService_Like_FullMethodName        = "/intr.v1.InteractiveService/Like"
-	InteractiveService_CancelLike_FullMethodName  = "/intr.v1.InteractiveService/CancelLike"
-	InteractiveService_Collect_FullMethodName     = "/intr.v1.InteractiveService/Collect"
-	InteractiveService_Get_FullMethodName         = "/intr.v1.InteractiveService/Get"
-	InteractiveService_GetByIds_FullMethodName    = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_IncrReadCnt_FullMethodName         = "/intr.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName                = "/intr.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName          = "/intr.v1.InteractiveService/CancelLike"
+	InteractiveService_React_FullMethodName               = "/intr.v1.InteractiveService/React"
+	InteractiveService_Collect_FullMethodName             = "/intr.v1.InteractiveService/Collect"
+	InteractiveService_Get_FullMethodName                 = "/intr.v1.InteractiveService/Get"
+	InteractiveService_GetByIds_FullMethodName            = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_CancelCollect_FullMethodName       = "/intr.v1.InteractiveService/CancelCollect"
+	InteractiveService_MoveCollectionItem_FullMethodName  = "/intr.v1.InteractiveService/MoveCollectionItem"
+	InteractiveService_CreateCollection_FullMethodName    = "/intr.v1.InteractiveService/CreateCollection"
+	InteractiveService_RenameCollection_FullMethodName    = "/intr.v1.InteractiveService/RenameCollection"
+	InteractiveService_DeleteCollection_FullMethodName    = "/intr.v1.InteractiveService/DeleteCollection"
+	InteractiveService_ListCollections_FullMethodName     = "/intr.v1.InteractiveService/ListCollections"
+	InteractiveService_ListCollectionItems_FullMethodName = "/intr.v1.InteractiveService/ListCollectionItems"
+	InteractiveService_ListLiked_FullMethodName           = "/intr.v1.InteractiveService/ListLiked"
+	InteractiveService_ListCollected_FullMethodName       = "/intr.v1.InteractiveService/ListCollected"
+	InteractiveService_ReportReadProgress_FullMethodName  = "/intr.v1.InteractiveService/ReportReadProgress"
+	InteractiveService_GetReadProgress_FullMethodName     = "/intr.v1.InteractiveService/GetReadProgress"
+	InteractiveService_ListReadHistory_FullMethodName     = "/intr.v1.InteractiveService/ListReadHistory"
+	InteractiveService_ClearReadHistory_FullMethodName    = "/intr.v1.InteractiveService/ClearReadHistory"
+	InteractiveService_PauseReadHistory_FullMethodName    = "/intr.v1.InteractiveService/PauseReadHistory"
+	InteractiveService_GetDailyReaders_FullMethodName     = "/intr.v1.InteractiveService/GetDailyReaders"
 )
 
 // InteractiveServiceClient is the client API for InteractiveService service.
@@ -33,10 +49,40 @@ const (
 type InteractiveServiceClient interface {
 	IncrReadCnt(ctx context.Context, in *IncrReadCntRequest, opts ...grpc.CallOption) (*IncrReadCntResponse, error)
 	Like(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*LikeResponse, error)
+	// CancelLike 取消表态，不管是点赞还是别的表态
 	CancelLike(ctx context.Context, in *CancelLikeRequest, opts ...grpc.CallOption) (*CancelLikeResponse, error)
+	// React 表态，点赞也是一种表态，一个人对一个东西只能有一种表态
+	React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
 	Collect(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (*CollectResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error)
+	// CancelCollect 取消收藏，不管在哪个收藏夹里面
+	CancelCollect(ctx context.Context, in *CancelCollectRequest, opts ...grpc.CallOption) (*CancelCollectResponse, error)
+	// MoveCollectionItem 把收藏的东西挪到另外一个收藏夹
+	MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error)
+	// 收藏夹，cid 是 0 的是默认收藏夹，不能改名也不能删除
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error)
+	// DeleteCollection 收藏夹里面的东西会挪到默认收藏夹
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error)
+	// ListLiked 我点赞过的东西，别的表态不算，按照点赞的时间倒序
+	ListLiked(ctx context.Context, in *ListLikedRequest, opts ...grpc.CallOption) (*ListLikedResponse, error)
+	// ListCollected 我收藏的所有东西，不管在哪个收藏夹，按照收藏的时间倒序
+	ListCollected(ctx context.Context, in *ListCollectedRequest, opts ...grpc.CallOption) (*ListCollectedResponse, error)
+	// 阅读历史，打开文章的时候由阅读事件记录，阅读的过程中上报进度
+	// ReportReadProgress 上报读到了哪里，暂停了记录阅读历史的话什么都不做
+	ReportReadProgress(ctx context.Context, in *ReportReadProgressRequest, opts ...grpc.CallOption) (*ReportReadProgressResponse, error)
+	// GetReadProgress 上次读到了哪里，方便接着往下看
+	GetReadProgress(ctx context.Context, in *GetReadProgressRequest, opts ...grpc.CallOption) (*GetReadProgressResponse, error)
+	// ListReadHistory 最近看过的东西，按照最后一次阅读的时间倒序
+	ListReadHistory(ctx context.Context, in *ListReadHistoryRequest, opts ...grpc.CallOption) (*ListReadHistoryResponse, error)
+	ClearReadHistory(ctx context.Context, in *ClearReadHistoryRequest, opts ...grpc.CallOption) (*ClearReadHistoryResponse, error)
+	// PauseReadHistory 暂停之后不再记录阅读历史，已经有的记录不动
+	PauseReadHistory(ctx context.Context, in *PauseReadHistoryRequest, opts ...grpc.CallOption) (*PauseReadHistoryResponse, error)
+	// GetDailyReaders 最近每一天的独立读者数，估算值，看阅读的趋势
+	GetDailyReaders(ctx context.Context, in *GetDailyReadersRequest, opts ...grpc.CallOption) (*GetDailyReadersResponse, error)
 }
 
 type interactiveServiceClient struct {
@@ -77,6 +123,16 @@ func (c *interactiveServiceClient) CancelLike(ctx context.Context, in *CancelLik
 	return out, nil
 }
 
+func (c *interactiveServiceClient) React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_React_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *interactiveServiceClient) Collect(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (*CollectResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CollectResponse)
@@ -107,16 +163,196 @@ func (c *interactiveServiceClient) GetByIds(ctx context.Context, in *GetByIdsReq
 	return out, nil
 }
 
+func (c *interactiveServiceClient) CancelCollect(ctx context.Context, in *CancelCollectRequest, opts ...grpc.CallOption) (*CancelCollectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelCollectResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelCollect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveCollectionItemResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_MoveCollectionItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_RenameCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionItemsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollectionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListLiked(ctx context.Context, in *ListLikedRequest, opts ...grpc.CallOption) (*ListLikedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListLiked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollected(ctx context.Context, in *ListCollectedRequest, opts ...grpc.CallOption) (*ListCollectedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectedResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollected_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ReportReadProgress(ctx context.Context, in *ReportReadProgressRequest, opts ...grpc.CallOption) (*ReportReadProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportReadProgressResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ReportReadProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) GetReadProgress(ctx context.Context, in *GetReadProgressRequest, opts ...grpc.CallOption) (*GetReadProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReadProgressResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetReadProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListReadHistory(ctx context.Context, in *ListReadHistoryRequest, opts ...grpc.CallOption) (*ListReadHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReadHistoryResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListReadHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ClearReadHistory(ctx context.Context, in *ClearReadHistoryRequest, opts ...grpc.CallOption) (*ClearReadHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearReadHistoryResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ClearReadHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) PauseReadHistory(ctx context.Context, in *PauseReadHistoryRequest, opts ...grpc.CallOption) (*PauseReadHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseReadHistoryResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_PauseReadHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) GetDailyReaders(ctx context.Context, in *GetDailyReadersRequest, opts ...grpc.CallOption) (*GetDailyReadersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyReadersResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetDailyReaders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveServiceServer is the server API for InteractiveService service.
 // All implementations must embed UnimplementedInteractiveServiceServer
 // for forward compatibility.
 type InteractiveServiceServer interface {
 	IncrReadCnt(context.Context, *IncrReadCntRequest) (*IncrReadCntResponse, error)
 	Like(context.Context, *LikeRequest) (*LikeResponse, error)
+	// CancelLike 取消表态，不管是点赞还是别的表态
 	CancelLike(context.Context, *CancelLikeRequest) (*CancelLikeResponse, error)
+	// React 表态，点赞也是一种表态，一个人对一个东西只能有一种表态
+	React(context.Context, *ReactRequest) (*ReactResponse, error)
 	Collect(context.Context, *CollectRequest) (*CollectResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error)
+	// CancelCollect 取消收藏，不管在哪个收藏夹里面
+	CancelCollect(context.Context, *CancelCollectRequest) (*CancelCollectResponse, error)
+	// MoveCollectionItem 把收藏的东西挪到另外一个收藏夹
+	MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error)
+	// 收藏夹，cid 是 0 的是默认收藏夹，不能改名也不能删除
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error)
+	// DeleteCollection 收藏夹里面的东西会挪到默认收藏夹
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error)
+	// ListLiked 我点赞过的东西，别的表态不算，按照点赞的时间倒序
+	ListLiked(context.Context, *ListLikedRequest) (*ListLikedResponse, error)
+	// ListCollected 我收藏的所有东西，不管在哪个收藏夹，按照收藏的时间倒序
+	ListCollected(context.Context, *ListCollectedRequest) (*ListCollectedResponse, error)
+	// 阅读历史，打开文章的时候由阅读事件记录，阅读的过程中上报进度
+	// ReportReadProgress 上报读到了哪里，暂停了记录阅读历史的话什么都不做
+	ReportReadProgress(context.Context, *ReportReadProgressRequest) (*ReportReadProgressResponse, error)
+	// GetReadProgress 上次读到了哪里，方便接着往下看
+	GetReadProgress(context.Context, *GetReadProgressRequest) (*GetReadProgressResponse, error)
+	// ListReadHistory 最近看过的东西，按照最后一次阅读的时间倒序
+	ListReadHistory(context.Context, *ListReadHistoryRequest) (*ListReadHistoryResponse, error)
+	ClearReadHistory(context.Context, *ClearReadHistoryRequest) (*ClearReadHistoryResponse, error)
+	// PauseReadHistory 暂停之后不再记录阅读历史，已经有的记录不动
+	PauseReadHistory(context.Context, *PauseReadHistoryRequest) (*PauseReadHistoryResponse, error)
+	// GetDailyReaders 最近每一天的独立读者数，估算值，看阅读的趋势
+	GetDailyReaders(context.Context, *GetDailyReadersRequest) (*GetDailyReadersResponse, error)
 	mustEmbedUnimplementedInteractiveServiceServer()
 }
 
@@ -136,6 +372,9 @@ func (UnimplementedInteractiveServiceServer) Like(context.Context, *LikeRequest)
 func (UnimplementedInteractiveServiceServer) CancelLike(context.Context, *CancelLikeRequest) (*CancelLikeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelLike not implemented")
 }
+func (UnimplementedInteractiveServiceServer) React(context.Context, *ReactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method React not implemented")
+}
 func (UnimplementedInteractiveServiceServer) Collect(context.Context, *CollectRequest) (*CollectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Collect not implemented")
 }
@@ -145,6 +384,51 @@ func (UnimplementedInteractiveServiceServer) Get(context.Context, *GetRequest) (
 func (UnimplementedInteractiveServiceServer) GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByIds not implemented")
 }
+func (UnimplementedInteractiveServiceServer) CancelCollect(context.Context, *CancelCollectRequest) (*CancelCollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCollect not implemented")
+}
+func (UnimplementedInteractiveServiceServer) MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCollectionItem not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionItems not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListLiked(context.Context, *ListLikedRequest) (*ListLikedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLiked not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollected(context.Context, *ListCollectedRequest) (*ListCollectedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollected not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ReportReadProgress(context.Context, *ReportReadProgressRequest) (*ReportReadProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportReadProgress not implemented")
+}
+func (UnimplementedInteractiveServiceServer) GetReadProgress(context.Context, *GetReadProgressRequest) (*GetReadProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadProgress not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListReadHistory(context.Context, *ListReadHistoryRequest) (*ListReadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReadHistory not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ClearReadHistory(context.Context, *ClearReadHistoryRequest) (*ClearReadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearReadHistory not implemented")
+}
+func (UnimplementedInteractiveServiceServer) PauseReadHistory(context.Context, *PauseReadHistoryRequest) (*PauseReadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseReadHistory not implemented")
+}
+func (UnimplementedInteractiveServiceServer) GetDailyReaders(context.Context, *GetDailyReadersRequest) (*GetDailyReadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyReaders not implemented")
+}
 func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
 func (UnimplementedInteractiveServiceServer) testEmbeddedByValue()                            {}
 
@@ -220,6 +504,24 @@ func _InteractiveService_CancelLike_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).React(ctx, req.(*ReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InteractiveService_Collect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CollectRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +576,276 @@ func _InteractiveService_GetByIds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_CancelCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelCollect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelCollect(ctx, req.(*CancelCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_MoveCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_MoveCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, req.(*MoveCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_RenameCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).RenameCollection(ctx, req.(*RenameCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollectionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, req.(*ListCollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListLiked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListLiked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListLiked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListLiked(ctx, req.(*ListLikedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollected(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollected_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollected(ctx, req.(*ListCollectedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ReportReadProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportReadProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ReportReadProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ReportReadProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ReportReadProgress(ctx, req.(*ReportReadProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_GetReadProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetReadProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetReadProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetReadProgress(ctx, req.(*GetReadProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListReadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListReadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListReadHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListReadHistory(ctx, req.(*ListReadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ClearReadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearReadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ClearReadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ClearReadHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ClearReadHistory(ctx, req.(*ClearReadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_PauseReadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseReadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).PauseReadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_PauseReadHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).PauseReadHistory(ctx, req.(*PauseReadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_GetDailyReaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyReadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetDailyReaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetDailyReaders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetDailyReaders(ctx, req.(*GetDailyReadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -293,6 +865,10 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelLike",
 			Handler:    _InteractiveService_CancelLike_Handler,
 		},
+		{
+			MethodName: "React",
+			Handler:    _InteractiveService_React_Handler,
+		},
 		{
 			MethodName: "Collect",
 			Handler:    _InteractiveService_Collect_Handler,
@@ -305,6 +881,66 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByIds",
 			Handler:    _InteractiveService_GetByIds_Handler,
 		},
+		{
+			MethodName: "CancelCollect",
+			Handler:    _InteractiveService_CancelCollect_Handler,
+		},
+		{
+			MethodName: "MoveCollectionItem",
+			Handler:    _InteractiveService_MoveCollectionItem_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _InteractiveService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _InteractiveService_RenameCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _InteractiveService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _InteractiveService_ListCollections_Handler,
+		},
+		{
+			MethodName: "ListCollectionItems",
+			Handler:    _InteractiveService_ListCollectionItems_Handler,
+		},
+		{
+			MethodName: "ListLiked",
+			Handler:    _InteractiveService_ListLiked_Handler,
+		},
+		{
+			MethodName: "ListCollected",
+			Handler:    _InteractiveService_ListCollected_Handler,
+		},
+		{
+			MethodName: "ReportReadProgress",
+			Handler:    _InteractiveService_ReportReadProgress_Handler,
+		},
+		{
+			MethodName: "GetReadProgress",
+			Handler:    _InteractiveService_GetReadProgress_Handler,
+		},
+		{
+			MethodName: "ListReadHistory",
+			Handler:    _InteractiveService_ListReadHistory_Handler,
+		},
+		{
+			MethodName: "ClearReadHistory",
+			Handler:    _InteractiveService_ClearReadHistory_Handler,
+		},
+		{
+			MethodName: "PauseReadHistory",
+			Handler:    _InteractiveService_PauseReadHistory_Handler,
+		},
+		{
+			MethodName: "GetDailyReaders",
+			Handler:    _InteractiveService_GetDailyReaders_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "intr/v1/intr.proto",
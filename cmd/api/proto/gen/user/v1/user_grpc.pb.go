@@ -23,6 +23,7 @@ const (
 	UserService_FindOrCreate_FullMethodName           = "/user.v1.UserService/FindOrCreate"
 	UserService_Login_FullMethodName                  = "/user.v1.UserService/Login"
 	UserService_Profile_FullMethodName                = "/user.v1.UserService/Profile"
+	UserService_BatchProfile_FullMethodName           = "/user.v1.UserService/BatchProfile"
 	UserService_UpdateNonSensitiveInfo_FullMethodName = "/user.v1.UserService/UpdateNonSensitiveInfo"
 	UserService_FindOrCreateByWechat_FullMethodName   = "/user.v1.UserService/FindOrCreateByWechat"
 )
@@ -35,6 +36,8 @@ type UserServiceClient interface {
 	FindOrCreate(ctx context.Context, in *FindOrCreateRequest, opts ...grpc.CallOption) (*FindOrCreateResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	// 批量查询用户信息，不存在的用户不会出现在结果里面
+	BatchProfile(ctx context.Context, in *BatchProfileRequest, opts ...grpc.CallOption) (*BatchProfileResponse, error)
 	UpdateNonSensitiveInfo(ctx context.Context, in *UpdateNonSensitiveInfoRequest, opts ...grpc.CallOption) (*UpdateNonSensitiveInfoResponse, error)
 	FindOrCreateByWechat(ctx context.Context, in *FindOrCreateByWechatRequest, opts ...grpc.CallOption) (*FindOrCreateByWechatResponse, error)
 }
@@ -83,6 +86,15 @@ func (c *userServiceClient) Profile(ctx context.Context, in *ProfileRequest, opt
 	return out, nil
 }
 
+func (c *userServiceClient) BatchProfile(ctx context.Context, in *BatchProfileRequest, opts ...grpc.CallOption) (*BatchProfileResponse, error) {
+	out := new(BatchProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdateNonSensitiveInfo(ctx context.Context, in *UpdateNonSensitiveInfoRequest, opts ...grpc.CallOption) (*UpdateNonSensitiveInfoResponse, error) {
 	out := new(UpdateNonSensitiveInfoResponse)
 	err := c.cc.Invoke(ctx, UserService_UpdateNonSensitiveInfo_FullMethodName, in, out, opts...)
@@ -109,6 +121,8 @@ type UserServiceServer interface {
 	FindOrCreate(context.Context, *FindOrCreateRequest) (*FindOrCreateResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	// 批量查询用户信息，不存在的用户不会出现在结果里面
+	BatchProfile(context.Context, *BatchProfileRequest) (*BatchProfileResponse, error)
 	UpdateNonSensitiveInfo(context.Context, *UpdateNonSensitiveInfoRequest) (*UpdateNonSensitiveInfoResponse, error)
 	FindOrCreateByWechat(context.Context, *FindOrCreateByWechatRequest) (*FindOrCreateByWechatResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
@@ -130,6 +144,9 @@ func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*Lo
 func (UnimplementedUserServiceServer) Profile(context.Context, *ProfileRequest) (*ProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Profile not implemented")
 }
+func (UnimplementedUserServiceServer) BatchProfile(context.Context, *BatchProfileRequest) (*BatchProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchProfile not implemented")
+}
 func (UnimplementedUserServiceServer) UpdateNonSensitiveInfo(context.Context, *UpdateNonSensitiveInfoRequest) (*UpdateNonSensitiveInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateNonSensitiveInfo not implemented")
 }
@@ -221,6 +238,24 @@ func _UserService_Profile_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_BatchProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchProfile(ctx, req.(*BatchProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UpdateNonSensitiveInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateNonSensitiveInfoRequest)
 	if err := dec(in); err != nil {
@@ -280,6 +315,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Profile",
 			Handler:    _UserService_Profile_Handler,
 		},
+		{
+			MethodName: "BatchProfile",
+			Handler:    _UserService_BatchProfile_Handler,
+		},
 		{
 			MethodName: "UpdateNonSensitiveInfo",
 			Handler:    _UserService_UpdateNonSensitiveInfo_Handler,
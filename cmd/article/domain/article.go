@@ -7,14 +7,35 @@ type Article struct {
 	Title   string
 	Status  ArticleStatus
 	Content string
-	// 作者
+	// 作者，也就是创建帖子的人
 	Author Author
+	// Authors 所有的作者，按照署名顺序排列，第一个是创建帖子的人
+	// 只有读者端才有
+	Authors []Author `json:"-"`
+	// Editor 这一次实际修改帖子的人，共同作者修改的时候和 Author 不一样
+	Editor Author `json:"-"`
 	Ctime  time.Time
 	Utime  time.Time
+	// PublishAt 定时发表的时间，只有在定时发表状态下才有意义
+	PublishAt time.Time
+	// Version 乐观锁的版本号，保存草稿的时候要带上最后看到的版本号
+	Version int64
+	// Dtime 删除的时间，没有删除就是零值
+	Dtime time.Time
+	// Rendered 渲染之后的内容，只有读者端才有
+	Rendered Rendered
+	// SeriesNav 所在系列的导航，只有读者端才有，不在系列里面就是 nil
+	// 系列里面的帖子撤回之后导航就要变，所以不跟着帖子一起缓存，单独缓存、单独失效
+	SeriesNav *SeriesNav `json:"-"`
+	// Visibility 可见范围，单独存储、单独缓存，修改的时候删掉缓存马上生效，所以也不跟着帖子一起缓存
+	Visibility Visibility `json:"-"`
 }
 
 // Abstract 取部分作为摘要
 func (a Article) Abstract() string {
+	if a.Rendered.Abstract != "" {
+		return a.Rendered.Abstract
+	}
 	cs := []rune(a.Content)
 	if len(cs) < 100 {
 		return a.Content
@@ -26,6 +47,19 @@ func (a Article) Published() bool {
 	return a.Status == ArticleStatusPublished
 }
 
+func (a Article) Scheduled() bool {
+	return a.Status == ArticleStatusScheduled
+}
+
+func (a Article) UnderReview() bool {
+	return a.Status == ArticleStatusUnderReview
+}
+
+// Deleted 在回收站里面的帖子
+func (a Article) Deleted() bool {
+	return !a.Dtime.IsZero()
+}
+
 type ArticleStatus uint8
 
 //go:inline
@@ -42,6 +76,11 @@ const (
 	ArticleStatusPublished
 	// ArticleStatusPrivate 仅自己可见
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 等待定时发表
+	ArticleStatusScheduled
+	// ArticleStatusUnderReview 发表的时候没有通过内容审核，等待人工审核
+	// 审核通过之前不会进入线上库
+	ArticleStatusUnderReview
 )
 
 // Author 在帖子这个领域内，
@@ -49,4 +88,15 @@ const (
 type Author struct {
 	Id   int64
 	Name string
+	Role AuthorRole
 }
+
+type AuthorRole uint8
+
+const (
+	AuthorRoleUnknown AuthorRole = iota
+	// AuthorRoleOwner 创建帖子的人，只有他能够邀请共同作者、撤回帖子
+	AuthorRoleOwner
+	// AuthorRoleCoAuthor 共同作者，可以编辑和发表帖子
+	AuthorRoleCoAuthor
+)
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Attachment 帖子的附件，比如说论文里面的图片和 PDF
+type Attachment struct {
+	Id int64
+	// Uid 上传的人
+	Uid int64
+	// ArticleId 为 0 说明还没有关联到帖子
+	ArticleId int64
+	// Hash 内容的 SHA-256，十六进制
+	Hash        string
+	Size        int64
+	ContentType string
+	FileName    string
+	// URL 读者访问的地址
+	URL   string
+	Ctime time.Time
+}
+
+// Key 附件在对象存储里面的 key，按照内容寻址，同样的内容只存一份
+func (a Attachment) Key() string {
+	return AttachmentKey(a.Hash)
+}
+
+func AttachmentKey(hash string) string {
+	return "attachments/" + hash[:2] + "/" + hash
+}
+
+// UploadTicket 上传凭证，客户端拿着它直接上传到对象存储
+type UploadTicket struct {
+	AttachmentId int64
+	// Exists 为 true 说明同样的内容已经上传过了，不需要再上传，直接确认就可以
+	Exists bool
+	URL    string
+	// Headers 上传的时候必须原样带上
+	Headers  map[string]string
+	ExpireAt time.Time
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/markdown"
+)
+
+type CitationSource uint8
+
+const (
+	// CitationSourceDeclared 作者自己声明的引用
+	CitationSourceDeclared CitationSource = iota + 1
+	// CitationSourceExtracted 发表的时候从正文的链接里面提取出来的
+	CitationSourceExtracted
+)
+
+func (s CitationSource) ToUint8() uint8 {
+	return uint8(s)
+}
+
+func (s CitationSource) Valid() bool {
+	return s == CitationSourceDeclared || s == CitationSourceExtracted
+}
+
+// Citation 一篇帖子引用了别的东西，要么是站内的帖子，要么是一个 DOI
+type Citation struct {
+	// ArticleId 引用的那篇帖子
+	ArticleId int64
+	// CitedId 被引用的站内帖子，引用外部文献的时候是 0
+	CitedId int64
+	// DOI 外部文献，统一转成小写
+	DOI    string
+	Source CitationSource
+	Ctime  time.Time
+}
+
+// External 是不是引用的外部文献
+func (c Citation) External() bool {
+	return c.CitedId == 0
+}
+
+// Key 同一篇帖子里面，同一个来源的引用按照这个去重
+func (c Citation) Key() string {
+	if c.External() {
+		return "doi:" + c.DOI
+	}
+	return "aid:" + strconv.FormatInt(c.CitedId, 10)
+}
+
+var (
+	doiRegexp = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	// 站内帖子的链接，绝对路径和完整的 URL 都可以
+	articleLinkRegexp = regexp.MustCompile(`^/articles/pub/(\d+)/?$`)
+)
+
+// NormalizeDOI 去掉前缀并且转成小写，不合法的返回空字符串
+// DOI 本身是大小写不敏感的
+func NormalizeDOI(doi string) string {
+	doi = strings.TrimSpace(doi)
+	lower := strings.ToLower(doi)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/",
+		"https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if strings.HasPrefix(lower, prefix) {
+			lower = lower[len(prefix):]
+			break
+		}
+	}
+	if !doiRegexp.MatchString(lower) {
+		return ""
+	}
+	return lower
+}
+
+// ExtractCitations 从正文的链接里面提取引用，
+// 包括站内帖子的链接和 doi.org 的链接，重复的只保留一个
+// 引用自己的链接会被忽略
+func (a Article) ExtractCitations() []Citation {
+	links := markdown.Render(a.Content).Links
+	res := make([]Citation, 0, len(links))
+	seen := make(map[string]struct{}, len(links))
+	for _, link := range links {
+		c, ok := parseCitationLink(link)
+		if !ok || c.CitedId == a.Id {
+			continue
+		}
+		c.ArticleId = a.Id
+		c.Source = CitationSourceExtracted
+		if _, ok = seen[c.Key()]; ok {
+			continue
+		}
+		seen[c.Key()] = struct{}{}
+		res = append(res, c)
+	}
+	return res
+}
+
+func parseCitationLink(link string) (Citation, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return Citation{}, false
+	}
+	switch strings.ToLower(u.Host) {
+	case "doi.org", "dx.doi.org":
+		// DOI 里面可能有转义过的字符
+		doi := NormalizeDOI(strings.TrimPrefix(u.Path, "/"))
+		return Citation{DOI: doi}, doi != ""
+	}
+	// 完整的 URL 不校验域名，站内的前端域名可能有好几个
+	matches := articleLinkRegexp.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return Citation{}, false
+	}
+	id, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil || id <= 0 {
+		return Citation{}, false
+	}
+	return Citation{CitedId: id}, true
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticle_ExtractCitations(t *testing.T) {
+	art := Article{
+		Id: 1,
+		Content: "参考 [上一篇](/articles/pub/12)、[全文](https://scholar.net/articles/pub/13/) " +
+			"和 [论文](https://doi.org/10.1000/ABC.1)\n\n" +
+			"又提到了 [上一篇](/articles/pub/12) 和 <https://dx.doi.org/10.1000/abc.1>，" +
+			"[自己](/articles/pub/1)、[草稿](/articles/12/edit)、[坏的](https://doi.org/abc)\n\n" +
+			"```\n[代码](/articles/pub/14)\n```",
+	}
+	assert.Equal(t, []Citation{
+		{ArticleId: 1, CitedId: 12, Source: CitationSourceExtracted},
+		{ArticleId: 1, CitedId: 13, Source: CitationSourceExtracted},
+		{ArticleId: 1, DOI: "10.1000/abc.1", Source: CitationSourceExtracted},
+	}, art.ExtractCitations())
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	testCases := []struct {
+		doi  string
+		want string
+	}{
+		{doi: "10.1000/XYZ", want: "10.1000/xyz"},
+		{doi: " https://doi.org/10.1000/xyz ", want: "10.1000/xyz"},
+		{doi: "doi:10.1000/xyz", want: "10.1000/xyz"},
+		{doi: "10.10/xyz"},
+		{doi: "10.1000/"},
+		{doi: "xyz"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.doi, func(t *testing.T) {
+			assert.Equal(t, tc.want, NormalizeDOI(tc.doi))
+		})
+	}
+}
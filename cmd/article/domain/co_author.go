@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type CoAuthorStatus uint8
+
+func (s CoAuthorStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	CoAuthorStatusUnknown CoAuthorStatus = iota
+	// CoAuthorStatusPending 已经邀请，还没有接受
+	CoAuthorStatusPending
+	// CoAuthorStatusAccepted 接受了邀请
+	CoAuthorStatusAccepted
+)
+
+// CoAuthor 帖子的共同作者，或者说一个共同作者的邀请
+type CoAuthor struct {
+	ArticleId int64
+	Author    Author
+	Status    CoAuthorStatus
+	Ctime     time.Time
+}
+
+func (c CoAuthor) Accepted() bool {
+	return c.Status == CoAuthorStatusAccepted
+}
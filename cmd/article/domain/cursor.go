@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/cursorx"
+)
+
+var ErrInvalidCursor = cursorx.ErrInvalidCursor
+
+// Cursor 帖子按照 (utime, id) 倒序翻页，Time 就是 utime
+type Cursor = cursorx.Cursor
+
+// FirstPage 从最新的数据开始
+var FirstPage = cursorx.FirstPage
+
+// CursorBefore 从 utime 之前开始，不包括 utime 本身
+func CursorBefore(utime time.Time) Cursor {
+	return Cursor{Time: utime}
+}
+
+// CursorAfter 排在 art 后面的那一页
+func CursorAfter(art Article) Cursor {
+	return Cursor{Time: art.Utime, Id: art.Id}
+}
+
+// DecodeCursor 空字符串就是第一页
+func DecodeCursor(s string) (Cursor, error) {
+	return cursorx.Decode(s)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/XD/ScholarNet/cmd/pkg/markdown"
+)
+
+const (
+	// abstractLen 摘要的长度，按照字符计算
+	abstractLen = 100
+	// 阅读速度，中日韩文字按字算，其它的按照单词算
+	cjkPerMinute  = 400
+	wordPerMinute = 200
+)
+
+// Rendered 帖子内容渲染之后的结果
+// 读者端用的都是这个，所以和已发表的帖子一起缓存
+type Rendered struct {
+	// HTML 经过过滤的 HTML，可以直接输出
+	HTML string
+	TOC  []TOCItem
+	// Abstract 纯文本的摘要
+	Abstract       string
+	ReadingMinutes int
+}
+
+// TOCItem 目录中的一项
+type TOCItem struct {
+	Level  int
+	Title  string
+	Anchor string
+}
+
+// Render 把 Markdown 格式的内容渲染出来
+func (a Article) Render() Rendered {
+	res := markdown.Render(a.Content)
+	toc := make([]TOCItem, 0, len(res.TOC))
+	for _, h := range res.TOC {
+		toc = append(toc, TOCItem{Level: h.Level, Title: h.Title, Anchor: h.Anchor})
+	}
+	return Rendered{
+		HTML:           res.HTML,
+		TOC:            toc,
+		Abstract:       abstract(res.PlainText),
+		ReadingMinutes: readingMinutes(res.PlainText),
+	}
+}
+
+func abstract(text string) string {
+	// 摘要里面不需要换行
+	text = strings.Join(strings.Fields(text), " ")
+	cs := []rune(text)
+	if len(cs) <= abstractLen {
+		return text
+	}
+	return string(cs[:abstractLen]) + "..."
+}
+
+// readingMinutes 估算阅读时间，不足一分钟按照一分钟算
+func readingMinutes(text string) int {
+	var cjk, words int
+	inWord := false
+	for _, c := range text {
+		switch {
+		case unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	if cjk == 0 && words == 0 {
+		return 0
+	}
+	minutes := float64(cjk)/cjkPerMinute + float64(words)/wordPerMinute
+	return max(int(math.Ceil(minutes)), 1)
+}
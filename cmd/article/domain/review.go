@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ReviewLog 人工审核的记录，谁在什么时候处理了哪个版本
+type ReviewLog struct {
+	Id        int64
+	ArticleId int64
+	// Version 审核的是哪个版本，审核期间作者又修改过的话，这一次审核就作废了
+	Version  int64
+	Reviewer int64
+	Approved bool
+	Ctime    time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// ArticleRevision 帖子的历史版本
+// 每一次保存草稿都会生成一个，生成之后就不会再修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	Title     string
+	Content   string
+	// 这一次是谁改的
+	Editor Author
+	Ctime  time.Time
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Series 系列，同一个作者的多篇帖子按照顺序组织在一起
+// 比如说多篇连载的教程，或者论文的逐章解读
+type Series struct {
+	Id          int64
+	Title       string
+	Description string
+	Author      Author
+	// ArticleIds 系列里面的帖子，按照顺序排列
+	ArticleIds []int64
+	Ctime      time.Time
+	Utime      time.Time
+}
+
+// SeriesItem 导航里面的帖子，只需要 ID 和标题
+type SeriesItem struct {
+	Id    int64
+	Title string
+}
+
+// SeriesNav 读者看帖子的时候，在系列里面的上一篇和下一篇
+// 只考虑已经发表的帖子
+type SeriesNav struct {
+	SeriesId    int64
+	SeriesTitle string
+	// Prev 和 Next 的 Id 为 0 说明没有
+	Prev SeriesItem
+	Next SeriesItem
+}
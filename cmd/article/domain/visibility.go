@@ -0,0 +1,29 @@
+package domain
+
+// Visibility 已经发表的帖子谁能看，和 ArticleStatus 是两回事
+// 只有发表了的帖子才需要考虑可见范围，撤回了的帖子谁都看不到
+type Visibility uint8
+
+const (
+	// VisibilityPublic 所有人都可以看，没有设置过的帖子都是公开的
+	VisibilityPublic Visibility = iota
+	// VisibilityFollowers 关注了作者的人才能看
+	VisibilityFollowers
+	// VisibilityMutual 作者和读者互相关注才能看
+	VisibilityMutual
+	// VisibilityAllowList 只有作者指定的人才能看
+	VisibilityAllowList
+)
+
+func (v Visibility) ToUint8() uint8 {
+	return uint8(v)
+}
+
+func (v Visibility) Valid() bool {
+	return v <= VisibilityAllowList
+}
+
+// Restricted 不是所有人都能看
+func (v Visibility) Restricted() bool {
+	return v != VisibilityPublic
+}
@@ -5,4 +5,10 @@ const (
 	// ArticleInvalidInput 含糊的输入错误
 	ArticleInvalidInput        = 402001
 	ArticleInternalServerError = 502001
+
+	// ArticleVersionConflict 保存草稿的时候，帖子已经被修改过了（比如说在另外一个标签页）
+	// 前端需要拿最新的版本合并之后再保存
+	ArticleVersionConflict = 402002
+	// ArticleContentRejected 帖子内容违规，不允许发表
+	ArticleContentRejected = 402003
 )
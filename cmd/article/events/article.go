@@ -3,17 +3,63 @@ package events
 import (
 	"encoding/json"
 	"github.com/IBM/sarama"
+	"strconv"
 )
 
-const topicReadEvent = "article_read_event"
+const (
+	// topicReadEvent interactive 收到之后更新阅读计数，记录阅读历史
+	topicReadEvent   = "read_article"
+	topicDeleteEvent = "article_delete_event"
+	// topicCitationEvent interactive 收到之后更新被引用的次数
+	topicCitationEvent = "article_citation_event"
+	// topicPublishEvent feed 收到之后发给粉丝
+	topicPublishEvent = "article_publish_event"
+	// topicSyncEvent 搜索收到之后更新索引
+	topicSyncEvent = "sync_article_event"
+)
 
 type ReadEvent struct {
 	Aid int64
 	Uid int64
+	// DeviceId 没有登录的读者靠它来去重，BFF 拿得到的时候才有
+	DeviceId string
+}
+
+// DeleteEvent 帖子删除之后发出来，feed、搜索和热榜收到之后把帖子去掉
+// 恢复的时候发 PublishEvent 和 SyncEvent 加回去，热榜下一次计算的时候自己会加回去
+type DeleteEvent struct {
+	Aid int64
+	// Uid 创建帖子的人
+	Uid int64
+}
+
+// PublishEvent 帖子重新出现在线上的时候发出来，和 feed 那边的 ArticlePublishedEvent 对应
+type PublishEvent struct {
+	Uid int64
+	Aid int64
+}
+
+// SyncEvent 和搜索那边的 ArticleEvent 对应，带的是线上库的内容
+type SyncEvent struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	Status  int32  `json:"status"`
+	Content string `json:"content"`
+}
+
+// CitationEvent 引用关系变了之后发出来
+// 带的是被引用的帖子现在的被引用次数，不是增量，所以重复消费也没有关系
+type CitationEvent struct {
+	// CiteCnts 被引用的帖子 => 被多少篇帖子引用了
+	CiteCnts map[int64]int64
 }
 
 type Producer interface {
 	ProduceReadEvent(evt ReadEvent) error
+	ProduceDeleteEvent(evt DeleteEvent) error
+	ProduceCitationEvent(evt CitationEvent) error
+	ProducePublishEvent(evt PublishEvent) error
+	ProduceSyncEvent(evt SyncEvent) error
 }
 
 type SaramaSyncProducer struct {
@@ -38,3 +84,58 @@ func (s *SaramaSyncProducer) ProduceReadEvent(evt ReadEvent) error {
 		})
 	return err
 }
+
+func (s *SaramaSyncProducer) ProduceDeleteEvent(evt DeleteEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.
+		SendMessage(&sarama.ProducerMessage{
+			Topic: topicDeleteEvent,
+			Key:   sarama.StringEncoder(strconv.FormatInt(evt.Aid, 10)),
+			Value: sarama.ByteEncoder(val),
+		})
+	return err
+}
+
+func (s *SaramaSyncProducer) ProduceCitationEvent(evt CitationEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.
+		SendMessage(&sarama.ProducerMessage{
+			Topic: topicCitationEvent,
+			Value: sarama.ByteEncoder(val),
+		})
+	return err
+}
+
+func (s *SaramaSyncProducer) ProducePublishEvent(evt PublishEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.
+		SendMessage(&sarama.ProducerMessage{
+			Topic: topicPublishEvent,
+			Key:   sarama.StringEncoder(strconv.FormatInt(evt.Aid, 10)),
+			Value: sarama.ByteEncoder(val),
+		})
+	return err
+}
+
+func (s *SaramaSyncProducer) ProduceSyncEvent(evt SyncEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.
+		SendMessage(&sarama.ProducerMessage{
+			Topic: topicSyncEvent,
+			Key:   sarama.StringEncoder(strconv.FormatInt(evt.Id, 10)),
+			Value: sarama.ByteEncoder(val),
+		})
+	return err
+}
@@ -4,18 +4,36 @@ import (
 	"context"
 	"github.com/IBM/sarama"
 	"github.com/XD/ScholarNet/cmd/article/domain"
-	"github.com/XD/ScholarNet/cmd/article/repository"
-	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
 	"github.com/XD/ScholarNet/cmd/pkg/canalx"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
 	"github.com/XD/ScholarNet/cmd/pkg/saramax"
 	"time"
 )
 
+const (
+	// 制作库
+	tableArticles = "articles"
+	// 线上库
+	tablePublishedArticles = "published_articles"
+)
+
+// MySQLBinlogConsumer 根据 binlog 来维护帖子的缓存，
+// 这样写数据库的时候就不需要再同步更新缓存了
+// binlog 可能乱序，也可能重复，读者端的缓存用 utime 来判断是不是旧的数据
 type MySQLBinlogConsumer struct {
 	client sarama.Client
 	l      logger.LoggerV1
-	repo   *repository.CachedArticleRepository
+	cache  cache.ArticleCache
+}
+
+func NewMySQLBinlogConsumer(client sarama.Client,
+	l logger.LoggerV1, c cache.ArticleCache) *MySQLBinlogConsumer {
+	return &MySQLBinlogConsumer{
+		client: client,
+		l:      l,
+		cache:  c,
+	}
 }
 
 func (r *MySQLBinlogConsumer) Start() error {
@@ -27,7 +45,7 @@ func (r *MySQLBinlogConsumer) Start() error {
 	go func() {
 		err := cg.Consume(context.Background(),
 			[]string{"webook_binlog"},
-			saramax.NewHandler[canalx.Message[dao.PublishedArticle]](r.l, r.Consume))
+			saramax.NewHandler[canalx.Message[binlogArticle]](r.l, r.Consume))
 		if err != nil {
 			r.l.Error("退出了消费循环异常", logger.Error(err))
 		}
@@ -36,24 +54,99 @@ func (r *MySQLBinlogConsumer) Start() error {
 }
 
 func (r *MySQLBinlogConsumer) Consume(msg *sarama.ConsumerMessage,
-	val canalx.Message[dao.PublishedArticle]) error {
-	// 因为共用了一个 topic，所以会有很多表的数据，不是自己的就不用管了
-	if val.Table != "published_articles" {
+	val canalx.Message[binlogArticle]) error {
+	if val.IsDdl {
 		return nil
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
+	// 因为共用了一个 topic，所以会有很多表的数据，不是自己的就不用管了
+	switch val.Table {
+	case tableArticles:
+		return r.consumeAuthor(ctx, val)
+	case tablePublishedArticles:
+		return r.consumeReader(ctx, val)
+	default:
+		return nil
+	}
+}
+
+// consumeAuthor 制作库的修改，删掉创作者的缓存就可以，下一次查询会重新加载
+func (r *MySQLBinlogConsumer) consumeAuthor(ctx context.Context,
+	val canalx.Message[binlogArticle]) error {
+	for _, data := range val.Data {
+		if err := r.cache.Del(ctx, int64(data.Id)); err != nil {
+			return err
+		}
+		if err := r.cache.DelFirstPage(ctx, int64(data.AuthorId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consumeReader 线上库的修改，发表的帖子直接刷新缓存，别的都删掉缓存
+func (r *MySQLBinlogConsumer) consumeReader(ctx context.Context,
+	val canalx.Message[binlogArticle]) error {
 	for _, data := range val.Data {
-		var err error
-		switch data.Status {
-		case domain.ArticleStatusPublished.ToUint8():
-			err = r.repo.Cache().SetPub(ctx, r.repo.ToDomain(dao.Article(data)))
-		case domain.ArticleStatusPrivate.ToUint8():
-			err = r.repo.Cache().DelPub(ctx, data.Id)
+		art := data.toDomain()
+		var (
+			ok  bool
+			err error
+		)
+		if val.Type != canalx.TypeDelete && art.Published() && !art.Deleted() {
+			// 渲染比较耗时，渲染之后的结果和帖子一起缓存
+			art.Rendered = art.Render()
+			ok, err = r.cache.SetPubIfNewer(ctx, art)
+		} else {
+			ok, err = r.cache.DelPubIfNewer(ctx, art.Id, art.Utime)
 		}
 		if err != nil {
 			return err
 		}
+		if !ok {
+			r.l.Debug("忽略乱序的 binlog",
+				logger.Int64("aid", art.Id),
+				logger.String("type", val.Type),
+				logger.Int64("utime", art.Utime.UnixMilli()))
+		}
 	}
 	return nil
 }
+
+// binlogArticle articles 和 published_articles 两张表的一行
+// canal 用的是列名，并且值都是字符串
+type binlogArticle struct {
+	Id        canalx.Int64 `json:"id"`
+	Title     string       `json:"title"`
+	Content   string       `json:"content"`
+	AuthorId  canalx.Int64 `json:"author_id"`
+	Status    canalx.Int64 `json:"status"`
+	Ctime     canalx.Int64 `json:"ctime"`
+	Utime     canalx.Int64 `json:"utime"`
+	PublishAt canalx.Int64 `json:"publish_at"`
+	Version   canalx.Int64 `json:"version"`
+	Dtime     canalx.Int64 `json:"dtime"`
+}
+
+func (b binlogArticle) toDomain() domain.Article {
+	res := domain.Article{
+		Id:      int64(b.Id),
+		Title:   b.Title,
+		Status:  domain.ArticleStatus(b.Status),
+		Content: b.Content,
+		Author: domain.Author{
+			Id: int64(b.AuthorId),
+		},
+		Version: int64(b.Version),
+		Ctime:   time.UnixMilli(int64(b.Ctime)),
+		Utime:   time.UnixMilli(int64(b.Utime)),
+	}
+	if b.PublishAt > 0 {
+		res.PublishAt = time.UnixMilli(int64(b.PublishAt))
+	}
+	if b.Dtime > 0 {
+		res.Dtime = time.UnixMilli(int64(b.Dtime))
+	}
+	return res
+}
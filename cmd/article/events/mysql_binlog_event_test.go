@@ -0,0 +1,127 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	cachemocks "github.com/XD/ScholarNet/cmd/article/repository/cache/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/canalx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMySQLBinlogConsumer_Consume(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) cache.ArticleCache
+		// canal 发过来的原始消息
+		msg string
+
+		wantErr error
+	}{
+		{
+			name: "发表，刷新读者端缓存",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().SetPubIfNewer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, art domain.Article) (bool, error) {
+						assert.Equal(t, int64(1), art.Id)
+						assert.Equal(t, int64(123), art.Author.Id)
+						assert.Equal(t, "标题", art.Title)
+						assert.Equal(t, time.UnixMilli(1700000000000), art.Utime)
+						assert.NotEmpty(t, art.Rendered.HTML)
+						return true, nil
+					})
+				return c
+			},
+			msg: `{"data":[{"id":"1","title":"标题","content":"# 内容","author_id":"123",
+"status":"2","ctime":"1700000000000","utime":"1700000000000","publish_at":"0","version":"1","dtime":"0"}],
+"database":"webook_article","table":"published_articles","type":"UPDATE","isDdl":false}`,
+		},
+		{
+			name: "撤回，删除读者端缓存",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DelPubIfNewer(gomock.Any(), int64(1), time.UnixMilli(1700000000001)).
+					Return(true, nil)
+				return c
+			},
+			msg: `{"data":[{"id":"1","author_id":"123","status":"3","utime":"1700000000001","dtime":"0"}],
+"database":"webook_article","table":"published_articles","type":"UPDATE","isDdl":false}`,
+		},
+		{
+			name: "放进回收站，删除读者端缓存",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DelPubIfNewer(gomock.Any(), int64(1), time.UnixMilli(1700000000002)).
+					Return(true, nil)
+				return c
+			},
+			msg: `{"data":[{"id":"1","author_id":"123","status":"2","utime":"1700000000002","dtime":"1700000000002"}],
+"database":"webook_article","table":"published_articles","type":"UPDATE","isDdl":false}`,
+		},
+		{
+			name: "乱序的旧消息",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().SetPubIfNewer(gomock.Any(), gomock.Any()).Return(false, nil)
+				return c
+			},
+			msg: `{"data":[{"id":"1","author_id":"123","status":"2","utime":"1600000000000","dtime":null}],
+"database":"webook_article","table":"published_articles","type":"INSERT","isDdl":false}`,
+		},
+		{
+			name: "彻底删除",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DelPubIfNewer(gomock.Any(), int64(1), time.UnixMilli(1700000000002)).
+					Return(true, nil)
+				return c
+			},
+			msg: `{"data":[{"id":"1","author_id":"123","status":"2","utime":"1700000000002","dtime":"1700000000002"}],
+"database":"webook_article","table":"published_articles","type":"DELETE","isDdl":false}`,
+		},
+		{
+			name: "制作库，删除作者的缓存",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+				c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).Return(nil)
+				return c
+			},
+			msg: `{"data":[{"id":"1","author_id":"123","status":"1","utime":"1700000000000"}],
+"database":"webook_article","table":"articles","type":"UPDATE","isDdl":false}`,
+		},
+		{
+			name: "别的表",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				return cachemocks.NewMockArticleCache(ctrl)
+			},
+			msg: `{"data":[{"id":"1"}],"database":"webook","table":"users","type":"UPDATE","isDdl":false}`,
+		},
+		{
+			name: "DDL",
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				return cachemocks.NewMockArticleCache(ctrl)
+			},
+			msg: `{"data":null,"database":"webook_article","table":"published_articles","type":"ALTER","isDdl":true}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			consumer := NewMySQLBinlogConsumer(nil, logger.NewNoOpLogger(), tc.mock(ctrl))
+			var val canalx.Message[binlogArticle]
+			err := json.Unmarshal([]byte(tc.msg), &val)
+			require.NoError(t, err)
+			err = consumer.Consume(nil, val)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
 	"github.com/XD/ScholarNet/cmd/article/domain"
 	"github.com/XD/ScholarNet/cmd/article/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -28,8 +31,33 @@ func (a *ArticleServiceServer) Save(ctx context.Context, request *articlev1.Save
 	if err != nil {
 		return nil, err
 	}
-	id, err := a.service.Save(ctx, art)
-	return &articlev1.SaveResponse{Id: id}, err
+	art.Version = request.GetVersion()
+	id, version, err := a.service.Save(ctx, art)
+	if errors.Is(err, service.ErrArticleDeleted) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, versionErr(err)
+	}
+	return &articlev1.SaveResponse{Id: id, Version: version}, nil
+}
+
+// versionErr 版本号相关的错误转成对应的 gRPC 错误，其它错误原样返回
+func versionErr(err error) error {
+	var conflict service.VersionConflictError
+	if errors.As(err, &conflict) {
+		// 把服务端现在的版本号带回去，前端可以据此合并
+		st, er := status.New(codes.Aborted, err.Error()).
+			WithDetails(&articlev1.VersionConflict{CurrentVersion: conflict.Current})
+		if er != nil {
+			return err
+		}
+		return st.Err()
+	}
+	if errors.Is(err, service.ErrMissingVersion) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
 }
 
 func (a *ArticleServiceServer) Publish(ctx context.Context, request *articlev1.PublishRequest) (*articlev1.PublishResponse, error) {
@@ -37,8 +65,19 @@ func (a *ArticleServiceServer) Publish(ctx context.Context, request *articlev1.P
 	if err != nil {
 		return nil, err
 	}
-	id, err := a.service.Publish(ctx, art)
-	return &articlev1.PublishResponse{Id: id}, err
+	if request.PublishAt != nil {
+		art.PublishAt = request.PublishAt.AsTime()
+	}
+	art.Version = request.GetVersion()
+	id, version, err := a.service.Publish(ctx, art)
+	if errors.Is(err, service.ErrContentRejected) ||
+		errors.Is(err, service.ErrArticleDeleted) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, versionErr(err)
+	}
+	return &articlev1.PublishResponse{Id: id, Version: version}, nil
 }
 
 func (a *ArticleServiceServer) Withdraw(ctx context.Context, request *articlev1.WithdrawRequest) (*articlev1.WithdrawResponse, error) {
@@ -46,8 +85,33 @@ func (a *ArticleServiceServer) Withdraw(ctx context.Context, request *articlev1.
 	return &articlev1.WithdrawResponse{}, err
 }
 
+func (a *ArticleServiceServer) CancelSchedule(ctx context.Context, request *articlev1.CancelScheduleRequest) (*articlev1.CancelScheduleResponse, error) {
+	err := a.service.CancelSchedule(ctx, request.GetUid(), request.GetId())
+	return &articlev1.CancelScheduleResponse{}, err
+}
+
+func (a *ArticleServiceServer) Reschedule(ctx context.Context, request *articlev1.RescheduleRequest) (*articlev1.RescheduleResponse, error) {
+	err := a.service.Reschedule(ctx, request.GetUid(), request.GetId(), request.GetPublishAt().AsTime())
+	return &articlev1.RescheduleResponse{}, err
+}
+
 func (a *ArticleServiceServer) List(ctx context.Context, request *articlev1.ListRequest) (*articlev1.ListResponse, error) {
-	articleList, err := a.service.List(ctx, request.GetAuthor(), int(request.GetOffset()), int(request.GetLimit()))
+	var (
+		articleList []domain.Article
+		next        domain.Cursor
+		err         error
+	)
+	if request.GetCursor() == "" && request.GetOffset() > 0 {
+		// 兼容还在用 offset 的调用方
+		articleList, err = a.service.List(ctx, request.GetAuthor(), int(request.GetOffset()), int(request.GetLimit()))
+	} else {
+		var cursor domain.Cursor
+		cursor, err = domain.DecodeCursor(request.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		articleList, next, err = a.service.ListByCursor(ctx, request.GetAuthor(), cursor, int(request.GetLimit()))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +124,8 @@ func (a *ArticleServiceServer) List(ctx context.Context, request *articlev1.List
 		list = append(list, newArticle)
 	}
 	return &articlev1.ListResponse{
-		Articles: list,
+		Articles:   list,
+		NextCursor: next.Encode(),
 	}, nil
 }
 
@@ -76,18 +141,76 @@ func (a *ArticleServiceServer) GetById(ctx context.Context, request *articlev1.G
 }
 
 func (a *ArticleServiceServer) GetPublishedById(ctx context.Context, request *articlev1.GetPublishedByIdRequest) (*articlev1.GetPublishedByIdResponse, error) {
-	art, err := a.service.GetPublishedById(ctx, request.GetId(), request.GetUid())
+	art, err := a.service.GetPublishedById(ctx, request.GetId(), request.GetUid(), request.GetDeviceId())
+	if errors.Is(err, service.ErrArticleNotVisible) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 	newArticle, err := convertToV(art)
 	if err != nil {
 		return nil, err
 	}
 	return &articlev1.GetPublishedByIdResponse{
-		Article: newArticle,
+		Article:   newArticle,
+		SeriesNav: convertSeriesNavToV(art.SeriesNav),
+	}, nil
+}
+
+// maxBatchGetPublished BatchGetPublished 一次最多查询的数量
+const maxBatchGetPublished = 500
+
+func (a *ArticleServiceServer) BatchGetPublished(ctx context.Context, request *articlev1.BatchGetPublishedRequest) (*articlev1.BatchGetPublishedResponse, error) {
+	ids := request.GetIds()
+	if len(ids) > maxBatchGetPublished {
+		return nil, status.Errorf(codes.InvalidArgument, "一次最多查询 %d 篇帖子", maxBatchGetPublished)
+	}
+	var (
+		arts    []domain.Article
+		missing []int64
+		err     error
+	)
+	if request.GetAsReader() {
+		arts, missing, err = a.service.BatchGetPublishedForReader(ctx, request.GetUid(), ids)
+	} else {
+		arts, missing, err = a.service.BatchGetPublished(ctx, ids)
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Article, 0, len(arts))
+	for _, art := range arts {
+		newArticle, err := convertToV(art)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, newArticle)
+	}
+	return &articlev1.BatchGetPublishedResponse{
+		Articles:   list,
+		MissingIds: missing,
 	}, nil
 }
 
 func (a *ArticleServiceServer) ListPub(ctx context.Context, request *articlev1.ListPubRequest) (*articlev1.ListPubResponse, error) {
-	artList, err := a.service.ListPub(ctx, request.GetStartTime().AsTime(), int(request.GetOffset()), int(request.GetLimit()))
+	var (
+		artList []domain.Article
+		next    domain.Cursor
+		err     error
+	)
+	if request.GetCursor() == "" && request.GetOffset() > 0 {
+		// 兼容还在用 offset 的调用方
+		artList, err = a.service.ListPub(ctx, request.GetUid(), request.GetStartTime().AsTime(), int(request.GetOffset()), int(request.GetLimit()))
+	} else {
+		cursor := domain.FirstPage
+		if request.GetCursor() != "" {
+			cursor, err = domain.DecodeCursor(request.GetCursor())
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		} else if request.StartTime != nil {
+			cursor = domain.CursorBefore(request.GetStartTime().AsTime())
+		}
+		artList, next, err = a.service.ListPubByCursor(ctx, request.GetUid(), cursor, int(request.GetLimit()))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -100,22 +223,83 @@ func (a *ArticleServiceServer) ListPub(ctx context.Context, request *articlev1.L
 		list = append(list, newArticle)
 	}
 	return &articlev1.ListPubResponse{
-		Articles: list,
+		Articles:   list,
+		NextCursor: next.Encode(),
+	}, nil
+}
+
+func (a *ArticleServiceServer) ListRevisions(ctx context.Context, request *articlev1.ListRevisionsRequest) (*articlev1.ListRevisionsResponse, error) {
+	revs, err := a.service.ListRevisions(ctx, request.GetUid(), request.GetArticleId(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Revision, 0, len(revs))
+	for _, rev := range revs {
+		list = append(list, convertRevisionToV(rev))
+	}
+	return &articlev1.ListRevisionsResponse{
+		Revisions: list,
 	}, nil
 }
 
+func (a *ArticleServiceServer) DiffRevisions(ctx context.Context, request *articlev1.DiffRevisionsRequest) (*articlev1.DiffRevisionsResponse, error) {
+	diff, err := a.service.DiffRevisions(ctx, request.GetUid(), request.GetArticleId(),
+		request.GetFrom(), request.GetTo())
+	return &articlev1.DiffRevisionsResponse{Diff: diff}, err
+}
+
+func (a *ArticleServiceServer) RestoreRevision(ctx context.Context, request *articlev1.RestoreRevisionRequest) (*articlev1.RestoreRevisionResponse, error) {
+	version, err := a.service.RestoreRevision(ctx, request.GetUid(), request.GetArticleId(),
+		request.GetRevisionId(), request.GetVersion())
+	if err != nil {
+		return nil, versionErr(err)
+	}
+	return &articlev1.RestoreRevisionResponse{Version: version}, nil
+}
+
+func convertRevisionToV(rev domain.ArticleRevision) *articlev1.Revision {
+	return &articlev1.Revision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Editor: &articlev1.Author{
+			Id:   rev.Editor.Id,
+			Name: rev.Editor.Name,
+		},
+		Ctime: timestamppb.New(rev.Ctime),
+	}
+}
+
 func convertToV(domainArticle domain.Article) (*articlev1.Article, error) {
 	newArticle := articlev1.Article{}
 	newArticle.Id = domainArticle.Id
 	newArticle.Title = domainArticle.Title
 	newArticle.Status = int32(domainArticle.Status)
 	newArticle.Content = domainArticle.Content
-	newArticle.Author = &articlev1.Author{
-		Id:   domainArticle.Author.Id,
-		Name: domainArticle.Author.Name,
+	newArticle.Author = convertAuthorToV(domainArticle.Author)
+	for _, author := range domainArticle.Authors {
+		newArticle.Authors = append(newArticle.Authors, convertAuthorToV(author))
 	}
 	newArticle.Ctime = timestamppb.New(domainArticle.Ctime)
 	newArticle.Utime = timestamppb.New(domainArticle.Utime)
+	if !domainArticle.PublishAt.IsZero() {
+		newArticle.PublishAt = timestamppb.New(domainArticle.PublishAt)
+	}
+	newArticle.Version = domainArticle.Version
+	newArticle.Visibility = int32(domainArticle.Visibility)
+	newArticle.Abstract = domainArticle.Abstract()
+	rendered := domainArticle.Rendered
+	newArticle.Html = rendered.HTML
+	newArticle.ReadingMinutes = int32(rendered.ReadingMinutes)
+	for _, item := range rendered.TOC {
+		newArticle.Toc = append(newArticle.Toc, &articlev1.TocItem{
+			Level:  int32(item.Level),
+			Title:  item.Title,
+			Anchor: item.Anchor,
+		})
+	}
 	return &newArticle, nil
 }
 
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AttachmentServiceServer struct {
+	articlev1.UnimplementedAttachmentServiceServer
+	service service.AttachmentService
+}
+
+func NewAttachmentServiceServer(svc service.AttachmentService) *AttachmentServiceServer {
+	return &AttachmentServiceServer{
+		service: svc,
+	}
+}
+
+func (a *AttachmentServiceServer) Register(server grpc.ServiceRegistrar) {
+	articlev1.RegisterAttachmentServiceServer(server, a)
+}
+
+func (a *AttachmentServiceServer) PrepareUpload(ctx context.Context, request *articlev1.PrepareUploadRequest) (*articlev1.PrepareUploadResponse, error) {
+	ticket, err := a.service.PrepareUpload(ctx, domain.Attachment{
+		Uid:         request.GetUid(),
+		Hash:        request.GetSha256(),
+		Size:        request.GetSize(),
+		ContentType: request.GetContentType(),
+		FileName:    request.GetFileName(),
+	})
+	switch {
+	case errors.Is(err, service.ErrInvalidAttachment),
+		errors.Is(err, service.ErrUnsupportedAttachmentType),
+		errors.Is(err, service.ErrAttachmentTooLarge):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	res := &articlev1.PrepareUploadResponse{
+		Id:        ticket.AttachmentId,
+		Exists:    ticket.Exists,
+		UploadUrl: ticket.URL,
+		Headers:   ticket.Headers,
+	}
+	if !ticket.ExpireAt.IsZero() {
+		res.ExpireAt = timestamppb.New(ticket.ExpireAt)
+	}
+	return res, nil
+}
+
+func (a *AttachmentServiceServer) ConfirmUpload(ctx context.Context, request *articlev1.ConfirmUploadRequest) (*articlev1.ConfirmUploadResponse, error) {
+	err := a.service.ConfirmUpload(ctx, request.GetUid(), request.GetId())
+	return &articlev1.ConfirmUploadResponse{}, convertAttachmentErr(err)
+}
+
+func (a *AttachmentServiceServer) Attach(ctx context.Context, request *articlev1.AttachRequest) (*articlev1.AttachResponse, error) {
+	err := a.service.Attach(ctx, request.GetUid(), request.GetArticleId(), request.GetId())
+	return &articlev1.AttachResponse{}, convertAttachmentErr(err)
+}
+
+func (a *AttachmentServiceServer) ListAttachments(ctx context.Context, request *articlev1.ListAttachmentsRequest) (*articlev1.ListAttachmentsResponse, error) {
+	atts, err := a.service.ListAttachments(ctx, request.GetArticleId())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Attachment, 0, len(atts))
+	for _, att := range atts {
+		list = append(list, &articlev1.Attachment{
+			Id:          att.Id,
+			ArticleId:   att.ArticleId,
+			FileName:    att.FileName,
+			ContentType: att.ContentType,
+			Size:        att.Size,
+			Url:         att.URL,
+			Ctime:       timestamppb.New(att.Ctime),
+		})
+	}
+	return &articlev1.ListAttachmentsResponse{Attachments: list}, nil
+}
+
+func convertAttachmentErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrAttachmentNotUploaded),
+		errors.Is(err, service.ErrAttachmentInOtherArticle):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxListCitedBy ListCitedBy 一页最多多少篇
+const maxListCitedBy = 100
+
+type CitationServiceServer struct {
+	articlev1.UnimplementedCitationServiceServer
+	service service.CitationService
+}
+
+func NewCitationServiceServer(svc service.CitationService) *CitationServiceServer {
+	return &CitationServiceServer{
+		service: svc,
+	}
+}
+
+func (c *CitationServiceServer) Register(server grpc.ServiceRegistrar) {
+	articlev1.RegisterCitationServiceServer(server, c)
+}
+
+func (c *CitationServiceServer) SetCitations(ctx context.Context, request *articlev1.SetCitationsRequest) (*articlev1.SetCitationsResponse, error) {
+	cits := make([]domain.Citation, 0, len(request.GetCitations()))
+	for _, cit := range request.GetCitations() {
+		cits = append(cits, domain.Citation{
+			CitedId: cit.GetCitedId(),
+			DOI:     cit.GetDoi(),
+		})
+	}
+	err := c.service.SetCitations(ctx, request.GetUid(), request.GetArticleId(), cits)
+	switch {
+	case errors.Is(err, service.ErrInvalidCitation),
+		errors.Is(err, service.ErrTooManyCitations):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCitedArticleMissing):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &articlev1.SetCitationsResponse{}, nil
+}
+
+func (c *CitationServiceServer) ListCitations(ctx context.Context, request *articlev1.ListCitationsRequest) (*articlev1.ListCitationsResponse, error) {
+	cits, err := c.service.ListCitations(ctx, request.GetArticleId())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Citation, 0, len(cits))
+	for _, cit := range cits {
+		list = append(list, &articlev1.Citation{
+			ArticleId: cit.ArticleId,
+			CitedId:   cit.CitedId,
+			Doi:       cit.DOI,
+			Source:    int32(cit.Source),
+			Ctime:     timestamppb.New(cit.Ctime),
+		})
+	}
+	return &articlev1.ListCitationsResponse{Citations: list}, nil
+}
+
+func (c *CitationServiceServer) ListCitedBy(ctx context.Context, request *articlev1.ListCitedByRequest) (*articlev1.ListCitedByResponse, error) {
+	limit := min(int(request.GetLimit()), maxListCitedBy)
+	arts, err := c.service.ListCitedBy(ctx, request.GetArticleId(), int(request.GetOffset()), limit)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Article, 0, len(arts))
+	for _, art := range arts {
+		newArticle, err := convertToV(art)
+		if err != nil {
+			return nil, err
+		}
+		// 列表里面不需要内容
+		newArticle.Content = ""
+		newArticle.Html = ""
+		newArticle.Toc = nil
+		list = append(list, newArticle)
+	}
+	return &articlev1.ListCitedByResponse{Articles: list}, nil
+}
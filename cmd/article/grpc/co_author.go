@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (a *ArticleServiceServer) InviteCoAuthor(ctx context.Context, request *articlev1.InviteCoAuthorRequest) (*articlev1.InviteCoAuthorResponse, error) {
+	err := a.service.InviteCoAuthor(ctx, request.GetUid(), request.GetId(), request.GetInvitee())
+	switch {
+	case errors.Is(err, service.ErrInvalidCoAuthor):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrDuplicateCoAuthor):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	return &articlev1.InviteCoAuthorResponse{}, err
+}
+
+func (a *ArticleServiceServer) RespondInvitation(ctx context.Context, request *articlev1.RespondInvitationRequest) (*articlev1.RespondInvitationResponse, error) {
+	err := a.service.RespondInvitation(ctx, request.GetUid(), request.GetId(), request.GetAccept())
+	if errors.Is(err, service.ErrInvitationNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &articlev1.RespondInvitationResponse{}, err
+}
+
+func (a *ArticleServiceServer) RemoveCoAuthor(ctx context.Context, request *articlev1.RemoveCoAuthorRequest) (*articlev1.RemoveCoAuthorResponse, error) {
+	err := a.service.RemoveCoAuthor(ctx, request.GetUid(), request.GetId(), request.GetCoAuthor())
+	return &articlev1.RemoveCoAuthorResponse{}, err
+}
+
+func (a *ArticleServiceServer) ListCoAuthors(ctx context.Context, request *articlev1.ListCoAuthorsRequest) (*articlev1.ListCoAuthorsResponse, error) {
+	cs, err := a.service.ListCoAuthors(ctx, request.GetUid(), request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.ListCoAuthorsResponse{
+		CoAuthors: convertCoAuthorsToV(cs),
+	}, nil
+}
+
+func (a *ArticleServiceServer) ListInvitations(ctx context.Context, request *articlev1.ListInvitationsRequest) (*articlev1.ListInvitationsResponse, error) {
+	cs, err := a.service.ListInvitations(ctx, request.GetUid(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.ListInvitationsResponse{
+		Invitations: convertCoAuthorsToV(cs),
+	}, nil
+}
+
+func convertCoAuthorsToV(cs []domain.CoAuthor) []*articlev1.CoAuthor {
+	res := make([]*articlev1.CoAuthor, 0, len(cs))
+	for _, c := range cs {
+		res = append(res, &articlev1.CoAuthor{
+			ArticleId: c.ArticleId,
+			Author:    convertAuthorToV(c.Author),
+			Status:    int32(c.Status),
+			Ctime:     timestamppb.New(c.Ctime),
+		})
+	}
+	return res
+}
+
+func convertAuthorToV(author domain.Author) *articlev1.Author {
+	return &articlev1.Author{
+		Id:   author.Id,
+		Name: author.Name,
+		Role: int32(author.Role),
+	}
+}
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (a *ArticleServiceServer) Delete(ctx context.Context, request *articlev1.DeleteRequest) (*articlev1.DeleteResponse, error) {
+	err := a.service.Delete(ctx, request.GetUid(), request.GetId())
+	if errors.Is(err, service.ErrPossibleIncorrectAuthor) {
+		// 不是创建者，或者已经在回收站里面了
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &articlev1.DeleteResponse{}, err
+}
+
+func (a *ArticleServiceServer) RestoreDeleted(ctx context.Context, request *articlev1.RestoreDeletedRequest) (*articlev1.RestoreDeletedResponse, error) {
+	err := a.service.RestoreDeleted(ctx, request.GetUid(), request.GetId())
+	if errors.Is(err, service.ErrNotInRecycleBin) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &articlev1.RestoreDeletedResponse{}, err
+}
+
+func (a *ArticleServiceServer) ListDeleted(ctx context.Context, request *articlev1.ListDeletedRequest) (*articlev1.ListDeletedResponse, error) {
+	arts, err := a.service.ListDeleted(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Article, 0, len(arts))
+	for _, art := range arts {
+		newArticle, err := convertToV(art)
+		if err != nil {
+			return nil, err
+		}
+		newArticle.Dtime = timestamppb.New(art.Dtime)
+		list = append(list, newArticle)
+	}
+	return &articlev1.ListDeletedResponse{
+		Articles: list,
+	}, nil
+}
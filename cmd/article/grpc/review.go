@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *ArticleServiceServer) ListUnderReview(ctx context.Context, request *articlev1.ListUnderReviewRequest) (*articlev1.ListUnderReviewResponse, error) {
+	arts, err := a.service.ListUnderReview(ctx, request.GetReviewer(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if errors.Is(err, service.ErrNotReviewer) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Article, 0, len(arts))
+	for _, art := range arts {
+		newArticle, err := convertToV(art)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, newArticle)
+	}
+	return &articlev1.ListUnderReviewResponse{
+		Articles: list,
+	}, nil
+}
+
+func (a *ArticleServiceServer) Review(ctx context.Context, request *articlev1.ReviewRequest) (*articlev1.ReviewResponse, error) {
+	err := a.service.Review(ctx, request.GetReviewer(), request.GetId(), request.GetApproved())
+	if errors.Is(err, service.ErrNotReviewer) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, service.ErrArticleNotUnderReview) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &articlev1.ReviewResponse{}, err
+}
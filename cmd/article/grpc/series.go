@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (a *ArticleServiceServer) CreateSeries(ctx context.Context, request *articlev1.CreateSeriesRequest) (*articlev1.CreateSeriesResponse, error) {
+	id, err := a.service.CreateSeries(ctx, domain.Series{
+		Title:       request.GetTitle(),
+		Description: request.GetDescription(),
+		Author: domain.Author{
+			Id: request.GetUid(),
+		},
+		ArticleIds: request.GetArticleIds(),
+	})
+	return &articlev1.CreateSeriesResponse{Id: id}, err
+}
+
+func (a *ArticleServiceServer) ReorderSeries(ctx context.Context, request *articlev1.ReorderSeriesRequest) (*articlev1.ReorderSeriesResponse, error) {
+	err := a.service.ReorderSeries(ctx, request.GetUid(), request.GetId(), request.GetArticleIds())
+	return &articlev1.ReorderSeriesResponse{}, err
+}
+
+func (a *ArticleServiceServer) ListSeries(ctx context.Context, request *articlev1.ListSeriesRequest) (*articlev1.ListSeriesResponse, error) {
+	series, err := a.service.ListSeries(ctx, request.GetAuthor(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Series, 0, len(series))
+	for _, s := range series {
+		list = append(list, &articlev1.Series{
+			Id:          s.Id,
+			Title:       s.Title,
+			Description: s.Description,
+			Author: &articlev1.Author{
+				Id: s.Author.Id,
+			},
+			ArticleIds: s.ArticleIds,
+			Ctime:      timestamppb.New(s.Ctime),
+			Utime:      timestamppb.New(s.Utime),
+		})
+	}
+	return &articlev1.ListSeriesResponse{
+		Series: list,
+	}, nil
+}
+
+func convertSeriesNavToV(nav *domain.SeriesNav) *articlev1.SeriesNav {
+	if nav == nil {
+		return nil
+	}
+	res := &articlev1.SeriesNav{
+		SeriesId:    nav.SeriesId,
+		SeriesTitle: nav.SeriesTitle,
+	}
+	if nav.Prev.Id > 0 {
+		res.Prev = &articlev1.SeriesItem{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		res.Next = &articlev1.SeriesItem{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return res
+}
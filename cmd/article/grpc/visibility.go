@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxFilterAudience FilterAudience 一次最多检查多少人
+const maxFilterAudience = 1000
+
+type VisibilityServiceServer struct {
+	articlev1.UnimplementedVisibilityServiceServer
+	service    service.VisibilityService
+	artService service.ArticleService
+}
+
+func NewVisibilityServiceServer(svc service.VisibilityService,
+	artSvc service.ArticleService) *VisibilityServiceServer {
+	return &VisibilityServiceServer{
+		service:    svc,
+		artService: artSvc,
+	}
+}
+
+func (v *VisibilityServiceServer) Register(server grpc.ServiceRegistrar) {
+	articlev1.RegisterVisibilityServiceServer(server, v)
+}
+
+func (v *VisibilityServiceServer) SetVisibility(ctx context.Context, request *articlev1.SetVisibilityRequest) (*articlev1.SetVisibilityResponse, error) {
+	err := v.service.SetVisibility(ctx, request.GetUid(), request.GetArticleId(),
+		domain.Visibility(request.GetVisibility()), request.GetAllowList())
+	switch {
+	case errors.Is(err, service.ErrInvalidVisibility),
+		errors.Is(err, service.ErrTooManyAudience):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrArticleDeleted):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &articlev1.SetVisibilityResponse{}, nil
+}
+
+func (v *VisibilityServiceServer) GetVisibility(ctx context.Context, request *articlev1.GetVisibilityRequest) (*articlev1.GetVisibilityResponse, error) {
+	vis, uids, err := v.service.GetVisibility(ctx, request.GetUid(), request.GetArticleId())
+	switch {
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor),
+		errors.Is(err, service.ErrArticleDeleted):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &articlev1.GetVisibilityResponse{
+		Visibility: int32(vis),
+		AllowList:  uids,
+	}, nil
+}
+
+func (v *VisibilityServiceServer) FilterAudience(ctx context.Context, request *articlev1.FilterAudienceRequest) (*articlev1.FilterAudienceResponse, error) {
+	if len(request.GetUids()) > maxFilterAudience {
+		return nil, status.Errorf(codes.InvalidArgument, "一次最多检查 %d 个人", maxFilterAudience)
+	}
+	// 要用到作者，BatchGetPublished 会顺便把可见范围填好
+	arts, _, err := v.artService.BatchGetPublished(ctx, []int64{request.GetArticleId()})
+	if err != nil {
+		return nil, err
+	}
+	if len(arts) == 0 {
+		return &articlev1.FilterAudienceResponse{}, nil
+	}
+	uids, err := v.service.FilterAudience(ctx, arts[0], request.GetUids())
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.FilterAudienceResponse{Uids: uids}, nil
+}
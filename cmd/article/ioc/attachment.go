@@ -0,0 +1,51 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// InitAttachmentDAO 附件的元数据不管 db.type 是什么，都放在 MySQL 里面
+func InitAttachmentDAO(db *gorm.DB) dao.AttachmentDAO {
+	return dao.NewGORMAttachmentDAO(db)
+}
+
+// InitAttachmentStorage 兼容 S3 协议的对象存储都可以，比如说 COS、MinIO
+func InitAttachmentStorage() dao.AttachmentStorage {
+	type Config struct {
+		Endpoint  string `yaml:"endpoint"`
+		Region    string `yaml:"region"`
+		AccessKey string `yaml:"accessKey"`
+		SecretKey string `yaml:"secretKey"`
+		Bucket    string `yaml:"bucket"`
+		// BaseURL 读者访问附件的地址前缀，一般是 CDN 的域名
+		BaseURL string `yaml:"baseURL"`
+		// Expire 上传链接的有效期
+		Expire time.Duration `yaml:"expire"`
+	}
+	cfg := Config{
+		Expire: time.Minute * 15,
+	}
+	err := viper.UnmarshalKey("attachment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""),
+		Region:      aws.String(cfg.Region),
+		Endpoint:    aws.String(cfg.Endpoint),
+		// 强制使用 /bucket/key 的形态
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return dao.NewS3AttachmentStorage(s3.New(sess), cfg.Bucket, cfg.Expire, cfg.BaseURL)
+}
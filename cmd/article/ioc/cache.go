@@ -0,0 +1,90 @@
+package ioc
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/messages"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// InitArticleCache Redis 前面再加一层热点帖子的本地缓存，
+// 帖子的缓存有修改，通过 Redis 的发布订阅通知别的节点删除本地缓存
+func InitArticleCache(client redis.UniversalClient, l logger.LoggerV1) cache.ArticleCache {
+	type Config struct {
+		// Size 本地缓存最多放多少篇帖子
+		Size int `yaml:"size"`
+		// Window 内被读了 Threshold 次就算是热点帖子
+		Threshold int64         `yaml:"threshold"`
+		Window    time.Duration `yaml:"window"`
+		// Expiration 收不到删除通知的时候，最多读到多久之前的数据
+		Expiration time.Duration `yaml:"expiration"`
+	}
+	cfg := Config{
+		Size:       1000,
+		Threshold:  100,
+		Window:     time.Second * 10,
+		Expiration: time.Minute,
+	}
+	err := viper.UnmarshalKey("localCache", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	arts, err := lru.New(cfg.Size)
+	if err != nil {
+		panic(err)
+	}
+	// 计数的 key 比热点帖子多得多
+	counters, err := lru.New(cfg.Size * 10)
+	if err != nil {
+		panic(err)
+	}
+	local := cache.NewLocalArticleCache(arts, counters, cfg.Threshold, cfg.Window, cfg.Expiration)
+
+	vector := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "basic_go",
+		Subsystem: "webook",
+		Help:      "统计读者端帖子缓存每一层的命中情况",
+		Name:      "article_cache_lookups",
+	}, []string{"tier", "result"})
+	prometheus.MustRegister(vector)
+
+	nodeID := generateNodeID()
+	c := cache.NewTieredArticleCache(cache.NewRedisArticleCache(client), local,
+		messages.NewRedisPublisher(client, nodeID), vector)
+	subscriber := messages.NewRedisSubscriber(client, c, nodeID, l)
+	if err = subscriber.Start(context.Background()); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// generateNodeID 生成唯一的节点ID
+func generateNodeID() string {
+	// 优先使用配置文件中的节点ID
+	if nodeID := viper.GetString("node.id"); nodeID != "" {
+		return nodeID
+	}
+
+	// 尝试使用环境变量
+	if nodeID := os.Getenv("NODE_ID"); nodeID != "" {
+		return nodeID
+	}
+
+	// 最后使用主机名+进程ID生成
+	hostname, _ := os.Hostname()
+	pid := os.Getpid()
+
+	// 使用MD5生成短一点的ID
+	data := fmt.Sprintf("%s-%d-%d", hostname, pid, time.Now().UnixNano())
+	hash := md5.Sum([]byte(data))
+	return fmt.Sprintf("node-%x", hash[:8])
+}
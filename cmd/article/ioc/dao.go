@@ -0,0 +1,44 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// InitArticleDAO 根据 db.type 来选择存储，默认是 MySQL
+func InitArticleDAO(db *gorm.DB) dao.ArticleDAO {
+	switch viper.GetString("db.type") {
+	case "mongo":
+		return dao.NewMongoDBDAO(InitMongoDB(), InitSnowflakeNode())
+	default:
+		return dao.NewGORMArticleDAO(db)
+	}
+}
+
+// InitArticleRepository 没有 binlog 的时候，发表和撤回之后要立刻删缓存
+func InitArticleRepository(d dao.ArticleDAO, c cache.ArticleCache,
+	l logger.LoggerV1) repository.ArticleRepository {
+	if binlogEnabled() {
+		return repository.NewArticleRepository(d, c, l)
+	}
+	return repository.NewArticleRepositoryWithoutBinlog(d, c, l)
+}
+
+// InitCitationDAO 和附件一样，引用关系不管 db.type 是什么都放在 MySQL 里面
+func InitCitationDAO(db *gorm.DB) dao.CitationDAO {
+	return dao.NewGORMCitationDAO(db)
+}
+
+// InitVisibilityDAO 可见范围也是不管 db.type 是什么都放在 MySQL 里面
+func InitVisibilityDAO(db *gorm.DB) dao.VisibilityDAO {
+	return dao.NewGORMVisibilityDAO(db)
+}
+
+// InitReviewDAO 审核人员和审核记录也是不管 db.type 是什么都放在 MySQL 里面
+func InitReviewDAO(db *gorm.DB) dao.ReviewDAO {
+	return dao.NewGORMReviewDAO(db)
+}
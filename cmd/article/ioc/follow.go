@@ -0,0 +1,25 @@
+package ioc
+
+import (
+	followv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitFollowRpcClient 判断可见范围的时候要查关注关系
+func InitFollowRpcClient() followv1.FollowServiceClient {
+	type config struct {
+		Addr string `yaml:"addr"`
+	}
+	var cfg config
+	err := viper.UnmarshalKey("grpc.client.follow", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	conn, err := grpc.Dial(cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return followv1.NewFollowServiceClient(conn)
+}
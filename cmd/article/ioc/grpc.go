@@ -10,6 +10,9 @@ import (
 )
 
 func InitGRPCxServer(articleServer *grpc2.ArticleServiceServer,
+	attachmentServer *grpc2.AttachmentServiceServer,
+	citationServer *grpc2.CitationServiceServer,
+	visibilityServer *grpc2.VisibilityServiceServer,
 	ecli *clientv3.Client,
 	l logger.LoggerV1) *grpcx.Server {
 	type Config struct {
@@ -24,6 +27,9 @@ func InitGRPCxServer(articleServer *grpc2.ArticleServiceServer,
 	}
 	server := grpc.NewServer()
 	articleServer.Register(server)
+	attachmentServer.Register(server)
+	citationServer.Register(server)
+	visibilityServer.Register(server)
 	return &grpcx.Server{
 		Server:     server,
 		Port:       cfg.Port,
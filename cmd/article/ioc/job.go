@@ -0,0 +1,51 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/article/job"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"github.com/XD/ScholarNet/cmd/pkg/cronjobx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/robfig/cron/v3"
+	"time"
+)
+
+func InitScheduledPublishJob(svc service.ArticleService, rlockClient *rlock.Client, l logger.LoggerV1) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, rlockClient, l, time.Second*30)
+}
+
+func InitAttachmentGCJob(svc service.AttachmentService, rlockClient *rlock.Client, l logger.LoggerV1) *job.AttachmentGCJob {
+	return job.NewAttachmentGCJob(svc, rlockClient, l, time.Minute*10)
+}
+
+func InitPurgeDeletedJob(svc service.ArticleService,
+	attSvc service.AttachmentService,
+	citSvc service.CitationService,
+	rlockClient *rlock.Client, l logger.LoggerV1) *job.PurgeDeletedJob {
+	return job.NewPurgeDeletedJob(svc, attSvc, citSvc, rlockClient, l, time.Minute*30)
+}
+
+// InitJobs 所有定时任务都在这里初始化
+func InitJobs(l logger.LoggerV1,
+	publishJob *job.ScheduledPublishJob,
+	gcJob *job.AttachmentGCJob,
+	purgeJob *job.PurgeDeletedJob) *cron.Cron {
+	res := cron.New(cron.WithSeconds())
+	cbd := cronjobx.NewCronJobBuilder(l)
+	// 每分钟检查一次，所以定时发表的精度是分钟级别的
+	_, err := res.AddJob("0 * * * * ?", cbd.Build(publishJob))
+	if err != nil {
+		panic(err)
+	}
+	// 附件清理不着急，每小时一次
+	_, err = res.AddJob("0 30 * * * ?", cbd.Build(gcJob))
+	if err != nil {
+		panic(err)
+	}
+	// 回收站按天保留，每天凌晨清理一次
+	_, err = res.AddJob("0 0 3 * * ?", cbd.Build(purgeJob))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -2,6 +2,8 @@ package ioc
 
 import (
 	"github.com/IBM/sarama"
+	"github.com/XD/ScholarNet/cmd/article/events"
+	"github.com/XD/ScholarNet/cmd/pkg/saramax"
 	"github.com/spf13/viper"
 )
 
@@ -22,3 +24,37 @@ func InitProducer() sarama.SyncProducer {
 	}
 	return producer
 }
+
+func InitSaramaClient() sarama.Client {
+	type Config struct {
+		Addrs []string `yaml:"addrs"`
+	}
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	client, err := sarama.NewClient(cfg.Addrs, saramaCfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// NewConsumers 组装 []consumer
+// binlog 只有 MySQL 才有，用 MongoDB 的时候帖子的缓存由 repository 自己删
+func NewConsumers(binlog *events.MySQLBinlogConsumer) []saramax.Consumer {
+	if !binlogEnabled() {
+		return nil
+	}
+	return []saramax.Consumer{
+		binlog,
+	}
+}
+
+// binlogEnabled 帖子的缓存是不是由 MySQLBinlogConsumer 来维护
+func binlogEnabled() bool {
+	return viper.GetString("db.type") != "mongo"
+}
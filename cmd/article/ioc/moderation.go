@@ -0,0 +1,34 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/moderation"
+	"github.com/spf13/viper"
+)
+
+// InitModerator 目前只有敏感词，接入外部的分类器的话，加到 Pipeline 后面就可以
+func InitModerator(l logger.LoggerV1) moderation.Moderator {
+	type Config struct {
+		WordsFile      string        `yaml:"wordsFile"`
+		ReloadInterval time.Duration `yaml:"reloadInterval"`
+	}
+	cfg := Config{
+		WordsFile:      "config/sensitive_words.txt",
+		ReloadInterval: time.Minute,
+	}
+	err := viper.UnmarshalKey("moderation", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	words := moderation.NewWordList(moderation.FileLoader(cfg.WordsFile),
+		moderation.VerdictReview, l)
+	if err = words.Reload(context.Background()); err != nil {
+		panic(err)
+	}
+	// 敏感词表修改之后不需要重启
+	go words.Watch(context.Background(), cfg.ReloadInterval)
+	return moderation.NewPipeline(words)
+}
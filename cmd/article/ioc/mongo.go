@@ -0,0 +1,49 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func InitMongoDB() *mongo.Database {
+	type Config struct {
+		URI      string `yaml:"uri"`
+		Database string `yaml:"database"`
+	}
+	c := Config{
+		URI:      "mongodb://localhost:27017",
+		Database: "webook",
+	}
+	err := viper.UnmarshalKey("mongo", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.URI))
+	if err != nil {
+		panic(err)
+	}
+	db := client.Database(c.Database)
+	err = dao.InitCollections(db)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// InitSnowflakeNode MongoDB 没有自增主键，用雪花算法生成 ID
+// 多个实例部署的时候，每个实例的 node 要不一样
+func InitSnowflakeNode() *snowflake.Node {
+	node, err := snowflake.NewNode(viper.GetInt64("snowflake.node"))
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
@@ -5,10 +5,15 @@ import (
 	"github.com/spf13/viper"
 )
 
-func InitRedis() redis.Cmdable {
+func InitRedisClient() redis.UniversalClient {
 	// 这里演示读取特定的某个字段
 	cmd := redis.NewClient(&redis.Options{
 		Addr: viper.GetString("redis.addr"),
 	})
 	return cmd
 }
+
+// InitRedis 发布订阅要用 redis.UniversalClient，别的地方只需要 redis.Cmdable
+func InitRedis(client redis.UniversalClient) redis.Cmdable {
+	return client
+}
@@ -0,0 +1,58 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+)
+
+// AttachmentGCJob 清理没有关联到帖子的附件
+// 执行得不频繁，所以每次执行的时候抢锁，执行完就释放，不像定时发表那样一直持有
+type AttachmentGCJob struct {
+	svc     service.AttachmentService
+	timeout time.Duration
+	client  *rlock.Client
+	key     string
+	l       logger.LoggerV1
+}
+
+func NewAttachmentGCJob(svc service.AttachmentService,
+	client *rlock.Client,
+	l logger.LoggerV1,
+	timeout time.Duration) *AttachmentGCJob {
+	return &AttachmentGCJob{
+		svc:     svc,
+		timeout: timeout,
+		client:  client,
+		key:     "rlock:cron_job:attachment_gc",
+		l:       l,
+	}
+}
+
+func (j *AttachmentGCJob) Name() string { return "attachment_gc" }
+
+func (j *AttachmentGCJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	lock, err := j.client.Lock(ctx, j.key, j.timeout, &rlock.FixIntervalRetry{
+		Interval: time.Millisecond * 100,
+		Max:      0,
+	}, time.Second)
+	cancel()
+	if err != nil {
+		// 没拿到锁，说明别的实例在执行
+		return nil
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if er := lock.Unlock(ctx); er != nil {
+			j.l.Error("释放分布式锁失败", logger.String("key", j.key), logger.Error(er))
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.CollectGarbage(ctx, time.Now())
+}
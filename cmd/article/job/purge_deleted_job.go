@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+)
+
+// PurgeDeletedJob 彻底删除回收站里面超过保留期的帖子
+// 先删附件和引用再删帖子，中途失败了下一次还能查到这篇帖子，重新来一遍
+// 附件在对象存储里面的内容由 AttachmentGCJob 回收
+type PurgeDeletedJob struct {
+	svc       service.ArticleService
+	attSvc    service.AttachmentService
+	citSvc    service.CitationService
+	timeout   time.Duration
+	client    *rlock.Client
+	key       string
+	l         logger.LoggerV1
+	batchSize int
+}
+
+func NewPurgeDeletedJob(svc service.ArticleService,
+	attSvc service.AttachmentService,
+	citSvc service.CitationService,
+	client *rlock.Client,
+	l logger.LoggerV1,
+	timeout time.Duration) *PurgeDeletedJob {
+	return &PurgeDeletedJob{
+		svc:       svc,
+		attSvc:    attSvc,
+		citSvc:    citSvc,
+		timeout:   timeout,
+		client:    client,
+		key:       "rlock:cron_job:purge_deleted",
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (j *PurgeDeletedJob) Name() string { return "purge_deleted_article" }
+
+func (j *PurgeDeletedJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	lock, err := j.client.Lock(ctx, j.key, j.timeout, &rlock.FixIntervalRetry{
+		Interval: time.Millisecond * 100,
+		Max:      0,
+	}, time.Second)
+	cancel()
+	if err != nil {
+		// 没拿到锁，说明别的实例在执行
+		return nil
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if er := lock.Unlock(ctx); er != nil {
+			j.l.Error("释放分布式锁失败", logger.String("key", j.key), logger.Error(er))
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	now := time.Now()
+	for {
+		arts, err := j.svc.ListExpiredDeleted(ctx, now, j.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if err = j.purge(ctx, art.Id); err != nil {
+				// 一直删不掉的帖子会一直排在最前面，不能跳过去继续
+				return err
+			}
+		}
+		if len(arts) < j.batchSize {
+			return nil
+		}
+	}
+}
+
+func (j *PurgeDeletedJob) purge(ctx context.Context, id int64) error {
+	err := j.attSvc.DeleteByArticle(ctx, id)
+	if err != nil {
+		j.l.Error("删除帖子的附件失败", logger.Int64("aid", id), logger.Error(err))
+		return err
+	}
+	err = j.citSvc.DeleteByArticle(ctx, id)
+	if err != nil {
+		j.l.Error("删除帖子的引用失败", logger.Int64("aid", id), logger.Error(err))
+		return err
+	}
+	err = j.svc.Purge(ctx, id)
+	if err != nil {
+		j.l.Error("彻底删除帖子失败", logger.Int64("aid", id), logger.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"github.com/XD/ScholarNet/cmd/article/service"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+	"sync"
+	"time"
+)
+
+// ScheduledPublishJob 把到期的定时发表的帖子发表出去
+// 多个实例部署的时候，只有拿到分布式锁的那个实例会执行
+type ScheduledPublishJob struct {
+	svc       service.ArticleService
+	timeout   time.Duration
+	client    *rlock.Client
+	key       string
+	l         logger.LoggerV1
+	lock      *rlock.Lock
+	localLock *sync.Mutex
+}
+
+func NewScheduledPublishJob(svc service.ArticleService,
+	client *rlock.Client,
+	l logger.LoggerV1,
+	timeout time.Duration) *ScheduledPublishJob {
+	return &ScheduledPublishJob{
+		svc:       svc,
+		timeout:   timeout,
+		client:    client,
+		key:       "rlock:cron_job:scheduled_publish",
+		l:         l,
+		localLock: &sync.Mutex{},
+	}
+}
+
+func (j *ScheduledPublishJob) Name() string { return "scheduled_publish" }
+
+func (j *ScheduledPublishJob) Run() error {
+	j.localLock.Lock()
+	defer j.localLock.Unlock()
+	if j.lock == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		lock, err := j.client.Lock(ctx, j.key, j.timeout, &rlock.FixIntervalRetry{
+			Interval: time.Millisecond * 100,
+			Max:      0,
+		}, time.Second)
+		if err != nil {
+			// 没拿到锁，说明别的实例在执行
+			return nil
+		}
+		j.lock = lock
+		go func() {
+			// 一直续约，直到续约失败或者 Close
+			err1 := lock.AutoRefresh(j.timeout/2, time.Second)
+			if err1 != nil {
+				j.l.Error("续约失败", logger.Error(err1))
+			}
+			j.localLock.Lock()
+			j.lock = nil
+			j.localLock.Unlock()
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.PublishScheduled(ctx, time.Now())
+}
+
+func (j *ScheduledPublishJob) Close() error {
+	j.localLock.Lock()
+	lock := j.lock
+	j.lock = nil
+	j.localLock.Unlock()
+	if lock == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return lock.Unlock(ctx)
+}
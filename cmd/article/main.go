@@ -8,6 +8,18 @@ import (
 func main() {
 	initViperV2Watch()
 	app := Init()
+	for _, c := range app.Consumers {
+		err := c.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
+	// 定时发表的任务
+	app.Cron.Start()
+	defer func() {
+		// 等待正在执行的任务结束
+		<-app.Cron.Stop().Done()
+	}()
 	err := app.GRPCServer.Serve()
 	if err != nil {
 		panic(err)
@@ -0,0 +1,125 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// LocalCacheInvalidateChannel 删除帖子本地缓存的通知频道
+	LocalCacheInvalidateChannel = "article:local_cache:invalidate"
+)
+
+// InvalidateMessage 删除本地缓存的通知
+type InvalidateMessage struct {
+	NodeID    string  `json:"node_id"`   // 发送节点ID
+	Ids       []int64 `json:"ids"`       // 要删除的帖子
+	Timestamp int64   `json:"timestamp"` // 时间戳
+}
+
+// LocalCacheInvalidator 收到通知之后删除本地缓存
+type LocalCacheInvalidator interface {
+	Invalidate(ids ...int64)
+}
+
+// RedisPublisher Redis发布者
+type RedisPublisher struct {
+	client redis.UniversalClient
+	nodeID string
+}
+
+func NewRedisPublisher(client redis.UniversalClient, nodeID string) *RedisPublisher {
+	return &RedisPublisher{
+		client: client,
+		nodeID: nodeID,
+	}
+}
+
+// PublishInvalidate 通知别的节点删除本地缓存
+func (r *RedisPublisher) PublishInvalidate(ctx context.Context, ids ...int64) error {
+	msg := InvalidateMessage{
+		NodeID:    r.nodeID,
+		Ids:       ids,
+		Timestamp: time.Now().Unix(),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("序列化通知消息失败: %w", err)
+	}
+	return r.client.Publish(ctx, LocalCacheInvalidateChannel, msgBytes).Err()
+}
+
+// RedisSubscriber Redis订阅者
+type RedisSubscriber struct {
+	client      redis.UniversalClient
+	subscriber  *redis.PubSub
+	invalidator LocalCacheInvalidator
+	nodeID      string
+	l           logger.LoggerV1
+}
+
+func NewRedisSubscriber(client redis.UniversalClient,
+	invalidator LocalCacheInvalidator,
+	nodeID string, l logger.LoggerV1) *RedisSubscriber {
+	return &RedisSubscriber{
+		client:      client,
+		invalidator: invalidator,
+		nodeID:      nodeID,
+		l:           l,
+	}
+}
+
+// Start 开始订阅
+func (r *RedisSubscriber) Start(ctx context.Context) error {
+	r.subscriber = r.client.Subscribe(ctx, LocalCacheInvalidateChannel)
+	// 确认订阅成功，不然启动的时候发现不了 Redis 的问题
+	if _, err := r.subscriber.Receive(ctx); err != nil {
+		return err
+	}
+	// 启动消息处理协程
+	go r.handleMessages(ctx)
+	return nil
+}
+
+// Stop 停止订阅
+func (r *RedisSubscriber) Stop() error {
+	if r.subscriber != nil {
+		return r.subscriber.Close()
+	}
+	return nil
+}
+
+// handleMessages 处理订阅消息
+func (r *RedisSubscriber) handleMessages(ctx context.Context) {
+	ch := r.subscriber.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			r.processMessage(msg)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// processMessage 处理单条消息
+func (r *RedisSubscriber) processMessage(msg *redis.Message) {
+	var notifyMsg InvalidateMessage
+	if err := json.Unmarshal([]byte(msg.Payload), &notifyMsg); err != nil {
+		r.l.Error("解析删除本地缓存的通知失败", logger.Error(err))
+		return
+	}
+	// 忽略自己发送的消息，自己的本地缓存在发送之前就删掉了
+	if notifyMsg.NodeID == r.nodeID {
+		return
+	}
+	r.invalidator.Invalidate(notifyMsg.Ids...)
+}
@@ -2,21 +2,53 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/XD/ScholarNet/cmd/article/domain"
 	"github.com/XD/ScholarNet/cmd/article/repository/cache"
 	"github.com/XD/ScholarNet/cmd/article/repository/dao"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
 	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
-//go:generate mockgen -source=./type.go -package=repomocks -destination=mocks/article.mock.go ArticleRepository
+var (
+	ErrPossibleIncorrectAuthor = dao.ErrPossibleIncorrectAuthor
+	ErrArticleNotScheduled     = dao.ErrArticleNotScheduled
+	ErrVersionConflict         = dao.ErrVersionConflict
+	ErrMissingVersion          = dao.ErrMissingVersion
+	ErrArticleInOtherSeries    = dao.ErrArticleInOtherSeries
+	ErrDuplicateCoAuthor       = dao.ErrDuplicateCoAuthor
+	ErrDataNotFound            = dao.ErrDataNotFound
+	ErrArticleNotUnderReview   = errors.New("帖子不处于待审核状态")
+)
+
+type VersionConflictError = dao.VersionConflictError
+
+const (
+	// earlyRefreshBeta 大于 1 更倾向于提前刷新，小于 1 更倾向于等到快过期了再刷新
+	earlyRefreshBeta = 1.0
+	// defaultLoadCost 还没有从数据库加载过的时候，假定的加载耗时
+	defaultLoadCost = 50 * time.Millisecond
+	// pubLoadTimeout singleflight 里面加载已发表帖子的超时时间，
+	// 加载是大家共用的，不能跟着某一个请求的 ctx 走
+	pubLoadTimeout = time.Second
+)
+
+//go:generate mockgen -source=./article.go -package=repomocks -destination=mocks/article.mock.go ArticleRepository
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
 	List(ctx context.Context, author int64,
 		offset int, limit int) ([]domain.Article, error)
+	// ListByCursor 和 List 一样，不过是按照 (utime, id) 倒序用游标翻页
+	ListByCursor(ctx context.Context, author int64,
+		cursor domain.Cursor, limit int) ([]domain.Article, error)
 
 	// Sync 本身要求先保存到制作库，再同步到线上库
 	Sync(ctx context.Context, art domain.Article) (int64, error)
@@ -25,7 +57,58 @@ type ArticleRepository interface {
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 
 	GetPublishedById(ctx context.Context, id int64) (domain.Article, error)
+	// BatchGetPublished 先查缓存，没有命中的一次从线上库查出来并且回写缓存
+	// 不存在或者已经删除的不在结果里面
+	BatchGetPublished(ctx context.Context, ids []int64) (map[int64]domain.Article, error)
 	ListPub(ctx context.Context, utime time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPubByCursor 按照 (utime, id) 倒序用游标翻页
+	ListPubByCursor(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
+
+	// Delete 把帖子放进回收站，只有创建者可以删除
+	Delete(ctx context.Context, uid, id int64) error
+	// RestoreDeleted 恢复 after 之后删除的帖子，过了保留期返回 ErrDataNotFound
+	RestoreDeleted(ctx context.Context, uid, id int64, after time.Time) error
+	// ListDeleted 回收站，按照删除时间倒序
+	ListDeleted(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	// ListDeletedBefore before 之前删除的帖子，按照删除时间升序
+	ListDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	// Purge 彻底删除已经在回收站里面的帖子
+	Purge(ctx context.Context, id int64) error
+
+	// ListRevisions 历史版本列表，不包含内容
+	ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, artId, id int64) (domain.ArticleRevision, error)
+
+	// ListScheduled 找出 publishAt 小于等于 before 的定时发表的帖子
+	ListScheduled(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	// CancelSchedule 取消定时发表，帖子回到未发表状态
+	CancelSchedule(ctx context.Context, uid, id int64) error
+	// Reschedule 修改定时发表的时间
+	Reschedule(ctx context.Context, uid, id int64, publishAt time.Time) error
+
+	// ListUnderReview 等待人工审核的帖子，先提交的在前面
+	ListUnderReview(ctx context.Context, offset, limit int) ([]domain.Article, error)
+	// RejectReview 审核不通过，帖子回到未发表状态
+	RejectReview(ctx context.Context, uid, id int64) error
+
+	CreateSeries(ctx context.Context, s domain.Series) (int64, error)
+	// SetSeriesArticles 用 artIds 整体替换系列里面的帖子，顺序就是 artIds 的顺序
+	SetSeriesArticles(ctx context.Context, uid, seriesId int64, artIds []int64) error
+	ListSeries(ctx context.Context, author int64, offset, limit int) ([]domain.Series, error)
+	// GetSeriesNav 帖子在系列里面的导航，不在任何系列里面返回 nil
+	GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error)
+
+	// AddCoAuthor 邀请共同作者，重复邀请返回 ErrDuplicateCoAuthor
+	AddCoAuthor(ctx context.Context, artId, uid int64) error
+	// GetCoAuthor 不存在返回 ErrDataNotFound
+	GetCoAuthor(ctx context.Context, artId, uid int64) (domain.CoAuthor, error)
+	// AcceptCoAuthor 接受邀请，没有待接受的邀请返回 ErrDataNotFound
+	AcceptCoAuthor(ctx context.Context, artId, uid int64) error
+	RemoveCoAuthor(ctx context.Context, artId, uid int64) error
+	// ListCoAuthors 所有的共同作者，包括还没有接受邀请的，按照邀请的顺序
+	ListCoAuthors(ctx context.Context, artId int64) ([]domain.CoAuthor, error)
+	// ListInvitations 用户收到的还没有处理的邀请
+	ListInvitations(ctx context.Context, uid int64, offset, limit int) ([]domain.CoAuthor, error)
 }
 
 type CachedArticleRepository struct {
@@ -40,6 +123,14 @@ type CachedArticleRepository struct {
 	// SyncV2 用
 	db *gorm.DB
 	l  logger.LoggerV1
+
+	// group 合并同一篇帖子并发的缓存未命中
+	group singleflight.Group
+	// loadCost 最近一次从数据库加载并渲染帖子的耗时，提前刷新缓存要用
+	loadCost atomic.Int64
+	// evictOnSync 没有 binlog 来维护缓存（比如说用的是 MongoDB），
+	// 发表和撤回之后就要自己删掉缓存
+	evictOnSync bool
 }
 
 func (repo *CachedArticleRepository) Cache() cache.ArticleCache {
@@ -62,6 +153,22 @@ func (repo *CachedArticleRepository) ListPub(ctx context.Context, utime time.Tim
 	return res, err
 }
 
+func (repo *CachedArticleRepository) ListPubByCursor(ctx context.Context,
+	cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	utime, id := cursor.Keys()
+	val, err := repo.dao.ListPubByCursor(ctx, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map[dao.PublishedArticle, domain.Article](val, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.ToDomain(dao.Article(src))
+	})
+	go func() {
+		repo.prePublishCache(ctx, res)
+	}()
+	return res, nil
+}
+
 func NewArticleRepository(dao dao.ArticleDAO,
 	c cache.ArticleCache,
 	l logger.LoggerV1) ArticleRepository {
@@ -72,6 +179,19 @@ func NewArticleRepository(dao dao.ArticleDAO,
 	}
 }
 
+// NewArticleRepositoryWithoutBinlog 没有 MySQLBinlogConsumer 的时候用，
+// 发表和撤回之后会立刻删掉作者和读者的缓存
+func NewArticleRepositoryWithoutBinlog(dao dao.ArticleDAO,
+	c cache.ArticleCache,
+	l logger.LoggerV1) ArticleRepository {
+	return &CachedArticleRepository{
+		dao:         dao,
+		l:           l,
+		cache:       c,
+		evictOnSync: true,
+	}
+}
+
 func NewArticleRepositoryV1(authorDAO dao.ArticleAuthorDAO,
 	readerDAO dao.ArticleReaderDAO) ArticleRepository {
 	return &CachedArticleRepository{
@@ -81,27 +201,116 @@ func NewArticleRepositoryV1(authorDAO dao.ArticleAuthorDAO,
 }
 
 func (repo *CachedArticleRepository) GetPublishedById(ctx context.Context, id int64) (domain.Article, error) {
-	res, err := repo.cache.GetPub(ctx, id)
+	res, ttl, err := repo.cache.GetPubWithTTL(ctx, id)
 	if err == nil {
-		return res, err
+		if repo.shouldRefreshEarly(ttl) {
+			// 提前刷新，这一次还是返回缓存里面的数据
+			repo.loadPublishedShared(id)
+		}
+		return res, nil
+	}
+	if err != cache.ErrKeyNotExist {
+		repo.l.Error("查询已发表文章缓存失败",
+			logger.Error(err), logger.Int64("aid", id))
 	}
+	// 热点帖子缓存过期的时候，同一时刻只让一个请求去查数据库
+	// 每个请求只等自己的 ctx，自己超时了不影响别的请求
+	select {
+	case <-ctx.Done():
+		return domain.Article{}, ctx.Err()
+	case r := <-repo.loadPublishedShared(id):
+		if r.Err != nil {
+			return domain.Article{}, r.Err
+		}
+		return r.Val.(domain.Article), nil
+	}
+}
+
+// loadPublishedShared 合并同一篇帖子并发的加载，用的是独立的 ctx，
+// 第一个请求取消了或者超时了，也不会连累等着同一个结果的别的请求
+func (repo *CachedArticleRepository) loadPublishedShared(id int64) <-chan singleflight.Result {
+	return repo.group.DoChan(repo.pubFlightKey(id), func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), pubLoadTimeout)
+		defer cancel()
+		return repo.loadPublished(ctx, id)
+	})
+}
+
+// loadPublished 从线上库查出来，渲染之后回写缓存
+// 这里是同步写缓存，不然 singleflight 结束之后进来的请求还是会打到数据库上
+func (repo *CachedArticleRepository) loadPublished(ctx context.Context, id int64) (domain.Article, error) {
+	start := time.Now()
 	art, err := repo.dao.GetPubById(ctx, id)
 	if err != nil {
 		return domain.Article{}, err
 	}
-	res = domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Status:  domain.ArticleStatus(art.Status),
-		Content: art.Content,
+	res := repo.ToDomain(dao.Article(art))
+	// 渲染比较耗时，渲染之后的结果和帖子一起缓存
+	res.Rendered = res.Render()
+	repo.loadCost.Store(int64(time.Since(start)))
+	if err = repo.cache.SetPub(ctx, res); err != nil {
+		repo.l.Error("缓存已发表文章失败",
+			logger.Error(err), logger.Int64("aid", res.Id))
 	}
-	// 也可以同步
-	go func() {
-		if err = repo.cache.SetPub(ctx, res); err != nil {
-			repo.l.Error("缓存已发表文章失败",
-				logger.Error(err), logger.Int64("aid", res.Id))
+	return res, nil
+}
+
+// shouldRefreshEarly 概率提前刷新（XFetch）
+// 越接近过期，重新加载越慢，提前刷新的概率就越大，
+// 这样缓存还没有过期就已经有一个请求把它刷新了
+func (repo *CachedArticleRepository) shouldRefreshEarly(ttl time.Duration) bool {
+	if ttl <= 0 {
+		// 没有过期时间
+		return false
+	}
+	cost := time.Duration(repo.loadCost.Load())
+	if cost <= 0 {
+		cost = defaultLoadCost
+	}
+	return -float64(cost)*earlyRefreshBeta*math.Log(rand.Float64()) >= float64(ttl)
+}
+
+func (repo *CachedArticleRepository) pubFlightKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func (repo *CachedArticleRepository) BatchGetPublished(ctx context.Context,
+	ids []int64) (map[int64]domain.Article, error) {
+	res, err := repo.cache.GetPubs(ctx, ids)
+	if err != nil {
+		// 缓存出问题了，全部查数据库
+		repo.l.Error("批量查询已发表文章缓存失败", logger.Error(err))
+		res = make(map[int64]domain.Article, len(ids))
+	}
+	misses := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := res[id]; !ok {
+			misses = append(misses, id)
 		}
-	}()
+	}
+	if len(misses) == 0 {
+		return res, nil
+	}
+	pubs, err := repo.dao.ListPubByIds(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]domain.Article, 0, len(pubs))
+	for _, pub := range pubs {
+		art := repo.ToDomain(dao.Article(pub))
+		art.Rendered = art.Render()
+		res[art.Id] = art
+		loaded = append(loaded, art)
+	}
+	if len(loaded) > 0 {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if er := repo.cache.SetPubs(ctx, loaded); er != nil {
+				repo.l.Error("批量缓存已发表文章失败", logger.Error(er))
+			}
+		}()
+	}
 	return res, nil
 }
 
@@ -141,6 +350,37 @@ func (repo *CachedArticleRepository) List(ctx context.Context, author int64,
 	if err != nil {
 		return nil, err
 	}
+	return repo.cacheFirstPage(ctx, author, offset == 0 && limit == 100, arts), nil
+}
+
+func (repo *CachedArticleRepository) ListByCursor(ctx context.Context, author int64,
+	cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	// 第一页和 List 共用一份缓存
+	if cursor.IsFirstPage() && limit == 100 {
+		data, err := repo.cache.GetFirstPage(ctx, author)
+		if err == nil {
+			go func() {
+				repo.preCache(ctx, data)
+			}()
+			return data, nil
+		}
+		if err != cache.ErrKeyNotExist {
+			repo.l.Error("查询缓存文章失败",
+				logger.Int64("author", author), logger.Error(err))
+		}
+	}
+	utime, id := cursor.Keys()
+	arts, err := repo.dao.GetByAuthorCursor(ctx, author, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.cacheFirstPage(ctx, author, cursor.IsFirstPage() && limit == 100, arts), nil
+}
+
+// cacheFirstPage 转换成 domain.Article，默认页大小的第一页顺便回写缓存
+// 其它页写进去的话，下一次查第一页就会拿到错误的数据
+func (repo *CachedArticleRepository) cacheFirstPage(ctx context.Context, author int64,
+	firstPage bool, arts []dao.Article) []domain.Article {
 	res := slice.Map[dao.Article, domain.Article](arts,
 		func(idx int, src dao.Article) domain.Article {
 			return repo.ToDomain(src)
@@ -149,13 +389,16 @@ func (repo *CachedArticleRepository) List(ctx context.Context, author int64,
 	go func() {
 		repo.preCache(ctx, res)
 	}()
+	if !firstPage {
+		return res
+	}
 	// 你这个也可以做成异步的
-	err = repo.cache.SetFirstPage(ctx, author, res)
+	err := repo.cache.SetFirstPage(ctx, author, res)
 	if err != nil {
 		repo.l.Error("刷新第一页文章的缓存失败",
 			logger.Int64("author", author), logger.Error(err))
 	}
-	return res, nil
+	return res
 }
 
 func (repo *CachedArticleRepository) preCache(ctx context.Context,
@@ -175,8 +418,10 @@ func (repo *CachedArticleRepository) prePublishCache(ctx context.Context,
 	// 1MB
 	const contentSizeThreshold = 1024 * 1024
 	if len(arts) > 0 && len(arts[0].Content) <= contentSizeThreshold {
+		art := arts[0]
+		art.Rendered = art.Render()
 		// 你也可以记录日志
-		if err := repo.cache.PreSetPub(ctx, arts[0]); err != nil {
+		if err := repo.cache.PreSetPub(ctx, art); err != nil {
 			repo.l.Error("提前准备缓存失败", logger.Error(err))
 		}
 	}
@@ -184,7 +429,15 @@ func (repo *CachedArticleRepository) prePublishCache(ctx context.Context,
 
 func (repo *CachedArticleRepository) SyncStatus(ctx context.Context,
 	uid, id int64, status domain.ArticleStatus) error {
-	return repo.dao.SyncStatus(ctx, uid, id, status.ToUint8())
+	err := repo.dao.SyncStatus(ctx, uid, id, status.ToUint8())
+	if err != nil {
+		return err
+	}
+	if repo.evictOnSync {
+		repo.evict(ctx, uid, id)
+	}
+	repo.evictSeriesNavs(ctx, id)
+	return nil
 }
 
 func (repo *CachedArticleRepository) Sync(ctx context.Context,
@@ -193,19 +446,15 @@ func (repo *CachedArticleRepository) Sync(ctx context.Context,
 	if err != nil {
 		return 0, err
 	}
-	go func() {
-		author := art.Author.Id
-		err = repo.cache.DelFirstPage(ctx, author)
-		if err != nil {
-			repo.l.Error("删除第一页缓存失败",
-				logger.Int64("author", author), logger.Error(err))
-		}
-		err = repo.cache.SetPub(ctx, art)
-		if err != nil {
-			repo.l.Error("提前设置缓存失败",
-				logger.Int64("author", author), logger.Error(err))
-		}
-	}()
+	art.Id = id
+	// 共同作者的第一页从 binlog 里面看不出来，只能在这里删
+	repo.delEditorFirstPage(ctx, art)
+	if repo.evictOnSync {
+		repo.evict(ctx, art.Author.Id, id)
+	}
+	// 否则作者的第一页和读者端的缓存交给 binlog 去更新（MySQLBinlogConsumer）
+	// 系列导航 binlog 不管，标题变了、第一次发表都会影响相邻帖子的导航
+	repo.evictSeriesNavs(ctx, id)
 	return id, nil
 }
 
@@ -298,11 +547,125 @@ func (repo *CachedArticleRepository) Update(ctx context.Context,
 		repo.l.Error("删除缓存失败",
 			logger.Int64("author", author), logger.Error(err))
 	}
+	repo.delEditorFirstPage(ctx, art)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListRevisions(ctx context.Context,
+	artId int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := repo.dao.ListRevisions(ctx, artId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleRevision, domain.ArticleRevision](revs,
+		func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+			return repo.revisionToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) GetRevision(ctx context.Context,
+	artId, id int64) (domain.ArticleRevision, error) {
+	// 历史版本不会被修改，但是访问频率很低，所以也没必要缓存
+	rev, err := repo.dao.GetRevision(ctx, artId, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return repo.revisionToDomain(rev), nil
+}
+
+func (repo *CachedArticleRepository) ListScheduled(ctx context.Context,
+	before time.Time, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListScheduled(ctx, domain.ArticleStatusScheduled.ToUint8(),
+		before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts,
+		func(idx int, src dao.Article) domain.Article {
+			return repo.ToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) CancelSchedule(ctx context.Context, uid, id int64) error {
+	err := repo.dao.UpdateSchedule(ctx, uid, id,
+		domain.ArticleStatusScheduled.ToUint8(),
+		domain.ArticleStatusUnpublished.ToUint8(), 0)
+	if err != nil {
+		return err
+	}
+	repo.delFirstPage(ctx, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) Reschedule(ctx context.Context, uid, id int64, publishAt time.Time) error {
+	err := repo.dao.UpdateSchedule(ctx, uid, id,
+		domain.ArticleStatusScheduled.ToUint8(),
+		domain.ArticleStatusScheduled.ToUint8(), publishAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+	repo.delFirstPage(ctx, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListUnderReview(ctx context.Context,
+	offset, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListByStatus(ctx, domain.ArticleStatusUnderReview.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts,
+		func(idx int, src dao.Article) domain.Article {
+			return repo.ToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) RejectReview(ctx context.Context, uid, id int64) error {
+	// 和定时发表一样，都是有条件地修改状态
+	err := repo.dao.UpdateSchedule(ctx, uid, id,
+		domain.ArticleStatusUnderReview.ToUint8(),
+		domain.ArticleStatusUnpublished.ToUint8(), 0)
+	if errors.Is(err, dao.ErrArticleNotScheduled) {
+		return ErrArticleNotUnderReview
+	}
+	if err != nil {
+		return err
+	}
+	repo.delFirstPage(ctx, uid)
 	return nil
 }
 
+func (repo *CachedArticleRepository) delFirstPage(ctx context.Context, author int64) {
+	err := repo.cache.DelFirstPage(ctx, author)
+	if err != nil {
+		repo.l.Error("删除缓存失败",
+			logger.Int64("author", author), logger.Error(err))
+	}
+}
+
+// delEditorFirstPage 共同作者修改的时候，他的列表里面也有这篇帖子
+// 其余共同作者的第一页缓存就等它自然过期
+func (repo *CachedArticleRepository) delEditorFirstPage(ctx context.Context, art domain.Article) {
+	if art.Editor.Id > 0 && art.Editor.Id != art.Author.Id {
+		repo.delFirstPage(ctx, art.Editor.Id)
+	}
+}
+
+func (repo *CachedArticleRepository) revisionToDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Editor: domain.Author{
+			Id: rev.EditorId,
+		},
+		Ctime: time.UnixMilli(rev.Ctime),
+	}
+}
+
 func (repo *CachedArticleRepository) ToDomain(art dao.Article) domain.Article {
-	return domain.Article{
+	res := domain.Article{
 		Id:      art.Id,
 		Title:   art.Title,
 		Status:  domain.ArticleStatus(art.Status),
@@ -310,10 +673,24 @@ func (repo *CachedArticleRepository) ToDomain(art dao.Article) domain.Article {
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
+		Version: art.Version,
+		Ctime:   time.UnixMilli(art.Ctime),
+		Utime:   time.UnixMilli(art.Utime),
+	}
+	if art.PublishAt > 0 {
+		res.PublishAt = time.UnixMilli(art.PublishAt)
 	}
+	if art.Dtime > 0 {
+		res.Dtime = time.UnixMilli(art.Dtime)
+	}
+	return res
 }
 
 func (repo *CachedArticleRepository) toEntity(art domain.Article) dao.Article {
+	var publishAt int64
+	if !art.PublishAt.IsZero() {
+		publishAt = art.PublishAt.UnixMilli()
+	}
 	return dao.Article{
 		Id:       art.Id,
 		Title:    art.Title,
@@ -322,6 +699,9 @@ func (repo *CachedArticleRepository) toEntity(art domain.Article) dao.Article {
 		// 这一步，就是将领域状态转化为存储状态。
 		// 这里我们就是直接转换，
 		// 有些情况下，这里可能是借助一个 map 来转
-		Status: uint8(art.Status),
+		Status:    uint8(art.Status),
+		PublishAt: publishAt,
+		Version:   art.Version,
+		EditorId:  art.Editor.Id,
 	}
 }
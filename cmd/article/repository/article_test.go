@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	cachemocks "github.com/XD/ScholarNet/cmd/article/repository/cache/mocks"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	artdaomocks "github.com/XD/ScholarNet/cmd/article/repository/dao/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedArticleRepository_BatchGetPublished(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache)
+		ids  []int64
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "全部命中缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPubs(gomock.Any(), []int64{1, 2}).
+					Return(map[int64]domain.Article{1: {Id: 1}, 2: {Id: 2}}, nil)
+				return d, c
+			},
+			ids:     []int64{1, 2},
+			wantIds: []int64{1, 2},
+		},
+		{
+			name: "部分命中，没有命中的一次查出来",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPubs(gomock.Any(), []int64{1, 2, 3}).
+					Return(map[int64]domain.Article{2: {Id: 2}}, nil)
+				// 3 不存在
+				d.EXPECT().ListPubByIds(gomock.Any(), []int64{1, 3}).
+					Return([]dao.PublishedArticle{{Id: 1, Content: "# 标题"}}, nil)
+				c.EXPECT().SetPubs(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				return d, c
+			},
+			ids:     []int64{1, 2, 3},
+			wantIds: []int64{1, 2},
+		},
+		{
+			name: "缓存出错，全部查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPubs(gomock.Any(), []int64{1, 2}).
+					Return(nil, errors.New("redis 错误"))
+				d.EXPECT().ListPubByIds(gomock.Any(), []int64{1, 2}).
+					Return([]dao.PublishedArticle{{Id: 1}, {Id: 2}}, nil)
+				c.EXPECT().SetPubs(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				return d, c
+			},
+			ids:     []int64{1, 2},
+			wantIds: []int64{1, 2},
+		},
+		{
+			name: "数据库出错",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPubs(gomock.Any(), []int64{1}).
+					Return(map[int64]domain.Article{}, nil)
+				d.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("db 错误"))
+				return d, c
+			},
+			ids:     []int64{1},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewArticleRepository(d, c, logger.NewNoOpLogger())
+			res, err := repo.BatchGetPublished(context.Background(), tc.ids)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			require.Len(t, res, len(tc.wantIds))
+			for _, id := range tc.wantIds {
+				assert.Equal(t, id, res[id].Id)
+			}
+		})
+	}
+}
+
+func TestCachedArticleRepository_GetPublishedById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	c := cachemocks.NewMockArticleCache(ctrl)
+
+	const n = 10
+	var missed sync.WaitGroup
+	missed.Add(n)
+	c.EXPECT().GetPubWithTTL(gomock.Any(), int64(1)).Times(n).
+		DoAndReturn(func(ctx context.Context, id int64) (domain.Article, time.Duration, error) {
+			missed.Done()
+			return domain.Article{}, 0, cache.ErrKeyNotExist
+		})
+	release := make(chan struct{})
+	// 并发的缓存未命中只会查一次数据库
+	d.EXPECT().GetPubById(gomock.Any(), int64(1)).Times(1).
+		DoAndReturn(func(ctx context.Context, id int64) (dao.PublishedArticle, error) {
+			<-release
+			return dao.PublishedArticle{Id: 1, Title: "标题"}, nil
+		})
+	c.EXPECT().SetPub(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	repo := NewArticleRepository(d, c, logger.NewNoOpLogger())
+	var wg sync.WaitGroup
+	res := make([]domain.Article, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res[i], errs[i] = repo.GetPublishedById(context.Background(), 1)
+		}(i)
+	}
+	missed.Wait()
+	// 等所有的请求都进入 singleflight
+	time.Sleep(time.Millisecond * 100)
+	close(release)
+	wg.Wait()
+	for i := 0; i < n; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "标题", res[i].Title)
+	}
+}
+
+func TestCachedArticleRepository_GetPublishedByIdCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	c := cachemocks.NewMockArticleCache(ctrl)
+
+	c.EXPECT().GetPubWithTTL(gomock.Any(), int64(1)).Times(2).
+		Return(domain.Article{}, time.Duration(0), cache.ErrKeyNotExist)
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	d.EXPECT().GetPubById(gomock.Any(), int64(1)).Times(1).
+		DoAndReturn(func(ctx context.Context, id int64) (dao.PublishedArticle, error) {
+			close(loading)
+			<-release
+			// 发起加载的请求已经取消了，加载本身不受影响
+			if err := ctx.Err(); err != nil {
+				return dao.PublishedArticle{}, err
+			}
+			return dao.PublishedArticle{Id: 1, Title: "标题"}, nil
+		})
+	c.EXPECT().SetPub(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	repo := NewArticleRepository(d, c, logger.NewNoOpLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.GetPublishedById(ctx, 1)
+		firstErr <- err
+	}()
+	<-loading
+	var (
+		second domain.Article
+		err    error
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		second, err = repo.GetPublishedById(context.Background(), 1)
+	}()
+	// 等第二个请求进入 singleflight
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	// 第一个请求不用等加载完就返回了
+	assert.Equal(t, context.Canceled, <-firstErr)
+	close(release)
+	<-done
+	require.NoError(t, err)
+	assert.Equal(t, "标题", second.Title)
+}
+
+func TestCachedArticleRepository_shouldRefreshEarly(t *testing.T) {
+	repo := &CachedArticleRepository{}
+	assert.False(t, repo.shouldRefreshEarly(-1))
+	// 离过期还早，基本不可能提前刷新
+	assert.False(t, repo.shouldRefreshEarly(time.Hour))
+	// 马上就要过期了
+	assert.True(t, repo.shouldRefreshEarly(time.Nanosecond))
+}
+
+func TestCachedArticleRepository_SyncWithoutBinlog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	c := cachemocks.NewMockArticleCache(ctrl)
+	d.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	// 没有 binlog，作者、共同作者和读者的缓存都要立刻删掉
+	c.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+	c.EXPECT().DelPub(gomock.Any(), int64(1)).Return(nil)
+	c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).Return(nil)
+	d.EXPECT().ListCoAuthors(gomock.Any(), int64(1)).
+		Return([]dao.ArticleCoAuthor{{UserId: 456, Status: dao.CoAuthorStatusAccepted}}, nil)
+	c.EXPECT().DelFirstPage(gomock.Any(), int64(456)).Return(nil)
+	// 同一个系列里面的导航也要删掉
+	d.EXPECT().GetSeriesArticle(gomock.Any(), int64(1)).
+		Return(dao.SeriesArticle{SeriesId: 7, ArticleId: 1}, nil)
+	d.EXPECT().ListSeriesArticles(gomock.Any(), int64(7)).
+		Return([]dao.SeriesArticle{{SeriesId: 7, ArticleId: 2}, {SeriesId: 7, ArticleId: 1}}, nil)
+	c.EXPECT().DelSeriesNavs(gomock.Any(), int64(2), int64(1)).Return(nil)
+
+	repo := NewArticleRepositoryWithoutBinlog(d, c, logger.NewNoOpLogger())
+	id, err := repo.Sync(context.Background(), domain.Article{
+		Title:  "标题",
+		Author: domain.Author{Id: 123},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestCachedArticleRepository_GetSeriesNav(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache)
+
+		wantNav *domain.SeriesNav
+		wantErr error
+	}{
+		{
+			name: "缓存命中",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetSeriesNav(gomock.Any(), int64(1)).
+					Return(&domain.SeriesNav{SeriesId: 7, Next: domain.SeriesItem{Id: 2, Title: "下一篇"}}, nil)
+				return d, c
+			},
+			wantNav: &domain.SeriesNav{SeriesId: 7, Next: domain.SeriesItem{Id: 2, Title: "下一篇"}},
+		},
+		{
+			name: "缓存命中，不在系列里面",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetSeriesNav(gomock.Any(), int64(1)).Return(nil, nil)
+				return d, c
+			},
+		},
+		{
+			name: "缓存没有命中，不在系列里面也要缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetSeriesNav(gomock.Any(), int64(1)).Return(nil, cache.ErrKeyNotExist)
+				d.EXPECT().GetSeriesArticle(gomock.Any(), int64(1)).Return(dao.SeriesArticle{}, dao.ErrDataNotFound)
+				c.EXPECT().SetSeriesNav(gomock.Any(), int64(1), (*domain.SeriesNav)(nil)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "缓存没有命中，查询数据库失败",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetSeriesNav(gomock.Any(), int64(1)).Return(nil, cache.ErrKeyNotExist)
+				d.EXPECT().GetSeriesArticle(gomock.Any(), int64(1)).Return(dao.SeriesArticle{}, errors.New("mock db error"))
+				return d, c
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewArticleRepository(d, c, logger.NewNoOpLogger())
+			nav, err := repo.GetSeriesNav(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantNav, nav)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var (
+	ErrAttachmentNotFound = dao.ErrAttachmentNotFound
+	ErrObjectNotFound     = dao.ErrObjectNotFound
+)
+
+// AttachmentRepository 附件的元数据在数据库里面，内容在对象存储里面
+type AttachmentRepository interface {
+	// PrepareUpload 记录下附件，内容还没有上传过的话返回上传的链接
+	PrepareUpload(ctx context.Context, att domain.Attachment) (domain.UploadTicket, error)
+	// ConfirmUpload 确认内容已经上传到对象存储，并且大小和类型都对得上
+	// 没有上传返回 ErrObjectNotFound
+	ConfirmUpload(ctx context.Context, att domain.Attachment) (bool, error)
+	// GetById 不存在返回 ErrAttachmentNotFound
+	GetById(ctx context.Context, id int64) (domain.Attachment, error)
+	// Uploaded 附件的内容是不是已经确认上传了
+	Uploaded(ctx context.Context, hash string) (bool, error)
+	Link(ctx context.Context, uid, id, artId int64) error
+	ListByArticle(ctx context.Context, artId int64) ([]domain.Attachment, error)
+	// DeleteByArticle 帖子彻底删除的时候用，对象存储里面的内容由 DeleteUnreferencedBlobs 回收
+	DeleteByArticle(ctx context.Context, artId int64) error
+
+	// DeleteUnlinked 删除 before 之前上传但是没有关联到帖子的附件
+	DeleteUnlinked(ctx context.Context, before time.Time, limit int) (int64, error)
+	// DeleteUnreferencedBlobs 删除没有被任何附件引用的内容，包括对象存储里面的
+	DeleteUnreferencedBlobs(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+type S3AttachmentRepository struct {
+	dao     dao.AttachmentDAO
+	storage dao.AttachmentStorage
+}
+
+func NewAttachmentRepository(d dao.AttachmentDAO, storage dao.AttachmentStorage) AttachmentRepository {
+	return &S3AttachmentRepository{
+		dao:     d,
+		storage: storage,
+	}
+}
+
+func (repo *S3AttachmentRepository) PrepareUpload(ctx context.Context,
+	att domain.Attachment) (domain.UploadTicket, error) {
+	blob, err := repo.dao.UpsertBlob(ctx, dao.AttachmentBlob{
+		Hash:        att.Hash,
+		Size:        att.Size,
+		ContentType: att.ContentType,
+		Status:      dao.BlobStatusPending,
+	})
+	if err != nil {
+		return domain.UploadTicket{}, err
+	}
+	// 内容一样，大小和类型都以第一次上传的为准
+	id, err := repo.dao.Insert(ctx, dao.Attachment{
+		Uid:         att.Uid,
+		Hash:        blob.Hash,
+		Size:        blob.Size,
+		ContentType: blob.ContentType,
+		FileName:    att.FileName,
+	})
+	if err != nil {
+		return domain.UploadTicket{}, err
+	}
+	if blob.Status == dao.BlobStatusUploaded {
+		return domain.UploadTicket{AttachmentId: id, Exists: true}, nil
+	}
+	req, err := repo.storage.PresignPut(ctx, domain.AttachmentKey(blob.Hash),
+		blob.Size, blob.ContentType, blob.Hash)
+	if err != nil {
+		return domain.UploadTicket{}, err
+	}
+	return domain.UploadTicket{
+		AttachmentId: id,
+		URL:          req.URL,
+		Headers:      req.Headers,
+		ExpireAt:     req.ExpireAt,
+	}, nil
+}
+
+func (repo *S3AttachmentRepository) ConfirmUpload(ctx context.Context, att domain.Attachment) (bool, error) {
+	info, err := repo.storage.Head(ctx, att.Key())
+	if err != nil {
+		return false, err
+	}
+	if info.Size != att.Size || info.ContentType != att.ContentType {
+		return false, nil
+	}
+	return true, repo.dao.MarkBlobUploaded(ctx, att.Hash)
+}
+
+func (repo *S3AttachmentRepository) GetById(ctx context.Context, id int64) (domain.Attachment, error) {
+	att, err := repo.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return repo.toDomain(att), nil
+}
+
+func (repo *S3AttachmentRepository) Uploaded(ctx context.Context, hash string) (bool, error) {
+	blob, err := repo.dao.GetBlob(ctx, hash)
+	if err != nil {
+		return false, err
+	}
+	return blob.Status == dao.BlobStatusUploaded, nil
+}
+
+func (repo *S3AttachmentRepository) Link(ctx context.Context, uid, id, artId int64) error {
+	return repo.dao.Link(ctx, uid, id, artId)
+}
+
+func (repo *S3AttachmentRepository) ListByArticle(ctx context.Context, artId int64) ([]domain.Attachment, error) {
+	atts, err := repo.dao.ListByArticle(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Attachment, domain.Attachment](atts,
+		func(idx int, src dao.Attachment) domain.Attachment {
+			return repo.toDomain(src)
+		}), nil
+}
+
+func (repo *S3AttachmentRepository) DeleteByArticle(ctx context.Context, artId int64) error {
+	return repo.dao.DeleteByArticle(ctx, artId)
+}
+
+func (repo *S3AttachmentRepository) DeleteUnlinked(ctx context.Context,
+	before time.Time, limit int) (int64, error) {
+	return repo.dao.DeleteUnlinked(ctx, before.UnixMilli(), limit)
+}
+
+func (repo *S3AttachmentRepository) DeleteUnreferencedBlobs(ctx context.Context,
+	before time.Time, limit int) (int, error) {
+	blobs, err := repo.dao.ListUnreferencedBlobs(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, blob := range blobs {
+		// 先删数据库，确认没有人引用了再删对象存储
+		// 删除数据库之后又有人上传同样的内容，会重新生成上传链接，
+		// 确认上传的时候对象不存在，客户端重新上传一次就可以
+		ok, err := repo.dao.DeleteBlob(ctx, blob.Hash)
+		if err != nil {
+			return cnt, err
+		}
+		if !ok {
+			continue
+		}
+		err = repo.storage.Delete(ctx, domain.AttachmentKey(blob.Hash))
+		if err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+func (repo *S3AttachmentRepository) toDomain(att dao.Attachment) domain.Attachment {
+	res := domain.Attachment{
+		Id:          att.Id,
+		Uid:         att.Uid,
+		ArticleId:   att.ArticleId,
+		Hash:        att.Hash,
+		Size:        att.Size,
+		ContentType: att.ContentType,
+		FileName:    att.FileName,
+		Ctime:       time.UnixMilli(att.Ctime),
+	}
+	res.URL = repo.storage.URL(res.Key())
+	return res
+}
@@ -5,12 +5,20 @@ import (
 	userv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/user/v1"
 	"github.com/XD/ScholarNet/cmd/article/domain"
 	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
 )
 
 // AuthorRepository 封装user的client用于获取用户信息
 type AuthorRepository interface {
 	// FindAuthor id为文章id
 	FindAuthor(ctx context.Context, id int64) (domain.Author, error)
+	// FindAuthors id为文章id，返回所有已经接受邀请的作者，
+	// 按照署名顺序排列，第一个是创建帖子的人
+	FindAuthors(ctx context.Context, id int64) ([]domain.Author, error)
+	// BatchFindAuthors 和 FindAuthors 一样，返回帖子 ID 到作者的映射
+	// arts 里面要有创建帖子的人，所有作者的信息一次查出来
+	BatchFindAuthors(ctx context.Context, arts []domain.Article) (map[int64][]domain.Author, error)
 }
 
 type GrpcAuthorRepository struct {
@@ -41,3 +49,85 @@ func (g *GrpcAuthorRepository) FindAuthor(ctx context.Context, id int64) (domain
 		Name: u.User.Nickname,
 	}, nil
 }
+
+func (g *GrpcAuthorRepository) FindAuthors(ctx context.Context, id int64) ([]domain.Author, error) {
+	var (
+		eg        errgroup.Group
+		art       dao.PublishedArticle
+		coAuthors []dao.ArticleCoAuthor
+	)
+	eg.Go(func() error {
+		var err error
+		art, err = g.dao.GetPubById(ctx, id)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		coAuthors, err = g.dao.ListCoAuthors(ctx, id)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	authors := []domain.Author{{Id: art.AuthorId, Role: domain.AuthorRoleOwner}}
+	for _, c := range coAuthors {
+		if c.Status == domain.CoAuthorStatusAccepted.ToUint8() {
+			authors = append(authors, domain.Author{Id: c.UserId, Role: domain.AuthorRoleCoAuthor})
+		}
+	}
+	if err := g.fillNames(ctx, authors); err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+func (g *GrpcAuthorRepository) BatchFindAuthors(ctx context.Context,
+	arts []domain.Article) (map[int64][]domain.Author, error) {
+	if len(arts) == 0 {
+		return map[int64][]domain.Author{}, nil
+	}
+	artIds := slice.Map(arts, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+	coAuthors, err := g.dao.ListCoAuthorsByArticles(ctx, artIds,
+		domain.CoAuthorStatusAccepted.ToUint8())
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]domain.Author, len(arts))
+	all := make([]domain.Author, 0, len(arts)+len(coAuthors))
+	for _, art := range arts {
+		all = append(all, domain.Author{Id: art.Author.Id, Role: domain.AuthorRoleOwner})
+	}
+	for _, c := range coAuthors {
+		all = append(all, domain.Author{Id: c.UserId, Role: domain.AuthorRoleCoAuthor})
+	}
+	if err = g.fillNames(ctx, all); err != nil {
+		return nil, err
+	}
+	// 前面 len(arts) 个是创建帖子的人，后面的共同作者已经按照帖子和署名顺序排好了
+	for i, art := range arts {
+		res[art.Id] = []domain.Author{all[i]}
+	}
+	for i, c := range coAuthors {
+		res[c.ArticleId] = append(res[c.ArticleId], all[len(arts)+i])
+	}
+	return res, nil
+}
+
+// fillNames 一次把所有作者的信息都查出来
+func (g *GrpcAuthorRepository) fillNames(ctx context.Context, authors []domain.Author) error {
+	ids := slice.Map(authors, func(idx int, src domain.Author) int64 {
+		return src.Id
+	})
+	resp, err := g.client.BatchProfile(ctx, &userv1.BatchProfileRequest{Ids: ids})
+	if err != nil {
+		return err
+	}
+	for i := range authors {
+		if u, ok := resp.Users[authors[i].Id]; ok {
+			authors[i].Name = u.Nickname
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
 	"github.com/XD/ScholarNet/cmd/article/domain"
@@ -9,7 +10,18 @@ import (
 	"time"
 )
 
-var ErrKeyNotExist = redis.Nil
+var (
+	ErrKeyNotExist = redis.Nil
+	//go:embed lua/set_pub_if_newer.lua
+	luaSetPubIfNewer string
+)
+
+const (
+	// pubExpiration 读者端帖子缓存的过期时间
+	pubExpiration = time.Minute * 30
+	// pubUtimeExpiration 记录的 utime 要比 binlog 可能重复投递的时间长
+	pubUtimeExpiration = time.Hour * 24
+)
 
 //go:generate mockgen -source=./article.go -package=cachemocks -destination=mocks/article.mock.go ArticleCache
 type ArticleCache interface {
@@ -21,12 +33,36 @@ type ArticleCache interface {
 
 	Set(ctx context.Context, art domain.Article) error
 	Get(ctx context.Context, id int64) (domain.Article, error)
+	Del(ctx context.Context, id int64) error
 
 	// SetPub 正常来说，创作者和读者的 Redis 集群要分开，因为读者是一个核心中的核心
 	PreSetPub(ctx context.Context, art domain.Article) error
 	SetPub(ctx context.Context, article domain.Article) error
 	DelPub(ctx context.Context, id int64) error
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
+	// GetPubWithTTL 和 GetPub 一样，顺便返回缓存还有多久过期，提前刷新缓存要用
+	GetPubWithTTL(ctx context.Context, id int64) (domain.Article, time.Duration, error)
+	// GetPubs 一次 MGET，返回命中的，没有命中的不在结果里面
+	GetPubs(ctx context.Context, ids []int64) (map[int64]domain.Article, error)
+	// SetPubs 和 SetPub 一样，不过是一次 pipeline 写进去
+	SetPubs(ctx context.Context, arts []domain.Article) error
+	// SetPubIfNewer 和 SetPub 一样，不过 art.Utime 比上一次用的旧就什么也不做
+	// 给 binlog 用，乱序或者重复的消息不会把新的数据覆盖掉，返回有没有更新
+	SetPubIfNewer(ctx context.Context, art domain.Article) (bool, error)
+	// DelPubIfNewer 和 SetPubIfNewer 一样，不过是删除缓存
+	DelPubIfNewer(ctx context.Context, id int64, utime time.Time) (bool, error)
+
+	// 系列导航和可见范围是读者看帖子的时候要一起查的，和读者端的帖子分开缓存、分开失效
+	// 只走 Redis，不放本地缓存，改了之后所有节点马上生效
+
+	// GetSeriesNav 没有命中返回 ErrKeyNotExist，不在系列里面的帖子也会缓存，返回 nil
+	GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error)
+	SetSeriesNav(ctx context.Context, artId int64, nav *domain.SeriesNav) error
+	DelSeriesNavs(ctx context.Context, artIds ...int64) error
+	// GetVisibilities 返回命中的，没有命中的不在结果里面
+	GetVisibilities(ctx context.Context, artIds []int64) (map[int64]domain.Visibility, error)
+	SetVisibilities(ctx context.Context, vs map[int64]domain.Visibility) error
+	DelVisibility(ctx context.Context, artId int64) error
 }
 
 type RedisArticleCache struct {
@@ -54,6 +90,90 @@ func (r *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Articl
 	return res, err
 }
 
+func (r *RedisArticleCache) GetPubWithTTL(ctx context.Context, id int64) (domain.Article, time.Duration, error) {
+	key := r.readerArtKey(id)
+	// 一次往返拿到数据和过期时间
+	pipe := r.client.Pipeline()
+	getCmd := pipe.Get(ctx, key)
+	ttlCmd := pipe.PTTL(ctx, key)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return domain.Article{}, 0, err
+	}
+	data, err := getCmd.Bytes()
+	if err != nil {
+		return domain.Article{}, 0, err
+	}
+	var res domain.Article
+	err = json.Unmarshal(data, &res)
+	return res, ttlCmd.Val(), err
+}
+
+func (r *RedisArticleCache) GetPubs(ctx context.Context, ids []int64) (map[int64]domain.Article, error) {
+	res := make(map[int64]domain.Article, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, r.readerArtKey(id))
+	}
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, val := range vals {
+		// 没有命中的是 nil
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		var art domain.Article
+		if err = json.Unmarshal([]byte(str), &art); err != nil {
+			// 坏掉的数据当作没有命中，等着重新写
+			continue
+		}
+		res[art.Id] = art
+	}
+	return res, nil
+}
+
+func (r *RedisArticleCache) SetPubs(ctx context.Context, arts []domain.Article) error {
+	pipe := r.client.Pipeline()
+	for _, art := range arts {
+		data, err := json.Marshal(art)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, r.readerArtKey(art.Id), data, pubExpiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisArticleCache) SetPubIfNewer(ctx context.Context, art domain.Article) (bool, error) {
+	data, err := json.Marshal(art)
+	if err != nil {
+		return false, err
+	}
+	return r.setPubIfNewer(ctx, art.Id, art.Utime, string(data))
+}
+
+func (r *RedisArticleCache) DelPubIfNewer(ctx context.Context, id int64, utime time.Time) (bool, error) {
+	return r.setPubIfNewer(ctx, id, utime, "")
+}
+
+func (r *RedisArticleCache) setPubIfNewer(ctx context.Context, id int64, utime time.Time, data string) (bool, error) {
+	res, err := r.client.Eval(ctx, luaSetPubIfNewer,
+		[]string{r.readerArtKey(id), r.readerUtimeKey(id)},
+		utime.UnixMilli(), data,
+		int64(pubExpiration/time.Second), int64(pubUtimeExpiration/time.Second)).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
 func (r *RedisArticleCache) SetPub(ctx context.Context, art domain.Article) error {
 	data, err := json.Marshal(art)
 	if err != nil {
@@ -62,7 +182,7 @@ func (r *RedisArticleCache) SetPub(ctx context.Context, art domain.Article) erro
 	return r.client.Set(ctx, r.readerArtKey(art.Id),
 		data,
 		// 设置长过期时间
-		time.Minute*30).Err()
+		pubExpiration).Err()
 }
 
 func (r *RedisArticleCache) PreSetPub(ctx context.Context, art domain.Article) error {
@@ -95,6 +215,10 @@ func (r *RedisArticleCache) Set(ctx context.Context, art domain.Article) error {
 	return r.client.Set(ctx, r.authorArtKey(art.Id), data, time.Minute).Err()
 }
 
+func (r *RedisArticleCache) Del(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.authorArtKey(id)).Err()
+}
+
 func (r *RedisArticleCache) DelFirstPage(ctx context.Context, author int64) error {
 	return r.client.Del(ctx, r.firstPageKey(author)).Err()
 }
@@ -132,6 +256,11 @@ func (r *RedisArticleCache) readerArtKey(id int64) string {
 	return fmt.Sprintf("article:reader:%d", id)
 }
 
+// readerUtimeKey 读者端的缓存上一次是用哪个 utime 的数据更新的
+func (r *RedisArticleCache) readerUtimeKey(id int64) string {
+	return fmt.Sprintf("article:reader_utime:%d", id)
+}
+
 func (r *RedisArticleCache) firstPageKey(author int64) string {
 	return fmt.Sprintf("article:first_page:%d", author)
 }
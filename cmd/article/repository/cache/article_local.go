@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// LocalArticleCache 热点帖子的本地缓存
+// 只有在 window 时间内被读了 threshold 次的帖子才会放进来，
+// 不然本地缓存很快就会被长尾的帖子挤满
+type LocalArticleCache struct {
+	// id => localArticleItem
+	arts *lru.Cache
+	// id => *hotCounter，计数本身也要淘汰，所以也用 LRU
+	counters *lru.Cache
+
+	threshold  int64
+	window     time.Duration
+	expiration time.Duration
+}
+
+func NewLocalArticleCache(arts *lru.Cache, counters *lru.Cache,
+	threshold int64, window time.Duration, expiration time.Duration) *LocalArticleCache {
+	return &LocalArticleCache{
+		arts:       arts,
+		counters:   counters,
+		threshold:  threshold,
+		window:     window,
+		expiration: expiration,
+	}
+}
+
+// Get 返回帖子，以及 Redis 里面那份缓存还有多久过期
+func (l *LocalArticleCache) Get(id int64) (domain.Article, time.Duration, bool) {
+	val, ok := l.arts.Get(id)
+	if !ok {
+		return domain.Article{}, 0, false
+	}
+	itm, ok := val.(localArticleItem)
+	now := time.Now()
+	if !ok || !itm.expire.After(now) {
+		l.arts.Remove(id)
+		return domain.Article{}, 0, false
+	}
+	return itm.art, itm.redisExpire.Sub(now), true
+}
+
+// Hot 记录一次访问，返回这个帖子现在是不是热点
+func (l *LocalArticleCache) Hot(id int64) bool {
+	now := time.Now()
+	val, _, _ := l.counters.PeekOrAdd(id, &hotCounter{start: now, cnt: 1})
+	if val == nil {
+		// 第一次访问
+		return l.threshold <= 1
+	}
+	return val.(*hotCounter).incr(now, l.window) >= l.threshold
+}
+
+// Set redisTTL 是 Redis 里面那份缓存剩下的过期时间，
+// 本地缓存不能比 Redis 的活得久
+func (l *LocalArticleCache) Set(art domain.Article, redisTTL time.Duration) {
+	now := time.Now()
+	expire := now.Add(l.expiration)
+	redisExpire := now.Add(redisTTL)
+	if redisExpire.Before(expire) {
+		expire = redisExpire
+	}
+	l.arts.Add(art.Id, localArticleItem{
+		art:         art,
+		expire:      expire,
+		redisExpire: redisExpire,
+	})
+}
+
+func (l *LocalArticleCache) Del(id int64) {
+	l.arts.Remove(id)
+}
+
+type localArticleItem struct {
+	art    domain.Article
+	expire time.Time
+	// Redis 里面的缓存什么时候过期，提前刷新要用
+	redisExpire time.Time
+}
+
+// hotCounter 固定窗口计数
+type hotCounter struct {
+	mu    sync.Mutex
+	start time.Time
+	cnt   int64
+}
+
+func (c *hotCounter) incr(now time.Time, window time.Duration) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.start) >= window {
+		c.start = now
+		c.cnt = 0
+	}
+	c.cnt++
+	return c.cnt
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalArticleCache(t *testing.T, threshold int64,
+	window, expiration time.Duration) *LocalArticleCache {
+	arts, err := lru.New(10)
+	require.NoError(t, err)
+	counters, err := lru.New(10)
+	require.NoError(t, err)
+	return NewLocalArticleCache(arts, counters, threshold, window, expiration)
+}
+
+func TestLocalArticleCache_Hot(t *testing.T) {
+	l := newTestLocalArticleCache(t, 3, time.Minute, time.Minute)
+	assert.False(t, l.Hot(1))
+	assert.False(t, l.Hot(1))
+	assert.True(t, l.Hot(1))
+	// 别的帖子单独计数
+	assert.False(t, l.Hot(2))
+
+	// 窗口过了重新计数
+	l = newTestLocalArticleCache(t, 2, time.Millisecond*10, time.Minute)
+	assert.False(t, l.Hot(1))
+	time.Sleep(time.Millisecond * 20)
+	assert.False(t, l.Hot(1))
+	assert.True(t, l.Hot(1))
+}
+
+func TestLocalArticleCache_Get(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expiration time.Duration
+		redisTTL   time.Duration
+		wait       time.Duration
+		del        bool
+
+		wantOk bool
+	}{
+		{
+			name:       "命中",
+			expiration: time.Minute,
+			redisTTL:   time.Minute * 10,
+			wantOk:     true,
+		},
+		{
+			name:       "本地缓存过期",
+			expiration: time.Millisecond * 10,
+			redisTTL:   time.Minute * 10,
+			wait:       time.Millisecond * 20,
+		},
+		{
+			name:       "Redis 先过期，本地缓存也不能用了",
+			expiration: time.Minute,
+			redisTTL:   time.Millisecond * 10,
+			wait:       time.Millisecond * 20,
+		},
+		{
+			name:       "被删除",
+			expiration: time.Minute,
+			redisTTL:   time.Minute * 10,
+			del:        true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newTestLocalArticleCache(t, 1, time.Minute, tc.expiration)
+			l.Set(domain.Article{Id: 1, Title: "标题"}, tc.redisTTL)
+			if tc.del {
+				l.Del(1)
+			}
+			time.Sleep(tc.wait)
+			art, ttl, ok := l.Get(1)
+			assert.Equal(t, tc.wantOk, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, "标题", art.Title)
+			// 返回的是 Redis 剩下的过期时间
+			assert.True(t, ttl > tc.expiration)
+		})
+	}
+}
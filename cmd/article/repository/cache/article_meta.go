@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+)
+
+// metaExpiration 系列导航和可见范围的过期时间
+// 改系列、改可见范围的时候会主动删掉，相邻帖子改标题、撤回这种删不干净的，最多旧这么久
+const metaExpiration = time.Minute * 10
+
+func (r *RedisArticleCache) GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error) {
+	data, err := r.client.Get(ctx, r.seriesNavKey(artId)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// 不在系列里面的存的是 null
+	var res *domain.SeriesNav
+	err = json.Unmarshal(data, &res)
+	return res, err
+}
+
+func (r *RedisArticleCache) SetSeriesNav(ctx context.Context, artId int64, nav *domain.SeriesNav) error {
+	data, err := json.Marshal(nav)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.seriesNavKey(artId), data, metaExpiration).Err()
+}
+
+func (r *RedisArticleCache) DelSeriesNavs(ctx context.Context, artIds ...int64) error {
+	if len(artIds) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(artIds))
+	for _, id := range artIds {
+		keys = append(keys, r.seriesNavKey(id))
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisArticleCache) GetVisibilities(ctx context.Context, artIds []int64) (map[int64]domain.Visibility, error) {
+	res := make(map[int64]domain.Visibility, len(artIds))
+	if len(artIds) == 0 {
+		return res, nil
+	}
+	keys := make([]string, 0, len(artIds))
+	for _, id := range artIds {
+		keys = append(keys, r.visibilityKey(id))
+	}
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, val := range vals {
+		// 没有命中的是 nil
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		v, er := strconv.ParseUint(str, 10, 8)
+		if er != nil {
+			// 坏掉的数据当作没有命中，等着重新写
+			continue
+		}
+		res[artIds[i]] = domain.Visibility(v)
+	}
+	return res, nil
+}
+
+func (r *RedisArticleCache) SetVisibilities(ctx context.Context, vs map[int64]domain.Visibility) error {
+	if len(vs) == 0 {
+		return nil
+	}
+	pipe := r.client.Pipeline()
+	for id, v := range vs {
+		pipe.Set(ctx, r.visibilityKey(id), v.ToUint8(), metaExpiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisArticleCache) DelVisibility(ctx context.Context, artId int64) error {
+	return r.client.Del(ctx, r.visibilityKey(artId)).Err()
+}
+
+func (r *RedisArticleCache) seriesNavKey(artId int64) string {
+	return fmt.Sprintf("article:series_nav:%d", artId)
+}
+
+func (r *RedisArticleCache) visibilityKey(artId int64) string {
+	return fmt.Sprintf("article:visibility:%d", artId)
+}
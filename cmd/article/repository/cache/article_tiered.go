@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	tierLocal = "local"
+	tierRedis = "redis"
+)
+
+// InvalidationPublisher 通知别的节点删除本地缓存
+type InvalidationPublisher interface {
+	PublishInvalidate(ctx context.Context, ids ...int64) error
+}
+
+// TieredArticleCache 在 Redis 前面加一层热点帖子的本地缓存
+// 只有读者端的缓存走本地缓存，创作者那边的直接用 Redis
+// 读者端的缓存有任何修改，都要删掉本地缓存并且通知别的节点也删掉
+type TieredArticleCache struct {
+	ArticleCache
+	local     *LocalArticleCache
+	publisher InvalidationPublisher
+	// tier, result 两个标签，命中率就是 hit / (hit + miss)
+	vector *prometheus.CounterVec
+}
+
+func NewTieredArticleCache(c ArticleCache, local *LocalArticleCache,
+	publisher InvalidationPublisher, vector *prometheus.CounterVec) *TieredArticleCache {
+	return &TieredArticleCache{
+		ArticleCache: c,
+		local:        local,
+		publisher:    publisher,
+		vector:       vector,
+	}
+}
+
+func (t *TieredArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
+	art, _, err := t.GetPubWithTTL(ctx, id)
+	return art, err
+}
+
+func (t *TieredArticleCache) GetPubWithTTL(ctx context.Context, id int64) (domain.Article, time.Duration, error) {
+	if art, ttl, ok := t.local.Get(id); ok {
+		t.record(tierLocal, true)
+		return art, ttl, nil
+	}
+	t.record(tierLocal, false)
+	art, ttl, err := t.ArticleCache.GetPubWithTTL(ctx, id)
+	t.record(tierRedis, err == nil)
+	if err != nil {
+		return domain.Article{}, 0, err
+	}
+	// ttl 小于 0 说明 key 没有过期时间，这种不应该出现，就不放本地缓存了
+	if ttl > 0 && t.local.Hot(id) {
+		t.local.Set(art, ttl)
+	}
+	return art, ttl, nil
+}
+
+func (t *TieredArticleCache) GetPubs(ctx context.Context, ids []int64) (map[int64]domain.Article, error) {
+	res := make(map[int64]domain.Article, len(ids))
+	misses := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if art, _, ok := t.local.Get(id); ok {
+			res[id] = art
+			continue
+		}
+		misses = append(misses, id)
+	}
+	t.recordN(tierLocal, len(res), len(misses))
+	if len(misses) == 0 {
+		return res, nil
+	}
+	// 批量查询拿不到过期时间，所以不往本地缓存放
+	arts, err := t.ArticleCache.GetPubs(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	t.recordN(tierRedis, len(arts), len(misses)-len(arts))
+	for id, art := range arts {
+		res[id] = art
+	}
+	return res, nil
+}
+
+func (t *TieredArticleCache) SetPub(ctx context.Context, art domain.Article) error {
+	if err := t.ArticleCache.SetPub(ctx, art); err != nil {
+		return err
+	}
+	return t.invalidate(ctx, art.Id)
+}
+
+func (t *TieredArticleCache) PreSetPub(ctx context.Context, art domain.Article) error {
+	if err := t.ArticleCache.PreSetPub(ctx, art); err != nil {
+		return err
+	}
+	return t.invalidate(ctx, art.Id)
+}
+
+func (t *TieredArticleCache) SetPubs(ctx context.Context, arts []domain.Article) error {
+	if err := t.ArticleCache.SetPubs(ctx, arts); err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	return t.invalidate(ctx, ids...)
+}
+
+func (t *TieredArticleCache) SetPubIfNewer(ctx context.Context, art domain.Article) (bool, error) {
+	ok, err := t.ArticleCache.SetPubIfNewer(ctx, art)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return ok, t.invalidate(ctx, art.Id)
+}
+
+func (t *TieredArticleCache) DelPubIfNewer(ctx context.Context, id int64, utime time.Time) (bool, error) {
+	ok, err := t.ArticleCache.DelPubIfNewer(ctx, id, utime)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return ok, t.invalidate(ctx, id)
+}
+
+func (t *TieredArticleCache) DelPub(ctx context.Context, id int64) error {
+	if err := t.ArticleCache.DelPub(ctx, id); err != nil {
+		return err
+	}
+	return t.invalidate(ctx, id)
+}
+
+// Invalidate 收到别的节点的通知之后删除本地缓存，不会再通知出去
+func (t *TieredArticleCache) Invalidate(ids ...int64) {
+	for _, id := range ids {
+		t.local.Del(id)
+	}
+}
+
+// invalidate 要在 Redis 更新之后，
+// 不然别的节点删掉本地缓存之后，可能又从 Redis 读到了旧的数据
+func (t *TieredArticleCache) invalidate(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	t.Invalidate(ids...)
+	return t.publisher.PublishInvalidate(ctx, ids...)
+}
+
+func (t *TieredArticleCache) record(tier string, hit bool) {
+	if hit {
+		t.recordN(tier, 1, 0)
+		return
+	}
+	t.recordN(tier, 0, 1)
+}
+
+func (t *TieredArticleCache) recordN(tier string, hits, misses int) {
+	if hits > 0 {
+		t.vector.WithLabelValues(tier, "hit").Add(float64(hits))
+	}
+	if misses > 0 {
+		t.vector.WithLabelValues(tier, "miss").Add(float64(misses))
+	}
+}
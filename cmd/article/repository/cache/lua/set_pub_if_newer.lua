@@ -0,0 +1,17 @@
+-- KEYS[1] 读者端帖子的缓存，KEYS[2] 上一次更新缓存用的 utime
+-- ARGV[1] utime，ARGV[2] 帖子，空字符串表示删除缓存
+-- ARGV[3] 帖子缓存的过期时间（秒），ARGV[4] utime 的过期时间（秒）
+local last = tonumber(redis.call("GET", KEYS[2]))
+local utime = tonumber(ARGV[1])
+if last ~= nil and utime < last then
+    -- 乱序过来的旧数据
+    return 0
+end
+-- utime 一样的是重复的消息，再执行一次也没有关系
+redis.call("SET", KEYS[2], ARGV[1], "EX", ARGV[4])
+if ARGV[2] == "" then
+    redis.call("DEL", KEYS[1])
+else
+    redis.call("SET", KEYS[1], ARGV[2], "EX", ARGV[3])
+end
+return 1
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=./article.go -package=cachemocks -destination=mocks/article.mock.go ArticleCache
+//	mockgen -source=./article.go -package=cachemocks -destination=mocks/article.mock.go
 //
 // Package cachemocks is a generated GoMock package.
 package cachemocks
@@ -11,6 +11,7 @@ package cachemocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/XD/ScholarNet/cmd/article/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -39,6 +40,20 @@ func (m *MockArticleCache) EXPECT() *MockArticleCacheMockRecorder {
 	return m.recorder
 }
 
+// Del mocks base method.
+func (m *MockArticleCache) Del(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockArticleCacheMockRecorder) Del(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockArticleCache)(nil).Del), ctx, id)
+}
+
 // DelFirstPage mocks base method.
 func (m *MockArticleCache) DelFirstPage(ctx context.Context, author int64) error {
 	m.ctrl.T.Helper()
@@ -53,6 +68,68 @@ func (mr *MockArticleCacheMockRecorder) DelFirstPage(ctx, author any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelFirstPage", reflect.TypeOf((*MockArticleCache)(nil).DelFirstPage), ctx, author)
 }
 
+// DelPub mocks base method.
+func (m *MockArticleCache) DelPub(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelPub", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelPub indicates an expected call of DelPub.
+func (mr *MockArticleCacheMockRecorder) DelPub(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelPub", reflect.TypeOf((*MockArticleCache)(nil).DelPub), ctx, id)
+}
+
+// DelPubIfNewer mocks base method.
+func (m *MockArticleCache) DelPubIfNewer(ctx context.Context, id int64, utime time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelPubIfNewer", ctx, id, utime)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DelPubIfNewer indicates an expected call of DelPubIfNewer.
+func (mr *MockArticleCacheMockRecorder) DelPubIfNewer(ctx, id, utime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelPubIfNewer", reflect.TypeOf((*MockArticleCache)(nil).DelPubIfNewer), ctx, id, utime)
+}
+
+// DelSeriesNavs mocks base method.
+func (m *MockArticleCache) DelSeriesNavs(ctx context.Context, artIds ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range artIds {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DelSeriesNavs", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelSeriesNavs indicates an expected call of DelSeriesNavs.
+func (mr *MockArticleCacheMockRecorder) DelSeriesNavs(ctx any, artIds ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, artIds...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelSeriesNavs", reflect.TypeOf((*MockArticleCache)(nil).DelSeriesNavs), varargs...)
+}
+
+// DelVisibility mocks base method.
+func (m *MockArticleCache) DelVisibility(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelVisibility", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelVisibility indicates an expected call of DelVisibility.
+func (mr *MockArticleCacheMockRecorder) DelVisibility(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelVisibility", reflect.TypeOf((*MockArticleCache)(nil).DelVisibility), ctx, artId)
+}
+
 // Get mocks base method.
 func (m *MockArticleCache) Get(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +175,81 @@ func (mr *MockArticleCacheMockRecorder) GetPub(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPub", reflect.TypeOf((*MockArticleCache)(nil).GetPub), ctx, id)
 }
 
+// GetPubWithTTL mocks base method.
+func (m *MockArticleCache) GetPubWithTTL(ctx context.Context, id int64) (domain.Article, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubWithTTL", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPubWithTTL indicates an expected call of GetPubWithTTL.
+func (mr *MockArticleCacheMockRecorder) GetPubWithTTL(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubWithTTL", reflect.TypeOf((*MockArticleCache)(nil).GetPubWithTTL), ctx, id)
+}
+
+// GetPubs mocks base method.
+func (m *MockArticleCache) GetPubs(ctx context.Context, ids []int64) (map[int64]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubs", ctx, ids)
+	ret0, _ := ret[0].(map[int64]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubs indicates an expected call of GetPubs.
+func (mr *MockArticleCacheMockRecorder) GetPubs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubs", reflect.TypeOf((*MockArticleCache)(nil).GetPubs), ctx, ids)
+}
+
+// GetSeriesNav mocks base method.
+func (m *MockArticleCache) GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesNav", ctx, artId)
+	ret0, _ := ret[0].(*domain.SeriesNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesNav indicates an expected call of GetSeriesNav.
+func (mr *MockArticleCacheMockRecorder) GetSeriesNav(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesNav", reflect.TypeOf((*MockArticleCache)(nil).GetSeriesNav), ctx, artId)
+}
+
+// GetVisibilities mocks base method.
+func (m *MockArticleCache) GetVisibilities(ctx context.Context, artIds []int64) (map[int64]domain.Visibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibilities", ctx, artIds)
+	ret0, _ := ret[0].(map[int64]domain.Visibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibilities indicates an expected call of GetVisibilities.
+func (mr *MockArticleCacheMockRecorder) GetVisibilities(ctx, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibilities", reflect.TypeOf((*MockArticleCache)(nil).GetVisibilities), ctx, artIds)
+}
+
+// PreSetPub mocks base method.
+func (m *MockArticleCache) PreSetPub(ctx context.Context, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreSetPub", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreSetPub indicates an expected call of PreSetPub.
+func (mr *MockArticleCacheMockRecorder) PreSetPub(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreSetPub", reflect.TypeOf((*MockArticleCache)(nil).PreSetPub), ctx, art)
+}
+
 // Set mocks base method.
 func (m *MockArticleCache) Set(ctx context.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
@@ -139,3 +291,60 @@ func (mr *MockArticleCacheMockRecorder) SetPub(ctx, article any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPub", reflect.TypeOf((*MockArticleCache)(nil).SetPub), ctx, article)
 }
+
+// SetPubIfNewer mocks base method.
+func (m *MockArticleCache) SetPubIfNewer(ctx context.Context, art domain.Article) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPubIfNewer", ctx, art)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPubIfNewer indicates an expected call of SetPubIfNewer.
+func (mr *MockArticleCacheMockRecorder) SetPubIfNewer(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPubIfNewer", reflect.TypeOf((*MockArticleCache)(nil).SetPubIfNewer), ctx, art)
+}
+
+// SetPubs mocks base method.
+func (m *MockArticleCache) SetPubs(ctx context.Context, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPubs", ctx, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPubs indicates an expected call of SetPubs.
+func (mr *MockArticleCacheMockRecorder) SetPubs(ctx, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPubs", reflect.TypeOf((*MockArticleCache)(nil).SetPubs), ctx, arts)
+}
+
+// SetSeriesNav mocks base method.
+func (m *MockArticleCache) SetSeriesNav(ctx context.Context, artId int64, nav *domain.SeriesNav) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSeriesNav", ctx, artId, nav)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSeriesNav indicates an expected call of SetSeriesNav.
+func (mr *MockArticleCacheMockRecorder) SetSeriesNav(ctx, artId, nav any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSeriesNav", reflect.TypeOf((*MockArticleCache)(nil).SetSeriesNav), ctx, artId, nav)
+}
+
+// SetVisibilities mocks base method.
+func (m *MockArticleCache) SetVisibilities(ctx context.Context, vs map[int64]domain.Visibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVisibilities", ctx, vs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVisibilities indicates an expected call of SetVisibilities.
+func (mr *MockArticleCacheMockRecorder) SetVisibilities(ctx, vs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibilities", reflect.TypeOf((*MockArticleCache)(nil).SetVisibilities), ctx, vs)
+}
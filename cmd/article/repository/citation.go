@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+// CitationRepository 引用关系，不管 db.type 是什么都放在 MySQL 里面
+type CitationRepository interface {
+	// Replace 用 cits 替换掉帖子 source 来源的所有引用
+	Replace(ctx context.Context, artId int64, source domain.CitationSource, cits []domain.Citation) error
+	ListByArticle(ctx context.Context, artId int64) ([]domain.Citation, error)
+	// ListCitedBy 引用了 citedId 的帖子，不管这些帖子现在是什么状态
+	ListCitedBy(ctx context.Context, citedId int64, offset, limit int) ([]int64, error)
+	// CountCitedBy 被引用的次数，同一篇帖子引用多次只算一次
+	CountCitedBy(ctx context.Context, citedIds []int64) (map[int64]int64, error)
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type DBCitationRepository struct {
+	dao dao.CitationDAO
+}
+
+func NewCitationRepository(d dao.CitationDAO) CitationRepository {
+	return &DBCitationRepository{dao: d}
+}
+
+func (repo *DBCitationRepository) Replace(ctx context.Context, artId int64,
+	source domain.CitationSource, cits []domain.Citation) error {
+	return repo.dao.Replace(ctx, artId, source.ToUint8(),
+		slice.Map[domain.Citation, dao.ArticleCitation](cits,
+			func(idx int, src domain.Citation) dao.ArticleCitation {
+				return repo.toEntity(src)
+			}))
+}
+
+func (repo *DBCitationRepository) ListByArticle(ctx context.Context, artId int64) ([]domain.Citation, error) {
+	cits, err := repo.dao.ListByArticle(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleCitation, domain.Citation](cits,
+		func(idx int, src dao.ArticleCitation) domain.Citation {
+			return repo.toDomain(src)
+		}), nil
+}
+
+func (repo *DBCitationRepository) ListCitedBy(ctx context.Context, citedId int64,
+	offset, limit int) ([]int64, error) {
+	return repo.dao.ListCitedBy(ctx, citedId, offset, limit)
+}
+
+func (repo *DBCitationRepository) CountCitedBy(ctx context.Context,
+	citedIds []int64) (map[int64]int64, error) {
+	return repo.dao.CountCitedBy(ctx, citedIds)
+}
+
+func (repo *DBCitationRepository) DeleteByArticle(ctx context.Context, artId int64) error {
+	return repo.dao.DeleteByArticle(ctx, artId)
+}
+
+func (repo *DBCitationRepository) toEntity(c domain.Citation) dao.ArticleCitation {
+	return dao.ArticleCitation{
+		ArticleId: c.ArticleId,
+		CitedId:   c.CitedId,
+		DOI:       c.DOI,
+		Source:    c.Source.ToUint8(),
+	}
+}
+
+func (repo *DBCitationRepository) toDomain(c dao.ArticleCitation) domain.Citation {
+	return domain.Citation{
+		ArticleId: c.ArticleId,
+		CitedId:   c.CitedId,
+		DOI:       c.DOI,
+		Source:    domain.CitationSource(c.Source),
+		Ctime:     time.UnixMilli(c.Ctime),
+	}
+}
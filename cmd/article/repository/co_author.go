@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+func (repo *CachedArticleRepository) AddCoAuthor(ctx context.Context, artId, uid int64) error {
+	return repo.dao.InsertCoAuthor(ctx, dao.ArticleCoAuthor{
+		ArticleId: artId,
+		UserId:    uid,
+		Status:    domain.CoAuthorStatusPending.ToUint8(),
+	})
+}
+
+func (repo *CachedArticleRepository) GetCoAuthor(ctx context.Context, artId, uid int64) (domain.CoAuthor, error) {
+	c, err := repo.dao.GetCoAuthor(ctx, artId, uid)
+	if err != nil {
+		return domain.CoAuthor{}, err
+	}
+	return repo.coAuthorToDomain(c), nil
+}
+
+func (repo *CachedArticleRepository) AcceptCoAuthor(ctx context.Context, artId, uid int64) error {
+	err := repo.dao.UpdateCoAuthorStatus(ctx, artId, uid,
+		domain.CoAuthorStatusPending.ToUint8(),
+		domain.CoAuthorStatusAccepted.ToUint8())
+	if err != nil {
+		return err
+	}
+	// 他的帖子列表里面多了一篇
+	repo.delFirstPage(ctx, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) RemoveCoAuthor(ctx context.Context, artId, uid int64) error {
+	err := repo.dao.DeleteCoAuthor(ctx, artId, uid)
+	if err != nil {
+		return err
+	}
+	repo.delFirstPage(ctx, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListCoAuthors(ctx context.Context, artId int64) ([]domain.CoAuthor, error) {
+	cs, err := repo.dao.ListCoAuthors(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleCoAuthor, domain.CoAuthor](cs,
+		func(idx int, src dao.ArticleCoAuthor) domain.CoAuthor {
+			return repo.coAuthorToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) ListInvitations(ctx context.Context,
+	uid int64, offset, limit int) ([]domain.CoAuthor, error) {
+	cs, err := repo.dao.ListCoAuthorsByUser(ctx, uid,
+		domain.CoAuthorStatusPending.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleCoAuthor, domain.CoAuthor](cs,
+		func(idx int, src dao.ArticleCoAuthor) domain.CoAuthor {
+			return repo.coAuthorToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) coAuthorToDomain(c dao.ArticleCoAuthor) domain.CoAuthor {
+	return domain.CoAuthor{
+		ArticleId: c.ArticleId,
+		Author: domain.Author{
+			Id:   c.UserId,
+			Role: domain.AuthorRoleCoAuthor,
+		},
+		Status: domain.CoAuthorStatus(c.Status),
+		Ctime:  time.UnixMilli(c.Ctime),
+	}
+}
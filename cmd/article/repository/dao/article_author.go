@@ -33,16 +33,20 @@ func (dao *GORMArticleAuthorDAO) Create(ctx context.Context,
 	return art.Id, err
 }
 
-// UpdateById 只更新标题和
+// UpdateById 只更新标题和内容，和 GORMArticleDAO 一样必须带上版本号
 func (dao *GORMArticleAuthorDAO) UpdateById(ctx context.Context,
 	art Article) error {
+	if art.Version <= 0 {
+		return ErrMissingVersion
+	}
 	now := time.Now().UnixMilli()
 	res := dao.db.Model(&Article{}).WithContext(ctx).
-		Where("id=? AND author_id = ? ", art.Id, art.AuthorId).
+		Where("id=? AND author_id = ? AND version = ?", art.Id, art.AuthorId, art.Version).
 		Updates(map[string]any{
 			"title":   art.Title,
 			"content": art.Content,
 			"utime":   now,
+			"version": gorm.Expr("version + 1"),
 		})
 	err := res.Error
 	if err != nil {
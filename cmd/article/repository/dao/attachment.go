@@ -0,0 +1,192 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAttachmentNotFound = gorm.ErrRecordNotFound
+
+const (
+	// BlobStatusPending 已经生成了上传的链接，还没有确认上传成功
+	BlobStatusPending uint8 = iota + 1
+	// BlobStatusUploaded 确认已经上传到对象存储了
+	BlobStatusUploaded
+)
+
+// AttachmentBlob 附件的内容，按照内容的 SHA-256 去重
+// 同样的内容不管上传多少次，对象存储里面都只有一份
+type AttachmentBlob struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// Hash 内容的 SHA-256，十六进制，也决定了对象存储里面的 key
+	Hash        string `gorm:"type:char(64);uniqueIndex"`
+	Size        int64
+	ContentType string `gorm:"type:varchar(128)"`
+	Status      uint8
+	Ctime       int64
+	Utime       int64
+}
+
+// Attachment 用户上传的一个附件，多个附件可以指向同一个 AttachmentBlob
+type Attachment struct {
+	Id   int64  `gorm:"primaryKey,autoIncrement"`
+	Uid  int64  `gorm:"index"`
+	Hash string `gorm:"type:char(64);index"`
+	// Size 和 ContentType 冗余一份，内容不会变，所以也不存在不一致的问题
+	Size        int64
+	ContentType string `gorm:"type:varchar(128)"`
+	FileName    string `gorm:"type:varchar(255)"`
+	// ArticleId 为 0 说明还没有关联到帖子，过一段时间就会被回收
+	ArticleId int64 `gorm:"index"`
+	Ctime     int64
+	Utime     int64 `gorm:"index"`
+}
+
+//go:generate mockgen -source=./attachment.go -package=artdaomocks -destination=mocks/attachment.mock.go AttachmentDAO
+type AttachmentDAO interface {
+	// UpsertBlob 同样的内容已经存在的话什么都不做，返回的是数据库里面的那一条
+	UpsertBlob(ctx context.Context, b AttachmentBlob) (AttachmentBlob, error)
+	GetBlob(ctx context.Context, hash string) (AttachmentBlob, error)
+	MarkBlobUploaded(ctx context.Context, hash string) error
+
+	Insert(ctx context.Context, a Attachment) (int64, error)
+	// GetById 不存在返回 ErrAttachmentNotFound
+	GetById(ctx context.Context, id int64) (Attachment, error)
+	// Link 把附件关联到帖子，只有上传的人可以关联，并且一个附件只能关联一个帖子，
+	// 否则返回 ErrPossibleIncorrectAuthor
+	Link(ctx context.Context, uid, id, artId int64) error
+	// ListByArticle 按照上传的顺序
+	ListByArticle(ctx context.Context, artId int64) ([]Attachment, error)
+	// DeleteByArticle 帖子彻底删除的时候用，内容等着 ListUnreferencedBlobs 来回收
+	DeleteByArticle(ctx context.Context, artId int64) error
+
+	// DeleteUnlinked 删除 utime 早于 before 并且没有关联到帖子的附件，返回删除的数量
+	DeleteUnlinked(ctx context.Context, before int64, limit int) (int64, error)
+	// ListUnreferencedBlobs 没有任何附件引用，并且 utime 早于 before 的内容
+	ListUnreferencedBlobs(ctx context.Context, before int64, limit int) ([]AttachmentBlob, error)
+	// DeleteBlob 只有仍然没有被引用的时候才会删除，返回是否真的删除了
+	DeleteBlob(ctx context.Context, hash string) (bool, error)
+}
+
+type GORMAttachmentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMAttachmentDAO(db *gorm.DB) AttachmentDAO {
+	return &GORMAttachmentDAO{db: db}
+}
+
+func (dao *GORMAttachmentDAO) UpsertBlob(ctx context.Context, b AttachmentBlob) (AttachmentBlob, error) {
+	now := time.Now().UnixMilli()
+	b.Ctime = now
+	b.Utime = now
+	err := dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hash"}},
+		DoNothing: true,
+	}).Create(&b).Error
+	if err != nil {
+		return AttachmentBlob{}, err
+	}
+	return dao.GetBlob(ctx, b.Hash)
+}
+
+func (dao *GORMAttachmentDAO) GetBlob(ctx context.Context, hash string) (AttachmentBlob, error) {
+	var res AttachmentBlob
+	err := dao.db.WithContext(ctx).Where("hash = ?", hash).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMAttachmentDAO) MarkBlobUploaded(ctx context.Context, hash string) error {
+	return dao.db.WithContext(ctx).Model(&AttachmentBlob{}).
+		Where("hash = ?", hash).
+		Updates(map[string]any{
+			"status": BlobStatusUploaded,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *GORMAttachmentDAO) Insert(ctx context.Context, a Attachment) (int64, error) {
+	now := time.Now().UnixMilli()
+	a.Ctime = now
+	a.Utime = now
+	err := dao.db.WithContext(ctx).Create(&a).Error
+	return a.Id, err
+}
+
+func (dao *GORMAttachmentDAO) GetById(ctx context.Context, id int64) (Attachment, error) {
+	var res Attachment
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMAttachmentDAO) Link(ctx context.Context, uid, id, artId int64) error {
+	res := dao.db.WithContext(ctx).Model(&Attachment{}).
+		Where("id = ? AND uid = ? AND article_id IN ?", id, uid, []int64{0, artId}).
+		Updates(map[string]any{
+			"article_id": artId,
+			"utime":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrPossibleIncorrectAuthor
+	}
+	return nil
+}
+
+func (dao *GORMAttachmentDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Delete(&Attachment{}).Error
+}
+
+func (dao *GORMAttachmentDAO) ListByArticle(ctx context.Context, artId int64) ([]Attachment, error) {
+	var res []Attachment
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Order("id ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMAttachmentDAO) DeleteUnlinked(ctx context.Context, before int64, limit int) (int64, error) {
+	// 不是所有的数据库都支持 DELETE ... LIMIT，所以先查出来
+	var ids []int64
+	err := dao.db.WithContext(ctx).Model(&Attachment{}).
+		Where("article_id = ? AND utime < ?", 0, before).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	// 查出来之后可能又被关联了，所以条件要再带一遍
+	res := dao.db.WithContext(ctx).
+		Where("id IN ? AND article_id = ?", ids, 0).
+		Delete(&Attachment{})
+	return res.RowsAffected, res.Error
+}
+
+func (dao *GORMAttachmentDAO) ListUnreferencedBlobs(ctx context.Context, before int64, limit int) ([]AttachmentBlob, error) {
+	var res []AttachmentBlob
+	err := dao.db.WithContext(ctx).
+		Where("utime < ? AND NOT EXISTS (?)", before,
+			dao.db.Model(&Attachment{}).Select("1").
+				Where("attachments.hash = attachment_blobs.hash")).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMAttachmentDAO) DeleteBlob(ctx context.Context, hash string) (bool, error) {
+	res := dao.db.WithContext(ctx).
+		Where("hash = ? AND NOT EXISTS (?)", hash,
+			dao.db.Model(&Attachment{}).Select("1").
+				Where("attachments.hash = attachment_blobs.hash")).
+		Delete(&AttachmentBlob{})
+	return res.RowsAffected > 0, res.Error
+}
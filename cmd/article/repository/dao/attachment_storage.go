@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ErrObjectNotFound 对象存储里面没有这个对象
+var ErrObjectNotFound = errors.New("对象不存在")
+
+// PresignedRequest 预签名的请求，客户端直接拿着它去访问对象存储
+type PresignedRequest struct {
+	URL string
+	// Headers 客户端必须原样带上这些请求头，否则签名对不上
+	Headers  map[string]string
+	ExpireAt time.Time
+}
+
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// AttachmentStorage 附件内容的存储，上传是客户端直接传到对象存储的，不经过我们的服务
+type AttachmentStorage interface {
+	// PresignPut 生成上传的链接，大小、类型和 SHA-256 都会签进去
+	// 客户端上传的内容和声明的不一样，对象存储会直接拒绝
+	PresignPut(ctx context.Context, key string, size int64, contentType, sha256Hex string) (PresignedRequest, error)
+	// Head 不存在返回 ErrObjectNotFound
+	Head(ctx context.Context, key string) (ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	// URL 读者访问附件的地址
+	URL(key string) string
+}
+
+type S3AttachmentStorage struct {
+	oss    *s3.S3
+	bucket string
+	// expire 上传链接的有效期
+	expire time.Duration
+	// baseURL 附件对外访问的地址，一般是 CDN 的域名
+	baseURL string
+}
+
+func NewS3AttachmentStorage(oss *s3.S3, bucket string, expire time.Duration, baseURL string) AttachmentStorage {
+	return &S3AttachmentStorage{
+		oss:     oss,
+		bucket:  bucket,
+		expire:  expire,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *S3AttachmentStorage) PresignPut(ctx context.Context, key string,
+	size int64, contentType, sha256Hex string) (PresignedRequest, error) {
+	sum, err := hex.DecodeString(sha256Hex)
+	if err != nil {
+		return PresignedRequest{}, err
+	}
+	req, _ := s.oss.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:         aws.String(s.bucket),
+		Key:            aws.String(key),
+		ContentLength:  aws.Int64(size),
+		ContentType:    aws.String(contentType),
+		ChecksumSHA256: aws.String(base64.StdEncoding.EncodeToString(sum)),
+	})
+	req.SetContext(ctx)
+	url, header, err := req.PresignRequest(s.expire)
+	if err != nil {
+		return PresignedRequest{}, err
+	}
+	return PresignedRequest{
+		URL:      url,
+		Headers:  flattenHeader(header),
+		ExpireAt: time.Now().Add(s.expire),
+	}, nil
+}
+
+func (s *S3AttachmentStorage) Head(ctx context.Context, key string) (ObjectInfo, error) {
+	out, err := s.oss.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var aerr awserr.RequestFailure
+		if errors.As(err, &aerr) && aerr.StatusCode() == http.StatusNotFound {
+			return ObjectInfo{}, ErrObjectNotFound
+		}
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Size:        aws.Int64Value(out.ContentLength),
+		ContentType: aws.StringValue(out.ContentType),
+	}, nil
+}
+
+func (s *S3AttachmentStorage) Delete(ctx context.Context, key string) error {
+	_, err := s.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3AttachmentStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func flattenHeader(header http.Header) map[string]string {
+	res := make(map[string]string, len(header))
+	for k, vs := range header {
+		res[http.CanonicalHeaderKey(k)] = strings.Join(vs, ",")
+	}
+	return res
+}
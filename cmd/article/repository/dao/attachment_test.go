@@ -0,0 +1,131 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMAttachmentDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/attachment.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTables(db))
+	dao := NewGORMAttachmentDAO(db)
+	ctx := context.Background()
+	hash := sha256Hex([]byte("图片"))
+
+	blob, err := dao.UpsertBlob(ctx, AttachmentBlob{
+		Hash: hash, Size: 6, ContentType: "image/png", Status: BlobStatusPending,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, BlobStatusPending, blob.Status)
+	require.NoError(t, dao.MarkBlobUploaded(ctx, hash))
+	// 同样的内容再来一次，拿到的是已经存在的
+	blob, err = dao.UpsertBlob(ctx, AttachmentBlob{
+		Hash: hash, Size: 6, ContentType: "image/png", Status: BlobStatusPending,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, BlobStatusUploaded, blob.Status)
+
+	linked, err := dao.Insert(ctx, Attachment{Uid: 123, Hash: hash, FileName: "a.png"})
+	require.NoError(t, err)
+	unlinked, err := dao.Insert(ctx, Attachment{Uid: 123, Hash: hash, FileName: "b.png"})
+	require.NoError(t, err)
+
+	// 别人的附件
+	assert.Equal(t, ErrPossibleIncorrectAuthor, dao.Link(ctx, 456, linked, 1))
+	require.NoError(t, dao.Link(ctx, 123, linked, 1))
+	// 重复关联没有问题，但是不能换到别的帖子上
+	require.NoError(t, dao.Link(ctx, 123, linked, 1))
+	assert.Equal(t, ErrPossibleIncorrectAuthor, dao.Link(ctx, 123, linked, 2))
+	atts, err := dao.ListByArticle(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	assert.Equal(t, linked, atts[0].Id)
+
+	// 还被引用着，不会被回收
+	future := time.Now().Add(time.Hour).UnixMilli()
+	blobs, err := dao.ListUnreferencedBlobs(ctx, future, 10)
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+
+	cnt, err := dao.DeleteUnlinked(ctx, future, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+	_, err = dao.GetById(ctx, unlinked)
+	assert.Equal(t, ErrAttachmentNotFound, err)
+	_, err = dao.GetById(ctx, linked)
+	require.NoError(t, err)
+
+	// 关联的附件也删掉之后，内容就没有人引用了
+	require.NoError(t, db.Delete(&Attachment{}, linked).Error)
+	blobs, err = dao.ListUnreferencedBlobs(ctx, future, 10)
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	assert.Equal(t, hash, blobs[0].Hash)
+	ok, err := dao.DeleteBlob(ctx, hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = dao.DeleteBlob(ctx, hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestS3AttachmentStorage(t *testing.T) {
+	fake, client := newFakeS3(t)
+	storage := NewS3AttachmentStorage(client, "webook", time.Minute, "https://cdn.example.com/")
+	ctx := context.Background()
+	data := []byte("%PDF-1.4 论文")
+	hash := sha256Hex(data)
+	key := "attachments/" + hash
+
+	_, err := storage.Head(ctx, key)
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	req, err := storage.PresignPut(ctx, key, int64(len(data)), "application/pdf", hash)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", req.Headers["Content-Type"])
+
+	// 内容和声明的不一样
+	resp := upload(t, req, []byte("%PDF-1.4 别的论文"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.False(t, fake.has("webook", key))
+
+	resp = upload(t, req, data)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	info, err := storage.Head(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, ObjectInfo{Size: int64(len(data)), ContentType: "application/pdf"}, info)
+	assert.Equal(t, "https://cdn.example.com/"+key, storage.URL(key))
+
+	require.NoError(t, storage.Delete(ctx, key))
+	_, err = storage.Head(ctx, key)
+	assert.Equal(t, ErrObjectNotFound, err)
+}
+
+// upload 模拟客户端拿着预签名的链接上传
+func upload(t *testing.T, req PresignedRequest, data []byte) *http.Response {
+	httpReq, err := http.NewRequest(http.MethodPut, req.URL, bytes.NewReader(data))
+	require.NoError(t, err)
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
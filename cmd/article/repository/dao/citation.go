@@ -0,0 +1,115 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleCitation 帖子之间的引用关系，也包括引用的外部文献
+// 同一篇帖子可以既声明又在正文里面链接同一个东西，所以 source 也在唯一索引里面
+type ArticleCitation struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:article_source_cited"`
+	// CitedId 被引用的站内帖子，引用外部文献的时候是 0
+	// 被引用的查询都是按照它来的
+	CitedId int64  `gorm:"uniqueIndex:article_source_cited;index"`
+	DOI     string `gorm:"type:varchar(255);uniqueIndex:article_source_cited"`
+	Source  uint8  `gorm:"uniqueIndex:article_source_cited"`
+	Ctime   int64
+}
+
+//go:generate mockgen -source=./citation.go -package=artdaomocks -destination=mocks/citation.mock.go CitationDAO
+type CitationDAO interface {
+	// Replace 用 cits 替换掉帖子 source 来源的所有引用
+	Replace(ctx context.Context, artId int64, source uint8, cits []ArticleCitation) error
+	// ListByArticle 帖子引用了什么，按照添加的顺序
+	ListByArticle(ctx context.Context, artId int64) ([]ArticleCitation, error)
+	// ListCitedBy 哪些帖子引用了 citedId，同一篇帖子只会出现一次，按照 id 倒序
+	ListCitedBy(ctx context.Context, citedId int64, offset, limit int) ([]int64, error)
+	// CountCitedBy 每篇帖子被多少篇帖子引用了，没有被引用的也会返回 0
+	CountCitedBy(ctx context.Context, citedIds []int64) (map[int64]int64, error)
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type GORMCitationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCitationDAO(db *gorm.DB) CitationDAO {
+	return &GORMCitationDAO{db: db}
+}
+
+func (dao *GORMCitationDAO) Replace(ctx context.Context, artId int64,
+	source uint8, cits []ArticleCitation) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ? AND source = ?", artId, source).
+			Delete(&ArticleCitation{}).Error
+		if err != nil || len(cits) == 0 {
+			return err
+		}
+		for i := range cits {
+			cits[i].Id = 0
+			cits[i].ArticleId = artId
+			cits[i].Source = source
+			cits[i].Ctime = now
+		}
+		return tx.Create(&cits).Error
+	})
+}
+
+func (dao *GORMCitationDAO) ListByArticle(ctx context.Context, artId int64) ([]ArticleCitation, error) {
+	var res []ArticleCitation
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Order("id ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCitationDAO) ListCitedBy(ctx context.Context, citedId int64,
+	offset, limit int) ([]int64, error) {
+	var res []int64
+	err := dao.db.WithContext(ctx).Model(&ArticleCitation{}).
+		Distinct("article_id").
+		Where("cited_id = ?", citedId).
+		Order("article_id DESC").
+		Offset(offset).Limit(limit).
+		Pluck("article_id", &res).Error
+	return res, err
+}
+
+func (dao *GORMCitationDAO) CountCitedBy(ctx context.Context,
+	citedIds []int64) (map[int64]int64, error) {
+	res := make(map[int64]int64, len(citedIds))
+	if len(citedIds) == 0 {
+		return res, nil
+	}
+	var rows []struct {
+		CitedId int64
+		Cnt     int64
+	}
+	err := dao.db.WithContext(ctx).Model(&ArticleCitation{}).
+		Select("cited_id, COUNT(DISTINCT article_id) AS cnt").
+		Where("cited_id IN ?", citedIds).
+		Group("cited_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range citedIds {
+		res[id] = 0
+	}
+	for _, row := range rows {
+		res[row.CitedId] = row.Cnt
+	}
+	return res, nil
+}
+
+func (dao *GORMCitationDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Delete(&ArticleCitation{}).Error
+}
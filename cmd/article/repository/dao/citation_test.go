@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMCitationDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/citation.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTables(db))
+	dao := NewGORMCitationDAO(db)
+	ctx := context.Background()
+	const (
+		declared  uint8 = 1
+		extracted uint8 = 2
+	)
+
+	// 1 声明引用了 10 和一篇外部文献，正文里面又链接了 10
+	require.NoError(t, dao.Replace(ctx, 1, declared, []ArticleCitation{
+		{CitedId: 10}, {DOI: "10.1000/xyz"},
+	}))
+	require.NoError(t, dao.Replace(ctx, 1, extracted, []ArticleCitation{{CitedId: 10}}))
+	require.NoError(t, dao.Replace(ctx, 2, extracted, []ArticleCitation{{CitedId: 10}, {CitedId: 11}}))
+
+	cits, err := dao.ListByArticle(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, cits, 3)
+	assert.Equal(t, int64(10), cits[0].CitedId)
+	assert.Equal(t, "10.1000/xyz", cits[1].DOI)
+	assert.Equal(t, extracted, cits[2].Source)
+
+	// 同一篇帖子引用了两次也只算一次
+	cnts, err := dao.CountCitedBy(ctx, []int64{10, 11, 12})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{10: 2, 11: 1, 12: 0}, cnts)
+
+	ids, err := dao.ListCitedBy(ctx, 10, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 1}, ids)
+	ids, err = dao.ListCitedBy(ctx, 10, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	// 重新声明只会替换声明的部分
+	require.NoError(t, dao.Replace(ctx, 1, declared, nil))
+	cits, err = dao.ListByArticle(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, cits, 1)
+	assert.Equal(t, extracted, cits[0].Source)
+
+	require.NoError(t, dao.DeleteByArticle(ctx, 2))
+	cnts, err = dao.CountCitedBy(ctx, []int64{10, 11})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{10: 1, 11: 0}, cnts)
+}
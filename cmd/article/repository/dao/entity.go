@@ -1,21 +1,97 @@
 package dao
 
 type Article struct {
-	Id int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	// 按照 (utime, id) 翻页，所以 id 也在 utime 的联合索引里面
+	Id int64 `gorm:"primaryKey,autoIncrement;index:,composite:utime_id,priority:2;index:,composite:author_utime_id,priority:3" bson:"id,omitempty"`
 	// 标题的长度
 	// 正常都不会超过这个长度
 	Title   string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	Content string `gorm:"type=BLOB" bson:"content,omitempty"`
 	// 作者
-	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8 `bson:"status,omitempty"`
+	AuthorId int64 `gorm:"index:,composite:author_utime_id,priority:1" bson:"author_id,omitempty"`
+	Status   uint8 `bson:"status,omitempty" gorm:"index:,composite:status_publish_at"`
 	Ctime    int64 `bson:"ctime,omitempty"`
-	Utime    int64 `bson:"utime,omitempty" gorm:"index"`
+	Utime    int64 `bson:"utime,omitempty" gorm:"index:,composite:utime_id,priority:1;index:,composite:author_utime_id,priority:2"`
+	// PublishAt 定时发表的时间，定时任务会按照 status + publish_at 来查找
+	PublishAt int64 `bson:"publish_at,omitempty" gorm:"index:,composite:status_publish_at"`
+	// Version 乐观锁，每次更新加一
+	Version int64 `bson:"version,omitempty"`
+	// Dtime 删除的时间，0 表示没有删除。删除的帖子在回收站里面保留一段时间，之后彻底删除
+	Dtime int64 `bson:"dtime,omitempty" gorm:"index"`
+	// EditorId 这一次是谁修改的，共同作者修改的时候和 AuthorId 不一样
+	// 不存储，只用来记录历史版本
+	EditorId int64 `gorm:"-" bson:"-"`
 }
 
 // PublishedArticle 衍生类型，偷个懒
 type PublishedArticle Article
 
+// ArticleRevision 帖子的历史版本，只插入，不更新
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64  `gorm:"index" bson:"article_id,omitempty"`
+	Title     string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	Content   string `gorm:"type=BLOB" bson:"content,omitempty"`
+	// 这一次是谁改的
+	EditorId int64 `bson:"editor_id,omitempty"`
+	Ctime    int64 `bson:"ctime,omitempty"`
+}
+
+// newRevision 用这一次保存的内容构造历史版本
+func newRevision(art Article, now int64) ArticleRevision {
+	editor := art.EditorId
+	if editor == 0 {
+		editor = art.AuthorId
+	}
+	return ArticleRevision{
+		ArticleId: art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		EditorId:  editor,
+		Ctime:     now,
+	}
+}
+
+// Series 系列，同一个作者的多篇帖子按照顺序组织在一起
+type Series struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Title       string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+	Description string `gorm:"type=varchar(4096)" bson:"description,omitempty"`
+	AuthorId    int64  `gorm:"index" bson:"author_id,omitempty"`
+	Ctime       int64  `bson:"ctime,omitempty"`
+	Utime       int64  `bson:"utime,omitempty"`
+}
+
+// SeriesArticle 系列里面的帖子，一篇帖子只能属于一个系列
+type SeriesArticle struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	SeriesId  int64 `gorm:"index:,composite:series_position" bson:"series_id,omitempty"`
+	ArticleId int64 `gorm:"uniqueIndex" bson:"article_id,omitempty"`
+	// Position 在系列中的位置，从 0 开始
+	Position int   `gorm:"index:,composite:series_position" bson:"position"`
+	Ctime    int64 `bson:"ctime,omitempty"`
+}
+
+const (
+	// CoAuthorStatusPending 已经邀请，还没有接受
+	CoAuthorStatusPending uint8 = iota + 1
+	// CoAuthorStatusAccepted 接受了邀请，可以编辑帖子
+	CoAuthorStatusAccepted
+)
+
+// ArticleCoAuthor 帖子的共同作者，创建帖子的人还是记录在 Article.AuthorId 上
+type ArticleCoAuthor struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64 `gorm:"uniqueIndex:uniq_article_co_author" bson:"article_id,omitempty"`
+	// UserId 上面的索引用来查询某个用户参与的帖子
+	UserId int64 `gorm:"uniqueIndex:uniq_article_co_author;index" bson:"user_id,omitempty"`
+	Status uint8 `bson:"status,omitempty"`
+	// Position 邀请的顺序，也就是作者署名的顺序
+	Position int   `bson:"position"`
+	Ctime    int64 `bson:"ctime,omitempty"`
+	Utime    int64 `bson:"utime,omitempty"`
+}
+
 // PublishedArticleV1 s3 演示专属
 type PublishedArticleV1 struct {
 	Id       int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
@@ -2,7 +2,7 @@ package dao
 
 import (
 	"context"
-	"errors"
+	"database/sql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -15,15 +15,26 @@ type GORMArticleDAO struct {
 func (dao *GORMArticleDAO) ListPubByUtime(ctx context.Context, utime time.Time, offset int, limit int) ([]PublishedArticle, error) {
 	var res []PublishedArticle
 	err := dao.db.WithContext(ctx).Order("utime DESC").
-		Where("utime < ?", utime.UnixMilli()).
+		Where("utime < ? AND dtime = 0", utime.UnixMilli()).
 		Limit(limit).Offset(offset).Find(&res).Error
 	return res, err
 }
 
+func (dao *GORMArticleDAO) ListPubByCursor(ctx context.Context, utime, id int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	err := dao.db.WithContext(ctx).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id).
+		Where("dtime = 0").
+		Order("utime DESC, id DESC").
+		Limit(limit).Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
 	var arts []Article
 	err := dao.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", author).
+		Where("author_id = ? OR id IN (?)", author, dao.coAuthored(author)).
+		Where("dtime = 0").
 		Offset(offset).
 		Limit(limit).
 		Order("utime DESC").
@@ -31,10 +42,29 @@ func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, author int64, offset
 	return arts, err
 }
 
+func (dao *GORMArticleDAO) GetByAuthorCursor(ctx context.Context, author int64, utime, id int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? OR id IN (?)", author, dao.coAuthored(author)).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id).
+		Where("dtime = 0").
+		Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+// coAuthored 作为共同作者参与的帖子
+func (dao *GORMArticleDAO) coAuthored(uid int64) *gorm.DB {
+	return dao.db.Model(&ArticleCoAuthor{}).
+		Select("article_id").
+		Where("user_id = ? AND status = ?", uid, CoAuthorStatusAccepted)
+}
+
 func (dao *GORMArticleDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
 	var pub PublishedArticle
 	err := dao.db.WithContext(ctx).
-		Where("id = ?", id).
+		Where("id = ? AND dtime = 0", id).
 		First(&pub).Error
 	return pub, err
 }
@@ -47,6 +77,46 @@ func (dao *GORMArticleDAO) GetById(ctx context.Context, id int64) (Article, erro
 	return art, err
 }
 
+func (dao *GORMArticleDAO) ListScheduled(ctx context.Context, status uint8, before int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).
+		Where("status = ? AND publish_at <= ? AND dtime = 0", status, before).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *GORMArticleDAO) ListByStatus(ctx context.Context, status uint8, offset, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).
+		Where("status = ? AND dtime = 0", status).
+		Order("utime ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *GORMArticleDAO) UpdateSchedule(ctx context.Context, author, id int64,
+	oldStatus, newStatus uint8, publishAt int64) error {
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, author, oldStatus).
+		Updates(map[string]any{
+			"status":     newStatus,
+			"publish_at": publishAt,
+			"utime":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		// 也可能是作者不对，但是这里没必要区分
+		return ErrArticleNotScheduled
+	}
+	return nil
+}
+
 func NewGORMArticleDAO(db *gorm.DB) ArticleDAO {
 	return &GORMArticleDAO{
 		db: db,
@@ -54,10 +124,17 @@ func NewGORMArticleDAO(db *gorm.DB) ArticleDAO {
 }
 
 func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, author, id int64, status uint8) error {
+	// 状态变了也要更新 utime，binlog 那边靠 utime 判断消息的先后
+	now := time.Now().UnixMilli()
+	updates := map[string]any{
+		"status": status,
+		"utime":  now,
+	}
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 回收站里面的帖子不能再撤回
 		res := tx.Model(&Article{}).
-			Where("id=? AND author_id = ?", id, author).
-			Update("status", status)
+			Where("id=? AND author_id = ? AND dtime = 0", id, author).
+			Updates(updates)
 		if res.Error != nil {
 			return res.Error
 		}
@@ -66,7 +143,7 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, author, id int64, sta
 		}
 
 		res = tx.Model(&PublishedArticle{}).
-			Where("id=? AND author_id = ?", id, author).Update("status", status)
+			Where("id=? AND author_id = ?", id, author).Updates(updates)
 		if res.Error != nil {
 			return res.Error
 		}
@@ -150,33 +227,364 @@ func (dao *GORMArticleDAO) SyncClosure(ctx context.Context,
 	return id, err
 }
 
+// Insert 同时会记录第一个历史版本
 func (dao *GORMArticleDAO) Insert(ctx context.Context,
 	art Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	err := dao.db.WithContext(ctx).Create(&art).Error
+	art.Version = 1
+	// 如果 dao.db 本身已经是一个事务了（比如说 Sync 里面），
+	// GORM 会使用 SAVEPOINT 来处理嵌套事务
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&art).Error
+		if err != nil {
+			return err
+		}
+		rev := newRevision(art, now)
+		return tx.Create(&rev).Error
+	})
 	return art.Id, err
 }
 
-// UpdateById 只更新标题、内容和状态
+// UpdateById 只更新标题、内容和状态，并且记录一个历史版本
 func (dao *GORMArticleDAO) UpdateById(ctx context.Context,
 	art Article) error {
+	// 不带版本号就会覆盖掉别人的修改，所以所有的更新都必须带
+	if art.Version <= 0 {
+		return ErrMissingVersion
+	}
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id=? AND author_id = ? AND version = ?", art.Id, art.AuthorId, art.Version).
+			Updates(map[string]any{
+				"title":      art.Title,
+				"content":    art.Content,
+				"status":     art.Status,
+				"publish_at": art.PublishAt,
+				"utime":      now,
+				"version":    gorm.Expr("version + 1"),
+			})
+		err := res.Error
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return dao.updateFailed(tx, art)
+		}
+		rev := newRevision(art, now)
+		return tx.Create(&rev).Error
+	})
+}
+
+// updateFailed 更新不到数据的时候，区分是版本号不对还是作者不对
+func (dao *GORMArticleDAO) updateFailed(tx *gorm.DB, art Article) error {
+	var cur Article
+	err := tx.Select("author_id", "version").
+		Where("id = ?", art.Id).First(&cur).Error
+	if err != nil || cur.AuthorId != art.AuthorId {
+		// 比较可能就是有人更新别人的文章，比如说攻击者跟你过不去
+		return ErrPossibleIncorrectAuthor
+	}
+	return VersionConflictError{Current: cur.Version}
+}
+
+func (dao *GORMArticleDAO) ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]ArticleRevision, error) {
+	var res []ArticleRevision
+	// 列表不需要 Content，内容可能很大
+	err := dao.db.WithContext(ctx).
+		Select("id", "article_id", "title", "editor_id", "ctime").
+		Where("article_id = ?", artId).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) GetRevision(ctx context.Context, artId, id int64) (ArticleRevision, error) {
+	var res ArticleRevision
+	err := dao.db.WithContext(ctx).
+		Where("id = ? AND article_id = ?", id, artId).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	if len(ids) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).
+		Where("id IN ? AND dtime = 0", ids).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) InsertSeries(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := dao.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (dao *GORMArticleDAO) GetSeriesById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := dao.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&s).Error
+	return s, err
+}
+
+func (dao *GORMArticleDAO) ListSeriesByAuthor(ctx context.Context, author int64, offset, limit int) ([]Series, error) {
+	var res []Series
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", author).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) SetSeriesArticles(ctx context.Context, author, seriesId int64, artIds []int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Series{}).
+			Where("id = ? AND author_id = ?", seriesId, author).
+			Update("utime", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrPossibleIncorrectAuthor
+		}
+		if len(artIds) > 0 {
+			var cnt int64
+			err := tx.Model(&Article{}).
+				Where("id IN ? AND author_id = ?", artIds, author).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt != int64(len(artIds)) {
+				return ErrPossibleIncorrectAuthor
+			}
+			err = tx.Model(&SeriesArticle{}).
+				Where("article_id IN ? AND series_id <> ?", artIds, seriesId).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				return ErrArticleInOtherSeries
+			}
+		}
+		err := tx.Where("series_id = ?", seriesId).
+			Delete(&SeriesArticle{}).Error
+		if err != nil || len(artIds) == 0 {
+			return err
+		}
+		members := make([]SeriesArticle, 0, len(artIds))
+		for i, id := range artIds {
+			members = append(members, SeriesArticle{
+				SeriesId:  seriesId,
+				ArticleId: id,
+				Position:  i,
+				Ctime:     now,
+			})
+		}
+		return tx.Create(&members).Error
+	})
+}
+
+func (dao *GORMArticleDAO) ListSeriesArticles(ctx context.Context, seriesId int64) ([]SeriesArticle, error) {
+	var res []SeriesArticle
+	err := dao.db.WithContext(ctx).
+		Where("series_id = ?", seriesId).
+		Order("position ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) GetSeriesArticle(ctx context.Context, artId int64) (SeriesArticle, error) {
+	var res SeriesArticle
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) InsertCoAuthor(ctx context.Context, c ArticleCoAuthor) error {
 	now := time.Now().UnixMilli()
-	res := dao.db.Model(&Article{}).WithContext(ctx).
-		Where("id=? AND author_id = ? ", art.Id, art.AuthorId).
+	c.Ctime = now
+	c.Utime = now
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&ArticleCoAuthor{}).
+			Where("article_id = ? AND user_id = ?", c.ArticleId, c.UserId).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrDuplicateCoAuthor
+		}
+		var pos sql.NullInt64
+		err = tx.Model(&ArticleCoAuthor{}).
+			Select("MAX(position)").
+			Where("article_id = ?", c.ArticleId).
+			Scan(&pos).Error
+		if err != nil {
+			return err
+		}
+		if pos.Valid {
+			c.Position = int(pos.Int64) + 1
+		}
+		return tx.Create(&c).Error
+	})
+}
+
+func (dao *GORMArticleDAO) GetCoAuthor(ctx context.Context, artId, uid int64) (ArticleCoAuthor, error) {
+	var res ArticleCoAuthor
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ? AND user_id = ?", artId, uid).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) UpdateCoAuthorStatus(ctx context.Context, artId, uid int64, oldStatus, newStatus uint8) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleCoAuthor{}).
+		Where("article_id = ? AND user_id = ? AND status = ?", artId, uid, oldStatus).
 		Updates(map[string]any{
-			"title":   art.Title,
-			"content": art.Content,
-			"status":  art.Status,
-			"utime":   now,
+			"status": newStatus,
+			"utime":  time.Now().UnixMilli(),
 		})
-	err := res.Error
-	if err != nil {
-		return err
+	if res.Error != nil {
+		return res.Error
 	}
 	if res.RowsAffected == 0 {
-		return errors.New("更新数据失败")
+		return ErrDataNotFound
 	}
 	return nil
 }
+
+func (dao *GORMArticleDAO) DeleteCoAuthor(ctx context.Context, artId, uid int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ? AND user_id = ?", artId, uid).
+		Delete(&ArticleCoAuthor{}).Error
+}
+
+func (dao *GORMArticleDAO) ListCoAuthors(ctx context.Context, artId int64) ([]ArticleCoAuthor, error) {
+	var res []ArticleCoAuthor
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Order("position ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListCoAuthorsByArticles(ctx context.Context, artIds []int64, status uint8) ([]ArticleCoAuthor, error) {
+	var res []ArticleCoAuthor
+	err := dao.db.WithContext(ctx).
+		Where("article_id IN ? AND status = ?", artIds, status).
+		Order("article_id ASC, position ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListCoAuthorsByUser(ctx context.Context, uid int64, status uint8, offset, limit int) ([]ArticleCoAuthor, error) {
+	var res []ArticleCoAuthor
+	err := dao.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", uid, status).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) SoftDelete(ctx context.Context, author, id int64, now int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND dtime = 0", id, author).
+			Updates(map[string]any{"dtime": now, "utime": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrPossibleIncorrectAuthor
+		}
+		// 没有发表过的帖子，线上库里面没有数据
+		return tx.Model(&PublishedArticle{}).
+			Where("id = ? AND author_id = ?", id, author).
+			Updates(map[string]any{"dtime": now, "utime": now}).Error
+	})
+}
+
+func (dao *GORMArticleDAO) RestoreDeleted(ctx context.Context, author, id int64, after int64) error {
+	updates := map[string]any{
+		"dtime": 0,
+		"utime": time.Now().UnixMilli(),
+	}
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND dtime >= ?", id, author, after).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrDataNotFound
+		}
+		return tx.Model(&PublishedArticle{}).
+			Where("id = ? AND author_id = ?", id, author).
+			Updates(updates).Error
+	})
+}
+
+func (dao *GORMArticleDAO) ListDeleted(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ? AND dtime > 0", author).
+		Order("dtime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *GORMArticleDAO) ListDeletedBefore(ctx context.Context, before int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).
+		Where("dtime > 0 AND dtime < ?", before).
+		Order("dtime ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *GORMArticleDAO) HardDelete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND dtime > 0", id).Delete(&Article{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			// 已经恢复了，或者已经被别的实例删掉了
+			return ErrDataNotFound
+		}
+		if err := tx.Where("id = ?", id).Delete(&PublishedArticle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticleRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticleCoAuthor{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("article_id = ?", id).Delete(&SeriesArticle{}).Error
+	})
+}
@@ -5,9 +5,26 @@ import (
 )
 
 func InitTables(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&Article{},
 		&PublishedArticle{},
 		&PublishedArticleV1{},
+		&ArticleRevision{},
+		&Series{},
+		&SeriesArticle{},
+		&ArticleCoAuthor{},
+		&AttachmentBlob{},
+		&Attachment{},
+		&ArticleCitation{},
+		&ArticleVisibility{},
+		&ArticleAudience{},
+		&ArticleReviewer{},
+		&ArticleReviewLog{},
 	)
+	if err != nil {
+		return err
+	}
+	// 加上版本号之前的帖子都是 0，客户端拿不到版本号就没办法修改，统一当做版本 1
+	return db.Model(&Article{}).Where("version = ?", 0).
+		Update("version", 1).Error
 }
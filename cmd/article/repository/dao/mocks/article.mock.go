@@ -40,6 +40,20 @@ func (m *MockArticleDAO) EXPECT() *MockArticleDAOMockRecorder {
 	return m.recorder
 }
 
+// DeleteCoAuthor mocks base method.
+func (m *MockArticleDAO) DeleteCoAuthor(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoAuthor indicates an expected call of DeleteCoAuthor.
+func (mr *MockArticleDAOMockRecorder) DeleteCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoAuthor", reflect.TypeOf((*MockArticleDAO)(nil).DeleteCoAuthor), ctx, artId, uid)
+}
+
 // GetByAuthor mocks base method.
 func (m *MockArticleDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]dao.Article, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +69,21 @@ func (mr *MockArticleDAOMockRecorder) GetByAuthor(ctx, author, offset, limit any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthor), ctx, author, offset, limit)
 }
 
+// GetByAuthorCursor mocks base method.
+func (m *MockArticleDAO) GetByAuthorCursor(ctx context.Context, author, utime, id int64, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthorCursor", ctx, author, utime, id, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthorCursor indicates an expected call of GetByAuthorCursor.
+func (mr *MockArticleDAOMockRecorder) GetByAuthorCursor(ctx, author, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthorCursor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthorCursor), ctx, author, utime, id, limit)
+}
+
 // GetById mocks base method.
 func (m *MockArticleDAO) GetById(ctx context.Context, id int64) (dao.Article, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +99,21 @@ func (mr *MockArticleDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleDAO)(nil).GetById), ctx, id)
 }
 
+// GetCoAuthor mocks base method.
+func (m *MockArticleDAO) GetCoAuthor(ctx context.Context, artId, uid int64) (dao.ArticleCoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(dao.ArticleCoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoAuthor indicates an expected call of GetCoAuthor.
+func (mr *MockArticleDAOMockRecorder) GetCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetCoAuthor), ctx, artId, uid)
+}
+
 // GetPubById mocks base method.
 func (m *MockArticleDAO) GetPubById(ctx context.Context, id int64) (dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +129,65 @@ func (mr *MockArticleDAOMockRecorder) GetPubById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleDAO)(nil).GetPubById), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockArticleDAO) GetRevision(ctx context.Context, artId, id int64) (dao.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, artId, id)
+	ret0, _ := ret[0].(dao.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleDAOMockRecorder) GetRevision(ctx, artId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleDAO)(nil).GetRevision), ctx, artId, id)
+}
+
+// GetSeriesArticle mocks base method.
+func (m *MockArticleDAO) GetSeriesArticle(ctx context.Context, artId int64) (dao.SeriesArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesArticle", ctx, artId)
+	ret0, _ := ret[0].(dao.SeriesArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesArticle indicates an expected call of GetSeriesArticle.
+func (mr *MockArticleDAOMockRecorder) GetSeriesArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesArticle", reflect.TypeOf((*MockArticleDAO)(nil).GetSeriesArticle), ctx, artId)
+}
+
+// GetSeriesById mocks base method.
+func (m *MockArticleDAO) GetSeriesById(ctx context.Context, id int64) (dao.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesById", ctx, id)
+	ret0, _ := ret[0].(dao.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesById indicates an expected call of GetSeriesById.
+func (mr *MockArticleDAOMockRecorder) GetSeriesById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesById", reflect.TypeOf((*MockArticleDAO)(nil).GetSeriesById), ctx, id)
+}
+
+// HardDelete mocks base method.
+func (m *MockArticleDAO) HardDelete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockArticleDAOMockRecorder) HardDelete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockArticleDAO)(nil).HardDelete), ctx, id)
+}
+
 // Insert mocks base method.
 func (m *MockArticleDAO) Insert(ctx context.Context, art dao.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +203,155 @@ func (mr *MockArticleDAOMockRecorder) Insert(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockArticleDAO)(nil).Insert), ctx, art)
 }
 
+// InsertCoAuthor mocks base method.
+func (m *MockArticleDAO) InsertCoAuthor(ctx context.Context, c dao.ArticleCoAuthor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCoAuthor", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCoAuthor indicates an expected call of InsertCoAuthor.
+func (mr *MockArticleDAOMockRecorder) InsertCoAuthor(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCoAuthor", reflect.TypeOf((*MockArticleDAO)(nil).InsertCoAuthor), ctx, c)
+}
+
+// InsertSeries mocks base method.
+func (m *MockArticleDAO) InsertSeries(ctx context.Context, s dao.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSeries", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSeries indicates an expected call of InsertSeries.
+func (mr *MockArticleDAOMockRecorder) InsertSeries(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSeries", reflect.TypeOf((*MockArticleDAO)(nil).InsertSeries), ctx, s)
+}
+
+// ListByStatus mocks base method.
+func (m *MockArticleDAO) ListByStatus(ctx context.Context, status uint8, offset, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockArticleDAOMockRecorder) ListByStatus(ctx, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockArticleDAO)(nil).ListByStatus), ctx, status, offset, limit)
+}
+
+// ListCoAuthors mocks base method.
+func (m *MockArticleDAO) ListCoAuthors(ctx context.Context, artId int64) ([]dao.ArticleCoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoAuthors", ctx, artId)
+	ret0, _ := ret[0].([]dao.ArticleCoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoAuthors indicates an expected call of ListCoAuthors.
+func (mr *MockArticleDAOMockRecorder) ListCoAuthors(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthors", reflect.TypeOf((*MockArticleDAO)(nil).ListCoAuthors), ctx, artId)
+}
+
+// ListCoAuthorsByArticles mocks base method.
+func (m *MockArticleDAO) ListCoAuthorsByArticles(ctx context.Context, artIds []int64, status uint8) ([]dao.ArticleCoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoAuthorsByArticles", ctx, artIds, status)
+	ret0, _ := ret[0].([]dao.ArticleCoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoAuthorsByArticles indicates an expected call of ListCoAuthorsByArticles.
+func (mr *MockArticleDAOMockRecorder) ListCoAuthorsByArticles(ctx, artIds, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthorsByArticles", reflect.TypeOf((*MockArticleDAO)(nil).ListCoAuthorsByArticles), ctx, artIds, status)
+}
+
+// ListCoAuthorsByUser mocks base method.
+func (m *MockArticleDAO) ListCoAuthorsByUser(ctx context.Context, uid int64, status uint8, offset, limit int) ([]dao.ArticleCoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoAuthorsByUser", ctx, uid, status, offset, limit)
+	ret0, _ := ret[0].([]dao.ArticleCoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoAuthorsByUser indicates an expected call of ListCoAuthorsByUser.
+func (mr *MockArticleDAOMockRecorder) ListCoAuthorsByUser(ctx, uid, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthorsByUser", reflect.TypeOf((*MockArticleDAO)(nil).ListCoAuthorsByUser), ctx, uid, status, offset, limit)
+}
+
+// ListDeleted mocks base method.
+func (m *MockArticleDAO) ListDeleted(ctx context.Context, author int64, offset, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, author, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleDAOMockRecorder) ListDeleted(ctx, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleDAO)(nil).ListDeleted), ctx, author, offset, limit)
+}
+
+// ListDeletedBefore mocks base method.
+func (m *MockArticleDAO) ListDeletedBefore(ctx context.Context, before int64, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedBefore", ctx, before, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedBefore indicates an expected call of ListDeletedBefore.
+func (mr *MockArticleDAOMockRecorder) ListDeletedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedBefore", reflect.TypeOf((*MockArticleDAO)(nil).ListDeletedBefore), ctx, before, limit)
+}
+
+// ListPubByCursor mocks base method.
+func (m *MockArticleDAO) ListPubByCursor(ctx context.Context, utime, id int64, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCursor", ctx, utime, id, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByCursor indicates an expected call of ListPubByCursor.
+func (mr *MockArticleDAOMockRecorder) ListPubByCursor(ctx, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCursor", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByCursor), ctx, utime, id, limit)
+}
+
+// ListPubByIds mocks base method.
+func (m *MockArticleDAO) ListPubByIds(ctx context.Context, ids []int64) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByIds", ctx, ids)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByIds indicates an expected call of ListPubByIds.
+func (mr *MockArticleDAOMockRecorder) ListPubByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByIds", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByIds), ctx, ids)
+}
+
 // ListPubByUtime mocks base method.
 func (m *MockArticleDAO) ListPubByUtime(ctx context.Context, utime time.Time, offset, limit int) ([]dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +367,108 @@ func (mr *MockArticleDAOMockRecorder) ListPubByUtime(ctx, utime, offset, limit a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByUtime", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByUtime), ctx, utime, offset, limit)
 }
 
+// ListRevisions mocks base method.
+func (m *MockArticleDAO) ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]dao.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]dao.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleDAOMockRecorder) ListRevisions(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleDAO)(nil).ListRevisions), ctx, artId, offset, limit)
+}
+
+// ListScheduled mocks base method.
+func (m *MockArticleDAO) ListScheduled(ctx context.Context, status uint8, before int64, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduled", ctx, status, before, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduled indicates an expected call of ListScheduled.
+func (mr *MockArticleDAOMockRecorder) ListScheduled(ctx, status, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduled", reflect.TypeOf((*MockArticleDAO)(nil).ListScheduled), ctx, status, before, limit)
+}
+
+// ListSeriesArticles mocks base method.
+func (m *MockArticleDAO) ListSeriesArticles(ctx context.Context, seriesId int64) ([]dao.SeriesArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeriesArticles", ctx, seriesId)
+	ret0, _ := ret[0].([]dao.SeriesArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeriesArticles indicates an expected call of ListSeriesArticles.
+func (mr *MockArticleDAOMockRecorder) ListSeriesArticles(ctx, seriesId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeriesArticles", reflect.TypeOf((*MockArticleDAO)(nil).ListSeriesArticles), ctx, seriesId)
+}
+
+// ListSeriesByAuthor mocks base method.
+func (m *MockArticleDAO) ListSeriesByAuthor(ctx context.Context, author int64, offset, limit int) ([]dao.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeriesByAuthor", ctx, author, offset, limit)
+	ret0, _ := ret[0].([]dao.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeriesByAuthor indicates an expected call of ListSeriesByAuthor.
+func (mr *MockArticleDAOMockRecorder) ListSeriesByAuthor(ctx, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeriesByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).ListSeriesByAuthor), ctx, author, offset, limit)
+}
+
+// RestoreDeleted mocks base method.
+func (m *MockArticleDAO) RestoreDeleted(ctx context.Context, author, id, after int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeleted", ctx, author, id, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDeleted indicates an expected call of RestoreDeleted.
+func (mr *MockArticleDAOMockRecorder) RestoreDeleted(ctx, author, id, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeleted", reflect.TypeOf((*MockArticleDAO)(nil).RestoreDeleted), ctx, author, id, after)
+}
+
+// SetSeriesArticles mocks base method.
+func (m *MockArticleDAO) SetSeriesArticles(ctx context.Context, author, seriesId int64, artIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSeriesArticles", ctx, author, seriesId, artIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSeriesArticles indicates an expected call of SetSeriesArticles.
+func (mr *MockArticleDAOMockRecorder) SetSeriesArticles(ctx, author, seriesId, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSeriesArticles", reflect.TypeOf((*MockArticleDAO)(nil).SetSeriesArticles), ctx, author, seriesId, artIds)
+}
+
+// SoftDelete mocks base method.
+func (m *MockArticleDAO) SoftDelete(ctx context.Context, author, id, now int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, author, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockArticleDAOMockRecorder) SoftDelete(ctx, author, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockArticleDAO)(nil).SoftDelete), ctx, author, id, now)
+}
+
 // Sync mocks base method.
 func (m *MockArticleDAO) Sync(ctx context.Context, art dao.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -157,3 +511,31 @@ func (mr *MockArticleDAOMockRecorder) UpdateById(ctx, art any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockArticleDAO)(nil).UpdateById), ctx, art)
 }
+
+// UpdateCoAuthorStatus mocks base method.
+func (m *MockArticleDAO) UpdateCoAuthorStatus(ctx context.Context, artId, uid int64, oldStatus, newStatus uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoAuthorStatus", ctx, artId, uid, oldStatus, newStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCoAuthorStatus indicates an expected call of UpdateCoAuthorStatus.
+func (mr *MockArticleDAOMockRecorder) UpdateCoAuthorStatus(ctx, artId, uid, oldStatus, newStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoAuthorStatus", reflect.TypeOf((*MockArticleDAO)(nil).UpdateCoAuthorStatus), ctx, artId, uid, oldStatus, newStatus)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockArticleDAO) UpdateSchedule(ctx context.Context, author, id int64, oldStatus, newStatus uint8, publishAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, author, id, oldStatus, newStatus, publishAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockArticleDAOMockRecorder) UpdateSchedule(ctx, author, id, oldStatus, newStatus, publishAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockArticleDAO)(nil).UpdateSchedule), ctx, author, id, oldStatus, newStatus, publishAt)
+}
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./attachment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./attachment.go -package=artdaomocks -destination=mocks/attachment.mock.go AttachmentDAO
+//
+// Package artdaomocks is a generated GoMock package.
+package artdaomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/XD/ScholarNet/cmd/article/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentDAO is a mock of AttachmentDAO interface.
+type MockAttachmentDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentDAOMockRecorder
+}
+
+// MockAttachmentDAOMockRecorder is the mock recorder for MockAttachmentDAO.
+type MockAttachmentDAOMockRecorder struct {
+	mock *MockAttachmentDAO
+}
+
+// NewMockAttachmentDAO creates a new mock instance.
+func NewMockAttachmentDAO(ctrl *gomock.Controller) *MockAttachmentDAO {
+	mock := &MockAttachmentDAO{ctrl: ctrl}
+	mock.recorder = &MockAttachmentDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentDAO) EXPECT() *MockAttachmentDAOMockRecorder {
+	return m.recorder
+}
+
+// DeleteBlob mocks base method.
+func (m *MockAttachmentDAO) DeleteBlob(ctx context.Context, hash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlob", ctx, hash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBlob indicates an expected call of DeleteBlob.
+func (mr *MockAttachmentDAOMockRecorder) DeleteBlob(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockAttachmentDAO)(nil).DeleteBlob), ctx, hash)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockAttachmentDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockAttachmentDAOMockRecorder) DeleteByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockAttachmentDAO)(nil).DeleteByArticle), ctx, artId)
+}
+
+// DeleteUnlinked mocks base method.
+func (m *MockAttachmentDAO) DeleteUnlinked(ctx context.Context, before int64, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnlinked", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnlinked indicates an expected call of DeleteUnlinked.
+func (mr *MockAttachmentDAOMockRecorder) DeleteUnlinked(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnlinked", reflect.TypeOf((*MockAttachmentDAO)(nil).DeleteUnlinked), ctx, before, limit)
+}
+
+// GetBlob mocks base method.
+func (m *MockAttachmentDAO) GetBlob(ctx context.Context, hash string) (dao.AttachmentBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlob", ctx, hash)
+	ret0, _ := ret[0].(dao.AttachmentBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlob indicates an expected call of GetBlob.
+func (mr *MockAttachmentDAOMockRecorder) GetBlob(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockAttachmentDAO)(nil).GetBlob), ctx, hash)
+}
+
+// GetById mocks base method.
+func (m *MockAttachmentDAO) GetById(ctx context.Context, id int64) (dao.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAttachmentDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAttachmentDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockAttachmentDAO) Insert(ctx context.Context, a dao.Attachment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, a)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAttachmentDAOMockRecorder) Insert(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAttachmentDAO)(nil).Insert), ctx, a)
+}
+
+// Link mocks base method.
+func (m *MockAttachmentDAO) Link(ctx context.Context, uid, id, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", ctx, uid, id, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockAttachmentDAOMockRecorder) Link(ctx, uid, id, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockAttachmentDAO)(nil).Link), ctx, uid, id, artId)
+}
+
+// ListByArticle mocks base method.
+func (m *MockAttachmentDAO) ListByArticle(ctx context.Context, artId int64) ([]dao.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticle", ctx, artId)
+	ret0, _ := ret[0].([]dao.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticle indicates an expected call of ListByArticle.
+func (mr *MockAttachmentDAOMockRecorder) ListByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticle", reflect.TypeOf((*MockAttachmentDAO)(nil).ListByArticle), ctx, artId)
+}
+
+// ListUnreferencedBlobs mocks base method.
+func (m *MockAttachmentDAO) ListUnreferencedBlobs(ctx context.Context, before int64, limit int) ([]dao.AttachmentBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnreferencedBlobs", ctx, before, limit)
+	ret0, _ := ret[0].([]dao.AttachmentBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnreferencedBlobs indicates an expected call of ListUnreferencedBlobs.
+func (mr *MockAttachmentDAOMockRecorder) ListUnreferencedBlobs(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnreferencedBlobs", reflect.TypeOf((*MockAttachmentDAO)(nil).ListUnreferencedBlobs), ctx, before, limit)
+}
+
+// MarkBlobUploaded mocks base method.
+func (m *MockAttachmentDAO) MarkBlobUploaded(ctx context.Context, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBlobUploaded", ctx, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkBlobUploaded indicates an expected call of MarkBlobUploaded.
+func (mr *MockAttachmentDAOMockRecorder) MarkBlobUploaded(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBlobUploaded", reflect.TypeOf((*MockAttachmentDAO)(nil).MarkBlobUploaded), ctx, hash)
+}
+
+// UpsertBlob mocks base method.
+func (m *MockAttachmentDAO) UpsertBlob(ctx context.Context, b dao.AttachmentBlob) (dao.AttachmentBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBlob", ctx, b)
+	ret0, _ := ret[0].(dao.AttachmentBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertBlob indicates an expected call of UpsertBlob.
+func (mr *MockAttachmentDAOMockRecorder) UpsertBlob(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBlob", reflect.TypeOf((*MockAttachmentDAO)(nil).UpsertBlob), ctx, b)
+}
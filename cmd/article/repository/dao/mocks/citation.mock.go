@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./citation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./citation.go -package=artdaomocks -destination=mocks/citation.mock.go CitationDAO
+//
+// Package artdaomocks is a generated GoMock package.
+package artdaomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/XD/ScholarNet/cmd/article/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCitationDAO is a mock of CitationDAO interface.
+type MockCitationDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockCitationDAOMockRecorder
+}
+
+// MockCitationDAOMockRecorder is the mock recorder for MockCitationDAO.
+type MockCitationDAOMockRecorder struct {
+	mock *MockCitationDAO
+}
+
+// NewMockCitationDAO creates a new mock instance.
+func NewMockCitationDAO(ctrl *gomock.Controller) *MockCitationDAO {
+	mock := &MockCitationDAO{ctrl: ctrl}
+	mock.recorder = &MockCitationDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCitationDAO) EXPECT() *MockCitationDAOMockRecorder {
+	return m.recorder
+}
+
+// CountCitedBy mocks base method.
+func (m *MockCitationDAO) CountCitedBy(ctx context.Context, citedIds []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCitedBy", ctx, citedIds)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCitedBy indicates an expected call of CountCitedBy.
+func (mr *MockCitationDAOMockRecorder) CountCitedBy(ctx, citedIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCitedBy", reflect.TypeOf((*MockCitationDAO)(nil).CountCitedBy), ctx, citedIds)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockCitationDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockCitationDAOMockRecorder) DeleteByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockCitationDAO)(nil).DeleteByArticle), ctx, artId)
+}
+
+// ListByArticle mocks base method.
+func (m *MockCitationDAO) ListByArticle(ctx context.Context, artId int64) ([]dao.ArticleCitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticle", ctx, artId)
+	ret0, _ := ret[0].([]dao.ArticleCitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticle indicates an expected call of ListByArticle.
+func (mr *MockCitationDAOMockRecorder) ListByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticle", reflect.TypeOf((*MockCitationDAO)(nil).ListByArticle), ctx, artId)
+}
+
+// ListCitedBy mocks base method.
+func (m *MockCitationDAO) ListCitedBy(ctx context.Context, citedId int64, offset, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCitedBy", ctx, citedId, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitedBy indicates an expected call of ListCitedBy.
+func (mr *MockCitationDAOMockRecorder) ListCitedBy(ctx, citedId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitedBy", reflect.TypeOf((*MockCitationDAO)(nil).ListCitedBy), ctx, citedId, offset, limit)
+}
+
+// Replace mocks base method.
+func (m *MockCitationDAO) Replace(ctx context.Context, artId int64, source uint8, cits []dao.ArticleCitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, artId, source, cits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockCitationDAOMockRecorder) Replace(ctx, artId, source, cits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockCitationDAO)(nil).Replace), ctx, artId, source, cits)
+}
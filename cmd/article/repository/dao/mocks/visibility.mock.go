@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./visibility.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./visibility.go -package=artdaomocks -destination=mocks/visibility.mock.go VisibilityDAO
+//
+// Package artdaomocks is a generated GoMock package.
+package artdaomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/XD/ScholarNet/cmd/article/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVisibilityDAO is a mock of VisibilityDAO interface.
+type MockVisibilityDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityDAOMockRecorder
+}
+
+// MockVisibilityDAOMockRecorder is the mock recorder for MockVisibilityDAO.
+type MockVisibilityDAOMockRecorder struct {
+	mock *MockVisibilityDAO
+}
+
+// NewMockVisibilityDAO creates a new mock instance.
+func NewMockVisibilityDAO(ctrl *gomock.Controller) *MockVisibilityDAO {
+	mock := &MockVisibilityDAO{ctrl: ctrl}
+	mock.recorder = &MockVisibilityDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityDAO) EXPECT() *MockVisibilityDAOMockRecorder {
+	return m.recorder
+}
+
+// BatchGet mocks base method.
+func (m *MockVisibilityDAO) BatchGet(ctx context.Context, artIds []int64) ([]dao.ArticleVisibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", ctx, artIds)
+	ret0, _ := ret[0].([]dao.ArticleVisibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockVisibilityDAOMockRecorder) BatchGet(ctx, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockVisibilityDAO)(nil).BatchGet), ctx, artIds)
+}
+
+// Delete mocks base method.
+func (m *MockVisibilityDAO) Delete(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVisibilityDAOMockRecorder) Delete(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVisibilityDAO)(nil).Delete), ctx, artId)
+}
+
+// FindAudience mocks base method.
+func (m *MockVisibilityDAO) FindAudience(ctx context.Context, artId int64, uids []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAudience", ctx, artId, uids)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAudience indicates an expected call of FindAudience.
+func (mr *MockVisibilityDAOMockRecorder) FindAudience(ctx, artId, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAudience", reflect.TypeOf((*MockVisibilityDAO)(nil).FindAudience), ctx, artId, uids)
+}
+
+// Get mocks base method.
+func (m *MockVisibilityDAO) Get(ctx context.Context, artId int64) (dao.ArticleVisibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, artId)
+	ret0, _ := ret[0].(dao.ArticleVisibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVisibilityDAOMockRecorder) Get(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVisibilityDAO)(nil).Get), ctx, artId)
+}
+
+// ListAudience mocks base method.
+func (m *MockVisibilityDAO) ListAudience(ctx context.Context, artId int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAudience", ctx, artId)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAudience indicates an expected call of ListAudience.
+func (mr *MockVisibilityDAOMockRecorder) ListAudience(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAudience", reflect.TypeOf((*MockVisibilityDAO)(nil).ListAudience), ctx, artId)
+}
+
+// Set mocks base method.
+func (m *MockVisibilityDAO) Set(ctx context.Context, v dao.ArticleVisibility, allowList []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, v, allowList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockVisibilityDAOMockRecorder) Set(ctx, v, allowList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockVisibilityDAO)(nil).Set), ctx, v, allowList)
+}
@@ -2,7 +2,6 @@ package dao
 
 import (
 	"context"
-	"errors"
 	"github.com/bwmarrin/snowflake"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,27 +12,131 @@ import (
 type MongoDBDAO struct {
 	col     *mongo.Collection
 	liveCol *mongo.Collection
-	node    *snowflake.Node
+	revCol  *mongo.Collection
+	// 系列和系列里面的帖子
+	seriesCol    *mongo.Collection
+	seriesArtCol *mongo.Collection
+	coAuthorCol  *mongo.Collection
+	node         *snowflake.Node
 }
 
 func (m *MongoDBDAO) ListPubByUtime(ctx context.Context, utime time.Time, offset int, limit int) ([]PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+	filter := bson.D{bson.E{Key: "utime",
+		Value: bson.D{bson.E{Key: "$lt", Value: utime.UnixMilli()}}}, notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
 }
 
 func (m *MongoDBDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+	filter := bson.D{bson.E{Key: "id", Value: id}, notDeleted}
+	var res PublishedArticle
+	err := m.liveCol.FindOne(ctx, filter).Decode(&res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListPubByCursor(ctx context.Context, utime, id int64, limit int) ([]PublishedArticle, error) {
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, append(afterCursor(utime, id), notDeleted), opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
 }
 
 func (m *MongoDBDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+	filter, err := m.authorFilter(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+	// 和 GORM 的实现保持一致，按照更新时间倒序
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) GetByAuthorCursor(ctx context.Context, author int64, utime, id int64, limit int) ([]Article, error) {
+	filter, err := m.authorFilter(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+	filter = bson.D{bson.E{Key: "$and", Value: bson.A{filter, afterCursor(utime, id)}}, notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+// authorFilter author 创建的，或者作为共同作者参与的帖子，不包括已经删除的
+func (m *MongoDBDAO) authorFilter(ctx context.Context, author int64) (bson.D, error) {
+	// 先找出作为共同作者参与的帖子
+	coCursor, err := m.coAuthorCol.Find(ctx, bson.D{bson.E{Key: "user_id", Value: author},
+		bson.E{Key: "status", Value: CoAuthorStatusAccepted}})
+	if err != nil {
+		return nil, err
+	}
+	var coAuthors []ArticleCoAuthor
+	if err = coCursor.All(ctx, &coAuthors); err != nil {
+		return nil, err
+	}
+	filter := bson.D{bson.E{Key: "author_id", Value: author}}
+	if len(coAuthors) == 0 {
+		return append(filter, notDeleted), nil
+	}
+	ids := make([]int64, 0, len(coAuthors))
+	for _, c := range coAuthors {
+		ids = append(ids, c.ArticleId)
+	}
+	return bson.D{bson.E{Key: "$or", Value: bson.A{
+		filter,
+		bson.D{bson.E{Key: "id", Value: bson.D{bson.E{Key: "$in", Value: ids}}}},
+	}}, notDeleted}, nil
+}
+
+// notDeleted 没有删除的帖子，以前的数据里面没有 dtime 这个字段
+var notDeleted = bson.E{Key: "dtime", Value: bson.D{bson.E{Key: "$not",
+	Value: bson.D{bson.E{Key: "$gt", Value: 0}}}}}
+
+// afterCursor 严格排在 (utime, id) 后面，也就是 utime < ? OR (utime = ? AND id < ?)
+func afterCursor(utime, id int64) bson.D {
+	return bson.D{bson.E{Key: "$or", Value: bson.A{
+		bson.D{bson.E{Key: "utime", Value: bson.D{bson.E{Key: "$lt", Value: utime}}}},
+		bson.D{bson.E{Key: "utime", Value: utime},
+			bson.E{Key: "id", Value: bson.D{bson.E{Key: "$lt", Value: id}}}},
+	}}}
 }
 
 func (m *MongoDBDAO) GetById(ctx context.Context, id int64) (Article, error) {
-	//TODO implement me
-	panic("implement me")
+	filter := bson.D{bson.E{Key: "id", Value: id}}
+	var res Article
+	err := m.col.FindOne(ctx, filter).Decode(&res)
+	return res, err
 }
 
 func InitCollections(db *mongo.Database) error {
@@ -51,21 +154,128 @@ func InitCollections(db *mongo.Database) error {
 			Options: options.Index(),
 		},
 	}
+	// 制作库：GetByAuthor 按照 utime 排序，定时任务按照 status + publish_at 查找，
+	// 回收站和清理任务按照 dtime 查找
 	_, err := db.Collection("articles").Indexes().
-		CreateMany(ctx, index)
+		CreateMany(ctx, append(index,
+			mongo.IndexModel{
+				Keys:    bson.D{bson.E{Key: "dtime", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			mongo.IndexModel{
+				Keys: bson.D{bson.E{Key: "author_id", Value: 1},
+					bson.E{Key: "utime", Value: -1},
+					bson.E{Key: "id", Value: -1},
+				},
+				Options: options.Index(),
+			},
+			mongo.IndexModel{
+				Keys: bson.D{bson.E{Key: "status", Value: 1},
+					bson.E{Key: "publish_at", Value: 1},
+				},
+				Options: options.Index(),
+			}))
 	if err != nil {
 		return err
 	}
+	// 线上库：ListPubByUtime 按照 utime 排序
 	_, err = db.Collection("published_articles").Indexes().
-		CreateMany(ctx, index)
+		CreateMany(ctx, append(index,
+			mongo.IndexModel{
+				Keys: bson.D{bson.E{Key: "utime", Value: -1},
+					bson.E{Key: "id", Value: -1},
+				},
+				Options: options.Index(),
+			}))
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_revisions").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{bson.E{Key: "article_id", Value: 1},
+					bson.E{Key: "id", Value: -1},
+				},
+				Options: options.Index(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("series").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{bson.E{Key: "author_id", Value: 1},
+					bson.E{Key: "id", Value: -1},
+				},
+				Options: options.Index(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+	// 一篇帖子只能属于一个系列
+	_, err = db.Collection("series_articles").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "article_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{bson.E{Key: "series_id", Value: 1},
+					bson.E{Key: "position", Value: 1},
+				},
+				Options: options.Index(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_co_authors").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys: bson.D{bson.E{Key: "article_id", Value: 1},
+					bson.E{Key: "user_id", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{bson.E{Key: "user_id", Value: 1},
+					bson.E{Key: "status", Value: 1},
+				},
+				Options: options.Index(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+	// 加上版本号之前的帖子没有 version，客户端拿不到版本号就没办法修改，统一当做版本 1
+	// 帖子多的话要一段时间，不和建索引共用超时时间
+	backfillCtx, backfillCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer backfillCancel()
+	_, err = db.Collection("articles").UpdateMany(backfillCtx,
+		bson.D{bson.E{Key: "version", Value: bson.D{bson.E{Key: "$exists", Value: false}}}},
+		bson.D{bson.E{Key: "$set", Value: bson.D{bson.E{Key: "version", Value: 1}}}})
 	return err
 }
 
 func NewMongoDBDAO(db *mongo.Database, node *snowflake.Node) ArticleDAO {
 	return &MongoDBDAO{
-		col:     db.Collection("articles"),
-		liveCol: db.Collection("published_articles"),
-		node:    node,
+		col:          db.Collection("articles"),
+		liveCol:      db.Collection("published_articles"),
+		revCol:       db.Collection("article_revisions"),
+		seriesCol:    db.Collection("series"),
+		seriesArtCol: db.Collection("series_articles"),
+		coAuthorCol:  db.Collection("article_co_authors"),
+		node:         node,
 	}
 }
 
@@ -74,13 +284,22 @@ func (m *MongoDBDAO) Insert(ctx context.Context, art Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	art.Utime = now
 	art.Ctime = now
+	art.Version = 1
 	_, err := m.col.InsertOne(ctx, art)
-	return art.Id, err
+	if err != nil {
+		return art.Id, err
+	}
+	return art.Id, m.insertRevision(ctx, art, now)
 }
 
 func (m *MongoDBDAO) UpdateById(ctx context.Context, art Article) error {
+	if art.Version <= 0 {
+		return ErrMissingVersion
+	}
 	filter := bson.D{bson.E{Key: "id", Value: art.Id},
-		bson.E{Key: "author_id", Value: art.AuthorId}}
+		bson.E{Key: "author_id", Value: art.AuthorId},
+		bson.E{Key: "version", Value: art.Version}}
+	now := time.Now().UnixMilli()
 	sets := bson.D{bson.E{Key: "$set",
 		// 这里你可以考虑直接使用整个 art，因为会忽略零值。
 		// 参考 Sync 中的写法
@@ -88,6 +307,102 @@ func (m *MongoDBDAO) UpdateById(ctx context.Context, art Article) error {
 		Value: bson.D{bson.E{Key: "title", Value: art.Title},
 			bson.E{Key: "content", Value: art.Content},
 			bson.E{Key: "status", Value: art.Status},
+			bson.E{Key: "publish_at", Value: art.PublishAt},
+			bson.E{Key: "utime", Value: now},
+		}},
+		bson.E{Key: "$inc", Value: bson.D{bson.E{Key: "version", Value: 1}}}}
+	res, err := m.col.UpdateOne(ctx, filter, sets)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return m.updateFailed(ctx, art)
+	}
+	return m.insertRevision(ctx, art, now)
+}
+
+// updateFailed 更新不到数据的时候，区分是版本号不对还是作者不对
+func (m *MongoDBDAO) updateFailed(ctx context.Context, art Article) error {
+	var cur Article
+	err := m.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: art.Id}}).Decode(&cur)
+	if err != nil || cur.AuthorId != art.AuthorId {
+		// 比较可能就是有人更新别人的文章，比如说攻击者跟你过不去
+		return ErrPossibleIncorrectAuthor
+	}
+	return VersionConflictError{Current: cur.Version}
+}
+
+// insertRevision MongoDB 这里没有事务，历史版本写失败了，文章本身还是保存成功的
+func (m *MongoDBDAO) insertRevision(ctx context.Context, art Article, now int64) error {
+	rev := newRevision(art, now)
+	rev.Id = m.node.Generate().Int64()
+	_, err := m.revCol.InsertOne(ctx, rev)
+	return err
+}
+
+func (m *MongoDBDAO) ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]ArticleRevision, error) {
+	filter := bson.D{bson.E{Key: "article_id", Value: artId}}
+	// snowflake 的 id 本身就是按照时间递增的
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetProjection(bson.D{bson.E{Key: "content", Value: 0}})
+	cursor, err := m.revCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleRevision
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) GetRevision(ctx context.Context, artId, id int64) (ArticleRevision, error) {
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "article_id", Value: artId}}
+	var res ArticleRevision
+	err := m.revCol.FindOne(ctx, filter).Decode(&res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListScheduled(ctx context.Context, status uint8, before int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "status", Value: status},
+		bson.E{Key: "publish_at", Value: bson.D{bson.E{Key: "$lte", Value: before}}}, notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "publish_at", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListByStatus(ctx context.Context, status uint8, offset, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "status", Value: status}, notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) UpdateSchedule(ctx context.Context, author, id int64,
+	oldStatus, newStatus uint8, publishAt int64) error {
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "author_id", Value: author},
+		bson.E{Key: "status", Value: oldStatus}}
+	sets := bson.D{bson.E{Key: "$set",
+		Value: bson.D{bson.E{Key: "status", Value: newStatus},
+			bson.E{Key: "publish_at", Value: publishAt},
 			bson.E{Key: "utime", Value: time.Now().UnixMilli()},
 		}}}
 	res, err := m.col.UpdateOne(ctx, filter, sets)
@@ -95,8 +410,7 @@ func (m *MongoDBDAO) UpdateById(ctx context.Context, art Article) error {
 		return err
 	}
 	if res.MatchedCount != 1 {
-		// 比较可能就是有人更新别人的文章，比如说攻击者跟你过不去
-		return errors.New("更新失败")
+		return ErrArticleNotScheduled
 	}
 	return nil
 }
@@ -132,12 +446,329 @@ func (m *MongoDBDAO) SyncStatus(ctx context.Context, author, id int64, status ui
 		bson.E{Key: "author_id", Value: author}}
 	sets := bson.D{bson.E{Key: "$set",
 		Value: bson.D{bson.E{Key: "status", Value: status}}}}
-	res, err := m.col.UpdateOne(ctx, filter, sets)
+	// 回收站里面的帖子不能再撤回
+	res, err := m.col.UpdateOne(ctx, append(filter, notDeleted), sets)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return ErrPossibleIncorrectAuthor
+	}
+	// 和 GORM 的实现一样，线上库也要同步状态
+	// 没有事务，所以这里失败了制作库的状态也已经改了
+	res, err = m.liveCol.UpdateOne(ctx, filter, sets)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount != 1 {
+	if res.MatchedCount != 1 {
 		return ErrPossibleIncorrectAuthor
 	}
 	return nil
 }
+
+func (m *MongoDBDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	if len(ids) == 0 {
+		return res, nil
+	}
+	filter := bson.D{bson.E{Key: "id",
+		Value: bson.D{bson.E{Key: "$in", Value: ids}}}, notDeleted}
+	cursor, err := m.liveCol.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) InsertSeries(ctx context.Context, s Series) (int64, error) {
+	s.Id = m.node.Generate().Int64()
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	_, err := m.seriesCol.InsertOne(ctx, s)
+	return s.Id, err
+}
+
+func (m *MongoDBDAO) GetSeriesById(ctx context.Context, id int64) (Series, error) {
+	var res Series
+	err := m.seriesCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&res)
+	if err == mongo.ErrNoDocuments {
+		return res, ErrDataNotFound
+	}
+	return res, err
+}
+
+func (m *MongoDBDAO) ListSeriesByAuthor(ctx context.Context, author int64, offset, limit int) ([]Series, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: author}}
+	// snowflake 的 id 本身就是按照时间递增的
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.seriesCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Series
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+// SetSeriesArticles MongoDB 这里没有事务，先校验，再删掉旧的，插入新的
+func (m *MongoDBDAO) SetSeriesArticles(ctx context.Context, author, seriesId int64, artIds []int64) error {
+	now := time.Now().UnixMilli()
+	res, err := m.seriesCol.UpdateOne(ctx,
+		bson.D{bson.E{Key: "id", Value: seriesId}, bson.E{Key: "author_id", Value: author}},
+		bson.D{bson.E{Key: "$set", Value: bson.D{bson.E{Key: "utime", Value: now}}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return ErrPossibleIncorrectAuthor
+	}
+	if len(artIds) > 0 {
+		cnt, err := m.col.CountDocuments(ctx, bson.D{
+			bson.E{Key: "id", Value: bson.D{bson.E{Key: "$in", Value: artIds}}},
+			bson.E{Key: "author_id", Value: author}})
+		if err != nil {
+			return err
+		}
+		if cnt != int64(len(artIds)) {
+			return ErrPossibleIncorrectAuthor
+		}
+		cnt, err = m.seriesArtCol.CountDocuments(ctx, bson.D{
+			bson.E{Key: "article_id", Value: bson.D{bson.E{Key: "$in", Value: artIds}}},
+			bson.E{Key: "series_id", Value: bson.D{bson.E{Key: "$ne", Value: seriesId}}}})
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleInOtherSeries
+		}
+	}
+	_, err = m.seriesArtCol.DeleteMany(ctx, bson.D{bson.E{Key: "series_id", Value: seriesId}})
+	if err != nil || len(artIds) == 0 {
+		return err
+	}
+	members := make([]any, 0, len(artIds))
+	for i, id := range artIds {
+		members = append(members, SeriesArticle{
+			Id:        m.node.Generate().Int64(),
+			SeriesId:  seriesId,
+			ArticleId: id,
+			Position:  i,
+			Ctime:     now,
+		})
+	}
+	_, err = m.seriesArtCol.InsertMany(ctx, members)
+	return err
+}
+
+func (m *MongoDBDAO) ListSeriesArticles(ctx context.Context, seriesId int64) ([]SeriesArticle, error) {
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "position", Value: 1}})
+	cursor, err := m.seriesArtCol.Find(ctx, bson.D{bson.E{Key: "series_id", Value: seriesId}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []SeriesArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) GetSeriesArticle(ctx context.Context, artId int64) (SeriesArticle, error) {
+	var res SeriesArticle
+	err := m.seriesArtCol.FindOne(ctx, bson.D{bson.E{Key: "article_id", Value: artId}}).Decode(&res)
+	if err == mongo.ErrNoDocuments {
+		return res, ErrDataNotFound
+	}
+	return res, err
+}
+
+func (m *MongoDBDAO) InsertCoAuthor(ctx context.Context, c ArticleCoAuthor) error {
+	now := time.Now().UnixMilli()
+	c.Id = m.node.Generate().Int64()
+	c.Ctime = now
+	c.Utime = now
+	var last ArticleCoAuthor
+	opts := options.FindOne().SetSort(bson.D{bson.E{Key: "position", Value: -1}})
+	err := m.coAuthorCol.FindOne(ctx, bson.D{bson.E{Key: "article_id", Value: c.ArticleId}}, opts).Decode(&last)
+	switch err {
+	case nil:
+		c.Position = last.Position + 1
+	case mongo.ErrNoDocuments:
+	default:
+		return err
+	}
+	// 依赖唯一索引防止重复邀请
+	_, err = m.coAuthorCol.InsertOne(ctx, c)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateCoAuthor
+	}
+	return err
+}
+
+func (m *MongoDBDAO) GetCoAuthor(ctx context.Context, artId, uid int64) (ArticleCoAuthor, error) {
+	var res ArticleCoAuthor
+	err := m.coAuthorCol.FindOne(ctx, bson.D{bson.E{Key: "article_id", Value: artId},
+		bson.E{Key: "user_id", Value: uid}}).Decode(&res)
+	if err == mongo.ErrNoDocuments {
+		return res, ErrDataNotFound
+	}
+	return res, err
+}
+
+func (m *MongoDBDAO) UpdateCoAuthorStatus(ctx context.Context, artId, uid int64, oldStatus, newStatus uint8) error {
+	filter := bson.D{bson.E{Key: "article_id", Value: artId},
+		bson.E{Key: "user_id", Value: uid},
+		bson.E{Key: "status", Value: oldStatus}}
+	res, err := m.coAuthorCol.UpdateOne(ctx, filter, bson.D{bson.E{Key: "$set", Value: bson.D{
+		bson.E{Key: "status", Value: newStatus},
+		bson.E{Key: "utime", Value: time.Now().UnixMilli()},
+	}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (m *MongoDBDAO) DeleteCoAuthor(ctx context.Context, artId, uid int64) error {
+	_, err := m.coAuthorCol.DeleteOne(ctx, bson.D{bson.E{Key: "article_id", Value: artId},
+		bson.E{Key: "user_id", Value: uid}})
+	return err
+}
+
+func (m *MongoDBDAO) ListCoAuthors(ctx context.Context, artId int64) ([]ArticleCoAuthor, error) {
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "position", Value: 1}})
+	cursor, err := m.coAuthorCol.Find(ctx, bson.D{bson.E{Key: "article_id", Value: artId}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleCoAuthor
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListCoAuthorsByArticles(ctx context.Context, artIds []int64, status uint8) ([]ArticleCoAuthor, error) {
+	filter := bson.D{bson.E{Key: "article_id", Value: bson.D{bson.E{Key: "$in", Value: artIds}}},
+		bson.E{Key: "status", Value: status}}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "article_id", Value: 1},
+		bson.E{Key: "position", Value: 1}})
+	cursor, err := m.coAuthorCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleCoAuthor
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListCoAuthorsByUser(ctx context.Context, uid int64, status uint8, offset, limit int) ([]ArticleCoAuthor, error) {
+	filter := bson.D{bson.E{Key: "user_id", Value: uid},
+		bson.E{Key: "status", Value: status}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.coAuthorCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleCoAuthor
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) SoftDelete(ctx context.Context, author, id int64, now int64) error {
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "author_id", Value: author}}
+	sets := bson.D{bson.E{Key: "$set",
+		Value: bson.D{bson.E{Key: "dtime", Value: now}}}}
+	res, err := m.col.UpdateOne(ctx, append(filter, notDeleted), sets)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return ErrPossibleIncorrectAuthor
+	}
+	// 没有发表过的帖子，线上库里面没有数据
+	_, err = m.liveCol.UpdateOne(ctx, filter, sets)
+	return err
+}
+
+func (m *MongoDBDAO) RestoreDeleted(ctx context.Context, author, id int64, after int64) error {
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "author_id", Value: author}}
+	sets := bson.D{bson.E{Key: "$unset",
+		Value: bson.D{bson.E{Key: "dtime", Value: ""}}}}
+	res, err := m.col.UpdateOne(ctx, append(filter,
+		bson.E{Key: "dtime", Value: bson.D{bson.E{Key: "$gte", Value: after}}}), sets)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return ErrDataNotFound
+	}
+	_, err = m.liveCol.UpdateOne(ctx, filter, sets)
+	return err
+}
+
+func (m *MongoDBDAO) ListDeleted(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: author},
+		bson.E{Key: "dtime", Value: bson.D{bson.E{Key: "$gt", Value: 0}}}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "dtime", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListDeletedBefore(ctx context.Context, before int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "dtime", Value: bson.D{
+		bson.E{Key: "$gt", Value: 0},
+		bson.E{Key: "$lt", Value: before}}}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "dtime", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) HardDelete(ctx context.Context, id int64) error {
+	res, err := m.col.DeleteOne(ctx, bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "dtime", Value: bson.D{bson.E{Key: "$gt", Value: 0}}}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount != 1 {
+		// 已经恢复了，或者已经被别的实例删掉了
+		return ErrDataNotFound
+	}
+	// 没有事务，中间失败了的话，剩下的数据不会再被查到，只是占点空间
+	if _, err = m.liveCol.DeleteOne(ctx, bson.D{bson.E{Key: "id", Value: id}}); err != nil {
+		return err
+	}
+	byArticle := bson.D{bson.E{Key: "article_id", Value: id}}
+	if _, err = m.revCol.DeleteMany(ctx, byArticle); err != nil {
+		return err
+	}
+	if _, err = m.coAuthorCol.DeleteMany(ctx, byArticle); err != nil {
+		return err
+	}
+	_, err = m.seriesArtCol.DeleteMany(ctx, byArticle)
+	return err
+}
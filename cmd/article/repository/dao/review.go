@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleReviewer 可以处理人工审核的人，由管理员直接在表里面维护
+// 和引用一样，不管 db.type 是什么都放在 MySQL 里面
+type ArticleReviewer struct {
+	Uid   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Ctime int64
+}
+
+// ArticleReviewLog 人工审核的记录，只增不改
+type ArticleReviewLog struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"index"`
+	Version   int64
+	Reviewer  int64 `gorm:"index"`
+	Approved  bool
+	Ctime     int64
+}
+
+//go:generate mockgen -source=./review.go -package=artdaomocks -destination=mocks/review.mock.go ReviewDAO
+type ReviewDAO interface {
+	IsReviewer(ctx context.Context, uid int64) (bool, error)
+	InsertLog(ctx context.Context, log ArticleReviewLog) (int64, error)
+}
+
+type GORMReviewDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMReviewDAO(db *gorm.DB) ReviewDAO {
+	return &GORMReviewDAO{db: db}
+}
+
+func (dao *GORMReviewDAO) IsReviewer(ctx context.Context, uid int64) (bool, error) {
+	var res ArticleReviewer
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (dao *GORMReviewDAO) InsertLog(ctx context.Context, log ArticleReviewLog) (int64, error) {
+	log.Ctime = time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Create(&log).Error
+	return log.Id, err
+}
@@ -123,3 +123,22 @@ func (o *S3DAO) SyncStatus(ctx context.Context, author, id int64, status uint8)
 	}
 	return err
 }
+
+// HardDelete 线上库的内容在 OSS 上，也要一起删掉
+func (o *S3DAO) HardDelete(ctx context.Context, id int64) error {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := NewGORMArticleDAO(tx)
+		if err := txDAO.HardDelete(ctx, id); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&PublishedArticleV1{}).Error
+	})
+	if err != nil {
+		return err
+	}
+	_, err = o.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: o.bucket,
+		Key:    ekit.ToPtr[string](strconv.FormatInt(id, 10)),
+	})
+	return err
+}
@@ -3,19 +3,109 @@ package dao
 import (
 	"context"
 	"errors"
+	"fmt"
+	"gorm.io/gorm"
 	"time"
 )
 
-var ErrPossibleIncorrectAuthor = errors.New("用户在尝试操作非本人数据")
+var (
+	ErrPossibleIncorrectAuthor = errors.New("用户在尝试操作非本人数据")
+	ErrArticleNotScheduled     = errors.New("帖子不处于定时发表状态")
+	ErrVersionConflict         = errors.New("帖子已经被修改过了")
+	ErrMissingVersion          = errors.New("修改帖子必须带上版本号")
+	ErrArticleInOtherSeries    = errors.New("帖子已经属于别的系列")
+	ErrDataNotFound            = gorm.ErrRecordNotFound
+	ErrDuplicateCoAuthor       = errors.New("已经邀请过这个共同作者了")
+)
+
+// VersionConflictError 更新的时候版本号对不上，
+// 说明在这期间帖子被修改过了，Current 是数据库里面现在的版本号
+type VersionConflictError struct {
+	Current int64
+}
+
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("%s，当前版本 %d", ErrVersionConflict.Error(), e.Current)
+}
+
+// Unwrap 可以用 errors.Is(err, ErrVersionConflict) 来判断
+func (e VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
 
 //go:generate mockgen -source=./types.go -package=artdaomocks -destination=mocks/article.mock.go ArticleDAO
 type ArticleDAO interface {
 	Insert(ctx context.Context, art Article) (int64, error)
+	// UpdateById 必须带上 art.Version，没有带的话返回 ErrMissingVersion
+	// 只有版本号一致的时候才会更新，否则返回 VersionConflictError。更新成功之后版本号加一
 	UpdateById(ctx context.Context, art Article) error
+	// GetByAuthor author 是创建者或者已经接受邀请的共同作者的帖子，按照更新时间倒序
 	GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error)
+	// GetByAuthorCursor 和 GetByAuthor 一样，不过是按照 (utime, id) 倒序，
+	// 只返回严格排在 (utime, id) 后面的帖子
+	GetByAuthorCursor(ctx context.Context, author int64, utime, id int64, limit int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
 	Sync(ctx context.Context, art Article) (int64, error)
 	SyncStatus(ctx context.Context, author, id int64, status uint8) error
 	ListPubByUtime(ctx context.Context, utime time.Time, offset int, limit int) ([]PublishedArticle, error)
+	// ListPubByCursor 按照 (utime, id) 倒序，只返回严格排在 (utime, id) 后面的帖子
+	// 翻页的时候不受 offset 深度的影响，期间有帖子更新也不会重复或者遗漏
+	ListPubByCursor(ctx context.Context, utime, id int64, limit int) ([]PublishedArticle, error)
+
+	// ListRevisions 按照时间倒序列出历史版本，不返回 Content
+	ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, artId, id int64) (ArticleRevision, error)
+
+	// ListScheduled 找出状态为 status 并且 publish_at <= before 的帖子，按照 publish_at 升序
+	ListScheduled(ctx context.Context, status uint8, before int64, limit int) ([]Article, error)
+	// UpdateSchedule 只有当前状态是 oldStatus 的时候，才会更新状态和 publish_at
+	// 否则返回 ErrArticleNotScheduled
+	UpdateSchedule(ctx context.Context, author, id int64, oldStatus, newStatus uint8, publishAt int64) error
+
+	// ListByStatus 制作库里面处于 status 状态的帖子，按照更新时间升序，也就是先提交的在前面
+	ListByStatus(ctx context.Context, status uint8, offset, limit int) ([]Article, error)
+
+	// ListPubByIds 批量查询已发表的帖子，不保证顺序，不存在的 id 会被忽略
+	ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
+
+	InsertSeries(ctx context.Context, s Series) (int64, error)
+	GetSeriesById(ctx context.Context, id int64) (Series, error)
+	// ListSeriesByAuthor 按照创建时间倒序
+	ListSeriesByAuthor(ctx context.Context, author int64, offset, limit int) ([]Series, error)
+	// SetSeriesArticles 用 artIds 整体替换系列里面的帖子，artIds 的顺序就是帖子在系列中的顺序
+	// 系列和帖子都必须属于 author，否则返回 ErrPossibleIncorrectAuthor；
+	// 帖子已经在别的系列里面了，返回 ErrArticleInOtherSeries
+	SetSeriesArticles(ctx context.Context, author, seriesId int64, artIds []int64) error
+	// ListSeriesArticles 按照 Position 升序
+	ListSeriesArticles(ctx context.Context, seriesId int64) ([]SeriesArticle, error)
+	// GetSeriesArticle 查找帖子所在的系列，不在任何系列里面返回 ErrDataNotFound
+	GetSeriesArticle(ctx context.Context, artId int64) (SeriesArticle, error)
+
+	// InsertCoAuthor 邀请共同作者，Position 会自动排在最后。重复邀请返回 ErrDuplicateCoAuthor
+	InsertCoAuthor(ctx context.Context, c ArticleCoAuthor) error
+	// GetCoAuthor 不存在返回 ErrDataNotFound
+	GetCoAuthor(ctx context.Context, artId, uid int64) (ArticleCoAuthor, error)
+	// UpdateCoAuthorStatus 只有当前状态是 oldStatus 的时候才会更新，否则返回 ErrDataNotFound
+	UpdateCoAuthorStatus(ctx context.Context, artId, uid int64, oldStatus, newStatus uint8) error
+	DeleteCoAuthor(ctx context.Context, artId, uid int64) error
+	// ListCoAuthors 按照 Position 升序
+	ListCoAuthors(ctx context.Context, artId int64) ([]ArticleCoAuthor, error)
+	// ListCoAuthorsByArticles 多篇帖子处于 status 状态的共同作者，按照 article_id、Position 升序
+	ListCoAuthorsByArticles(ctx context.Context, artIds []int64, status uint8) ([]ArticleCoAuthor, error)
+	// ListCoAuthorsByUser 某个用户处于 status 状态的邀请，按照时间倒序
+	ListCoAuthorsByUser(ctx context.Context, uid int64, status uint8, offset, limit int) ([]ArticleCoAuthor, error)
+
+	// SoftDelete 制作库和线上库都标记为删除，只有创建者可以删除，否则返回 ErrPossibleIncorrectAuthor
+	// 删除之后除了 GetById 和回收站相关的方法，别的方法都查不到这篇帖子
+	SoftDelete(ctx context.Context, author, id int64, now int64) error
+	// RestoreDeleted 恢复 after 之后删除的帖子，不存在或者已经过了保留期返回 ErrDataNotFound
+	RestoreDeleted(ctx context.Context, author, id int64, after int64) error
+	// ListDeleted 回收站，按照删除时间倒序
+	ListDeleted(ctx context.Context, author int64, offset, limit int) ([]Article, error)
+	// ListDeletedBefore before 之前删除的帖子，按照删除时间升序，给清理的定时任务用
+	ListDeletedBefore(ctx context.Context, before int64, limit int) ([]Article, error)
+	// HardDelete 彻底删除帖子，包括线上库、历史版本、共同作者和系列里面的记录
+	// 只能删除已经软删除的帖子，否则返回 ErrDataNotFound
+	HardDelete(ctx context.Context, id int64) error
 }
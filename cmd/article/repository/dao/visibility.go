@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleVisibility 帖子的可见范围，只有不公开的帖子才有记录
+// 不管 db.type 是什么都放在 MySQL 里面，和帖子本身分开存储，
+// 所以改可见范围不需要动线上库和缓存
+type ArticleVisibility struct {
+	ArticleId  int64 `gorm:"primaryKey;autoIncrement:false"`
+	Visibility uint8
+	Ctime      int64
+	Utime      int64
+}
+
+// ArticleAudience 可见范围是白名单的时候，哪些人可以看
+type ArticleAudience struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:article_uid"`
+	Uid       int64 `gorm:"uniqueIndex:article_uid"`
+	Ctime     int64
+}
+
+//go:generate mockgen -source=./visibility.go -package=artdaomocks -destination=mocks/visibility.mock.go VisibilityDAO
+type VisibilityDAO interface {
+	// Get 没有设置过，也就是公开的帖子返回 ErrDataNotFound
+	Get(ctx context.Context, artId int64) (ArticleVisibility, error)
+	// BatchGet 只返回不公开的帖子
+	BatchGet(ctx context.Context, artIds []int64) ([]ArticleVisibility, error)
+	// Set 整体替换可见范围和白名单，改成公开的时候直接删掉记录
+	Set(ctx context.Context, v ArticleVisibility, allowList []int64) error
+	// ListAudience 白名单里面的所有人，按照添加的顺序
+	ListAudience(ctx context.Context, artId int64) ([]int64, error)
+	// FindAudience uids 里面哪些人在白名单里面
+	FindAudience(ctx context.Context, artId int64, uids []int64) ([]int64, error)
+	Delete(ctx context.Context, artId int64) error
+}
+
+type GORMVisibilityDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMVisibilityDAO(db *gorm.DB) VisibilityDAO {
+	return &GORMVisibilityDAO{db: db}
+}
+
+func (dao *GORMVisibilityDAO) Get(ctx context.Context, artId int64) (ArticleVisibility, error) {
+	var res ArticleVisibility
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMVisibilityDAO) BatchGet(ctx context.Context, artIds []int64) ([]ArticleVisibility, error) {
+	var res []ArticleVisibility
+	if len(artIds) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).
+		Where("article_id IN ?", artIds).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMVisibilityDAO) Set(ctx context.Context, v ArticleVisibility, allowList []int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ?", v.ArticleId).
+			Delete(&ArticleAudience{}).Error
+		if err != nil {
+			return err
+		}
+		// 公开就是默认值，不需要记录
+		if v.Visibility == 0 {
+			return tx.Where("article_id = ?", v.ArticleId).
+				Delete(&ArticleVisibility{}).Error
+		}
+		v.Ctime = now
+		v.Utime = now
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "article_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"visibility": v.Visibility,
+				"utime":      now,
+			}),
+		}).Create(&v).Error
+		if err != nil || len(allowList) == 0 {
+			return err
+		}
+		audience := make([]ArticleAudience, 0, len(allowList))
+		for _, uid := range allowList {
+			audience = append(audience, ArticleAudience{
+				ArticleId: v.ArticleId,
+				Uid:       uid,
+				Ctime:     now,
+			})
+		}
+		return tx.Create(&audience).Error
+	})
+}
+
+func (dao *GORMVisibilityDAO) ListAudience(ctx context.Context, artId int64) ([]int64, error) {
+	var res []int64
+	err := dao.db.WithContext(ctx).Model(&ArticleAudience{}).
+		Where("article_id = ?", artId).
+		Order("id ASC").
+		Pluck("uid", &res).Error
+	return res, err
+}
+
+func (dao *GORMVisibilityDAO) FindAudience(ctx context.Context, artId int64, uids []int64) ([]int64, error) {
+	var res []int64
+	if len(uids) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).Model(&ArticleAudience{}).
+		Where("article_id = ? AND uid IN ?", artId, uids).
+		Pluck("uid", &res).Error
+	return res, err
+}
+
+func (dao *GORMVisibilityDAO) Delete(ctx context.Context, artId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ?", artId).
+			Delete(&ArticleAudience{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("article_id = ?", artId).
+			Delete(&ArticleVisibility{}).Error
+	})
+}
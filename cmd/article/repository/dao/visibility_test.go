@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMVisibilityDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/visibility.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTables(db))
+	dao := NewGORMVisibilityDAO(db)
+	ctx := context.Background()
+	const (
+		followers uint8 = 1
+		allowList uint8 = 3
+	)
+
+	// 没有设置过就是公开的
+	_, err = dao.Get(ctx, 1)
+	assert.Equal(t, ErrDataNotFound, err)
+
+	require.NoError(t, dao.Set(ctx, ArticleVisibility{ArticleId: 1, Visibility: allowList}, []int64{30, 10, 20}))
+	require.NoError(t, dao.Set(ctx, ArticleVisibility{ArticleId: 2, Visibility: followers}, nil))
+	v, err := dao.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, allowList, v.Visibility)
+
+	uids, err := dao.ListAudience(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{30, 10, 20}, uids)
+	uids, err = dao.FindAudience(ctx, 1, []int64{10, 11, 30})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{10, 30}, uids)
+
+	vs, err := dao.BatchGet(ctx, []int64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Len(t, vs, 2)
+
+	// 改成关注者可见，白名单就没有了
+	require.NoError(t, dao.Set(ctx, ArticleVisibility{ArticleId: 1, Visibility: followers}, nil))
+	v, err = dao.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, followers, v.Visibility)
+	uids, err = dao.ListAudience(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, uids)
+
+	// 改回公开和删除都不留记录
+	require.NoError(t, dao.Set(ctx, ArticleVisibility{ArticleId: 1}, nil))
+	_, err = dao.Get(ctx, 1)
+	assert.Equal(t, ErrDataNotFound, err)
+	require.NoError(t, dao.Delete(ctx, 2))
+	vs, err = dao.BatchGet(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	assert.Empty(t, vs)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	followv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FollowRepository 封装 follow 的 client，判断可见范围的时候用
+type FollowRepository interface {
+	// Follows follower 有没有关注 followee
+	Follows(ctx context.Context, follower, followee int64) (bool, error)
+}
+
+type GrpcFollowRepository struct {
+	client followv1.FollowServiceClient
+}
+
+func NewGrpcFollowRepository(client followv1.FollowServiceClient) FollowRepository {
+	return &GrpcFollowRepository{client: client}
+}
+
+func (g *GrpcFollowRepository) Follows(ctx context.Context, follower, followee int64) (bool, error) {
+	_, err := g.client.FollowInfo(ctx, &followv1.FollowInfoRequest{
+		Follower: follower,
+		Followee: followee,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.NotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
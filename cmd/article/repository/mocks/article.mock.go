@@ -0,0 +1,524 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article.go -package=repomocks -destination=mocks/article.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/XD/ScholarNet/cmd/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleRepository is a mock of ArticleRepository interface.
+type MockArticleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleRepositoryMockRecorder
+}
+
+// MockArticleRepositoryMockRecorder is the mock recorder for MockArticleRepository.
+type MockArticleRepositoryMockRecorder struct {
+	mock *MockArticleRepository
+}
+
+// NewMockArticleRepository creates a new mock instance.
+func NewMockArticleRepository(ctrl *gomock.Controller) *MockArticleRepository {
+	mock := &MockArticleRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptCoAuthor mocks base method.
+func (m *MockArticleRepository) AcceptCoAuthor(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptCoAuthor indicates an expected call of AcceptCoAuthor.
+func (mr *MockArticleRepositoryMockRecorder) AcceptCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptCoAuthor", reflect.TypeOf((*MockArticleRepository)(nil).AcceptCoAuthor), ctx, artId, uid)
+}
+
+// AddCoAuthor mocks base method.
+func (m *MockArticleRepository) AddCoAuthor(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCoAuthor indicates an expected call of AddCoAuthor.
+func (mr *MockArticleRepositoryMockRecorder) AddCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCoAuthor", reflect.TypeOf((*MockArticleRepository)(nil).AddCoAuthor), ctx, artId, uid)
+}
+
+// BatchGetPublished mocks base method.
+func (m *MockArticleRepository) BatchGetPublished(ctx context.Context, ids []int64) (map[int64]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetPublished", ctx, ids)
+	ret0, _ := ret[0].(map[int64]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetPublished indicates an expected call of BatchGetPublished.
+func (mr *MockArticleRepositoryMockRecorder) BatchGetPublished(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetPublished", reflect.TypeOf((*MockArticleRepository)(nil).BatchGetPublished), ctx, ids)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleRepository) CancelSchedule(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleRepositoryMockRecorder) CancelSchedule(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleRepository)(nil).CancelSchedule), ctx, uid, id)
+}
+
+// Create mocks base method.
+func (m *MockArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleRepositoryMockRecorder) Create(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepository)(nil).Create), ctx, art)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleRepository) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleRepositoryMockRecorder) CreateSeries(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleRepository)(nil).CreateSeries), ctx, s)
+}
+
+// Delete mocks base method.
+func (m *MockArticleRepository) Delete(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleRepositoryMockRecorder) Delete(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleRepository)(nil).Delete), ctx, uid, id)
+}
+
+// GetById mocks base method.
+func (m *MockArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleRepository)(nil).GetById), ctx, id)
+}
+
+// GetCoAuthor mocks base method.
+func (m *MockArticleRepository) GetCoAuthor(ctx context.Context, artId, uid int64) (domain.CoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(domain.CoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoAuthor indicates an expected call of GetCoAuthor.
+func (mr *MockArticleRepositoryMockRecorder) GetCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoAuthor", reflect.TypeOf((*MockArticleRepository)(nil).GetCoAuthor), ctx, artId, uid)
+}
+
+// GetPublishedById mocks base method.
+func (m *MockArticleRepository) GetPublishedById(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedById", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedById indicates an expected call of GetPublishedById.
+func (mr *MockArticleRepositoryMockRecorder) GetPublishedById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleRepository)(nil).GetPublishedById), ctx, id)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleRepository) GetRevision(ctx context.Context, artId, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, artId, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleRepositoryMockRecorder) GetRevision(ctx, artId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleRepository)(nil).GetRevision), ctx, artId, id)
+}
+
+// GetSeriesNav mocks base method.
+func (m *MockArticleRepository) GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesNav", ctx, artId)
+	ret0, _ := ret[0].(*domain.SeriesNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesNav indicates an expected call of GetSeriesNav.
+func (mr *MockArticleRepositoryMockRecorder) GetSeriesNav(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesNav", reflect.TypeOf((*MockArticleRepository)(nil).GetSeriesNav), ctx, artId)
+}
+
+// List mocks base method.
+func (m *MockArticleRepository) List(ctx context.Context, author int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, author, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleRepositoryMockRecorder) List(ctx, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleRepository)(nil).List), ctx, author, offset, limit)
+}
+
+// ListByCursor mocks base method.
+func (m *MockArticleRepository) ListByCursor(ctx context.Context, author int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, author, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockArticleRepositoryMockRecorder) ListByCursor(ctx, author, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockArticleRepository)(nil).ListByCursor), ctx, author, cursor, limit)
+}
+
+// ListCoAuthors mocks base method.
+func (m *MockArticleRepository) ListCoAuthors(ctx context.Context, artId int64) ([]domain.CoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoAuthors", ctx, artId)
+	ret0, _ := ret[0].([]domain.CoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoAuthors indicates an expected call of ListCoAuthors.
+func (mr *MockArticleRepositoryMockRecorder) ListCoAuthors(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoAuthors", reflect.TypeOf((*MockArticleRepository)(nil).ListCoAuthors), ctx, artId)
+}
+
+// ListDeleted mocks base method.
+func (m *MockArticleRepository) ListDeleted(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleRepositoryMockRecorder) ListDeleted(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleRepository)(nil).ListDeleted), ctx, uid, offset, limit)
+}
+
+// ListDeletedBefore mocks base method.
+func (m *MockArticleRepository) ListDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedBefore", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedBefore indicates an expected call of ListDeletedBefore.
+func (mr *MockArticleRepositoryMockRecorder) ListDeletedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedBefore", reflect.TypeOf((*MockArticleRepository)(nil).ListDeletedBefore), ctx, before, limit)
+}
+
+// ListInvitations mocks base method.
+func (m *MockArticleRepository) ListInvitations(ctx context.Context, uid int64, offset, limit int) ([]domain.CoAuthor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.CoAuthor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockArticleRepositoryMockRecorder) ListInvitations(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockArticleRepository)(nil).ListInvitations), ctx, uid, offset, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleRepository) ListPub(ctx context.Context, utime time.Time, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, utime, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleRepositoryMockRecorder) ListPub(ctx, utime, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleRepository)(nil).ListPub), ctx, utime, offset, limit)
+}
+
+// ListPubByCursor mocks base method.
+func (m *MockArticleRepository) ListPubByCursor(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCursor", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByCursor indicates an expected call of ListPubByCursor.
+func (mr *MockArticleRepositoryMockRecorder) ListPubByCursor(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCursor", reflect.TypeOf((*MockArticleRepository)(nil).ListPubByCursor), ctx, cursor, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleRepository) ListRevisions(ctx context.Context, artId int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleRepositoryMockRecorder) ListRevisions(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleRepository)(nil).ListRevisions), ctx, artId, offset, limit)
+}
+
+// ListScheduled mocks base method.
+func (m *MockArticleRepository) ListScheduled(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduled", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduled indicates an expected call of ListScheduled.
+func (mr *MockArticleRepositoryMockRecorder) ListScheduled(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduled", reflect.TypeOf((*MockArticleRepository)(nil).ListScheduled), ctx, before, limit)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleRepository) ListSeries(ctx context.Context, author int64, offset, limit int) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", ctx, author, offset, limit)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleRepositoryMockRecorder) ListSeries(ctx, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleRepository)(nil).ListSeries), ctx, author, offset, limit)
+}
+
+// ListUnderReview mocks base method.
+func (m *MockArticleRepository) ListUnderReview(ctx context.Context, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnderReview", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnderReview indicates an expected call of ListUnderReview.
+func (mr *MockArticleRepositoryMockRecorder) ListUnderReview(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnderReview", reflect.TypeOf((*MockArticleRepository)(nil).ListUnderReview), ctx, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleRepository) Purge(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleRepository)(nil).Purge), ctx, id)
+}
+
+// RejectReview mocks base method.
+func (m *MockArticleRepository) RejectReview(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockArticleRepositoryMockRecorder) RejectReview(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockArticleRepository)(nil).RejectReview), ctx, uid, id)
+}
+
+// RemoveCoAuthor mocks base method.
+func (m *MockArticleRepository) RemoveCoAuthor(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCoAuthor", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCoAuthor indicates an expected call of RemoveCoAuthor.
+func (mr *MockArticleRepositoryMockRecorder) RemoveCoAuthor(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoAuthor", reflect.TypeOf((*MockArticleRepository)(nil).RemoveCoAuthor), ctx, artId, uid)
+}
+
+// Reschedule mocks base method.
+func (m *MockArticleRepository) Reschedule(ctx context.Context, uid, id int64, publishAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, uid, id, publishAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockArticleRepositoryMockRecorder) Reschedule(ctx, uid, id, publishAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockArticleRepository)(nil).Reschedule), ctx, uid, id, publishAt)
+}
+
+// RestoreDeleted mocks base method.
+func (m *MockArticleRepository) RestoreDeleted(ctx context.Context, uid, id int64, after time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeleted", ctx, uid, id, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDeleted indicates an expected call of RestoreDeleted.
+func (mr *MockArticleRepositoryMockRecorder) RestoreDeleted(ctx, uid, id, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeleted", reflect.TypeOf((*MockArticleRepository)(nil).RestoreDeleted), ctx, uid, id, after)
+}
+
+// SetSeriesArticles mocks base method.
+func (m *MockArticleRepository) SetSeriesArticles(ctx context.Context, uid, seriesId int64, artIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSeriesArticles", ctx, uid, seriesId, artIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSeriesArticles indicates an expected call of SetSeriesArticles.
+func (mr *MockArticleRepositoryMockRecorder) SetSeriesArticles(ctx, uid, seriesId, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSeriesArticles", reflect.TypeOf((*MockArticleRepository)(nil).SetSeriesArticles), ctx, uid, seriesId, artIds)
+}
+
+// Sync mocks base method.
+func (m *MockArticleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockArticleRepositoryMockRecorder) Sync(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleRepository)(nil).Sync), ctx, art)
+}
+
+// SyncStatus mocks base method.
+func (m *MockArticleRepository) SyncStatus(ctx context.Context, uid, id int64, status domain.ArticleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, uid, id, status)
+}
+
+// Update mocks base method.
+func (m *MockArticleRepository) Update(ctx context.Context, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArticleRepositoryMockRecorder) Update(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleRepository)(nil).Update), ctx, art)
+}
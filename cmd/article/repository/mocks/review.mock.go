@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./review.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./review.go -package=repomocks -destination=mocks/review.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/XD/ScholarNet/cmd/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddLog mocks base method.
+func (m *MockReviewRepository) AddLog(ctx context.Context, log domain.ReviewLog) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLog", ctx, log)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLog indicates an expected call of AddLog.
+func (mr *MockReviewRepositoryMockRecorder) AddLog(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLog", reflect.TypeOf((*MockReviewRepository)(nil).AddLog), ctx, log)
+}
+
+// IsReviewer mocks base method.
+func (m *MockReviewRepository) IsReviewer(ctx context.Context, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReviewer", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReviewer indicates an expected call of IsReviewer.
+func (mr *MockReviewRepositoryMockRecorder) IsReviewer(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReviewer", reflect.TypeOf((*MockReviewRepository)(nil).IsReviewer), ctx, uid)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+func (repo *CachedArticleRepository) Delete(ctx context.Context, uid, id int64) error {
+	err := repo.dao.SoftDelete(ctx, uid, id, time.Now().UnixMilli())
+	if err != nil {
+		return err
+	}
+	repo.evict(ctx, uid, id)
+	repo.evictSeriesNavs(ctx, id)
+	return nil
+}
+
+func (repo *CachedArticleRepository) RestoreDeleted(ctx context.Context, uid, id int64, after time.Time) error {
+	err := repo.dao.RestoreDeleted(ctx, uid, id, after.UnixMilli())
+	if err != nil {
+		return err
+	}
+	repo.evict(ctx, uid, id)
+	repo.evictSeriesNavs(ctx, id)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListDeleted(ctx context.Context, uid int64,
+	offset, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListDeleted(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts,
+		func(idx int, src dao.Article) domain.Article {
+			return repo.ToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) ListDeletedBefore(ctx context.Context,
+	before time.Time, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListDeletedBefore(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts,
+		func(idx int, src dao.Article) domain.Article {
+			return repo.ToDomain(src)
+		}), nil
+}
+
+func (repo *CachedArticleRepository) Purge(ctx context.Context, id int64) error {
+	return repo.dao.HardDelete(ctx, id)
+}
+
+// evict 删除和恢复都会改变帖子能不能被看到，作者、共同作者和读者的缓存都要删掉
+func (repo *CachedArticleRepository) evict(ctx context.Context, uid, id int64) {
+	if err := repo.cache.Del(ctx, id); err != nil {
+		repo.l.Error("删除帖子缓存失败", logger.Int64("aid", id), logger.Error(err))
+	}
+	if err := repo.cache.DelPub(ctx, id); err != nil {
+		repo.l.Error("删除线上帖子缓存失败", logger.Int64("aid", id), logger.Error(err))
+	}
+	repo.delFirstPage(ctx, uid)
+	coAuthors, err := repo.dao.ListCoAuthors(ctx, id)
+	if err != nil {
+		repo.l.Error("查询共同作者失败", logger.Int64("aid", id), logger.Error(err))
+		return
+	}
+	for _, c := range coAuthors {
+		if c.Status == dao.CoAuthorStatusAccepted {
+			repo.delFirstPage(ctx, c.UserId)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+)
+
+//go:generate mockgen -source=./review.go -package=repomocks -destination=mocks/review.mock.go ReviewRepository
+
+// ReviewRepository 审核人员和人工审核的记录
+type ReviewRepository interface {
+	IsReviewer(ctx context.Context, uid int64) (bool, error)
+	// AddLog 返回记录的 ID
+	AddLog(ctx context.Context, log domain.ReviewLog) (int64, error)
+}
+
+type DBReviewRepository struct {
+	dao dao.ReviewDAO
+}
+
+func NewReviewRepository(d dao.ReviewDAO) ReviewRepository {
+	return &DBReviewRepository{dao: d}
+}
+
+func (repo *DBReviewRepository) IsReviewer(ctx context.Context, uid int64) (bool, error) {
+	return repo.dao.IsReviewer(ctx, uid)
+}
+
+func (repo *DBReviewRepository) AddLog(ctx context.Context, log domain.ReviewLog) (int64, error) {
+	return repo.dao.InsertLog(ctx, dao.ArticleReviewLog{
+		ArticleId: log.ArticleId,
+		Version:   log.Version,
+		Reviewer:  log.Reviewer,
+		Approved:  log.Approved,
+	})
+}
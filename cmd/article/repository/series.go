@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+)
+
+func (repo *CachedArticleRepository) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	return repo.dao.InsertSeries(ctx, dao.Series{
+		Title:       s.Title,
+		Description: s.Description,
+		AuthorId:    s.Author.Id,
+	})
+}
+
+func (repo *CachedArticleRepository) SetSeriesArticles(ctx context.Context,
+	uid, seriesId int64, artIds []int64) error {
+	// 移出去的帖子的导航也要删，所以先把原来的查出来
+	members, err := repo.dao.ListSeriesArticles(ctx, seriesId)
+	if err != nil {
+		return err
+	}
+	if err = repo.dao.SetSeriesArticles(ctx, uid, seriesId, artIds); err != nil {
+		return err
+	}
+	ids := slice.Map(members, func(idx int, src dao.SeriesArticle) int64 {
+		return src.ArticleId
+	})
+	repo.delSeriesNavs(ctx, append(ids, artIds...)...)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListSeries(ctx context.Context,
+	author int64, offset, limit int) ([]domain.Series, error) {
+	series, err := repo.dao.ListSeriesByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Series, len(series))
+	var eg errgroup.Group
+	for i, s := range series {
+		eg.Go(func() error {
+			members, er := repo.dao.ListSeriesArticles(ctx, s.Id)
+			if er != nil {
+				return er
+			}
+			res[i] = repo.seriesToDomain(s, members)
+			return nil
+		})
+	}
+	return res, eg.Wait()
+}
+
+func (repo *CachedArticleRepository) GetSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error) {
+	nav, err := repo.cache.GetSeriesNav(ctx, artId)
+	if err == nil {
+		return nav, nil
+	}
+	if err != cache.ErrKeyNotExist {
+		repo.l.Error("查询系列导航缓存失败",
+			logger.Error(err), logger.Int64("aid", artId))
+	}
+	nav, err = repo.loadSeriesNav(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	// 不在系列里面的也缓存，大部分帖子都不在系列里面
+	if err = repo.cache.SetSeriesNav(ctx, artId, nav); err != nil {
+		repo.l.Error("缓存系列导航失败",
+			logger.Error(err), logger.Int64("aid", artId))
+	}
+	return nav, nil
+}
+
+// evictSeriesNavs 帖子发表、撤回、删除之后，同一个系列里面相邻帖子的导航都变了
+func (repo *CachedArticleRepository) evictSeriesNavs(ctx context.Context, artId int64) {
+	member, err := repo.dao.GetSeriesArticle(ctx, artId)
+	if errors.Is(err, dao.ErrDataNotFound) {
+		return
+	}
+	if err != nil {
+		repo.l.Error("查询帖子所在的系列失败",
+			logger.Error(err), logger.Int64("aid", artId))
+		return
+	}
+	members, err := repo.dao.ListSeriesArticles(ctx, member.SeriesId)
+	if err != nil {
+		repo.l.Error("查询系列里面的帖子失败",
+			logger.Error(err), logger.Int64("seriesId", member.SeriesId))
+		return
+	}
+	repo.delSeriesNavs(ctx, slice.Map(members, func(idx int, src dao.SeriesArticle) int64 {
+		return src.ArticleId
+	})...)
+}
+
+func (repo *CachedArticleRepository) delSeriesNavs(ctx context.Context, artIds ...int64) {
+	if err := repo.cache.DelSeriesNavs(ctx, artIds...); err != nil {
+		repo.l.Error("删除系列导航缓存失败", logger.Error(err))
+	}
+}
+
+func (repo *CachedArticleRepository) loadSeriesNav(ctx context.Context, artId int64) (*domain.SeriesNav, error) {
+	member, err := repo.dao.GetSeriesArticle(ctx, artId)
+	if errors.Is(err, dao.ErrDataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		eg      errgroup.Group
+		series  dao.Series
+		members []dao.SeriesArticle
+	)
+	eg.Go(func() error {
+		var er error
+		series, er = repo.dao.GetSeriesById(ctx, member.SeriesId)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		members, er = repo.dao.ListSeriesArticles(ctx, member.SeriesId)
+		return er
+	})
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+	ids := slice.Map(members, func(idx int, src dao.SeriesArticle) int64 {
+		return src.ArticleId
+	})
+	pubs, err := repo.dao.ListPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	// 只有已发表的帖子才出现在导航里面，撤回了的就跳过
+	published := make(map[int64]string, len(pubs))
+	for _, pub := range pubs {
+		if domain.ArticleStatus(pub.Status) == domain.ArticleStatusPublished {
+			published[pub.Id] = pub.Title
+		}
+	}
+	nav := &domain.SeriesNav{
+		SeriesId:    series.Id,
+		SeriesTitle: series.Title,
+	}
+	var prev domain.SeriesItem
+	found := false
+	for _, id := range ids {
+		title, ok := published[id]
+		if !ok && id != artId {
+			continue
+		}
+		if found {
+			nav.Next = domain.SeriesItem{Id: id, Title: title}
+			break
+		}
+		if id == artId {
+			found = true
+			nav.Prev = prev
+			continue
+		}
+		prev = domain.SeriesItem{Id: id, Title: title}
+	}
+	return nav, nil
+}
+
+func (repo *CachedArticleRepository) seriesToDomain(s dao.Series, members []dao.SeriesArticle) domain.Series {
+	return domain.Series{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		Author: domain.Author{
+			Id: s.AuthorId,
+		},
+		ArticleIds: slice.Map(members, func(idx int, src dao.SeriesArticle) int64 {
+			return src.ArticleId
+		}),
+		Ctime: time.UnixMilli(s.Ctime),
+		Utime: time.UnixMilli(s.Utime),
+	}
+}
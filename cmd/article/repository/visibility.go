@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+// VisibilityRepository 帖子的可见范围，不管 db.type 是什么都放在 MySQL 里面
+// 读者每看一次帖子都要查，所以可见范围本身缓存在 Redis 里面，白名单不缓存
+type VisibilityRepository interface {
+	// Get 没有设置过的帖子就是公开的
+	Get(ctx context.Context, artId int64) (domain.Visibility, error)
+	// BatchGet 只返回不公开的帖子，不在结果里面的就是公开的
+	BatchGet(ctx context.Context, artIds []int64) (map[int64]domain.Visibility, error)
+	// Set 整体替换，allowList 只有 VisibilityAllowList 的时候才有用
+	Set(ctx context.Context, artId int64, v domain.Visibility, allowList []int64) error
+	ListAudience(ctx context.Context, artId int64) ([]int64, error)
+	// FindAudience uids 里面哪些人在白名单里面
+	FindAudience(ctx context.Context, artId int64, uids []int64) ([]int64, error)
+	Delete(ctx context.Context, artId int64) error
+}
+
+type CachedVisibilityRepository struct {
+	dao   dao.VisibilityDAO
+	cache cache.ArticleCache
+	l     logger.LoggerV1
+}
+
+func NewVisibilityRepository(d dao.VisibilityDAO, c cache.ArticleCache, l logger.LoggerV1) VisibilityRepository {
+	return &CachedVisibilityRepository{dao: d, cache: c, l: l}
+}
+
+func (repo *CachedVisibilityRepository) Get(ctx context.Context, artId int64) (domain.Visibility, error) {
+	vs, err := repo.BatchGet(ctx, []int64{artId})
+	// 不在结果里面的就是公开的，零值刚好是 VisibilityPublic
+	return vs[artId], err
+}
+
+func (repo *CachedVisibilityRepository) BatchGet(ctx context.Context,
+	artIds []int64) (map[int64]domain.Visibility, error) {
+	cached, err := repo.cache.GetVisibilities(ctx, artIds)
+	if err != nil {
+		// 缓存坏了就全部查数据库
+		repo.l.Error("查询可见范围缓存失败", logger.Error(err))
+		cached = map[int64]domain.Visibility{}
+	}
+	res := make(map[int64]domain.Visibility, len(artIds))
+	misses := make([]int64, 0, len(artIds))
+	for _, id := range artIds {
+		v, ok := cached[id]
+		if !ok {
+			misses = append(misses, id)
+			continue
+		}
+		if v.Restricted() {
+			res[id] = v
+		}
+	}
+	if len(misses) == 0 {
+		return res, nil
+	}
+	vs, err := repo.dao.BatchGet(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	// 公开的也要缓存，不然大部分帖子每次都会查数据库
+	loaded := make(map[int64]domain.Visibility, len(misses))
+	for _, id := range misses {
+		loaded[id] = domain.VisibilityPublic
+	}
+	for _, v := range vs {
+		loaded[v.ArticleId] = domain.Visibility(v.Visibility)
+		res[v.ArticleId] = domain.Visibility(v.Visibility)
+	}
+	if err = repo.cache.SetVisibilities(ctx, loaded); err != nil {
+		repo.l.Error("缓存可见范围失败", logger.Error(err))
+	}
+	return res, nil
+}
+
+func (repo *CachedVisibilityRepository) Set(ctx context.Context, artId int64,
+	v domain.Visibility, allowList []int64) error {
+	if v != domain.VisibilityAllowList {
+		allowList = nil
+	}
+	err := repo.dao.Set(ctx, dao.ArticleVisibility{
+		ArticleId:  artId,
+		Visibility: v.ToUint8(),
+	}, allowList)
+	if err != nil {
+		return err
+	}
+	repo.delCache(ctx, artId)
+	return nil
+}
+
+func (repo *CachedVisibilityRepository) ListAudience(ctx context.Context, artId int64) ([]int64, error) {
+	return repo.dao.ListAudience(ctx, artId)
+}
+
+func (repo *CachedVisibilityRepository) FindAudience(ctx context.Context, artId int64, uids []int64) ([]int64, error) {
+	return repo.dao.FindAudience(ctx, artId, uids)
+}
+
+func (repo *CachedVisibilityRepository) Delete(ctx context.Context, artId int64) error {
+	if err := repo.dao.Delete(ctx, artId); err != nil {
+		return err
+	}
+	repo.delCache(ctx, artId)
+	return nil
+}
+
+// delCache 改了之后要马上生效，删不掉的话最多等缓存过期
+func (repo *CachedVisibilityRepository) delCache(ctx context.Context, artId int64) {
+	if err := repo.cache.DelVisibility(ctx, artId); err != nil {
+		repo.l.Error("删除可见范围缓存失败",
+			logger.Int64("aid", artId), logger.Error(err))
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository/cache"
+	cachemocks "github.com/XD/ScholarNet/cmd/article/repository/cache/mocks"
+	"github.com/XD/ScholarNet/cmd/article/repository/dao"
+	artdaomocks "github.com/XD/ScholarNet/cmd/article/repository/dao/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedVisibilityRepository_BatchGet(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.VisibilityDAO, cache.ArticleCache)
+		ids  []int64
+
+		want    map[int64]domain.Visibility
+		wantErr error
+	}{
+		{
+			name: "全部命中，公开的不在结果里面",
+			mock: func(ctrl *gomock.Controller) (dao.VisibilityDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockVisibilityDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetVisibilities(gomock.Any(), []int64{1, 2}).
+					Return(map[int64]domain.Visibility{
+						1: domain.VisibilityPublic,
+						2: domain.VisibilityFollowers,
+					}, nil)
+				return d, c
+			},
+			ids:  []int64{1, 2},
+			want: map[int64]domain.Visibility{2: domain.VisibilityFollowers},
+		},
+		{
+			name: "没有命中的查数据库，公开的也缓存",
+			mock: func(ctrl *gomock.Controller) (dao.VisibilityDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockVisibilityDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetVisibilities(gomock.Any(), []int64{1, 2, 3}).
+					Return(map[int64]domain.Visibility{1: domain.VisibilityMutual}, nil)
+				d.EXPECT().BatchGet(gomock.Any(), []int64{2, 3}).
+					Return([]dao.ArticleVisibility{
+						{ArticleId: 3, Visibility: domain.VisibilityAllowList.ToUint8()},
+					}, nil)
+				c.EXPECT().SetVisibilities(gomock.Any(), map[int64]domain.Visibility{
+					2: domain.VisibilityPublic,
+					3: domain.VisibilityAllowList,
+				}).Return(nil)
+				return d, c
+			},
+			ids: []int64{1, 2, 3},
+			want: map[int64]domain.Visibility{
+				1: domain.VisibilityMutual,
+				3: domain.VisibilityAllowList,
+			},
+		},
+		{
+			name: "缓存出错了全部查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.VisibilityDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockVisibilityDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetVisibilities(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("mock redis error"))
+				d.EXPECT().BatchGet(gomock.Any(), []int64{1}).Return(nil, nil)
+				c.EXPECT().SetVisibilities(gomock.Any(), map[int64]domain.Visibility{
+					1: domain.VisibilityPublic,
+				}).Return(nil)
+				return d, c
+			},
+			ids:  []int64{1},
+			want: map[int64]domain.Visibility{},
+		},
+		{
+			name: "查询数据库失败",
+			mock: func(ctrl *gomock.Controller) (dao.VisibilityDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockVisibilityDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetVisibilities(gomock.Any(), []int64{1}).
+					Return(map[int64]domain.Visibility{}, nil)
+				d.EXPECT().BatchGet(gomock.Any(), []int64{1}).Return(nil, errors.New("mock db error"))
+				return d, c
+			},
+			ids:     []int64{1},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewVisibilityRepository(d, c, logger.NewNoOpLogger())
+			res, err := repo.BatchGet(context.Background(), tc.ids)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestCachedVisibilityRepository_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockVisibilityDAO(ctrl)
+	c := cachemocks.NewMockArticleCache(ctrl)
+	d.EXPECT().Set(gomock.Any(), dao.ArticleVisibility{
+		ArticleId:  1,
+		Visibility: domain.VisibilityFollowers.ToUint8(),
+	}, []int64(nil)).Return(nil)
+	// 改了之后马上删掉缓存
+	c.EXPECT().DelVisibility(gomock.Any(), int64(1)).Return(nil)
+
+	repo := NewVisibilityRepository(d, c, logger.NewNoOpLogger())
+	err := repo.Set(context.Background(), 1, domain.VisibilityFollowers, []int64{2})
+	assert.NoError(t, err)
+}
@@ -2,23 +2,69 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	searchv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/search/v1"
 	"github.com/XD/ScholarNet/cmd/article/domain"
 	"github.com/XD/ScholarNet/cmd/article/events"
 	"github.com/XD/ScholarNet/cmd/article/repository"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/moderation"
+	"github.com/pmezard/go-difflib/difflib"
 	"golang.org/x/sync/errgroup"
 	"time"
 )
 
+var (
+	ErrPossibleIncorrectAuthor = repository.ErrPossibleIncorrectAuthor
+	ErrArticleNotScheduled     = repository.ErrArticleNotScheduled
+	ErrVersionConflict         = repository.ErrVersionConflict
+	ErrInvalidPublishAt        = errors.New("定时发表的时间必须晚于当前时间")
+	ErrMissingVersion          = repository.ErrMissingVersion
+	ErrArticleInOtherSeries    = repository.ErrArticleInOtherSeries
+	ErrInvalidSeries           = errors.New("系列的标题不能为空，帖子不能重复")
+	ErrDuplicateCoAuthor       = repository.ErrDuplicateCoAuthor
+	ErrInvalidCoAuthor         = errors.New("不能邀请自己作为共同作者")
+	ErrInvitationNotFound      = errors.New("没有待处理的邀请")
+	ErrContentRejected         = errors.New("帖子内容违规")
+	ErrArticleNotUnderReview   = repository.ErrArticleNotUnderReview
+	ErrNotReviewer             = errors.New("只有审核人员可以处理人工审核")
+	ErrArticleDeleted          = errors.New("帖子已经删除了")
+	ErrNotInRecycleBin         = errors.New("回收站里面没有这篇帖子，或者已经超过了保留期")
+)
+
+// defaultRecycleRetention 删除的帖子在回收站里面保留的时间
+const defaultRecycleRetention = time.Hour * 24 * 30
+
+// VersionConflictError 保存草稿的时候版本号不对，Current 是服务端现在的版本号
+type VersionConflictError = repository.VersionConflictError
+
 //go:generate mockgen -source=./type.go -package=svcmocks -destination=mocks/article.mock.go ArticleService
 type ArticleService interface {
-	Save(ctx context.Context, art domain.Article) (int64, error)
-	Publish(ctx context.Context, art domain.Article) (int64, error)
+	// Save 保存草稿，返回帖子 ID 和保存之后的版本号
+	// 修改已有的帖子必须带上最后看到的版本号，如果期间被别人修改过，返回 VersionConflictError
+	// art.Author 是当前用户，创建者和已经接受邀请的共同作者都可以修改
+	Save(ctx context.Context, art domain.Article) (int64, int64, error)
+	// Publish 如果 art.PublishAt 晚于当前时间，那么就是定时发表
+	// 和 Save 一样，共同作者也可以发表
+	// 发表之前会先审核内容，需要人工审核的帖子进入待审核状态，
+	// 已经发表过的帖子，线上还是之前的版本；直接拒绝的返回 ErrContentRejected
+	// 版本号的要求和 Save 一样，返回帖子 ID 和发表之后的版本号
+	Publish(ctx context.Context, art domain.Article) (int64, int64, error)
 	Withdraw(ctx context.Context, uid, id int64) error
+	// CancelSchedule 取消定时发表，帖子回到未发表状态
+	CancelSchedule(ctx context.Context, uid, id int64) error
+	// Reschedule 修改定时发表的时间
+	Reschedule(ctx context.Context, uid, id int64, publishAt time.Time) error
+	// PublishScheduled 发表所有到期的定时帖子，给定时任务用
+	PublishScheduled(ctx context.Context, now time.Time) error
 	PublishV1(ctx context.Context, art domain.Article) (int64, error)
 	List(ctx context.Context, author int64,
 		offset, limit int) ([]domain.Article, error)
+	// ListByCursor 按照 (utime, id) 倒序用游标翻页，返回下一页的游标
+	// 没有下一页的时候，返回的游标是 FirstPage
+	ListByCursor(ctx context.Context, author int64,
+		cursor domain.Cursor, limit int) ([]domain.Article, domain.Cursor, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 
 	// 剩下的这个是给读者用的服务，暂时放到这里
@@ -26,9 +72,70 @@ type ArticleService interface {
 	// GetPublishedById 查找已经发表的
 	// 正常来说在微服务架构下，读者服务和创作者服务会是两个独立的服务
 	// 单体应用下可以混在一起，毕竟现在也没几个方法
-	GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error)
+	// uid 看不到这篇帖子的时候返回 ErrArticleNotVisible
+	// deviceId 只是跟着阅读事件发出去，没有登录的时候拿来统计独立读者，可以是空的
+	GetPublishedById(ctx context.Context, id, uid int64, deviceId string) (domain.Article, error)
+	// BatchGetPublished 批量查询已发表的帖子，给热榜、feed 这种内部调用方用
+	// 返回的帖子和 ids 的顺序一致，重复的 id 只返回一次
+	// 不存在、已经撤回或者已经删除的放在 missing 里面
+	// 不会发送阅读事件，也不会带上作者的名字和系列导航
+	// 会带上可见范围但是不会按照可见范围过滤，调用方要自己用 VisibilityService.FilterAudience 检查
+	BatchGetPublished(ctx context.Context, ids []int64) (arts []domain.Article, missing []int64, err error)
+	// BatchGetPublishedForReader 和 BatchGetPublished 一样，但是是给读者看的
+	// 会带上所有作者的名字，uid 看不到的帖子也放在 missing 里面，同样不会发送阅读事件
+	BatchGetPublishedForReader(ctx context.Context, uid int64, ids []int64) (arts []domain.Article, missing []int64, err error)
 	// ListPub 根据更新时间来分页，更新时间必须小于 startTime
-	ListPub(ctx context.Context, startTime time.Time, offset, limit int) ([]domain.Article, error)
+	// uid 是看帖子的人，没有登录就是 0，uid 看不到的帖子会被过滤掉，所以一页可能不满 limit
+	ListPub(ctx context.Context, uid int64, startTime time.Time, offset, limit int) ([]domain.Article, error)
+	// ListPubByCursor 和 ListByCursor 一样，offset 翻得深了会很慢，批量扫描的时候应该用这个
+	// 和 ListPub 一样会按照 uid 过滤，返回的游标不受过滤的影响
+	ListPubByCursor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, domain.Cursor, error)
+
+	// ListRevisions 列出帖子的历史版本，只有作者和共同作者可以看
+	ListRevisions(ctx context.Context, uid, artId int64, offset, limit int) ([]domain.ArticleRevision, error)
+	// DiffRevisions 比较两个历史版本的内容，返回 unified diff 格式
+	DiffRevisions(ctx context.Context, uid, artId, from, to int64) (string, error)
+	// RestoreRevision 将草稿恢复到某个历史版本
+	// 恢复本身也是一次保存，所以会生成一个新的历史版本
+	// version 是客户端最后看到的版本号，期间被别人修改过的话返回 VersionConflictError
+	// 返回恢复之后的版本号
+	RestoreRevision(ctx context.Context, uid, artId, revisionId, version int64) (int64, error)
+
+	// CreateSeries 创建系列，同时可以带上初始的帖子
+	CreateSeries(ctx context.Context, s domain.Series) (int64, error)
+	// ReorderSeries 调整系列里面的帖子，artIds 就是调整之后的帖子和顺序
+	// 不在 artIds 里面的帖子会被移出系列
+	ReorderSeries(ctx context.Context, uid, seriesId int64, artIds []int64) error
+	ListSeries(ctx context.Context, author int64, offset, limit int) ([]domain.Series, error)
+
+	// InviteCoAuthor 邀请共同作者，只有创建帖子的人可以邀请
+	InviteCoAuthor(ctx context.Context, uid, artId, invitee int64) error
+	// RespondInvitation 接受或者拒绝邀请，拒绝之后邀请就被删除了，可以重新邀请
+	RespondInvitation(ctx context.Context, uid, artId int64, accept bool) error
+	// RemoveCoAuthor 创建者可以移除共同作者，共同作者也可以自己退出
+	RemoveCoAuthor(ctx context.Context, uid, artId, coAuthor int64) error
+	// ListCoAuthors 帖子的所有共同作者，包括还没有接受邀请的，只有作者们能看
+	ListCoAuthors(ctx context.Context, uid, artId int64) ([]domain.CoAuthor, error)
+	// ListInvitations 用户收到的还没有处理的邀请
+	ListInvitations(ctx context.Context, uid int64, offset, limit int) ([]domain.CoAuthor, error)
+
+	// ListUnderReview 等待人工审核的帖子，reviewer 不是审核人员的话返回 ErrNotReviewer
+	ListUnderReview(ctx context.Context, reviewer int64, offset, limit int) ([]domain.Article, error)
+	// Review 人工审核，通过就发表，不通过就回到未发表状态
+	// 只有审核人员可以处理，每一次处理都会记录是谁做的
+	Review(ctx context.Context, reviewer, id int64, approved bool) error
+
+	// Delete 把帖子放进回收站，读者和作者的列表里面都看不到了，只有创建者可以删除
+	Delete(ctx context.Context, uid, id int64) error
+	// RestoreDeleted 从回收站恢复，恢复之后和删除之前一样
+	// 已经超过保留期的返回 ErrNotInRecycleBin
+	RestoreDeleted(ctx context.Context, uid, id int64) error
+	// ListDeleted 回收站，按照删除时间倒序
+	ListDeleted(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	// ListExpiredDeleted 超过保留期的帖子，给清理的定时任务用
+	ListExpiredDeleted(ctx context.Context, now time.Time, limit int) ([]domain.Article, error)
+	// Purge 彻底删除回收站里面的帖子
+	Purge(ctx context.Context, id int64) error
 }
 
 type articleService struct {
@@ -46,12 +153,82 @@ type articleService struct {
 
 	// 搞个异步的
 	producer events.Producer
+
+	// 发表之前的内容审核
+	moderator moderation.Moderator
+
+	// retention 删除的帖子在回收站里面保留的时间
+	retention time.Duration
+
+	// 发表之后从正文里面提取引用
+	citations CitationService
+
+	// 读者端按照可见范围过滤
+	visibility VisibilityService
+
+	// 审核人员和人工审核的记录
+	reviews repository.ReviewRepository
 }
 
-func (svc *articleService) ListPub(ctx context.Context,
+func (svc *articleService) ListPub(ctx context.Context, uid int64,
 	startTime time.Time,
 	offset, limit int) ([]domain.Article, error) {
-	return svc.repo.ListPub(ctx, startTime, offset, limit)
+	arts, err := svc.repo.ListPub(ctx, startTime, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return svc.visible(ctx, uid, arts)
+}
+
+func (svc *articleService) ListPubByCursor(ctx context.Context, uid int64,
+	cursor domain.Cursor, limit int) ([]domain.Article, domain.Cursor, error) {
+	for {
+		arts, err := svc.repo.ListPubByCursor(ctx, cursor, limit)
+		if err != nil {
+			return nil, domain.FirstPage, err
+		}
+		// 游标要按照过滤之前的最后一篇来算
+		next := nextCursor(arts, limit)
+		arts, err = svc.visible(ctx, uid, arts)
+		if err != nil || len(arts) > 0 || next.IsFirstPage() {
+			return arts, next, err
+		}
+		// 这一页全都看不了，接着翻，不然调用方拿到空的一页会以为已经没有了
+		cursor = next
+	}
+}
+
+// visible 只保留 uid 能看的帖子
+func (svc *articleService) visible(ctx context.Context, uid int64,
+	arts []domain.Article) ([]domain.Article, error) {
+	if err := svc.visibility.Fill(ctx, arts); err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		ok, err := svc.visibility.CanView(ctx, art, uid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func (svc *articleService) ListByCursor(ctx context.Context, author int64,
+	cursor domain.Cursor, limit int) ([]domain.Article, domain.Cursor, error) {
+	arts, err := svc.repo.ListByCursor(ctx, author, cursor, limit)
+	return arts, nextCursor(arts, limit), err
+}
+
+// nextCursor 不满一页说明已经没有了
+func nextCursor(arts []domain.Article, limit int) domain.Cursor {
+	if len(arts) == 0 || len(arts) < limit {
+		return domain.FirstPage
+	}
+	return domain.CursorAfter(arts[len(arts)-1])
 }
 
 func (svc *articleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
@@ -67,12 +244,21 @@ func NewArticleService(repo repository.ArticleRepository,
 	authorRepo repository.AuthorRepository,
 	l logger.LoggerV1,
 	producer events.Producer,
+	moderator moderation.Moderator,
+	citations CitationService,
+	visibility VisibilityService,
+	reviews repository.ReviewRepository,
 ) ArticleService {
 	return &articleService{
-		repo:     repo,
-		logger:   l,
-		userRepo: authorRepo,
-		producer: producer,
+		repo:       repo,
+		logger:     l,
+		userRepo:   authorRepo,
+		producer:   producer,
+		moderator:  moderator,
+		retention:  defaultRecycleRetention,
+		citations:  citations,
+		visibility: visibility,
+		reviews:    reviews,
 	}
 }
 
@@ -89,10 +275,70 @@ func NewArticleServiceV1(
 	}
 }
 
-func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error) {
+func (svc *articleService) BatchGetPublished(ctx context.Context,
+	ids []int64) ([]domain.Article, []int64, error) {
+	found, err := svc.repo.BatchGetPublished(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	arts := make([]domain.Article, 0, len(found))
+	var missing []int64
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		art, ok := found[id]
+		// 线上库里面撤回了的帖子也还在，只是状态变了
+		if !ok || !art.Published() {
+			missing = append(missing, id)
+			continue
+		}
+		arts = append(arts, art)
+	}
+	if err = svc.visibility.Fill(ctx, arts); err != nil {
+		return nil, nil, err
+	}
+	return arts, missing, nil
+}
+
+func (svc *articleService) BatchGetPublishedForReader(ctx context.Context,
+	uid int64, ids []int64) ([]domain.Article, []int64, error) {
+	arts, missing, err := svc.BatchGetPublished(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 共同作者也能看受限的帖子，所以要先填上作者再检查
+	authors, err := svc.userRepo.BatchFindAuthors(ctx, arts)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		art.Authors = authors[art.Id]
+		if len(art.Authors) > 0 {
+			art.Author = art.Authors[0]
+		}
+		ok, er := svc.visibility.CanView(ctx, art, uid)
+		if er != nil {
+			return nil, nil, er
+		}
+		if !ok {
+			missing = append(missing, art.Id)
+			continue
+		}
+		res = append(res, art)
+	}
+	return res, missing, nil
+}
+
+func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64, deviceId string) (domain.Article, error) {
 	var eg errgroup.Group
 	var art *domain.Article
-	var author *domain.Author
+	var authors []domain.Author
+	var nav *domain.SeriesNav
+	var visibility domain.Visibility
 	var err error
 	eg.Go(func() error {
 		res, eerr := svc.repo.GetPublishedById(ctx, id)
@@ -100,20 +346,51 @@ func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64)
 		return eerr
 	})
 	eg.Go(func() error {
-		res, eerr := svc.userRepo.FindAuthor(ctx, id)
-		author = &res
+		var eerr error
+		nav, eerr = svc.repo.GetSeriesNav(ctx, id)
+		if eerr != nil {
+			// 导航查不到不影响看帖子
+			svc.logger.Error("查询系列导航失败",
+				logger.Int64("aid", id), logger.Error(eerr))
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var eerr error
+		authors, eerr = svc.userRepo.FindAuthors(ctx, id)
+		return eerr
+	})
+	eg.Go(func() error {
+		arts := []domain.Article{{Id: id}}
+		eerr := svc.visibility.Fill(ctx, arts)
+		visibility = arts[0].Visibility
 		return eerr
 	})
 	if err = eg.Wait(); err != nil {
 		return domain.Article{}, err
 	}
-	art.Author = *author
+	// 第一个就是创建帖子的人
+	if len(authors) > 0 {
+		art.Author = authors[0]
+	}
+	art.Authors = authors
+	art.SeriesNav = nav
+	art.Visibility = visibility
 	res := *art
+	ok, err := svc.visibility.CanView(ctx, res, uid)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if !ok {
+		// 看不到的不算阅读
+		return domain.Article{}, ErrArticleNotVisible
+	}
 	go func() {
 		if err == nil {
 			er := svc.producer.ProduceReadEvent(events.ReadEvent{
-				Aid: id,
-				Uid: id,
+				Aid:      id,
+				Uid:      uid,
+				DeviceId: deviceId,
 			})
 			if er != nil {
 				svc.logger.Error("发送消息失败",
@@ -131,9 +408,34 @@ func (svc *articleService) Withdraw(ctx context.Context, uid, id int64) error {
 }
 
 func (svc *articleService) Save(ctx context.Context,
+	art domain.Article) (int64, int64, error) {
+	if art.Id > 0 && art.Version <= 0 {
+		return 0, 0, ErrMissingVersion
+	}
+	art, err := svc.asEditor(ctx, art)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := svc.save(ctx, art)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, nextVersion(art), nil
+}
+
+// nextVersion 新建的帖子版本号是 1，修改成功了版本号加一
+func nextVersion(art domain.Article) int64 {
+	if art.Id == 0 {
+		return 1
+	}
+	return art.Version + 1
+}
+
+func (svc *articleService) save(ctx context.Context,
 	art domain.Article) (int64, error) {
-	// 设置为未发表
+	// 设置为未发表，如果之前是定时发表，那么也就取消了
 	art.Status = domain.ArticleStatusUnpublished
+	art.PublishAt = time.Time{}
 	if art.Id > 0 {
 		err := svc.update(ctx, art)
 		return art.Id, err
@@ -142,9 +444,105 @@ func (svc *articleService) Save(ctx context.Context,
 }
 
 func (svc *articleService) Publish(ctx context.Context,
+	art domain.Article) (int64, int64, error) {
+	if art.Id > 0 && art.Version <= 0 {
+		return 0, 0, ErrMissingVersion
+	}
+	art, err := svc.asEditor(ctx, art)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := svc.publish(ctx, art)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, nextVersion(art), nil
+}
+
+func (svc *articleService) publish(ctx context.Context,
 	art domain.Article) (int64, error) {
+	// 定时发表也在这里审核，到时间之后就不再审核了
+	switch svc.moderate(ctx, art) {
+	case moderation.VerdictReject:
+		return 0, ErrContentRejected
+	case moderation.VerdictReview:
+		return svc.holdForReview(ctx, art)
+	}
+	if art.PublishAt.After(time.Now()) {
+		return svc.schedule(ctx, art)
+	}
 	art.Status = domain.ArticleStatusPublished
-	return svc.repo.Sync(ctx, art)
+	art.PublishAt = time.Time{}
+	return svc.sync(ctx, art)
+}
+
+// sync 发表到线上库，然后更新从正文里面提取的引用
+// 引用更新失败不影响发表，下一次发表的时候会重新提取
+func (svc *articleService) sync(ctx context.Context,
+	art domain.Article) (int64, error) {
+	id, err := svc.repo.Sync(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	art.Id = id
+	if er := svc.citations.SyncExtracted(ctx, art); er != nil {
+		svc.logger.Error("提取帖子的引用失败",
+			logger.Int64("aid", id),
+			logger.Error(er))
+	}
+	return id, nil
+}
+
+// schedule 定时发表只保存到制作库，到时间了由定时任务走 Sync 发表
+func (svc *articleService) schedule(ctx context.Context,
+	art domain.Article) (int64, error) {
+	art.Status = domain.ArticleStatusScheduled
+	if art.Id > 0 {
+		err := svc.update(ctx, art)
+		return art.Id, err
+	}
+	return svc.create(ctx, art)
+}
+
+func (svc *articleService) CancelSchedule(ctx context.Context, uid, id int64) error {
+	return svc.repo.CancelSchedule(ctx, uid, id)
+}
+
+func (svc *articleService) Reschedule(ctx context.Context, uid, id int64, publishAt time.Time) error {
+	if !publishAt.After(time.Now()) {
+		return ErrInvalidPublishAt
+	}
+	return svc.repo.Reschedule(ctx, uid, id, publishAt)
+}
+
+func (svc *articleService) PublishScheduled(ctx context.Context, now time.Time) error {
+	const batchSize = 100
+	for {
+		arts, err := svc.repo.ListScheduled(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, art := range arts {
+			art.Status = domain.ArticleStatusPublished
+			art.PublishAt = time.Time{}
+			_, err = svc.sync(ctx, art)
+			if err != nil {
+				// 失败的帖子还是定时发表状态，下一次任务会重试
+				failed++
+				svc.logger.Error("定时发表帖子失败",
+					logger.Int64("aid", art.Id),
+					logger.Error(err))
+			}
+		}
+		if len(arts) < batchSize {
+			return nil
+		}
+		// 整批都失败了，再查也还是这一批，等下一次任务
+		if failed == len(arts) {
+			return err
+		}
+	}
 }
 
 // PublishV1 基于使用两种 repository 的写法
@@ -185,6 +583,125 @@ func (svc *articleService) PublishV1(ctx context.Context,
 	return id, nil
 }
 
+func (svc *articleService) ListRevisions(ctx context.Context,
+	uid, artId int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	if _, err := svc.checkEditor(ctx, uid, artId); err != nil {
+		return nil, err
+	}
+	return svc.repo.ListRevisions(ctx, artId, offset, limit)
+}
+
+func (svc *articleService) DiffRevisions(ctx context.Context,
+	uid, artId, from, to int64) (string, error) {
+	if _, err := svc.checkEditor(ctx, uid, artId); err != nil {
+		return "", err
+	}
+	var eg errgroup.Group
+	var fromRev, toRev domain.ArticleRevision
+	eg.Go(func() error {
+		var err error
+		fromRev, err = svc.repo.GetRevision(ctx, artId, from)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		toRev, err = svc.repo.GetRevision(ctx, artId, to)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+	// 标题也算内容的一部分，放在第一行一起比较
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromRev.Title + "\n" + fromRev.Content),
+		B:        difflib.SplitLines(toRev.Title + "\n" + toRev.Content),
+		FromFile: fmt.Sprintf("revision-%d", from),
+		FromDate: fromRev.Ctime.Format(time.DateTime),
+		ToFile:   fmt.Sprintf("revision-%d", to),
+		ToDate:   toRev.Ctime.Format(time.DateTime),
+		Context:  3,
+	})
+}
+
+func (svc *articleService) RestoreRevision(ctx context.Context,
+	uid, artId, revisionId, version int64) (int64, error) {
+	// 恢复也会覆盖当前的草稿，所以和 Save 一样要检查版本号
+	if version <= 0 {
+		return 0, ErrMissingVersion
+	}
+	rev, err := svc.repo.GetRevision(ctx, artId, revisionId)
+	if err != nil {
+		return 0, err
+	}
+	art, err := svc.asEditor(ctx, domain.Article{
+		Id:      artId,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Author: domain.Author{
+			Id: uid,
+		},
+		Version: version,
+	})
+	if err != nil {
+		return 0, err
+	}
+	_, err = svc.save(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	return nextVersion(art), nil
+}
+
+// asEditor art.Author 是当前用户，如果他是共同作者，
+// 那么把 Author 换成创建帖子的人，他自己作为 Editor
+// 这样 DAO 按照作者校验的逻辑就不需要改
+func (svc *articleService) asEditor(ctx context.Context, art domain.Article) (domain.Article, error) {
+	if art.Id == 0 {
+		return art, nil
+	}
+	owner, err := svc.checkEditor(ctx, art.Author.Id, art.Id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if owner != art.Author.Id {
+		art.Editor = art.Author
+		art.Author = domain.Author{Id: owner}
+	}
+	return art, nil
+}
+
+// checkEditor 创建者和已经接受邀请的共同作者才能编辑和查看历史版本
+// 返回创建帖子的人
+func (svc *articleService) checkEditor(ctx context.Context, uid, artId int64) (int64, error) {
+	return checkEditor(ctx, svc.repo, uid, artId)
+}
+
+func checkEditor(ctx context.Context, repo repository.ArticleRepository, uid, artId int64) (int64, error) {
+	art, err := repo.GetById(ctx, artId)
+	if err != nil {
+		return 0, err
+	}
+	// 回收站里面的帖子要先恢复才能编辑
+	if art.Deleted() {
+		return 0, ErrArticleDeleted
+	}
+	owner := art.Author.Id
+	if owner == uid {
+		return owner, nil
+	}
+	c, err := repo.GetCoAuthor(ctx, artId, uid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return 0, ErrPossibleIncorrectAuthor
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !c.Accepted() {
+		return 0, ErrPossibleIncorrectAuthor
+	}
+	return owner, nil
+}
+
 func (svc *articleService) create(ctx context.Context,
 	art domain.Article) (int64, error) {
 	return svc.repo.Create(ctx, art)
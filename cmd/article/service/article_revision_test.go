@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	repomocks "github.com/XD/ScholarNet/cmd/article/repository/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newRevisionService(repo repository.ArticleRepository) ArticleService {
+	return NewArticleService(repo, nil, logger.NewNoOpLogger(), nil, nil, nil, nil, nil)
+}
+
+func TestArticleService_ListRevisions(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRepository
+		uid  int64
+
+		wantRevs []domain.ArticleRevision
+		wantErr  error
+	}{
+		{
+			name: "作者本人",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().ListRevisions(gomock.Any(), int64(1), 0, 10).
+					Return([]domain.ArticleRevision{{Id: 2, ArticleId: 1, Title: "第二版"}}, nil)
+				return repo
+			},
+			uid:      123,
+			wantRevs: []domain.ArticleRevision{{Id: 2, ArticleId: 1, Title: "第二版"}},
+		},
+		{
+			name: "别人的帖子",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().GetCoAuthor(gomock.Any(), int64(1), int64(456)).
+					Return(domain.CoAuthor{}, repository.ErrDataNotFound)
+				return repo
+			},
+			uid:     456,
+			wantErr: ErrPossibleIncorrectAuthor,
+		},
+		{
+			name: "帖子不存在",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrDataNotFound)
+				return repo
+			},
+			uid:     123,
+			wantErr: repository.ErrDataNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := newRevisionService(tc.mock(ctrl))
+			revs, err := svc.ListRevisions(context.Background(), tc.uid, 1, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRevs, revs)
+		})
+	}
+}
+
+func TestArticleService_DiffRevisions(t *testing.T) {
+	ctime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRepository
+
+		wantDiff string
+		wantErr  error
+	}{
+		{
+			name: "标题和内容一起比较",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, Title: "旧标题", Content: "第一行\n第二行", Ctime: ctime}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(3)).
+					Return(domain.ArticleRevision{Id: 3, Title: "新标题", Content: "第一行\n第二行", Ctime: ctime}, nil)
+				return repo
+			},
+			wantDiff: "--- revision-2\t2024-01-02 03:04:05\n" +
+				"+++ revision-3\t2024-01-02 03:04:05\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				"-旧标题\n" +
+				"+新标题\n" +
+				" 第一行\n" +
+				" 第二行\n",
+		},
+		{
+			name: "历史版本不存在",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2)).
+					Return(domain.ArticleRevision{}, repository.ErrDataNotFound)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(3)).
+					Return(domain.ArticleRevision{Id: 3}, nil)
+				return repo
+			},
+			wantErr: repository.ErrDataNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := newRevisionService(tc.mock(ctrl))
+			diff, err := svc.DiffRevisions(context.Background(), 123, 1, 2, 3)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantDiff, diff)
+		})
+	}
+}
+
+func TestArticleService_RestoreRevision(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.ArticleRepository
+		uid     int64
+		version int64
+
+		wantVersion int64
+		wantErr     error
+	}{
+		{
+			name: "恢复成草稿",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, ArticleId: 1, Title: "旧标题", Content: "旧内容"}, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{
+					Id:      1,
+					Title:   "旧标题",
+					Content: "旧内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+					Version: 3,
+				}).Return(nil)
+				return repo
+			},
+			uid:         123,
+			version:     3,
+			wantVersion: 4,
+		},
+		{
+			name: "没有带版本号",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				return repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:     123,
+			wantErr: ErrMissingVersion,
+		},
+		{
+			name: "期间被别人修改过",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, ArticleId: 1, Title: "旧标题", Content: "旧内容"}, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(VersionConflictError{Current: 5})
+				return repo
+			},
+			uid:     123,
+			version: 3,
+			wantErr: VersionConflictError{Current: 5},
+		},
+		{
+			name: "更新失败",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, ArticleId: 1, Title: "旧标题", Content: "旧内容"}, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				return repo
+			},
+			uid:     123,
+			version: 3,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := newRevisionService(tc.mock(ctrl))
+			version, err := svc.RestoreRevision(context.Background(), tc.uid, 1, 2, tc.version)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVersion, version)
+		})
+	}
+}
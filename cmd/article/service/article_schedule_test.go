@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	repomocks "github.com/XD/ScholarNet/cmd/article/repository/mocks"
+	svcmocks "github.com/XD/ScholarNet/cmd/article/service/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticleService_PublishSchedule(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRepository
+		art  domain.Article
+
+		wantId      int64
+		wantVersion int64
+		wantErr     error
+	}{
+		{
+			name: "新建定时发表",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Article{
+					Title:     "我的标题",
+					Content:   "我的内容",
+					Author:    domain.Author{Id: 123},
+					Status:    domain.ArticleStatusScheduled,
+					PublishAt: publishAt,
+				}).Return(int64(1), nil)
+				return repo
+			},
+			art: domain.Article{
+				Title:     "我的标题",
+				Content:   "我的内容",
+				Author:    domain.Author{Id: 123},
+				PublishAt: publishAt,
+			},
+			wantId:      1,
+			wantVersion: 1,
+		},
+		{
+			name: "修改已有的帖子为定时发表",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{
+					Id:        2,
+					Title:     "我的标题",
+					Content:   "我的内容",
+					Author:    domain.Author{Id: 123},
+					Status:    domain.ArticleStatusScheduled,
+					PublishAt: publishAt,
+					Version:   3,
+				}).Return(nil)
+				return repo
+			},
+			art: domain.Article{
+				Id:        2,
+				Title:     "我的标题",
+				Content:   "我的内容",
+				Author:    domain.Author{Id: 123},
+				PublishAt: publishAt,
+				Version:   3,
+			},
+			wantId:      2,
+			wantVersion: 4,
+		},
+		{
+			name: "已有的帖子没有带版本号",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				return repomocks.NewMockArticleRepository(ctrl)
+			},
+			art: domain.Article{
+				Id:        2,
+				Title:     "我的标题",
+				Author:    domain.Author{Id: 123},
+				PublishAt: publishAt,
+			},
+			wantErr: ErrMissingVersion,
+		},
+		{
+			name: "保存失败",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("mock db error"))
+				return repo
+			},
+			art: domain.Article{
+				Title:     "我的标题",
+				Author:    domain.Author{Id: 123},
+				PublishAt: publishAt,
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// 定时发表不会走 Sync，所以也不会提取引用
+			svc := NewArticleService(tc.mock(ctrl), nil, logger.NewNoOpLogger(), nil, nil, nil, nil, nil)
+			id, version, err := svc.Publish(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+			assert.Equal(t, tc.wantVersion, version)
+		})
+	}
+}
+
+func TestArticleService_Reschedule(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) repository.ArticleRepository
+		publishAt time.Time
+
+		wantErr error
+	}{
+		{
+			name: "修改成功",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Reschedule(gomock.Any(), int64(123), int64(1), gomock.Any()).Return(nil)
+				return repo
+			},
+			publishAt: time.Now().Add(time.Hour),
+		},
+		{
+			name: "时间已经过了",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				return repomocks.NewMockArticleRepository(ctrl)
+			},
+			publishAt: time.Now().Add(-time.Minute),
+			wantErr:   ErrInvalidPublishAt,
+		},
+		{
+			name: "不是定时发表的帖子",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRepository {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Reschedule(gomock.Any(), int64(123), int64(1), gomock.Any()).
+					Return(repository.ErrArticleNotScheduled)
+				return repo
+			},
+			publishAt: time.Now().Add(time.Hour),
+			wantErr:   ErrArticleNotScheduled,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, logger.NewNoOpLogger(), nil, nil, nil, nil, nil)
+			err := svc.Reschedule(context.Background(), 123, 1, tc.publishAt)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleService_PublishScheduled(t *testing.T) {
+	now := time.Now()
+	// batch 构造一批到期的定时发表的帖子，id 从 start 开始
+	batch := func(start, n int64) []domain.Article {
+		res := make([]domain.Article, 0, n)
+		for i := start; i < start+n; i++ {
+			res = append(res, domain.Article{
+				Id:        i,
+				Author:    domain.Author{Id: 123},
+				Status:    domain.ArticleStatusScheduled,
+				PublishAt: now.Add(-time.Minute),
+			})
+		}
+		return res
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService)
+
+		wantErr error
+	}{
+		{
+			name: "不满一批，发表完就结束",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				repo.EXPECT().ListScheduled(gomock.Any(), now, 100).Return(batch(1, 2), nil)
+				for _, art := range batch(1, 2) {
+					art.Status = domain.ArticleStatusPublished
+					art.PublishAt = time.Time{}
+					repo.EXPECT().Sync(gomock.Any(), art).Return(art.Id, nil)
+					citSvc.EXPECT().SyncExtracted(gomock.Any(), art).Return(nil)
+				}
+				return repo, citSvc
+			},
+		},
+		{
+			name: "满一批，接着查下一批",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().ListScheduled(gomock.Any(), now, 100).Return(batch(1, 100), nil),
+					repo.EXPECT().ListScheduled(gomock.Any(), now, 100).Return(batch(101, 1), nil),
+				)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						return art.Id, nil
+					}).Times(101)
+				citSvc.EXPECT().SyncExtracted(gomock.Any(), gomock.Any()).Return(nil).Times(101)
+				return repo, citSvc
+			},
+		},
+		{
+			name: "个别失败了不影响别的",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				repo.EXPECT().ListScheduled(gomock.Any(), now, 100).Return(batch(1, 2), nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						if art.Id == 1 {
+							return 0, errors.New("mock db error")
+						}
+						return art.Id, nil
+					}).Times(2)
+				citSvc.EXPECT().SyncExtracted(gomock.Any(), gomock.Any()).Return(nil)
+				return repo, citSvc
+			},
+		},
+		{
+			name: "整批都失败了，等下一次任务",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				repo.EXPECT().ListScheduled(gomock.Any(), now, 100).Return(batch(1, 100), nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("mock db error")).Times(100)
+				return repo, citSvc
+			},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				repo.EXPECT().ListScheduled(gomock.Any(), now, 100).
+					Return(nil, errors.New("mock db error"))
+				return repo, citSvc
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, citSvc := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, logger.NewNoOpLogger(), nil, nil, citSvc, nil, nil)
+			err := svc.PublishScheduled(context.Background(), now)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -169,7 +169,7 @@ func TestArticleService_PublishV1(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			authorRepo, readerRepo := tc.mock(ctrl)
-			svc := NewArticleServiceV1(authorRepo, readerRepo,
+			svc := NewArticleServiceV1(authorRepo, readerRepo, nil,
 				logger.NewNoOpLogger())
 			id, err := svc.PublishV1(context.Background(), tc.art)
 			assert.Equal(t, tc.wantErr, err)
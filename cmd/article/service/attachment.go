@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+var (
+	ErrInvalidAttachment         = errors.New("附件的文件名或者哈希不合法")
+	ErrUnsupportedAttachmentType = errors.New("不支持的附件类型")
+	ErrAttachmentTooLarge        = errors.New("附件太大了")
+	ErrAttachmentNotUploaded     = errors.New("附件还没有上传或者上传的内容不对")
+	ErrAttachmentNotFound        = repository.ErrAttachmentNotFound
+	ErrAttachmentInOtherArticle  = errors.New("附件已经关联到别的帖子上了")
+)
+
+// attachmentLimits 允许的类型和对应的大小上限
+var attachmentLimits = map[string]int64{
+	"image/png":       10 << 20,
+	"image/jpeg":      10 << 20,
+	"image/gif":       10 << 20,
+	"image/webp":      10 << 20,
+	"application/pdf": 50 << 20,
+}
+
+var sha256HexPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+//go:generate mockgen -source=./attachment.go -package=svcmocks -destination=mocks/attachment.mock.go AttachmentService
+type AttachmentService interface {
+	// PrepareUpload 客户端先算好内容的 SHA-256，拿着哈希、大小和类型来换上传链接
+	// 同样的内容已经有人上传过了，就不需要再上传，UploadTicket.Exists 为 true
+	PrepareUpload(ctx context.Context, att domain.Attachment) (domain.UploadTicket, error)
+	// ConfirmUpload 上传完成之后确认，确认过的附件才能关联到帖子上
+	ConfirmUpload(ctx context.Context, uid, id int64) error
+	// Attach 把附件关联到帖子上，作者和共同作者都可以
+	Attach(ctx context.Context, uid, artId, id int64) error
+	ListAttachments(ctx context.Context, artId int64) ([]domain.Attachment, error)
+	// CollectGarbage 清理上传之后一直没有关联到帖子的附件，以及没有任何附件引用的内容
+	// 给定时任务用
+	CollectGarbage(ctx context.Context, now time.Time) error
+	// DeleteByArticle 帖子彻底删除之前先删掉它的附件，
+	// 对象存储里面的内容等下一次 CollectGarbage 回收
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type attachmentService struct {
+	repo    repository.AttachmentRepository
+	artRepo repository.ArticleRepository
+	l       logger.LoggerV1
+	// ttl 上传之后超过这个时间还没有关联到帖子，就会被清理掉
+	ttl       time.Duration
+	batchSize int
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository,
+	artRepo repository.ArticleRepository,
+	l logger.LoggerV1) AttachmentService {
+	return &attachmentService{
+		repo:      repo,
+		artRepo:   artRepo,
+		l:         l,
+		ttl:       time.Hour * 24,
+		batchSize: 100,
+	}
+}
+
+func (svc *attachmentService) PrepareUpload(ctx context.Context,
+	att domain.Attachment) (domain.UploadTicket, error) {
+	if att.FileName == "" || len(att.FileName) > 255 ||
+		!sha256HexPattern.MatchString(att.Hash) || att.Size <= 0 {
+		return domain.UploadTicket{}, ErrInvalidAttachment
+	}
+	limit, ok := attachmentLimits[att.ContentType]
+	if !ok {
+		return domain.UploadTicket{}, ErrUnsupportedAttachmentType
+	}
+	if att.Size > limit {
+		return domain.UploadTicket{}, ErrAttachmentTooLarge
+	}
+	return svc.repo.PrepareUpload(ctx, att)
+}
+
+func (svc *attachmentService) ConfirmUpload(ctx context.Context, uid, id int64) error {
+	att, err := svc.getOwned(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	uploaded, err := svc.repo.Uploaded(ctx, att.Hash)
+	if err != nil || uploaded {
+		return err
+	}
+	ok, err := svc.repo.ConfirmUpload(ctx, att)
+	if errors.Is(err, repository.ErrObjectNotFound) {
+		return ErrAttachmentNotUploaded
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// 上传的内容和声明的不一样，对象存储那边签名的时候已经校验过了，
+		// 正常来说不会走到这里
+		svc.l.Warn("附件的大小或者类型对不上",
+			logger.Int64("id", id), logger.String("hash", att.Hash))
+		return ErrAttachmentNotUploaded
+	}
+	return nil
+}
+
+func (svc *attachmentService) Attach(ctx context.Context, uid, artId, id int64) error {
+	att, err := svc.getOwned(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	if att.ArticleId == artId {
+		return nil
+	}
+	if att.ArticleId != 0 {
+		return ErrAttachmentInOtherArticle
+	}
+	if _, err = checkEditor(ctx, svc.artRepo, uid, artId); err != nil {
+		return err
+	}
+	uploaded, err := svc.repo.Uploaded(ctx, att.Hash)
+	if err != nil {
+		return err
+	}
+	if !uploaded {
+		return ErrAttachmentNotUploaded
+	}
+	return svc.repo.Link(ctx, uid, id, artId)
+}
+
+func (svc *attachmentService) ListAttachments(ctx context.Context, artId int64) ([]domain.Attachment, error) {
+	return svc.repo.ListByArticle(ctx, artId)
+}
+
+func (svc *attachmentService) CollectGarbage(ctx context.Context, now time.Time) error {
+	before := now.Add(-svc.ttl)
+	for {
+		cnt, err := svc.repo.DeleteUnlinked(ctx, before, svc.batchSize)
+		if err != nil {
+			return err
+		}
+		if cnt < int64(svc.batchSize) {
+			break
+		}
+	}
+	for {
+		cnt, err := svc.repo.DeleteUnreferencedBlobs(ctx, before, svc.batchSize)
+		if err != nil {
+			return err
+		}
+		svc.l.Debug("清理附件内容", logger.Int("cnt", cnt))
+		if cnt < svc.batchSize {
+			return nil
+		}
+	}
+}
+
+func (svc *attachmentService) DeleteByArticle(ctx context.Context, artId int64) error {
+	return svc.repo.DeleteByArticle(ctx, artId)
+}
+
+// getOwned 只有上传的人才能操作自己的附件
+func (svc *attachmentService) getOwned(ctx context.Context, uid, id int64) (domain.Attachment, error) {
+	att, err := svc.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if att.Uid != uid {
+		return domain.Attachment{}, ErrPossibleIncorrectAuthor
+	}
+	return att, nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/events"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+var (
+	ErrInvalidCitation     = errors.New("只能引用别的帖子或者合法的 DOI")
+	ErrTooManyCitations    = errors.New("引用太多了")
+	ErrCitedArticleMissing = errors.New("引用的帖子不存在或者还没有发表")
+)
+
+// maxCitations 一篇帖子最多声明多少个引用
+const maxCitations = 500
+
+//go:generate mockgen -source=./citation.go -package=svcmocks -destination=mocks/citation.mock.go CitationService
+type CitationService interface {
+	// SetCitations 声明帖子的引用，会替换掉之前声明的，正文里面提取的不受影响
+	// 作者和共同作者都可以声明，引用的站内帖子必须是已经发表的
+	SetCitations(ctx context.Context, uid, artId int64, cits []domain.Citation) error
+	// ListCitations 帖子引用了什么，包括声明的和正文里面提取的
+	ListCitations(ctx context.Context, artId int64) ([]domain.Citation, error)
+	// ListCitedBy 引用了这篇帖子的帖子，没有发表的不会返回，所以一页可能不满 limit
+	ListCitedBy(ctx context.Context, artId int64, offset, limit int) ([]domain.Article, error)
+	// SyncExtracted 帖子发表之后，用正文里面的链接替换掉之前提取的引用
+	SyncExtracted(ctx context.Context, art domain.Article) error
+	// DeleteByArticle 帖子彻底删除之前先删掉它的引用
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type citationService struct {
+	repo     repository.CitationRepository
+	artRepo  repository.ArticleRepository
+	producer events.Producer
+	l        logger.LoggerV1
+}
+
+func NewCitationService(repo repository.CitationRepository,
+	artRepo repository.ArticleRepository,
+	producer events.Producer,
+	l logger.LoggerV1) CitationService {
+	return &citationService{
+		repo:     repo,
+		artRepo:  artRepo,
+		producer: producer,
+		l:        l,
+	}
+}
+
+func (svc *citationService) SetCitations(ctx context.Context, uid, artId int64,
+	cits []domain.Citation) error {
+	if len(cits) > maxCitations {
+		return ErrTooManyCitations
+	}
+	if _, err := checkEditor(ctx, svc.artRepo, uid, artId); err != nil {
+		return err
+	}
+	res := make([]domain.Citation, 0, len(cits))
+	seen := make(map[string]struct{}, len(cits))
+	citedIds := make([]int64, 0, len(cits))
+	for _, c := range cits {
+		c.ArticleId = artId
+		c.Source = domain.CitationSourceDeclared
+		switch {
+		case c.CitedId > 0:
+			if c.CitedId == artId {
+				return ErrInvalidCitation
+			}
+			c.DOI = ""
+		case c.CitedId == 0:
+			c.DOI = domain.NormalizeDOI(c.DOI)
+			if c.DOI == "" {
+				return ErrInvalidCitation
+			}
+		default:
+			return ErrInvalidCitation
+		}
+		if _, ok := seen[c.Key()]; ok {
+			continue
+		}
+		seen[c.Key()] = struct{}{}
+		res = append(res, c)
+		if !c.External() {
+			citedIds = append(citedIds, c.CitedId)
+		}
+	}
+	published, err := svc.published(ctx, citedIds)
+	if err != nil {
+		return err
+	}
+	if len(published) < len(citedIds) {
+		return ErrCitedArticleMissing
+	}
+	return svc.replace(ctx, artId, domain.CitationSourceDeclared, res)
+}
+
+func (svc *citationService) ListCitations(ctx context.Context, artId int64) ([]domain.Citation, error) {
+	return svc.repo.ListByArticle(ctx, artId)
+}
+
+func (svc *citationService) ListCitedBy(ctx context.Context, artId int64,
+	offset, limit int) ([]domain.Article, error) {
+	ids, err := svc.repo.ListCitedBy(ctx, artId, offset, limit)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	published, err := svc.published(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, len(published))
+	for _, id := range ids {
+		if art, ok := published[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func (svc *citationService) SyncExtracted(ctx context.Context, art domain.Article) error {
+	cits := art.ExtractCitations()
+	citedIds := make([]int64, 0, len(cits))
+	for _, c := range cits {
+		if !c.External() {
+			citedIds = append(citedIds, c.CitedId)
+		}
+	}
+	published, err := svc.published(ctx, citedIds)
+	if err != nil {
+		return err
+	}
+	// 正文里面的链接可能是随便写的，不存在的帖子就不算引用
+	res := make([]domain.Citation, 0, len(cits))
+	for _, c := range cits {
+		if _, ok := published[c.CitedId]; ok || c.External() {
+			res = append(res, c)
+		}
+	}
+	return svc.replace(ctx, art.Id, domain.CitationSourceExtracted, res)
+}
+
+func (svc *citationService) DeleteByArticle(ctx context.Context, artId int64) error {
+	old, err := svc.repo.ListByArticle(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if err = svc.repo.DeleteByArticle(ctx, artId); err != nil {
+		return err
+	}
+	svc.notify(ctx, old, nil)
+	return nil
+}
+
+// replace 替换之后，新旧两边被引用的帖子的被引用次数都可能变了
+func (svc *citationService) replace(ctx context.Context, artId int64,
+	source domain.CitationSource, cits []domain.Citation) error {
+	old, err := svc.repo.ListByArticle(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if err = svc.repo.Replace(ctx, artId, source, cits); err != nil {
+		return err
+	}
+	svc.notify(ctx, old, cits)
+	return nil
+}
+
+// notify 把被引用的次数发给 interactive，发不出去只记日志
+// 次数是全量的，下一次这些帖子的引用再变的时候就会更正过来
+func (svc *citationService) notify(ctx context.Context, old, cits []domain.Citation) {
+	citedIds := make([]int64, 0, len(old)+len(cits))
+	seen := make(map[int64]struct{}, len(old)+len(cits))
+	for _, c := range append(old, cits...) {
+		if c.External() {
+			continue
+		}
+		if _, ok := seen[c.CitedId]; ok {
+			continue
+		}
+		seen[c.CitedId] = struct{}{}
+		citedIds = append(citedIds, c.CitedId)
+	}
+	if len(citedIds) == 0 {
+		return
+	}
+	cnts, err := svc.repo.CountCitedBy(ctx, citedIds)
+	if err == nil {
+		err = svc.producer.ProduceCitationEvent(events.CitationEvent{CiteCnts: cnts})
+	}
+	if err != nil {
+		svc.l.Error("发送被引用次数失败",
+			logger.Int("cnt", len(citedIds)),
+			logger.Error(err))
+	}
+}
+
+// published 只保留已经发表的帖子
+func (svc *citationService) published(ctx context.Context, ids []int64) (map[int64]domain.Article, error) {
+	if len(ids) == 0 {
+		return map[int64]domain.Article{}, nil
+	}
+	arts, err := svc.artRepo.BatchGetPublished(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, art := range arts {
+		// 线上库里面撤回了的帖子也还在
+		if !art.Published() {
+			delete(arts, id)
+		}
+	}
+	return arts, nil
+}
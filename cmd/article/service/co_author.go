@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+)
+
+func (svc *articleService) InviteCoAuthor(ctx context.Context, uid, artId, invitee int64) error {
+	if uid == invitee {
+		return ErrInvalidCoAuthor
+	}
+	if err := svc.checkOwner(ctx, uid, artId); err != nil {
+		return err
+	}
+	return svc.repo.AddCoAuthor(ctx, artId, invitee)
+}
+
+func (svc *articleService) RespondInvitation(ctx context.Context, uid, artId int64, accept bool) error {
+	c, err := svc.repo.GetCoAuthor(ctx, artId, uid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return ErrInvitationNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if c.Status != domain.CoAuthorStatusPending {
+		return ErrInvitationNotFound
+	}
+	if !accept {
+		return svc.repo.RemoveCoAuthor(ctx, artId, uid)
+	}
+	err = svc.repo.AcceptCoAuthor(ctx, artId, uid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		// 并发的时候已经被处理了
+		return ErrInvitationNotFound
+	}
+	return err
+}
+
+func (svc *articleService) RemoveCoAuthor(ctx context.Context, uid, artId, coAuthor int64) error {
+	// 自己退出
+	if uid != coAuthor {
+		if err := svc.checkOwner(ctx, uid, artId); err != nil {
+			return err
+		}
+	}
+	return svc.repo.RemoveCoAuthor(ctx, artId, coAuthor)
+}
+
+func (svc *articleService) ListCoAuthors(ctx context.Context, uid, artId int64) ([]domain.CoAuthor, error) {
+	if _, err := svc.checkEditor(ctx, uid, artId); err != nil {
+		return nil, err
+	}
+	return svc.repo.ListCoAuthors(ctx, artId)
+}
+
+func (svc *articleService) ListInvitations(ctx context.Context,
+	uid int64, offset, limit int) ([]domain.CoAuthor, error) {
+	return svc.repo.ListInvitations(ctx, uid, offset, limit)
+}
+
+// checkOwner 管理共同作者只有创建帖子的人可以做
+func (svc *articleService) checkOwner(ctx context.Context, uid, artId int64) error {
+	art, err := svc.repo.GetById(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != uid {
+		return ErrPossibleIncorrectAuthor
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./citation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./citation.go -package=svcmocks -destination=mocks/citation.mock.go
+//
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/XD/ScholarNet/cmd/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCitationService is a mock of CitationService interface.
+type MockCitationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCitationServiceMockRecorder
+}
+
+// MockCitationServiceMockRecorder is the mock recorder for MockCitationService.
+type MockCitationServiceMockRecorder struct {
+	mock *MockCitationService
+}
+
+// NewMockCitationService creates a new mock instance.
+func NewMockCitationService(ctrl *gomock.Controller) *MockCitationService {
+	mock := &MockCitationService{ctrl: ctrl}
+	mock.recorder = &MockCitationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCitationService) EXPECT() *MockCitationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockCitationService) DeleteByArticle(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockCitationServiceMockRecorder) DeleteByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockCitationService)(nil).DeleteByArticle), ctx, artId)
+}
+
+// ListCitations mocks base method.
+func (m *MockCitationService) ListCitations(ctx context.Context, artId int64) ([]domain.Citation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCitations", ctx, artId)
+	ret0, _ := ret[0].([]domain.Citation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitations indicates an expected call of ListCitations.
+func (mr *MockCitationServiceMockRecorder) ListCitations(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitations", reflect.TypeOf((*MockCitationService)(nil).ListCitations), ctx, artId)
+}
+
+// ListCitedBy mocks base method.
+func (m *MockCitationService) ListCitedBy(ctx context.Context, artId int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCitedBy", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCitedBy indicates an expected call of ListCitedBy.
+func (mr *MockCitationServiceMockRecorder) ListCitedBy(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCitedBy", reflect.TypeOf((*MockCitationService)(nil).ListCitedBy), ctx, artId, offset, limit)
+}
+
+// SetCitations mocks base method.
+func (m *MockCitationService) SetCitations(ctx context.Context, uid, artId int64, cits []domain.Citation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCitations", ctx, uid, artId, cits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCitations indicates an expected call of SetCitations.
+func (mr *MockCitationServiceMockRecorder) SetCitations(ctx, uid, artId, cits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCitations", reflect.TypeOf((*MockCitationService)(nil).SetCitations), ctx, uid, artId, cits)
+}
+
+// SyncExtracted mocks base method.
+func (m *MockCitationService) SyncExtracted(ctx context.Context, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncExtracted", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncExtracted indicates an expected call of SyncExtracted.
+func (mr *MockCitationServiceMockRecorder) SyncExtracted(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncExtracted", reflect.TypeOf((*MockCitationService)(nil).SyncExtracted), ctx, art)
+}
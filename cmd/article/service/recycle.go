@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/events"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+func (svc *articleService) Delete(ctx context.Context, uid, id int64) error {
+	err := svc.repo.Delete(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	// 删除已经成功了，消息发不出去也不回滚，
+	// 下游最多是多展示一段时间，等彻底删除的时候也查不到了
+	er := svc.producer.ProduceDeleteEvent(events.DeleteEvent{Aid: id, Uid: uid})
+	if er != nil {
+		svc.logger.Error("发送删除帖子的消息失败",
+			logger.Int64("uid", uid),
+			logger.Int64("aid", id),
+			logger.Error(er))
+	}
+	return nil
+}
+
+func (svc *articleService) RestoreDeleted(ctx context.Context, uid, id int64) error {
+	err := svc.repo.RestoreDeleted(ctx, uid, id, time.Now().Add(-svc.retention))
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return ErrNotInRecycleBin
+	}
+	if err != nil {
+		return err
+	}
+	// 和删除一样，恢复已经成功了，消息发不出去也不回滚
+	svc.produceRestored(ctx, uid, id)
+	return nil
+}
+
+// produceRestored 删除的时候 feed 和搜索都把帖子去掉了，恢复的是已经发表的帖子的话要加回去
+func (svc *articleService) produceRestored(ctx context.Context, uid, id int64) {
+	art, err := svc.repo.GetPublishedById(ctx, id)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		// 从来没有发表过
+		return
+	}
+	if err != nil {
+		svc.logger.Error("查询恢复的帖子失败",
+			logger.Int64("aid", id),
+			logger.Error(err))
+		return
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return
+	}
+	if er := svc.producer.ProducePublishEvent(events.PublishEvent{Uid: uid, Aid: id}); er != nil {
+		svc.logger.Error("发送恢复帖子的消息失败",
+			logger.Int64("uid", uid),
+			logger.Int64("aid", id),
+			logger.Error(er))
+	}
+	er := svc.producer.ProduceSyncEvent(events.SyncEvent{
+		Id:      art.Id,
+		Title:   art.Title,
+		Status:  int32(art.Status.ToUint8()),
+		Content: art.Content,
+	})
+	if er != nil {
+		svc.logger.Error("发送同步搜索的消息失败",
+			logger.Int64("aid", id),
+			logger.Error(er))
+	}
+}
+
+func (svc *articleService) ListDeleted(ctx context.Context, uid int64,
+	offset, limit int) ([]domain.Article, error) {
+	return svc.repo.ListDeleted(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) ListExpiredDeleted(ctx context.Context,
+	now time.Time, limit int) ([]domain.Article, error) {
+	return svc.repo.ListDeletedBefore(ctx, now.Add(-svc.retention), limit)
+}
+
+func (svc *articleService) Purge(ctx context.Context, id int64) error {
+	// 先删可见范围，帖子已经在回收站里面了，谁都看不到
+	if err := svc.visibility.DeleteByArticle(ctx, id); err != nil {
+		return err
+	}
+	err := svc.repo.Purge(ctx, id)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		// 已经被恢复了，或者别的实例已经删掉了
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/moderation"
+)
+
+// moderate 标题和内容一起审核
+// 审核本身出错的时候，宁可转人工审核，也不能直接放出去
+func (svc *articleService) moderate(ctx context.Context, art domain.Article) moderation.Verdict {
+	if svc.moderator == nil {
+		return moderation.VerdictPass
+	}
+	res, err := svc.moderator.Moderate(ctx, art.Title+"\n"+art.Content)
+	if err != nil {
+		svc.logger.Error("内容审核失败，转人工审核",
+			logger.Int64("aid", art.Id), logger.Error(err))
+		return moderation.VerdictReview
+	}
+	if res.Verdict != moderation.VerdictPass {
+		words := make([]string, 0, len(res.Hits))
+		for _, h := range res.Hits {
+			words = append(words, h.Word)
+		}
+		svc.logger.Info("帖子没有通过内容审核",
+			logger.Int64("aid", art.Id),
+			logger.Int64("author", art.Author.Id),
+			logger.String("verdict", res.Verdict.String()),
+			logger.Field{Key: "words", Value: words},
+			logger.Field{Key: "reasons", Value: res.Reasons})
+	}
+	return res.Verdict
+}
+
+// holdForReview 只保存到制作库，审核通过之后再走 Sync 发表
+func (svc *articleService) holdForReview(ctx context.Context,
+	art domain.Article) (int64, error) {
+	art.Status = domain.ArticleStatusUnderReview
+	art.PublishAt = time.Time{}
+	if art.Id > 0 {
+		err := svc.update(ctx, art)
+		return art.Id, err
+	}
+	return svc.create(ctx, art)
+}
+
+func (svc *articleService) ListUnderReview(ctx context.Context,
+	reviewer int64, offset, limit int) ([]domain.Article, error) {
+	if err := svc.checkReviewer(ctx, reviewer); err != nil {
+		return nil, err
+	}
+	return svc.repo.ListUnderReview(ctx, offset, limit)
+}
+
+func (svc *articleService) Review(ctx context.Context, reviewer, id int64, approved bool) error {
+	if err := svc.checkReviewer(ctx, reviewer); err != nil {
+		return err
+	}
+	art, err := svc.repo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !art.UnderReview() {
+		return ErrArticleNotUnderReview
+	}
+	// 先记录再处理，记录失败就不处理，这样每一次通过或者拒绝都能查到是谁做的
+	// 处理失败的话会多一条记录，帖子还是待审核，可以重新审核
+	_, err = svc.reviews.AddLog(ctx, domain.ReviewLog{
+		ArticleId: id,
+		Version:   art.Version,
+		Reviewer:  reviewer,
+		Approved:  approved,
+	})
+	if err != nil {
+		return err
+	}
+	svc.logger.Info("人工审核帖子",
+		logger.Int64("aid", id),
+		logger.Int64("reviewer", reviewer),
+		logger.Bool("approved", approved))
+	if !approved {
+		return svc.repo.RejectReview(ctx, art.Author.Id, id)
+	}
+	// 带着版本号，审核期间作者又修改过的话，这一次审核就作废了
+	art.Status = domain.ArticleStatusPublished
+	_, err = svc.sync(ctx, art)
+	return err
+}
+
+// checkReviewer 只有审核人员可以查看待审核的帖子和处理审核
+func (svc *articleService) checkReviewer(ctx context.Context, uid int64) error {
+	ok, err := svc.reviews.IsReviewer(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotReviewer
+	}
+	return nil
+}
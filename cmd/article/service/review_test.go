@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	repomocks "github.com/XD/ScholarNet/cmd/article/repository/mocks"
+	svcmocks "github.com/XD/ScholarNet/cmd/article/service/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticleService_Review(t *testing.T) {
+	held := domain.Article{
+		Id:      1,
+		Title:   "我的标题",
+		Author:  domain.Author{Id: 123},
+		Status:  domain.ArticleStatusUnderReview,
+		Version: 3,
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService)
+		approved bool
+
+		wantErr error
+	}{
+		{
+			name: "通过就发表，并且记录是谁审核的",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				reviews := repomocks.NewMockReviewRepository(ctrl)
+				citSvc := svcmocks.NewMockCitationService(ctrl)
+				reviews.EXPECT().IsReviewer(gomock.Any(), int64(9)).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(held, nil)
+				reviews.EXPECT().AddLog(gomock.Any(), domain.ReviewLog{
+					ArticleId: 1,
+					Version:   3,
+					Reviewer:  9,
+					Approved:  true,
+				}).Return(int64(1), nil)
+				published := held
+				published.Status = domain.ArticleStatusPublished
+				repo.EXPECT().Sync(gomock.Any(), published).Return(int64(1), nil)
+				citSvc.EXPECT().SyncExtracted(gomock.Any(), published).Return(nil)
+				return repo, reviews, citSvc
+			},
+			approved: true,
+		},
+		{
+			name: "不通过",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				reviews := repomocks.NewMockReviewRepository(ctrl)
+				reviews.EXPECT().IsReviewer(gomock.Any(), int64(9)).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(held, nil)
+				reviews.EXPECT().AddLog(gomock.Any(), domain.ReviewLog{
+					ArticleId: 1,
+					Version:   3,
+					Reviewer:  9,
+				}).Return(int64(1), nil)
+				repo.EXPECT().RejectReview(gomock.Any(), int64(123), int64(1)).Return(nil)
+				return repo, reviews, svcmocks.NewMockCitationService(ctrl)
+			},
+		},
+		{
+			name: "不是审核人员",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService) {
+				reviews := repomocks.NewMockReviewRepository(ctrl)
+				reviews.EXPECT().IsReviewer(gomock.Any(), int64(9)).Return(false, nil)
+				return repomocks.NewMockArticleRepository(ctrl), reviews, svcmocks.NewMockCitationService(ctrl)
+			},
+			approved: true,
+			wantErr:  ErrNotReviewer,
+		},
+		{
+			name: "不是待审核的帖子",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				reviews := repomocks.NewMockReviewRepository(ctrl)
+				reviews.EXPECT().IsReviewer(gomock.Any(), int64(9)).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				return repo, reviews, svcmocks.NewMockCitationService(ctrl)
+			},
+			approved: true,
+			wantErr:  ErrArticleNotUnderReview,
+		},
+		{
+			name: "记录失败就不处理",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ReviewRepository, CitationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				reviews := repomocks.NewMockReviewRepository(ctrl)
+				reviews.EXPECT().IsReviewer(gomock.Any(), int64(9)).Return(true, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(held, nil)
+				reviews.EXPECT().AddLog(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("mock db error"))
+				return repo, reviews, svcmocks.NewMockCitationService(ctrl)
+			},
+			approved: true,
+			wantErr:  errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, reviews, citSvc := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, logger.NewNoOpLogger(), nil, nil, citSvc, nil, reviews)
+			err := svc.Review(context.Background(), 9, 1, tc.approved)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
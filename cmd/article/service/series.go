@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+)
+
+func (svc *articleService) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	if strings.TrimSpace(s.Title) == "" || hasDuplicate(s.ArticleIds) {
+		return 0, ErrInvalidSeries
+	}
+	id, err := svc.repo.CreateSeries(ctx, s)
+	if err != nil || len(s.ArticleIds) == 0 {
+		return id, err
+	}
+	return id, svc.repo.SetSeriesArticles(ctx, s.Author.Id, id, s.ArticleIds)
+}
+
+func (svc *articleService) ReorderSeries(ctx context.Context, uid, seriesId int64, artIds []int64) error {
+	if hasDuplicate(artIds) {
+		return ErrInvalidSeries
+	}
+	return svc.repo.SetSeriesArticles(ctx, uid, seriesId, artIds)
+}
+
+func (svc *articleService) ListSeries(ctx context.Context, author int64, offset, limit int) ([]domain.Series, error) {
+	return svc.repo.ListSeries(ctx, author, offset, limit)
+}
+
+func hasDuplicate(ids []int64) bool {
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return true
+		}
+		seen[id] = struct{}{}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/XD/ScholarNet/cmd/article/domain"
+	"github.com/XD/ScholarNet/cmd/article/repository"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	ErrInvalidVisibility = errors.New("不支持的可见范围")
+	ErrTooManyAudience   = errors.New("白名单里面的人太多了")
+	ErrArticleNotVisible = errors.New("没有权限查看这篇帖子")
+)
+
+const (
+	// maxAudience 白名单最多多少人
+	maxAudience = 1000
+	// followCheckConcurrency 同时向 follow 查询多少个关注关系
+	followCheckConcurrency = 8
+)
+
+// VisibilityService 已经发表的帖子谁能看
+// 作者和共同作者不管可见范围是什么都能看，没有登录的读者只能看公开的帖子
+type VisibilityService interface {
+	// SetVisibility 只有创建帖子的人可以设置，还没有发表的帖子也可以先设置好
+	// allowList 只有 VisibilityAllowList 的时候才有用，会整体替换掉之前的白名单
+	SetVisibility(ctx context.Context, uid, artId int64, v domain.Visibility, allowList []int64) error
+	// GetVisibility 作者和共同作者才能看到白名单
+	GetVisibility(ctx context.Context, uid, artId int64) (domain.Visibility, []int64, error)
+	// Fill 把可见范围填到帖子上面
+	Fill(ctx context.Context, arts []domain.Article) error
+	// CanView art 的 Visibility 要先填好
+	CanView(ctx context.Context, art domain.Article, uid int64) (bool, error)
+	// FilterAudience uids 里面哪些人能看 art，保持 uids 的顺序，art 的 Visibility 要先填好
+	FilterAudience(ctx context.Context, art domain.Article, uids []int64) ([]int64, error)
+	// DeleteByArticle 帖子彻底删除之前先删掉它的可见范围
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type visibilityService struct {
+	repo       repository.VisibilityRepository
+	artRepo    repository.ArticleRepository
+	followRepo repository.FollowRepository
+}
+
+func NewVisibilityService(repo repository.VisibilityRepository,
+	artRepo repository.ArticleRepository,
+	followRepo repository.FollowRepository) VisibilityService {
+	return &visibilityService{
+		repo:       repo,
+		artRepo:    artRepo,
+		followRepo: followRepo,
+	}
+}
+
+func (svc *visibilityService) SetVisibility(ctx context.Context, uid, artId int64,
+	v domain.Visibility, allowList []int64) error {
+	if !v.Valid() {
+		return ErrInvalidVisibility
+	}
+	art, err := svc.artRepo.GetById(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if art.Deleted() {
+		return ErrArticleDeleted
+	}
+	// 和撤回一样，只有创建帖子的人可以改
+	if art.Author.Id != uid {
+		return ErrPossibleIncorrectAuthor
+	}
+	uids := make([]int64, 0, len(allowList))
+	seen := make(map[int64]struct{}, len(allowList))
+	for _, u := range allowList {
+		// 作者自己本来就能看
+		if _, ok := seen[u]; ok || u <= 0 || u == uid {
+			continue
+		}
+		seen[u] = struct{}{}
+		uids = append(uids, u)
+	}
+	if len(uids) > maxAudience {
+		return ErrTooManyAudience
+	}
+	return svc.repo.Set(ctx, artId, v, uids)
+}
+
+func (svc *visibilityService) GetVisibility(ctx context.Context, uid, artId int64) (domain.Visibility, []int64, error) {
+	if _, err := checkEditor(ctx, svc.artRepo, uid, artId); err != nil {
+		return 0, nil, err
+	}
+	v, err := svc.repo.Get(ctx, artId)
+	if err != nil || v != domain.VisibilityAllowList {
+		return v, nil, err
+	}
+	uids, err := svc.repo.ListAudience(ctx, artId)
+	return v, uids, err
+}
+
+func (svc *visibilityService) Fill(ctx context.Context, arts []domain.Article) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	vs, err := svc.repo.BatchGet(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range arts {
+		arts[i].Visibility = vs[arts[i].Id]
+	}
+	return nil
+}
+
+func (svc *visibilityService) CanView(ctx context.Context, art domain.Article, uid int64) (bool, error) {
+	uids, err := svc.FilterAudience(ctx, art, []int64{uid})
+	return len(uids) > 0, err
+}
+
+func (svc *visibilityService) FilterAudience(ctx context.Context,
+	art domain.Article, uids []int64) ([]int64, error) {
+	if !art.Visibility.Restricted() {
+		return uids, nil
+	}
+	authors := make(map[int64]struct{}, len(art.Authors)+1)
+	authors[art.Author.Id] = struct{}{}
+	for _, a := range art.Authors {
+		authors[a.Id] = struct{}{}
+	}
+	// 作者们直接可以看，没有登录的直接不能看，剩下的才需要查
+	allowed := make([]bool, len(uids))
+	pending := make([]int64, 0, len(uids))
+	for i, uid := range uids {
+		if _, ok := authors[uid]; ok {
+			allowed[i] = true
+		} else if uid > 0 {
+			pending = append(pending, uid)
+		}
+	}
+	ok, err := svc.check(ctx, art, pending)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(uids))
+	for i, uid := range uids {
+		if allowed[i] || ok[uid] {
+			res = append(res, uid)
+		}
+	}
+	return res, nil
+}
+
+// check 不是作者的人里面哪些能看
+func (svc *visibilityService) check(ctx context.Context,
+	art domain.Article, uids []int64) (map[int64]bool, error) {
+	res := make(map[int64]bool, len(uids))
+	if len(uids) == 0 {
+		return res, nil
+	}
+	if art.Visibility == domain.VisibilityAllowList {
+		found, err := svc.repo.FindAudience(ctx, art.Id, uids)
+		if err != nil {
+			return nil, err
+		}
+		for _, uid := range found {
+			res[uid] = true
+		}
+		return res, nil
+	}
+	author := art.Author.Id
+	mutual := art.Visibility == domain.VisibilityMutual
+	ok := make([]bool, len(uids))
+	var eg errgroup.Group
+	eg.SetLimit(followCheckConcurrency)
+	for i, uid := range uids {
+		eg.Go(func() error {
+			follows, err := svc.followRepo.Follows(ctx, uid, author)
+			if err != nil || !follows || !mutual {
+				ok[i] = follows
+				return err
+			}
+			ok[i], err = svc.followRepo.Follows(ctx, author, uid)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	for i, uid := range uids {
+		res[uid] = ok[i]
+	}
+	return res, nil
+}
+
+func (svc *visibilityService) DeleteByArticle(ctx context.Context, artId int64) error {
+	return svc.repo.Delete(ctx, artId)
+}
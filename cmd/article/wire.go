@@ -7,34 +7,64 @@ import (
 	"github.com/XD/ScholarNet/cmd/article/grpc"
 	"github.com/XD/ScholarNet/cmd/article/ioc"
 	"github.com/XD/ScholarNet/cmd/article/repository"
-	"github.com/XD/ScholarNet/cmd/article/repository/cache"
-	"github.com/XD/ScholarNet/cmd/article/repository/dao"
 	"github.com/XD/ScholarNet/cmd/article/service"
 	"github.com/XD/ScholarNet/cmd/pkg/wego"
 	"github.com/google/wire"
+	rlock "github.com/gotomicro/redis-lock"
 )
 
 var thirdProvider = wire.NewSet(
+	ioc.InitRedisClient,
 	ioc.InitRedis,
 	ioc.InitLogger,
 	ioc.InitUserRpcClient,
+	ioc.InitFollowRpcClient,
 	ioc.InitProducer,
+	ioc.InitSaramaClient,
 	ioc.InitEtcdClient,
 	ioc.InitDB,
+	ioc.InitArticleDAO,
+	ioc.InitAttachmentDAO,
+	ioc.InitCitationDAO,
+	ioc.InitVisibilityDAO,
+	ioc.InitReviewDAO,
+	ioc.InitAttachmentStorage,
+	ioc.InitModerator,
+	rlock.NewClient,
+)
+
+var cronJob = wire.NewSet(
+	ioc.InitScheduledPublishJob,
+	ioc.InitAttachmentGCJob,
+	ioc.InitPurgeDeletedJob,
+	ioc.InitJobs,
 )
 
 func Init() *wego.App {
 	wire.Build(
 		thirdProvider,
+		cronJob,
 		events.NewSaramaSyncProducer,
-		cache.NewRedisArticleCache,
-		dao.NewGORMArticleDAO,
-		repository.NewArticleRepository,
+		events.NewMySQLBinlogConsumer,
+		ioc.NewConsumers,
+		ioc.InitArticleCache,
+		ioc.InitArticleRepository,
 		repository.NewGrpcAuthorRepository,
+		repository.NewAttachmentRepository,
+		repository.NewCitationRepository,
+		repository.NewVisibilityRepository,
+		repository.NewReviewRepository,
+		repository.NewGrpcFollowRepository,
 		service.NewArticleService,
+		service.NewAttachmentService,
+		service.NewCitationService,
+		service.NewVisibilityService,
 		grpc.NewArticleServiceServer,
+		grpc.NewAttachmentServiceServer,
+		grpc.NewCitationServiceServer,
+		grpc.NewVisibilityServiceServer,
 		ioc.InitGRPCxServer,
-		wire.Struct(new(wego.App), "GRPCServer"),
+		wire.Struct(new(wego.App), "GRPCServer", "Cron", "Consumers"),
 	)
 	return new(wego.App)
 }
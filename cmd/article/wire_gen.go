@@ -11,11 +11,10 @@ import (
 	"github.com/XD/ScholarNet/cmd/article/grpc"
 	"github.com/XD/ScholarNet/cmd/article/ioc"
 	"github.com/XD/ScholarNet/cmd/article/repository"
-	"github.com/XD/ScholarNet/cmd/article/repository/cache"
-	"github.com/XD/ScholarNet/cmd/article/repository/dao"
 	"github.com/XD/ScholarNet/cmd/article/service"
 	"github.com/XD/ScholarNet/cmd/pkg/wego"
 	"github.com/google/wire"
+	"github.com/gotomicro/redis-lock"
 )
 
 // Injectors from wire.go:
@@ -23,24 +22,55 @@ import (
 func Init() *wego.App {
 	loggerV1 := ioc.InitLogger()
 	db := ioc.InitDB(loggerV1)
-	articleDAO := dao.NewGORMArticleDAO(db)
-	cmdable := ioc.InitRedis()
-	articleCache := cache.NewRedisArticleCache(cmdable)
-	articleRepository := repository.NewArticleRepository(articleDAO, articleCache, loggerV1)
+	articleDAO := ioc.InitArticleDAO(db)
+	universalClient := ioc.InitRedisClient()
+	articleCache := ioc.InitArticleCache(universalClient, loggerV1)
+	articleRepository := ioc.InitArticleRepository(articleDAO, articleCache, loggerV1)
 	userServiceClient := ioc.InitUserRpcClient()
 	authorRepository := repository.NewGrpcAuthorRepository(articleDAO, userServiceClient)
 	syncProducer := ioc.InitProducer()
 	producer := events.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, authorRepository, loggerV1, producer)
+	moderator := ioc.InitModerator(loggerV1)
+	citationDAO := ioc.InitCitationDAO(db)
+	citationRepository := repository.NewCitationRepository(citationDAO)
+	citationService := service.NewCitationService(citationRepository, articleRepository, producer, loggerV1)
+	visibilityDAO := ioc.InitVisibilityDAO(db)
+	visibilityRepository := repository.NewVisibilityRepository(visibilityDAO, articleCache, loggerV1)
+	followServiceClient := ioc.InitFollowRpcClient()
+	followRepository := repository.NewGrpcFollowRepository(followServiceClient)
+	visibilityService := service.NewVisibilityService(visibilityRepository, articleRepository, followRepository)
+	reviewDAO := ioc.InitReviewDAO(db)
+	reviewRepository := repository.NewReviewRepository(reviewDAO)
+	articleService := service.NewArticleService(articleRepository, authorRepository, loggerV1, producer, moderator, citationService, visibilityService, reviewRepository)
 	articleServiceServer := grpc.NewArticleServiceServer(articleService)
+	attachmentDAO := ioc.InitAttachmentDAO(db)
+	attachmentStorage := ioc.InitAttachmentStorage()
+	attachmentRepository := repository.NewAttachmentRepository(attachmentDAO, attachmentStorage)
+	attachmentService := service.NewAttachmentService(attachmentRepository, articleRepository, loggerV1)
+	attachmentServiceServer := grpc.NewAttachmentServiceServer(attachmentService)
+	citationServiceServer := grpc.NewCitationServiceServer(citationService)
+	visibilityServiceServer := grpc.NewVisibilityServiceServer(visibilityService, articleService)
 	client := ioc.InitEtcdClient()
-	server := ioc.InitGRPCxServer(articleServiceServer, client, loggerV1)
+	server := ioc.InitGRPCxServer(articleServiceServer, attachmentServiceServer, citationServiceServer, visibilityServiceServer, client, loggerV1)
+	cmdable := ioc.InitRedis(universalClient)
+	client2 := rlock.NewClient(cmdable)
+	scheduledPublishJob := ioc.InitScheduledPublishJob(articleService, client2, loggerV1)
+	attachmentGCJob := ioc.InitAttachmentGCJob(attachmentService, client2, loggerV1)
+	purgeDeletedJob := ioc.InitPurgeDeletedJob(articleService, attachmentService, citationService, client2, loggerV1)
+	cron := ioc.InitJobs(loggerV1, scheduledPublishJob, attachmentGCJob, purgeDeletedJob)
+	saramaClient := ioc.InitSaramaClient()
+	mySQLBinlogConsumer := events.NewMySQLBinlogConsumer(saramaClient, loggerV1, articleCache)
+	v := ioc.NewConsumers(mySQLBinlogConsumer)
 	app := &wego.App{
 		GRPCServer: server,
+		Consumers:  v,
+		Cron:       cron,
 	}
 	return app
 }
 
 // wire.go:
 
-var thirdProvider = wire.NewSet(ioc.InitRedis, ioc.InitLogger, ioc.InitUserRpcClient, ioc.InitProducer, ioc.InitEtcdClient, ioc.InitDB)
+var thirdProvider = wire.NewSet(ioc.InitRedisClient, ioc.InitRedis, ioc.InitLogger, ioc.InitUserRpcClient, ioc.InitFollowRpcClient, ioc.InitProducer, ioc.InitSaramaClient, ioc.InitEtcdClient, ioc.InitDB, ioc.InitArticleDAO, ioc.InitAttachmentDAO, ioc.InitCitationDAO, ioc.InitVisibilityDAO, ioc.InitAttachmentStorage, ioc.InitModerator, rlock.NewClient)
+
+var cronJob = wire.NewSet(ioc.InitScheduledPublishJob, ioc.InitAttachmentGCJob, ioc.InitPurgeDeletedJob, ioc.InitJobs)
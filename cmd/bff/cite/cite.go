@@ -0,0 +1,224 @@
+// Package cite 把帖子渲染成文献管理软件认识的引用格式
+// 目前支持 BibTeX、RIS 和 CSL-JSON，Zotero、EndNote、JabRef 这些都能直接导入
+package cite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Publisher 引用里面的出版方，也就是网站的名字
+const Publisher = "ScholarNet"
+
+var ErrUnknownFormat = errors.New("不支持的引用格式")
+
+type Format string
+
+const (
+	FormatBibTeX Format = "bibtex"
+	FormatRIS    Format = "ris"
+	FormatCSL    Format = "csl"
+)
+
+// ParseFormat 空字符串当成 BibTeX，用得最多
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatBibTeX, nil
+	case FormatBibTeX, FormatRIS, FormatCSL:
+		return f, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatRIS:
+		return "application/x-research-info-systems; charset=utf-8"
+	case FormatCSL:
+		return "application/vnd.citationstyles.csl+json; charset=utf-8"
+	default:
+		return "application/x-bibtex; charset=utf-8"
+	}
+}
+
+// Ext 下载的时候文件的后缀
+func (f Format) Ext() string {
+	switch f {
+	case FormatRIS:
+		return "ris"
+	case FormatCSL:
+		return "json"
+	default:
+		return "bib"
+	}
+}
+
+// Entry 一篇帖子的引用信息
+type Entry struct {
+	Id    int64
+	Title string
+	// Authors 按照署名顺序排列
+	Authors []string
+	// Issued 第一次发表的时间
+	Issued time.Time
+	// URL 帖子的访问地址
+	URL string
+	// DOI 可以没有
+	DOI string
+}
+
+// Key 在 BibTeX 里面引用这篇帖子用的 key，也用作 RIS 和 CSL-JSON 里面的 ID
+func (e Entry) Key() string {
+	return fmt.Sprintf("scholarnet%d", e.Id)
+}
+
+// Render 多篇帖子渲染到同一个文件里面，CSL-JSON 不管几篇都是数组
+func Render(f Format, entries []Entry) ([]byte, error) {
+	switch f {
+	case FormatBibTeX:
+		return renderBibTeX(entries), nil
+	case FormatRIS:
+		return renderRIS(entries), nil
+	case FormatCSL:
+		return renderCSL(entries)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+var bibTeXReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func renderBibTeX(entries []Entry) []byte {
+	var buf bytes.Buffer
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "@misc{%s,\n", e.Key())
+		// 多套一层大括号，不然标题的大小写会被样式改掉
+		fmt.Fprintf(&buf, "  title = {{%s}},\n", bibTeXReplacer.Replace(oneLine(e.Title)))
+		if len(e.Authors) > 0 {
+			// 每个名字都用大括号包起来，中文名和昵称没有名和姓的区别
+			names := make([]string, 0, len(e.Authors))
+			for _, a := range e.Authors {
+				names = append(names, "{"+bibTeXReplacer.Replace(oneLine(a))+"}")
+			}
+			fmt.Fprintf(&buf, "  author = {%s},\n", strings.Join(names, " and "))
+		}
+		if !e.Issued.IsZero() {
+			fmt.Fprintf(&buf, "  year = {%d},\n", e.Issued.Year())
+			fmt.Fprintf(&buf, "  month = {%s},\n", strings.ToLower(e.Issued.Month().String()[:3]))
+		}
+		fmt.Fprintf(&buf, "  publisher = {%s},\n", Publisher)
+		if e.DOI != "" {
+			fmt.Fprintf(&buf, "  doi = {%s},\n", e.DOI)
+		}
+		fmt.Fprintf(&buf, "  url = {%s},\n", e.URL)
+		fmt.Fprintf(&buf, "  howpublished = {\\url{%s}}\n", e.URL)
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
+
+func renderRIS(entries []Entry) []byte {
+	var buf bytes.Buffer
+	// RIS 规定每一行都用 CRLF 结尾，标签后面是两个空格、一个短横线和一个空格
+	tag := func(t, v string) {
+		fmt.Fprintf(&buf, "%s  - %s\r\n", t, v)
+	}
+	for _, e := range entries {
+		tag("TY", "ELEC")
+		tag("ID", e.Key())
+		tag("TI", oneLine(e.Title))
+		for _, a := range e.Authors {
+			tag("AU", oneLine(a))
+		}
+		if !e.Issued.IsZero() {
+			tag("PY", e.Issued.Format("2006"))
+			tag("DA", e.Issued.Format("2006/01/02/"))
+		}
+		tag("PB", Publisher)
+		tag("UR", e.URL)
+		if e.DOI != "" {
+			tag("DO", e.DOI)
+		}
+		tag("ER", "")
+	}
+	return buf.Bytes()
+}
+
+type cslItem struct {
+	Id             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Author         []cslName `json:"author,omitempty"`
+	Issued         *cslDate  `json:"issued,omitempty"`
+	ContainerTitle string    `json:"container-title"`
+	Publisher      string    `json:"publisher"`
+	URL            string    `json:"URL"`
+	DOI            string    `json:"DOI,omitempty"`
+}
+
+// cslName 昵称拆不出名和姓，所以用 literal
+type cslName struct {
+	Literal string `json:"literal"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+func renderCSL(entries []Entry) ([]byte, error) {
+	items := make([]cslItem, 0, len(entries))
+	for _, e := range entries {
+		item := cslItem{
+			Id:             e.Key(),
+			Type:           "post",
+			Title:          oneLine(e.Title),
+			ContainerTitle: Publisher,
+			Publisher:      Publisher,
+			URL:            e.URL,
+			DOI:            e.DOI,
+		}
+		for _, a := range e.Authors {
+			item.Author = append(item.Author, cslName{Literal: oneLine(a)})
+		}
+		if !e.Issued.IsZero() {
+			item.Issued = &cslDate{DateParts: [][]int{
+				{e.Issued.Year(), int(e.Issued.Month()), e.Issued.Day()},
+			}}
+		}
+		items = append(items, item)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// 标题里面的 & < > 原样输出，不要转义成 \u0026 这种
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(items); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// oneLine 三种格式都是一个字段一行，标题和名字里面不能有换行
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,150 @@
+package cite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEntries = []Entry{
+	{
+		Id:      12,
+		Title:   "图神经网络 50% 的\n速度_优化 {综述}",
+		Authors: []string{"张三", "Alice & Bob"},
+		Issued:  time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+		URL:     "https://scholar.net/articles/pub/12",
+		DOI:     "10.1000/abc.1",
+	},
+	{
+		Id:    13,
+		Title: "没有作者",
+		URL:   "https://scholar.net/articles/pub/13",
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		s       string
+		want    Format
+		wantErr error
+	}{
+		{s: "", want: FormatBibTeX},
+		{s: " RIS ", want: FormatRIS},
+		{s: "csl", want: FormatCSL},
+		{s: "endnote", wantErr: ErrUnknownFormat},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			f, err := ParseFormat(tc.s)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, f)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "BibTeX",
+			format: FormatBibTeX,
+			want: `@misc{scholarnet12,
+  title = {{图神经网络 50\% 的 速度\_优化 \{综述\}}},
+  author = {{张三} and {Alice \& Bob}},
+  year = {2024},
+  month = {mar},
+  publisher = {ScholarNet},
+  doi = {10.1000/abc.1},
+  url = {https://scholar.net/articles/pub/12},
+  howpublished = {\url{https://scholar.net/articles/pub/12}}
+}
+
+@misc{scholarnet13,
+  title = {{没有作者}},
+  publisher = {ScholarNet},
+  url = {https://scholar.net/articles/pub/13},
+  howpublished = {\url{https://scholar.net/articles/pub/13}}
+}
+`,
+		},
+		{
+			name:   "RIS",
+			format: FormatRIS,
+			want: "TY  - ELEC\r\n" +
+				"ID  - scholarnet12\r\n" +
+				"TI  - 图神经网络 50% 的 速度_优化 {综述}\r\n" +
+				"AU  - 张三\r\n" +
+				"AU  - Alice & Bob\r\n" +
+				"PY  - 2024\r\n" +
+				"DA  - 2024/03/05/\r\n" +
+				"PB  - ScholarNet\r\n" +
+				"UR  - https://scholar.net/articles/pub/12\r\n" +
+				"DO  - 10.1000/abc.1\r\n" +
+				"ER  - \r\n" +
+				"TY  - ELEC\r\n" +
+				"ID  - scholarnet13\r\n" +
+				"TI  - 没有作者\r\n" +
+				"PB  - ScholarNet\r\n" +
+				"UR  - https://scholar.net/articles/pub/13\r\n" +
+				"ER  - \r\n",
+		},
+		{
+			name:   "CSL-JSON",
+			format: FormatCSL,
+			want: `[
+  {
+    "id": "scholarnet12",
+    "type": "post",
+    "title": "图神经网络 50% 的 速度_优化 {综述}",
+    "author": [
+      {
+        "literal": "张三"
+      },
+      {
+        "literal": "Alice & Bob"
+      }
+    ],
+    "issued": {
+      "date-parts": [
+        [
+          2024,
+          3,
+          5
+        ]
+      ]
+    },
+    "container-title": "ScholarNet",
+    "publisher": "ScholarNet",
+    "URL": "https://scholar.net/articles/pub/12",
+    "DOI": "10.1000/abc.1"
+  },
+  {
+    "id": "scholarnet13",
+    "type": "post",
+    "title": "没有作者",
+    "container-title": "ScholarNet",
+    "publisher": "ScholarNet",
+    "URL": "https://scholar.net/articles/pub/13"
+  }
+]
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Render(tc.format, testEntries)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(data))
+		})
+	}
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	_, err := Render("endnote", testEntries)
+	assert.Equal(t, ErrUnknownFormat, err)
+}
@@ -10,6 +10,20 @@ import (
 )
 
 func InitArticleClient(ecli *clientv3.Client) articlev1.ArticleServiceClient {
+	return articlev1.NewArticleServiceClient(dialArticle(ecli))
+}
+
+// InitCitationClient 引用和帖子是同一个服务
+func InitCitationClient(ecli *clientv3.Client) articlev1.CitationServiceClient {
+	return articlev1.NewCitationServiceClient(dialArticle(ecli))
+}
+
+// InitVisibilityClient 可见范围和帖子是同一个服务
+func InitVisibilityClient(ecli *clientv3.Client) articlev1.VisibilityServiceClient {
+	return articlev1.NewVisibilityServiceClient(dialArticle(ecli))
+}
+
+func dialArticle(ecli *clientv3.Client) *grpc.ClientConn {
 	type Config struct {
 		Target string `json:"target"`
 		Secure bool   `json:"secure"`
@@ -31,5 +45,5 @@ func InitArticleClient(ecli *clientv3.Client) articlev1.ArticleServiceClient {
 	if err != nil {
 		panic(err)
 	}
-	return articlev1.NewArticleServiceClient(cc)
+	return cc
 }
@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/spf13/viper"
+)
+
+// InitArticleCiteHandler 引用里面的 URL 要用前端对外的域名，不是 bff 自己的地址
+func InitArticleCiteHandler(svc articlev1.ArticleServiceClient, l logger.LoggerV1) *web.ArticleCiteHandler {
+	baseURL := viper.GetString("site.baseURL")
+	if baseURL == "" {
+		panic("没有配置 site.baseURL")
+	}
+	return web.NewArticleCiteHandler(svc, l, baseURL)
+}
@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	tagv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/tag/v1"
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func InitTagClient(ecli *clientv3.Client) tagv1.TagServiceClient {
+	type Config struct {
+		Target string `json:"target"`
+		Secure bool   `json:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.tag", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	rs, err := resolver.NewBuilder(ecli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(rs)}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.Dial(cfg.Target, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return tagv1.NewTagServiceClient(cc)
+}
@@ -0,0 +1,38 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/bff/transfer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+)
+
+// InitTransferFileStore 导出的文件放在兼容 S3 协议的对象存储里面
+// bucket 要配置一天过期的生命周期规则，和任务状态保留的时间一致
+func InitTransferFileStore() transfer.FileStore {
+	type Config struct {
+		Endpoint  string `yaml:"endpoint"`
+		Region    string `yaml:"region"`
+		AccessKey string `yaml:"accessKey"`
+		SecretKey string `yaml:"secretKey"`
+		Bucket    string `yaml:"bucket"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("transfer", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""),
+		Region:      aws.String(cfg.Region),
+		Endpoint:    aws.String(cfg.Endpoint),
+		// 强制使用 /bucket/key 的形态
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return transfer.NewS3FileStore(s3.New(sess), cfg.Bucket)
+}
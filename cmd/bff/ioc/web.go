@@ -20,6 +20,11 @@ func InitGinServer(
 	jwtHdl ijwt.Handler,
 	user *web.UserHandler,
 	article *web.ArticleHandler,
+	transfer *web.ArticleTransferHandler,
+	citation *web.ArticleCitationHandler,
+	cite *web.ArticleCiteHandler,
+	visibility *web.ArticleVisibilityHandler,
+	collection *web.CollectionHandler,
 	reward *web.RewardHandler) *ginx.Server {
 	engine := gin.Default()
 	engine.Use(
@@ -28,6 +33,11 @@ func InitGinServer(
 		middleware.NewJWTLoginMiddlewareBuilder(jwtHdl).Build())
 	user.RegisterRoutes(engine)
 	article.RegisterRoutes(engine)
+	transfer.RegisterRoutes(engine)
+	citation.RegisterRoutes(engine)
+	cite.RegisterRoutes(engine)
+	visibility.RegisterRoutes(engine)
+	collection.RegisterRoutes(engine)
 	reward.RegisterRoutes(engine)
 	addr := viper.GetString("http.addr")
 	ginx.InitCounter(prometheus.CounterOpts{
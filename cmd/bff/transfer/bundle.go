@@ -0,0 +1,202 @@
+package transfer
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	manifestName    = "manifest.json"
+	manifestVersion = 1
+
+	// 导入的时候的限制，防止压缩炸弹
+	maxBundleArticles = 2000
+	maxArticleSize    = 4 << 20
+	maxManifestSize   = 4 << 20
+)
+
+var ErrInvalidBundle = errors.New("导入的文件不是合法的导出包")
+
+// Manifest 导出包里面的 manifest.json，帖子的内容放在 File 指向的 Markdown 文件里面
+type Manifest struct {
+	Version    int            `json:"version"`
+	Author     int64          `json:"author"`
+	ExportedAt time.Time      `json:"exportedAt"`
+	Articles   []ManifestItem `json:"articles"`
+}
+
+type ManifestItem struct {
+	Id     int64     `json:"id"`
+	Title  string    `json:"title"`
+	File   string    `json:"file"`
+	Status string    `json:"status"`
+	Tags   []string  `json:"tags,omitempty"`
+	Ctime  time.Time `json:"ctime"`
+	Utime  time.Time `json:"utime"`
+	// Sha256 标题和内容的指纹，导入的时候用来去重，见 Fingerprint
+	Sha256 string `json:"sha256"`
+}
+
+// Article 导出导入过程中的帖子
+type Article struct {
+	Id      int64
+	Title   string
+	Content string
+	Status  int32
+	Tags    []string
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// Fingerprint 标题和内容都一样才认为是同一篇帖子
+func Fingerprint(title, content string) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, title)
+	_, _ = h.Write([]byte{0})
+	_, _ = io.WriteString(h, content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Pack 把帖子打包成 zip，每一篇帖子一个 Markdown 文件
+func Pack(w io.Writer, author int64, arts []Article, now time.Time) error {
+	zw := zip.NewWriter(w)
+	m := Manifest{
+		Version:    manifestVersion,
+		Author:     author,
+		ExportedAt: now,
+		Articles:   make([]ManifestItem, 0, len(arts)),
+	}
+	for i, art := range arts {
+		item := ManifestItem{
+			Id:     art.Id,
+			Title:  art.Title,
+			File:   fmt.Sprintf("articles/%04d-%d.md", i+1, art.Id),
+			Status: statusName(art.Status),
+			Tags:   art.Tags,
+			Ctime:  art.Ctime,
+			Utime:  art.Utime,
+			Sha256: Fingerprint(art.Title, art.Content),
+		}
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     item.File,
+			Method:   zip.Deflate,
+			Modified: art.Utime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(f, art.Content); err != nil {
+			return err
+		}
+		m.Articles = append(m.Articles, item)
+	}
+	f, err := zw.Create(manifestName)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(m); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Unpack 只读取 manifest 里面列出来的文件，别的文件直接忽略
+func Unpack(r io.ReaderAt, size int64) ([]Article, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	mf, ok := files[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("%w: 缺少 %s", ErrInvalidBundle, manifestName)
+	}
+	data, err := readLimited(mf, maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("%w: 不支持的版本 %d", ErrInvalidBundle, m.Version)
+	}
+	if len(m.Articles) > maxBundleArticles {
+		return nil, fmt.Errorf("%w: 帖子太多了", ErrInvalidBundle)
+	}
+	res := make([]Article, 0, len(m.Articles))
+	for _, item := range m.Articles {
+		f, ok := files[item.File]
+		if !ok {
+			return nil, fmt.Errorf("%w: 缺少 %s", ErrInvalidBundle, item.File)
+		}
+		content, err := readLimited(f, maxArticleSize)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, Article{
+			Id:      item.Id,
+			Title:   item.Title,
+			Content: string(content),
+			Status:  statusValue(item.Status),
+			Tags:    item.Tags,
+			Ctime:   item.Ctime,
+			Utime:   item.Utime,
+		})
+	}
+	return res, nil
+}
+
+// readLimited 不相信 zip 里面记录的大小，按照实际读出来的算
+func readLimited(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: %s 太大了", ErrInvalidBundle, f.Name)
+	}
+	return data, nil
+}
+
+// 和 article 服务里面 domain.ArticleStatus 的取值一致
+var statusNames = map[int32]string{
+	1: "unpublished",
+	2: "published",
+	3: "private",
+	4: "scheduled",
+	5: "under_review",
+}
+
+func statusName(status int32) string {
+	if name, ok := statusNames[status]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func statusValue(name string) int32 {
+	for k, v := range statusNames {
+		if v == name {
+			return k
+		}
+	}
+	return 0
+}
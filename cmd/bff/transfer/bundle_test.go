@@ -0,0 +1,81 @@
+package transfer
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpack(t *testing.T) {
+	now := time.UnixMilli(1700000000000).UTC()
+	arts := []Article{
+		{Id: 1, Title: "第一篇", Content: "# 标题\n内容", Status: 2,
+			Tags: []string{"Go", "分布式"}, Ctime: now, Utime: now},
+		{Id: 2, Title: "草稿", Content: "", Status: 1, Ctime: now, Utime: now},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Pack(&buf, 123, arts, now))
+
+	res, err := Unpack(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, arts, res)
+}
+
+func TestUnpack(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "不是 zip",
+			files: nil,
+		},
+		{
+			name:  "没有 manifest",
+			files: map[string]string{"articles/0001-1.md": "内容"},
+		},
+		{
+			name: "版本不对",
+			files: map[string]string{
+				manifestName: `{"version":2,"articles":[]}`,
+			},
+		},
+		{
+			name: "缺少帖子的文件",
+			files: map[string]string{
+				manifestName: `{"version":1,"articles":[{"id":1,"file":"articles/0001-1.md"}]}`,
+			},
+		},
+		{
+			name: "帖子太大",
+			files: map[string]string{
+				manifestName:         `{"version":1,"articles":[{"id":1,"file":"articles/0001-1.md"}]}`,
+				"articles/0001-1.md": strings.Repeat("a", maxArticleSize+1),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if tc.files == nil {
+				buf.WriteString("hello")
+			} else {
+				zw := zip.NewWriter(&buf)
+				for name, content := range tc.files {
+					f, err := zw.Create(name)
+					require.NoError(t, err)
+					_, err = f.Write([]byte(content))
+					require.NoError(t, err)
+				}
+				require.NoError(t, zw.Close())
+			}
+			_, err := Unpack(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			assert.ErrorIs(t, err, ErrInvalidBundle)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// FileStore 导出的文件可能很大，放在对象存储里面，Redis 里面的任务只记录对象的 key
+// 过期清理交给对象存储的生命周期规则
+type FileStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get 不存在返回 ErrJobNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type S3FileStore struct {
+	oss    *s3.S3
+	bucket string
+}
+
+func NewS3FileStore(oss *s3.S3, bucket string) FileStore {
+	return &S3FileStore{
+		oss:    oss,
+		bucket: bucket,
+	}
+}
+
+func (s *S3FileStore) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.oss.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/zip"),
+	})
+	return err
+}
+
+func (s *S3FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.oss.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var aerr awserr.RequestFailure
+		if errors.As(err, &aerr) && aerr.StatusCode() == http.StatusNotFound {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
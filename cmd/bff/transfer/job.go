@@ -0,0 +1,95 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrJobNotFound = errors.New("任务不存在或者已经过期了")
+
+type JobKind string
+
+const (
+	JobKindExport JobKind = "export"
+	JobKindImport JobKind = "import"
+)
+
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Job 导出或者导入的异步任务，前端轮询状态
+type Job struct {
+	Id     string    `json:"id"`
+	Uid    int64     `json:"uid"`
+	Kind   JobKind   `json:"kind"`
+	Status JobStatus `json:"status"`
+	// Total 一共要处理多少篇，Done 已经处理了多少篇
+	Total int `json:"total"`
+	Done  int `json:"done"`
+	// Created 和 Skipped 只有导入才有，Skipped 是重复而跳过的
+	Created []int64 `json:"created,omitempty"`
+	Skipped int     `json:"skipped"`
+	// File 导出的文件在对象存储里面的 key，不返回给前端
+	File  string    `json:"-"`
+	Error string    `json:"error,omitempty"`
+	Ctime time.Time `json:"ctime"`
+	Utime time.Time `json:"utime"`
+}
+
+func (j Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
+
+// JobStore 任务在哪个 BFF 实例上执行都可以查到
+type JobStore interface {
+	Save(ctx context.Context, job Job) error
+	Get(ctx context.Context, id string) (Job, error)
+}
+
+type RedisJobStore struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisJobStore(client redis.Cmdable) JobStore {
+	return &RedisJobStore{
+		client: client,
+		// 任务状态保留这么久，对象存储里面的文件也要配置同样的生命周期
+		expiration: time.Hour * 24,
+	}
+}
+
+func (s *RedisJobStore) Save(ctx context.Context, job Job) error {
+	val, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.key(job.Id), val, s.expiration).Err()
+}
+
+func (s *RedisJobStore) Get(ctx context.Context, id string) (Job, error) {
+	val, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	var job Job
+	err = json.Unmarshal(val, &job)
+	return job, err
+}
+
+func (s *RedisJobStore) key(id string) string {
+	return fmt.Sprintf("article:transfer:job:%s", id)
+}
@@ -0,0 +1,295 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	tagv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/tag/v1"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const tagBiz = "article"
+
+// Service 作者批量导出和导入帖子
+// 任务在当前实例上用 goroutine 执行，状态放在 JobStore 里面，所以轮询可以落到任何实例上
+// 实例在执行过程中重启的话，任务会一直停留在 running，前端超时之后重新发起就可以
+type Service struct {
+	articles articlev1.ArticleServiceClient
+	tags     tagv1.TagServiceClient
+	store    JobStore
+	files    FileStore
+	l        logger.LoggerV1
+	// timeout 单个任务最多执行多久
+	timeout   time.Duration
+	batchSize int32
+}
+
+func NewService(articles articlev1.ArticleServiceClient,
+	tags tagv1.TagServiceClient,
+	store JobStore,
+	files FileStore,
+	l logger.LoggerV1) *Service {
+	return &Service{
+		articles:  articles,
+		tags:      tags,
+		store:     store,
+		files:     files,
+		l:         l,
+		timeout:   time.Minute * 10,
+		batchSize: 100,
+	}
+}
+
+// StartExport 导出 uid 创建的所有帖子，回收站里面的不导出
+func (s *Service) StartExport(ctx context.Context, uid int64) (Job, error) {
+	job := s.newJob(uid, JobKindExport)
+	if err := s.store.Save(ctx, job); err != nil {
+		return Job{}, err
+	}
+	go s.run(job, s.export)
+	return job, nil
+}
+
+// StartImport 先同步解析，导入的文件有问题的话直接返回 ErrInvalidBundle
+// 每一篇帖子都作为草稿通过 ArticleService.Save 创建
+func (s *Service) StartImport(ctx context.Context, uid int64, data []byte) (Job, error) {
+	arts, err := Unpack(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Job{}, err
+	}
+	job := s.newJob(uid, JobKindImport)
+	job.Total = len(arts)
+	if err = s.store.Save(ctx, job); err != nil {
+		return Job{}, err
+	}
+	go s.run(job, func(ctx context.Context, job *Job) error {
+		return s.importArticles(ctx, job, arts)
+	})
+	return job, nil
+}
+
+// Job 只能查看自己的任务
+func (s *Service) Job(ctx context.Context, uid int64, id string) (Job, error) {
+	job, err := s.store.Get(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Uid != uid {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Download 导出任务成功之后才能下载
+func (s *Service) Download(ctx context.Context, uid int64, id string) ([]byte, error) {
+	job, err := s.Job(ctx, uid, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Kind != JobKindExport || job.Status != JobStatusSucceeded {
+		return nil, ErrJobNotFound
+	}
+	return s.files.Get(ctx, job.File)
+}
+
+func (s *Service) newJob(uid int64, kind JobKind) Job {
+	now := time.Now()
+	return Job{
+		Id:     uuid.New().String(),
+		Uid:    uid,
+		Kind:   kind,
+		Status: JobStatusRunning,
+		Ctime:  now,
+		Utime:  now,
+	}
+}
+
+func (s *Service) run(job Job, fn func(ctx context.Context, job *Job) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	err := fn(ctx, &job)
+	cancel()
+	job.Status = JobStatusSucceeded
+	if err != nil {
+		s.l.Error("导出导入帖子失败",
+			logger.String("job", job.Id),
+			logger.Int64("uid", job.Uid),
+			logger.Error(err))
+		job.Status = JobStatusFailed
+		job.Error = "系统错误"
+	}
+	// 任务超时的时候 ctx 已经过期了，最终状态要用新的 ctx 保存，不然会一直停留在 running
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	s.progress(ctx, &job)
+}
+
+// progress 进度保存失败不影响任务本身
+func (s *Service) progress(ctx context.Context, job *Job) {
+	job.Utime = time.Now()
+	if err := s.store.Save(ctx, *job); err != nil {
+		s.l.Error("保存任务进度失败", logger.String("job", job.Id), logger.Error(err))
+	}
+}
+
+func (s *Service) export(ctx context.Context, job *Job) error {
+	arts, err := s.listOwned(ctx, job.Uid)
+	if err != nil {
+		return err
+	}
+	job.Total = len(arts)
+	s.progress(ctx, job)
+	res := make([]Article, 0, len(arts))
+	for _, art := range arts {
+		tags, err := s.articleTags(ctx, job.Uid, art.GetId())
+		if err != nil {
+			return err
+		}
+		res = append(res, Article{
+			Id:      art.GetId(),
+			Title:   art.GetTitle(),
+			Content: art.GetContent(),
+			Status:  art.GetStatus(),
+			Tags:    tags,
+			Ctime:   art.GetCtime().AsTime(),
+			Utime:   art.GetUtime().AsTime(),
+		})
+		job.Done++
+		s.progress(ctx, job)
+	}
+	var buf bytes.Buffer
+	if err = Pack(&buf, job.Uid, res, time.Now()); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("transfer/export/%d/%s.zip", job.Uid, job.Id)
+	if err = s.files.Put(ctx, key, buf.Bytes()); err != nil {
+		return err
+	}
+	job.File = key
+	return nil
+}
+
+func (s *Service) importArticles(ctx context.Context, job *Job, arts []Article) error {
+	existing, err := s.listOwned(ctx, job.Uid)
+	if err != nil {
+		return err
+	}
+	// 已有的帖子和导入包里面前面的帖子都算重复
+	seen := make(map[string]struct{}, len(existing)+len(arts))
+	for _, art := range existing {
+		seen[Fingerprint(art.GetTitle(), art.GetContent())] = struct{}{}
+	}
+	tagIds, err := s.userTags(ctx, job.Uid)
+	if err != nil {
+		return err
+	}
+	for _, art := range arts {
+		fp := Fingerprint(art.Title, art.Content)
+		if _, ok := seen[fp]; ok {
+			job.Skipped++
+		} else {
+			seen[fp] = struct{}{}
+			resp, err := s.articles.Save(ctx, &articlev1.SaveRequest{
+				Article: &articlev1.Article{
+					Title:   art.Title,
+					Content: art.Content,
+					Author:  &articlev1.Author{Id: job.Uid},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			job.Created = append(job.Created, resp.GetId())
+			s.attachTags(ctx, job.Uid, resp.GetId(), art.Tags, tagIds)
+		}
+		job.Done++
+		s.progress(ctx, job)
+	}
+	return nil
+}
+
+// listOwned 作者列表里面还有别人邀请自己的帖子，只导出自己创建的
+func (s *Service) listOwned(ctx context.Context, uid int64) ([]*articlev1.Article, error) {
+	var (
+		res    []*articlev1.Article
+		cursor string
+	)
+	for {
+		resp, err := s.articles.List(ctx, &articlev1.ListRequest{
+			Author: uid,
+			Limit:  s.batchSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range resp.GetArticles() {
+			if art.GetAuthor().GetId() == uid {
+				res = append(res, art)
+			}
+		}
+		cursor = resp.GetNextCursor()
+		if cursor == "" {
+			return res, nil
+		}
+	}
+}
+
+func (s *Service) articleTags(ctx context.Context, uid, aid int64) ([]string, error) {
+	resp, err := s.tags.GetBizTags(ctx, &tagv1.GetBizTagsRequest{
+		Uid: uid, Biz: tagBiz, BizId: aid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(resp.GetTags()))
+	for _, t := range resp.GetTags() {
+		res = append(res, t.GetName())
+	}
+	return res, nil
+}
+
+// userTags 标签名字到 ID 的映射，导入的时候没有的标签会创建出来并且加进去
+func (s *Service) userTags(ctx context.Context, uid int64) (map[string]int64, error) {
+	resp, err := s.tags.GetTags(ctx, &tagv1.GetTagsRequest{Uid: uid})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(resp.GetTag()))
+	for _, t := range resp.GetTag() {
+		res[t.GetName()] = t.GetId()
+	}
+	return res, nil
+}
+
+// attachTags 标签是锦上添花，失败了帖子照样导入
+func (s *Service) attachTags(ctx context.Context, uid, aid int64,
+	names []string, tagIds map[string]int64) {
+	if len(names) == 0 {
+		return
+	}
+	tids := make([]int64, 0, len(names))
+	for _, name := range names {
+		tid, ok := tagIds[name]
+		if !ok {
+			resp, err := s.tags.CreateTag(ctx, &tagv1.CreateTagRequest{Uid: uid, Name: name})
+			if err != nil {
+				s.l.Error("创建标签失败", logger.Int64("uid", uid),
+					logger.String("tag", name), logger.Error(err))
+				continue
+			}
+			tid = resp.GetTag().GetId()
+			tagIds[name] = tid
+		}
+		tids = append(tids, tid)
+	}
+	_, err := s.tags.AttachTags(ctx, &tagv1.AttachTagsRequest{
+		Biz: tagBiz, BizId: aid, Uid: uid, Tids: tids,
+	})
+	if err != nil {
+		s.l.Error("给导入的帖子打标签失败", logger.Int64("aid", aid), logger.Error(err))
+	}
+}
@@ -0,0 +1,135 @@
+package transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	artmocks "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1/mocks"
+	tagv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/tag/v1"
+	tagmocks "github.com/XD/ScholarNet/cmd/api/proto/gen/tag/v1/mocks"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type memoryJobStore struct {
+	jobs map[string]Job
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{jobs: map[string]Job{}}
+}
+
+func (m *memoryJobStore) Save(ctx context.Context, job Job) error {
+	// 和 Redis 一样，ctx 过期了就保存不了
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.jobs[job.Id] = job
+	return nil
+}
+
+func (m *memoryJobStore) Get(ctx context.Context, id string) (Job, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+type memoryFileStore map[string][]byte
+
+func (m memoryFileStore) Put(ctx context.Context, key string, data []byte) error {
+	m[key] = data
+	return nil
+}
+
+func (m memoryFileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return data, nil
+}
+
+func TestService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	arts := artmocks.NewMockArticleServiceClient(ctrl)
+	tags := tagmocks.NewMockTagServiceClient(ctrl)
+
+	// 已经有一篇一样的了，另外一篇是别人邀请的，不算
+	arts.EXPECT().List(gomock.Any(), &articlev1.ListRequest{Author: 123, Limit: 100}).
+		Return(&articlev1.ListResponse{
+			Articles: []*articlev1.Article{
+				{Id: 1, Title: "已有", Content: "内容", Author: &articlev1.Author{Id: 123}},
+				{Id: 2, Title: "别人的", Content: "内容", Author: &articlev1.Author{Id: 456}},
+			},
+		}, nil)
+	tags.EXPECT().GetTags(gomock.Any(), &tagv1.GetTagsRequest{Uid: 123}).
+		Return(&tagv1.GetTagsResponse{Tag: []*tagv1.Tag{{Id: 7, Name: "Go"}}}, nil)
+	arts.EXPECT().Save(gomock.Any(), &articlev1.SaveRequest{
+		Article: &articlev1.Article{Title: "别人的", Content: "内容",
+			Author: &articlev1.Author{Id: 123}},
+	}).Return(&articlev1.SaveResponse{Id: 10}, nil)
+	tags.EXPECT().CreateTag(gomock.Any(), &tagv1.CreateTagRequest{Uid: 123, Name: "分布式"}).
+		Return(&tagv1.CreateTagResponse{Tag: &tagv1.Tag{Id: 8, Name: "分布式"}}, nil)
+	tags.EXPECT().AttachTags(gomock.Any(), &tagv1.AttachTagsRequest{
+		Biz: "article", BizId: 10, Uid: 123, Tids: []int64{7, 8},
+	}).Return(&tagv1.AttachTagsResponse{}, nil)
+
+	store := newMemoryJobStore()
+	svc := NewService(arts, tags, store, memoryFileStore{}, logger.NewNoOpLogger())
+	job := svc.newJob(123, JobKindImport)
+	err := svc.importArticles(context.Background(), &job, []Article{
+		{Title: "已有", Content: "内容"},
+		{Title: "别人的", Content: "内容", Tags: []string{"Go", "分布式"}},
+		// 导入包里面自己重复的
+		{Title: "别人的", Content: "内容"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, job.Created)
+	assert.Equal(t, 2, job.Skipped)
+	assert.Equal(t, 3, job.Done)
+	assert.Equal(t, job.Done, store.jobs[job.Id].Done)
+}
+
+func TestService_Download(t *testing.T) {
+	store := newMemoryJobStore()
+	files := memoryFileStore{}
+	svc := NewService(nil, nil, store, files, logger.NewNoOpLogger())
+	running := svc.newJob(123, JobKindExport)
+	running.File = "running.zip"
+	done := svc.newJob(123, JobKindExport)
+	done.Status = JobStatusSucceeded
+	done.File = "done.zip"
+	for _, job := range []Job{running, done} {
+		require.NoError(t, store.Save(context.Background(), job))
+		require.NoError(t, files.Put(context.Background(), job.File, []byte("zip")))
+	}
+
+	_, err := svc.Download(context.Background(), 123, running.Id)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	// 别人的任务
+	_, err = svc.Download(context.Background(), 456, done.Id)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	data, err := svc.Download(context.Background(), 123, done.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("zip"), data)
+}
+
+func TestService_RunTimeout(t *testing.T) {
+	store := newMemoryJobStore()
+	svc := NewService(nil, nil, store, memoryFileStore{}, logger.NewNoOpLogger())
+	svc.timeout = time.Millisecond
+	job := svc.newJob(123, JobKindExport)
+	svc.run(job, func(ctx context.Context, job *Job) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	// 超时之后最终状态还是要保存下来，不能一直停留在 running
+	assert.Equal(t, JobStatusFailed, store.jobs[job.Id].Status)
+}
@@ -5,12 +5,16 @@ import (
 	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
 	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
 	rewardv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/reward/v1"
+	"github.com/XD/ScholarNet/cmd/article/errs"
 	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
 	"github.com/XD/ScholarNet/cmd/pkg/ginx"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/http"
 	"strconv"
 	"time"
@@ -51,12 +55,45 @@ func (a *ArticleHandler) RegisterRoutes(s *gin.Engine) {
 	g.POST("/edit", a.Edit)
 	g.POST("/publish", a.Publish)
 	g.POST("/withdraw", a.Withdraw)
+	// 定时发表
+	g.POST("/schedule/cancel", ginx.WrapClaimsAndReq[ScheduleReq](a.CancelSchedule))
+	g.POST("/schedule/reschedule", ginx.WrapClaimsAndReq[ScheduleReq](a.Reschedule))
+	// 共同作者
+	g.POST("/delete", ginx.WrapClaimsAndReq[RecycleReq](a.Delete))
+	recycle := g.Group("/recycle_bin")
+	recycle.POST("/list", ginx.WrapClaimsAndReq[Page](a.ListDeleted))
+	recycle.POST("/restore", ginx.WrapClaimsAndReq[RecycleReq](a.RestoreDeleted))
+
+	co := g.Group("/coauthors")
+	co.POST("/invite", ginx.WrapClaimsAndReq[CoAuthorReq](a.InviteCoAuthor))
+	co.POST("/respond", ginx.WrapClaimsAndReq[RespondInvitationReq](a.RespondInvitation))
+	co.POST("/remove", ginx.WrapClaimsAndReq[CoAuthorReq](a.RemoveCoAuthor))
+	co.POST("/list", ginx.WrapClaimsAndReq[CoAuthorReq](a.ListCoAuthors))
+	co.POST("/invitations", ginx.WrapClaimsAndReq[Page](a.ListInvitations))
 
 	pub := g.Group("/pub")
 	//pub.GET("/pub", a.PubList)
 	pub.GET("/:id", ginx.WrapClaims(a.PubDetail))
 	pub.POST("/like", ginx.WrapClaimsAndReq[LikeReq](a.Like))
+	// 表态，点赞也是一种表态
+	pub.POST("/react", ginx.WrapClaimsAndReq[ReactReq](a.React))
 	pub.POST("/collect", ginx.WrapClaimsAndReq[CollectReq](a.Collect))
+	pub.POST("/collect/cancel", ginx.WrapClaimsAndReq[CollectReq](a.CancelCollect))
+	// 挪到 cid 这个收藏夹
+	pub.POST("/collect/move", ginx.WrapClaimsAndReq[CollectReq](a.MoveCollect))
+	// 我点赞过的和我收藏的帖子，按照时间倒序
+	pub.POST("/liked", ginx.WrapClaimsAndReq[CursorPage](a.ListLiked))
+	pub.POST("/collected", ginx.WrapClaimsAndReq[CursorPage](a.ListCollected))
+	// 阅读历史，打开帖子的时候会自动记录，阅读的时候上报进度
+	history := pub.Group("/history")
+	history.POST("", ginx.WrapClaimsAndReq[CursorPage](a.ListReadHistory))
+	history.POST("/progress", ginx.WrapClaimsAndReq[ReadProgressReq](a.ReportReadProgress))
+	// 上次读到了哪里，方便接着往下看
+	history.POST("/resume", ginx.WrapClaimsAndReq[ResumeReq](a.GetReadProgress))
+	history.POST("/clear", ginx.WrapClaims(a.ClearReadHistory))
+	history.POST("/pause", ginx.WrapClaimsAndReq[PauseHistoryReq](a.PauseReadHistory))
+	// 最近每一天的独立读者数
+	pub.POST("/readers", ginx.WrapClaimsAndReq[DailyReadersReq](a.DailyReaders))
 	// 打赏
 	pub.POST("/reward", ginx.WrapClaimsAndReq[RewardReq](a.Reward))
 }
@@ -133,8 +170,9 @@ func (a *ArticleHandler) List(ctx *gin.Context, req ListReq, usr jwt.UserClaims)
 					//Content: src.Content,
 					// 这个是创作者看自己的文章列表，也不需要这个字段
 					//Author: src.Author
-					Ctime: src.Ctime.AsTime().Format(time.DateTime),
-					Utime: src.Utime.AsTime().Format(time.DateTime),
+					Ctime:     src.Ctime.AsTime().Format(time.DateTime),
+					Utime:     src.Utime.AsTime().Format(time.DateTime),
+					PublishAt: formatPublishAt(src),
 				}
 			}),
 	}, nil
@@ -171,7 +209,8 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 	}
 	art := resp.GetArticle()
 	// 这是不借助数据库查询来判定的方法
-	if art.Author.Id != usr.Id {
+	// 不是创建者的话，再看看是不是共同作者
+	if art.Author.Id != usr.Id && !a.isCoAuthor(ctx, usr.Id, id) {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 4,
 			// 也不需要告诉前端究竟发生了什么
@@ -190,6 +229,7 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 			//Abstract: art.Abstract(),
 			Status:  art.Status,
 			Content: art.Content,
+			Version: art.Version,
 			// 这个是创作者看自己的文章列表，也不需要这个字段
 			//Author: art.Author
 			Ctime: art.Ctime.AsTime().Format(time.DateTime),
@@ -213,7 +253,7 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 		a.l.Error("获得用户会话信息失败")
 		return
 	}
-	idResp, err := a.svc.Publish(ctx, &articlev1.PublishRequest{
+	pubReq := &articlev1.PublishRequest{
 		Article: &articlev1.Article{
 			Id:      req.Id,
 			Title:   req.Title,
@@ -222,7 +262,34 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 				Id: usr.Id,
 			},
 		},
-	})
+		Version: req.Version,
+	}
+	if req.PublishAt > 0 {
+		pubReq.PublishAt = timestamppb.New(time.UnixMilli(req.PublishAt))
+	}
+	idResp, err := a.svc.Publish(ctx, pubReq)
+	if status.Code(err) == codes.FailedPrecondition {
+		ctx.JSON(http.StatusOK, Result{
+			Code: errs.ArticleContentRejected,
+			Msg:  "内容违规",
+		})
+		return
+	}
+	if conflict, ok := versionConflict(err); ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: errs.ArticleVersionConflict,
+			Msg:  "文章已经在别的地方被修改过了",
+			Data: VersionConflictVo{CurrentVersion: conflict.GetCurrentVersion()},
+		})
+		return
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "输入有误",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -236,6 +303,39 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 	})
 }
 
+func (a *ArticleHandler) CancelSchedule(ctx *gin.Context, req ScheduleReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.CancelSchedule(ctx, &articlev1.CancelScheduleRequest{
+		Uid: usr.Id, Id: req.Id,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (a *ArticleHandler) Reschedule(ctx *gin.Context, req ScheduleReq, usr jwt.UserClaims) (ginx.Result, error) {
+	publishAt := time.UnixMilli(req.PublishAt)
+	if !publishAt.After(time.Now()) {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "定时发表的时间必须晚于当前时间",
+		}, nil
+	}
+	_, err := a.svc.Reschedule(ctx, &articlev1.RescheduleRequest{
+		Uid: usr.Id, Id: req.Id, PublishAt: timestamppb.New(publishAt),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
 func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	var req ArticleReq
 	if err := ctx.Bind(&req); err != nil {
@@ -251,7 +351,26 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 		a.l.Error("获得用户会话信息失败")
 		return
 	}
-	id, err := a.svc.Save(ctx, &articlev1.SaveRequest{Article: req.toDTO(usr.Id)})
+	id, err := a.svc.Save(ctx, &articlev1.SaveRequest{
+		Article: req.toDTO(usr.Id),
+		Version: req.Version,
+	})
+	if conflict, ok := versionConflict(err); ok {
+		// 不是系统错误，前端拿最新的版本合并之后再保存
+		ctx.JSON(http.StatusOK, Result{
+			Code: errs.ArticleVersionConflict,
+			Msg:  "文章已经在别的地方被修改过了",
+			Data: VersionConflictVo{CurrentVersion: conflict.GetCurrentVersion()},
+		})
+		return
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "输入有误",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -265,6 +384,40 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	})
 }
 
+// versionConflict 从 gRPC 的错误里面取出版本冲突的信息
+func versionConflict(err error) (*articlev1.VersionConflict, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return nil, false
+	}
+	for _, detail := range st.Details() {
+		if conflict, ok := detail.(*articlev1.VersionConflict); ok {
+			return conflict, true
+		}
+	}
+	return nil, false
+}
+
+const (
+	deviceIdHeader = "X-Device-Id"
+	deviceIdCookie = "device_id"
+	// maxDeviceIdLen 设备 ID 是前端随便带的，太长的直接不要
+	maxDeviceIdLen = 64
+)
+
+// deviceId 取前端带上来的设备 ID，先看 header 再看 cookie，没有就是空字符串
+// 只用来给没有登录的读者去重，不做别的校验
+func deviceId(ctx *gin.Context) string {
+	id := ctx.GetHeader(deviceIdHeader)
+	if id == "" {
+		id, _ = ctx.Cookie(deviceIdCookie)
+	}
+	if len(id) > maxDeviceIdLen {
+		return ""
+	}
+	return id
+}
+
 func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc ginx.UserClaims) (Result, error) {
 	idstr := ctx.Param("id")
 	id, err := strconv.ParseInt(idstr, 10, 64)
@@ -285,7 +438,7 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc ginx.UserClaims) (Result
 	eg.Go(func() error {
 		var er error
 		artResp, er = a.svc.GetPublishedById(ctx, &articlev1.GetPublishedByIdRequest{
-			Id: id, Uid: uc.Id,
+			Id: id, Uid: uc.Id, DeviceId: deviceId(ctx),
 		})
 		return er
 	})
@@ -299,7 +452,12 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc ginx.UserClaims) (Result
 	})
 
 	err = eg.Wait()
-
+	if status.Code(err) == codes.PermissionDenied {
+		return Result{
+			Code: 4,
+			Msg:  "没有权限查看这篇帖子",
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: 5,
@@ -318,19 +476,33 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc ginx.UserClaims) (Result
 	intr := intrResp.Intr
 	return Result{
 		Data: ArticleVo{
-			Id:      art.Id,
-			Title:   art.Title,
-			Status:  art.Status,
-			Content: art.Content,
+			Id:       art.Id,
+			Title:    art.Title,
+			Status:   art.Status,
+			Content:  art.Content,
+			Abstract: art.Abstract,
+			HTML:     art.Html,
+			TOC: slice.Map[*articlev1.TocItem, TocItemVo](art.Toc,
+				func(idx int, src *articlev1.TocItem) TocItemVo {
+					return TocItemVo{Level: src.Level, Title: src.Title, Anchor: src.Anchor}
+				}),
+			ReadingMinutes: art.ReadingMinutes,
+			SeriesNav:      newSeriesNavVo(artResp.GetSeriesNav()),
+			Visibility:     art.Visibility,
 			// 要把作者信息带出去
 			Author:     art.Author.Name,
+			Authors:    newAuthorVos(art.Authors),
 			Ctime:      art.Ctime.AsTime().Format(time.DateTime),
 			Utime:      art.Utime.AsTime().Format(time.DateTime),
 			ReadCnt:    intr.ReadCnt,
 			CollectCnt: intr.CollectCnt,
 			LikeCnt:    intr.LikeCnt,
+			CiteCnt:    intr.CiteCnt,
+			ReaderCnt:  intr.ReaderCnt,
+			Reactions:  intr.Reactions,
 			Liked:      intr.Liked,
 			Collected:  intr.Collected,
+			Reaction:   intr.Reaction,
 		},
 	}, nil
 }
@@ -356,13 +528,62 @@ func (a *ArticleHandler) Like(ctx *gin.Context, req LikeReq, uc jwt.UserClaims)
 	return Result{Msg: "OK"}, nil
 }
 
+// React reaction 为空就是取消表态
+func (a *ArticleHandler) React(ctx *gin.Context, req ReactReq, uc jwt.UserClaims) (ginx.Result, error) {
+	var err error
+	if req.Reaction == "" {
+		_, err = a.intrSvc.CancelLike(ctx, &intrv1.CancelLikeRequest{
+			Biz: a.biz, BizId: req.Id, Uid: uc.Id,
+		})
+	} else {
+		_, err = a.intrSvc.React(ctx, &intrv1.ReactRequest{
+			Biz: a.biz, BizId: req.Id, Uid: uc.Id, Reaction: req.Reaction,
+		})
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "不支持这种表态"}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (a *ArticleHandler) DailyReaders(ctx *gin.Context, req DailyReadersReq, uc jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.intrSvc.GetDailyReaders(ctx, &intrv1.GetDailyReadersRequest{
+		Biz: a.biz, BizId: req.Id, Days: req.Days,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	vos := make([]DailyReadersVo, 0, len(resp.GetDays()))
+	for _, d := range resp.GetDays() {
+		vos = append(vos, DailyReadersVo{Date: d.GetDate(), Cnt: d.GetCnt()})
+	}
+	return ginx.Result{Data: vos}, nil
+}
+
 func (a *ArticleHandler) Reward(
 	ctx *gin.Context,
 	req RewardReq,
 	uc jwt.UserClaims) (ginx.Result, error) {
 	artResp, err := a.svc.GetPublishedById(ctx.Request.Context(), &articlev1.GetPublishedByIdRequest{
-		Id: req.Id,
+		Id: req.Id, Uid: uc.Id,
 	})
+	if status.Code(err) == codes.PermissionDenied {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "没有权限查看这篇帖子",
+		}, nil
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -400,6 +621,29 @@ func (a *ArticleHandler) Collect(
 		Biz: a.biz, BizId: req.Id, Uid: uc.Id,
 		Cid: req.Cid,
 	})
+	switch status.Code(err) {
+	case codes.OK:
+		return Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return Result{Code: 4, Msg: "收藏夹不存在"}, nil
+	case codes.AlreadyExists:
+		return Result{Code: 4, Msg: "已经收藏过了"}, nil
+	default:
+		return Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+// CancelCollect 不管在哪个收藏夹里面都会取消，cid 不用传
+func (a *ArticleHandler) CancelCollect(
+	ctx *gin.Context,
+	req CollectReq,
+	uc jwt.UserClaims) (Result, error) {
+	_, err := a.intrSvc.CancelCollect(ctx, &intrv1.CancelCollectRequest{
+		Biz: a.biz, BizId: req.Id, Uid: uc.Id,
+	})
 	if err != nil {
 		return Result{
 			Code: 5,
@@ -408,3 +652,26 @@ func (a *ArticleHandler) Collect(
 	}
 	return Result{Msg: "OK"}, nil
 }
+
+func (a *ArticleHandler) MoveCollect(
+	ctx *gin.Context,
+	req CollectReq,
+	uc jwt.UserClaims) (Result, error) {
+	_, err := a.intrSvc.MoveCollectionItem(ctx, &intrv1.MoveCollectionItemRequest{
+		Biz: a.biz, BizId: req.Id, Uid: uc.Id,
+		Cid: req.Cid,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return Result{Code: 4, Msg: "收藏夹不存在"}, nil
+	case codes.FailedPrecondition:
+		return Result{Code: 4, Msg: "还没有收藏"}, nil
+	default:
+		return Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"time"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ArticleCitationHandler)(nil)
+
+// ArticleCitationHandler 帖子的引用，被引用的次数和点赞数一起在详情里面返回
+type ArticleCitationHandler struct {
+	svc articlev1.CitationServiceClient
+	l   logger.LoggerV1
+}
+
+func NewArticleCitationHandler(svc articlev1.CitationServiceClient, l logger.LoggerV1) *ArticleCitationHandler {
+	return &ArticleCitationHandler{svc: svc, l: l}
+}
+
+func (h *ArticleCitationHandler) RegisterRoutes(s *gin.Engine) {
+	g := s.Group("/articles/citations")
+	g.POST("/set", ginx.WrapClaimsAndReq[SetCitationsReq](h.SetCitations))
+	g.POST("/list", ginx.WrapReq[CitationReq](h.ListCitations))
+	g.POST("/cited_by", ginx.WrapReq[CitedByReq](h.ListCitedBy))
+}
+
+type CitationVo struct {
+	// CitedId 被引用的帖子，引用外部文献的时候是 0
+	CitedId int64  `json:"citedId,omitempty"`
+	DOI     string `json:"doi,omitempty"`
+	// 1 是作者声明的，2 是从正文的链接里面提取的
+	Source int32  `json:"source,omitempty"`
+	Ctime  string `json:"ctime,omitempty"`
+}
+
+type SetCitationsReq struct {
+	Id        int64        `json:"id"`
+	Citations []CitationVo `json:"citations"`
+}
+
+type CitationReq struct {
+	Id int64 `json:"id"`
+}
+
+type CitedByReq struct {
+	Id int64 `json:"id"`
+	Page
+}
+
+func (h *ArticleCitationHandler) SetCitations(ctx *gin.Context, req SetCitationsReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.SetCitations(ctx, &articlev1.SetCitationsRequest{
+		Uid:       usr.Id,
+		ArticleId: req.Id,
+		Citations: slice.Map[CitationVo, *articlev1.Citation](req.Citations,
+			func(idx int, src CitationVo) *articlev1.Citation {
+				return &articlev1.Citation{CitedId: src.CitedId, Doi: src.DOI}
+			}),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "帖子不存在或者你不是作者"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (h *ArticleCitationHandler) ListCitations(ctx *gin.Context, req CitationReq) (ginx.Result, error) {
+	resp, err := h.svc.ListCitations(ctx, &articlev1.ListCitationsRequest{ArticleId: req.Id})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.Citation, CitationVo](resp.GetCitations(),
+			func(idx int, src *articlev1.Citation) CitationVo {
+				return CitationVo{
+					CitedId: src.CitedId,
+					DOI:     src.Doi,
+					Source:  src.Source,
+					Ctime:   src.Ctime.AsTime().Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (h *ArticleCitationHandler) ListCitedBy(ctx *gin.Context, req CitedByReq) (ginx.Result, error) {
+	resp, err := h.svc.ListCitedBy(ctx, &articlev1.ListCitedByRequest{
+		ArticleId: req.Id, Offset: int32(req.Offset), Limit: int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.Article, ArticleVo](resp.GetArticles(),
+			func(idx int, src *articlev1.Article) ArticleVo {
+				return ArticleVo{
+					Id:       src.Id,
+					Title:    src.Title,
+					Abstract: src.Abstract,
+					Author:   src.Author.GetName(),
+					Ctime:    src.Ctime.AsTime().Format(time.DateTime),
+					Utime:    src.Utime.AsTime().Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
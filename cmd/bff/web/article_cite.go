@@ -0,0 +1,156 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/cite"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxCiteExport 批量导出一次最多多少篇
+	maxCiteExport = 100
+	// articleStatusPublished 和 article 里面的 domain.ArticleStatusPublished 一致
+	articleStatusPublished = 2
+)
+
+var _ handler = (*ArticleCiteHandler)(nil)
+
+// ArticleCiteHandler 把已经发表的帖子导出成 BibTeX、RIS 或者 CSL-JSON，方便在论文里面引用
+// 直接返回文件，不走 Result
+type ArticleCiteHandler struct {
+	svc articlev1.ArticleServiceClient
+	l   logger.LoggerV1
+	// baseURL 帖子对外的访问地址前缀，拼上 /articles/pub/{id} 就是引用里面的 URL
+	baseURL string
+}
+
+func NewArticleCiteHandler(svc articlev1.ArticleServiceClient, l logger.LoggerV1,
+	baseURL string) *ArticleCiteHandler {
+	return &ArticleCiteHandler{
+		svc:     svc,
+		l:       l,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (h *ArticleCiteHandler) RegisterRoutes(s *gin.Engine) {
+	s.GET("/articles/pub/:id/cite", h.Cite)
+	s.POST("/articles/cite/export", h.Export)
+}
+
+type CiteExportReq struct {
+	// Ids 要导出的帖子，一般是收藏夹里面的，按照这个顺序输出
+	Ids []int64 `json:"ids"`
+	// Format bibtex、ris 或者 csl，不传就是 bibtex
+	Format string `json:"format"`
+}
+
+// Cite 导出一篇帖子，格式放在 format 参数里面
+func (h *ArticleCiteHandler) Cite(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	format, err := cite.ParseFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "不支持的格式"})
+		return
+	}
+	entries, err := h.entries(ctx, h.viewer(ctx), []int64{id})
+	if err != nil {
+		h.l.Error("导出引用失败", logger.Int64("aid", id), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	if len(entries) == 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "帖子不存在、还没有发表或者没有权限查看"})
+		return
+	}
+	h.write(ctx, format, entries[0].Key(), entries)
+}
+
+// Export 批量导出，撤回了、删除了或者看不到的帖子直接跳过
+func (h *ArticleCiteHandler) Export(ctx *gin.Context) {
+	var req CiteExportReq
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if len(req.Ids) == 0 || len(req.Ids) > maxCiteExport {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: fmt.Sprintf("一次可以导出 1 到 %d 篇", maxCiteExport)})
+		return
+	}
+	format, err := cite.ParseFormat(req.Format)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "不支持的格式"})
+		return
+	}
+	entries, err := h.entries(ctx, h.viewer(ctx), req.Ids)
+	if err != nil {
+		h.l.Error("批量导出引用失败", logger.Int("cnt", len(req.Ids)), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	h.write(ctx, format, "citations", entries)
+}
+
+// viewer 导出的人，没有登录的时候是 0，只能导出公开的帖子
+func (h *ArticleCiteHandler) viewer(ctx *gin.Context) int64 {
+	uc, _ := ctx.Get("user")
+	usr, _ := uc.(jwt.UserClaims)
+	return usr.Id
+}
+
+// entries 一次查出所有帖子的引用信息，按照 ids 的顺序，
+// 帖子不存在、不是已发表状态或者 uid 看不到的直接跳过
+// 导出不算阅读，所以用 BatchGetPublished，作者的名字也是 article 服务一次查出来的
+func (h *ArticleCiteHandler) entries(ctx *gin.Context, uid int64, ids []int64) ([]cite.Entry, error) {
+	resp, err := h.svc.BatchGetPublished(ctx, &articlev1.BatchGetPublishedRequest{
+		Ids:      ids,
+		AsReader: true,
+		Uid:      uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]cite.Entry, 0, len(resp.GetArticles()))
+	for _, art := range resp.GetArticles() {
+		if art.GetStatus() != articleStatusPublished {
+			continue
+		}
+		authors := make([]string, 0, len(art.GetAuthors()))
+		for _, a := range art.GetAuthors() {
+			authors = append(authors, a.GetName())
+		}
+		if len(authors) == 0 && art.GetAuthor().GetName() != "" {
+			authors = append(authors, art.GetAuthor().GetName())
+		}
+		res = append(res, cite.Entry{
+			Id:      art.GetId(),
+			Title:   art.GetTitle(),
+			Authors: authors,
+			// 线上库的创建时间就是第一次发表的时间
+			Issued: art.GetCtime().AsTime().Local(),
+			URL:    fmt.Sprintf("%s/articles/pub/%d", h.baseURL, art.GetId()),
+		})
+	}
+	return res, nil
+}
+
+func (h *ArticleCiteHandler) write(ctx *gin.Context, format cite.Format, name string, entries []cite.Entry) {
+	data, err := cite.Render(format, entries)
+	if err != nil {
+		h.l.Error("渲染引用失败", logger.String("format", string(format)), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format.Ext()))
+	ctx.Data(http.StatusOK, format.ContentType(), data)
+}
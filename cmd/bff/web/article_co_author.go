@@ -0,0 +1,84 @@
+package web
+
+import (
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *ArticleHandler) InviteCoAuthor(ctx *gin.Context, req CoAuthorReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.InviteCoAuthor(ctx, &articlev1.InviteCoAuthorRequest{
+		Uid: usr.Id, Id: req.Id, Invitee: req.Uid,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "不能邀请自己"}, nil
+	case codes.AlreadyExists:
+		return ginx.Result{Code: 4, Msg: "已经邀请过了"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (a *ArticleHandler) RespondInvitation(ctx *gin.Context, req RespondInvitationReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.RespondInvitation(ctx, &articlev1.RespondInvitationRequest{
+		Uid: usr.Id, Id: req.Id, Accept: req.Accept,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "邀请不存在"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (a *ArticleHandler) RemoveCoAuthor(ctx *gin.Context, req CoAuthorReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.RemoveCoAuthor(ctx, &articlev1.RemoveCoAuthorRequest{
+		Uid: usr.Id, Id: req.Id, CoAuthor: req.Uid,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (a *ArticleHandler) ListCoAuthors(ctx *gin.Context, req CoAuthorReq, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.svc.ListCoAuthors(ctx, &articlev1.ListCoAuthorsRequest{
+		Uid: usr.Id, Id: req.Id,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Data: newCoAuthorVos(resp.GetCoAuthors())}, nil
+}
+
+func (a *ArticleHandler) ListInvitations(ctx *gin.Context, req Page, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.svc.ListInvitations(ctx, &articlev1.ListInvitationsRequest{
+		Uid: usr.Id, Offset: int32(req.Offset), Limit: int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Data: newCoAuthorVos(resp.GetInvitations())}, nil
+}
+
+// isCoAuthor 能够查看共同作者列表的，就是创建者或者已经接受邀请的共同作者
+func (a *ArticleHandler) isCoAuthor(ctx *gin.Context, uid, id int64) bool {
+	_, err := a.svc.ListCoAuthors(ctx, &articlev1.ListCoAuthorsRequest{
+		Uid: uid, Id: id,
+	})
+	if err != nil {
+		a.l.Debug("不是共同作者", logger.Int64("uid", uid),
+			logger.Int64("aid", id), logger.Error(err))
+		return false
+	}
+	return true
+}
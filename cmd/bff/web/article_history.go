@@ -0,0 +1,135 @@
+package web
+
+import (
+	"time"
+
+	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReadArticleVo struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	// Progress 读到了哪里，百分比
+	Progress int32 `json:"progress"`
+	// ReadTime 最后一次阅读的时间
+	ReadTime string `json:"readTime"`
+	Removed  bool   `json:"removed,omitempty"`
+}
+
+type ReadHistoryVo struct {
+	CursorList[ReadArticleVo]
+	// Paused 暂停了记录阅读历史
+	Paused bool `json:"paused"`
+}
+
+type ReadProgressVo struct {
+	// Progress 没有看过就是 0，从头开始看
+	Progress int32 `json:"progress"`
+	// ReadTime 没有看过就是空字符串
+	ReadTime string `json:"readTime,omitempty"`
+}
+
+func (a *ArticleHandler) ListReadHistory(ctx *gin.Context, req CursorPage, uc jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.intrSvc.ListReadHistory(ctx, &intrv1.ListReadHistoryRequest{
+		Uid:    uc.Id,
+		Biz:    a.biz,
+		Cursor: req.Cursor,
+		Limit:  int32(req.Limit),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	records := resp.GetRecords()
+	ids := make([]int64, 0, len(records))
+	for _, r := range records {
+		ids = append(ids, r.GetBizId())
+	}
+	arts, err := a.publishedArticles(ctx, uc.Id, ids)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	vos := make([]ReadArticleVo, 0, len(records))
+	for _, r := range records {
+		art, ok := arts[r.GetBizId()]
+		vos = append(vos, ReadArticleVo{
+			Id:       r.GetBizId(),
+			Title:    art.GetTitle(),
+			Abstract: art.GetAbstract(),
+			Progress: r.GetProgress(),
+			ReadTime: time.UnixMilli(r.GetReadTime()).Format(time.DateTime),
+			Removed:  !ok,
+		})
+	}
+	return ginx.Result{
+		Data: ReadHistoryVo{
+			CursorList: CursorList[ReadArticleVo]{Items: vos, NextCursor: resp.GetNextCursor()},
+			Paused:     resp.GetPaused(),
+		},
+	}, nil
+}
+
+func (a *ArticleHandler) ReportReadProgress(ctx *gin.Context, req ReadProgressReq, uc jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.intrSvc.ReportReadProgress(ctx, &intrv1.ReportReadProgressRequest{
+		Uid:      uc.Id,
+		Biz:      a.biz,
+		BizId:    req.Id,
+		Progress: req.Progress,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (a *ArticleHandler) GetReadProgress(ctx *gin.Context, req ResumeReq, uc jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.intrSvc.GetReadProgress(ctx, &intrv1.GetReadProgressRequest{
+		Uid:   uc.Id,
+		Biz:   a.biz,
+		BizId: req.Id,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	var vo ReadProgressVo
+	if r := resp.GetRecord(); r != nil {
+		vo.Progress = r.GetProgress()
+		vo.ReadTime = time.UnixMilli(r.GetReadTime()).Format(time.DateTime)
+	}
+	return ginx.Result{Data: vo}, nil
+}
+
+func (a *ArticleHandler) ClearReadHistory(ctx *gin.Context, uc jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.intrSvc.ClearReadHistory(ctx, &intrv1.ClearReadHistoryRequest{
+		Uid: uc.Id,
+		Biz: a.biz,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (a *ArticleHandler) PauseReadHistory(ctx *gin.Context, req PauseHistoryReq, uc jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.intrSvc.PauseReadHistory(ctx, &intrv1.PauseReadHistoryRequest{
+		Uid:    uc.Id,
+		Paused: req.Paused,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
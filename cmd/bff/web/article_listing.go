@@ -0,0 +1,135 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type LikedArticleVo struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	Reaction string `json:"reaction"`
+	// Utime 表态的时间
+	Utime string `json:"utime"`
+	// Removed 帖子已经撤回、删除了或者看不到了，只剩下 id，还是可以取消表态
+	Removed bool `json:"removed,omitempty"`
+}
+
+type CollectedArticleVo struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	// Cid 在哪个收藏夹，0 是默认收藏夹
+	Cid int64 `json:"cid"`
+	// Ctime 收藏的时间
+	Ctime   string `json:"ctime"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+func (a *ArticleHandler) ListLiked(ctx *gin.Context, req CursorPage, uc jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.intrSvc.ListLiked(ctx, &intrv1.ListLikedRequest{
+		Uid:    uc.Id,
+		Biz:    a.biz,
+		Cursor: req.Cursor,
+		Limit:  int32(req.Limit),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	items := resp.GetItems()
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.GetBizId())
+	}
+	arts, err := a.publishedArticles(ctx, uc.Id, ids)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	vos := make([]LikedArticleVo, 0, len(items))
+	for _, item := range items {
+		art, ok := arts[item.GetBizId()]
+		vos = append(vos, LikedArticleVo{
+			Id:       item.GetBizId(),
+			Title:    art.GetTitle(),
+			Abstract: art.GetAbstract(),
+			Reaction: item.GetReaction(),
+			Utime:    time.UnixMilli(item.GetUtime()).Format(time.DateTime),
+			Removed:  !ok,
+		})
+	}
+	return ginx.Result{
+		Data: CursorList[LikedArticleVo]{Items: vos, NextCursor: resp.GetNextCursor()},
+	}, nil
+}
+
+func (a *ArticleHandler) ListCollected(ctx *gin.Context, req CursorPage, uc jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.intrSvc.ListCollected(ctx, &intrv1.ListCollectedRequest{
+		Uid:    uc.Id,
+		Biz:    a.biz,
+		Cursor: req.Cursor,
+		Limit:  int32(req.Limit),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	items := resp.GetItems()
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.GetBizId())
+	}
+	arts, err := a.publishedArticles(ctx, uc.Id, ids)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	vos := make([]CollectedArticleVo, 0, len(items))
+	for _, item := range items {
+		art, ok := arts[item.GetBizId()]
+		vos = append(vos, CollectedArticleVo{
+			Id:       item.GetBizId(),
+			Title:    art.GetTitle(),
+			Abstract: art.GetAbstract(),
+			Cid:      item.GetCid(),
+			Ctime:    time.UnixMilli(item.GetCtime()).Format(time.DateTime),
+			Removed:  !ok,
+		})
+	}
+	return ginx.Result{
+		Data: CursorList[CollectedArticleVo]{Items: vos, NextCursor: resp.GetNextCursor()},
+	}, nil
+}
+
+// publishedArticles 一次查出来所有帖子的标题和摘要，撤回了、删除了或者 uid 现在看不到的不在里面
+// 点赞、收藏之后作者可能改了可见范围，所以要按照读者的身份来查
+func (a *ArticleHandler) publishedArticles(ctx context.Context, uid int64, ids []int64) (map[int64]*articlev1.Article, error) {
+	if len(ids) == 0 {
+		return map[int64]*articlev1.Article{}, nil
+	}
+	resp, err := a.svc.BatchGetPublished(ctx, &articlev1.BatchGetPublishedRequest{
+		Ids: ids, AsReader: true, Uid: uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]*articlev1.Article, len(resp.GetArticles()))
+	for _, art := range resp.GetArticles() {
+		res[art.GetId()] = art
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"time"
+
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *ArticleHandler) Delete(ctx *gin.Context, req RecycleReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.Delete(ctx, &articlev1.DeleteRequest{
+		Uid: usr.Id, Id: req.Id,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		// 共同作者不能删除
+		return ginx.Result{Code: 4, Msg: "帖子不存在或者不是你创建的"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (a *ArticleHandler) RestoreDeleted(ctx *gin.Context, req RecycleReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := a.svc.RestoreDeleted(ctx, &articlev1.RestoreDeletedRequest{
+		Uid: usr.Id, Id: req.Id,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "回收站里面没有这篇帖子，或者已经过期了"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (a *ArticleHandler) ListDeleted(ctx *gin.Context, req Page, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := a.svc.ListDeleted(ctx, &articlev1.ListDeletedRequest{
+		Uid: usr.Id, Offset: int32(req.Offset), Limit: int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.Article, ArticleVo](resp.GetArticles(),
+			func(idx int, src *articlev1.Article) ArticleVo {
+				return ArticleVo{
+					Id:       src.Id,
+					Title:    src.Title,
+					Abstract: src.Abstract,
+					Status:   src.Status,
+					Ctime:    src.Ctime.AsTime().Format(time.DateTime),
+					Utime:    src.Utime.AsTime().Format(time.DateTime),
+					Dtime:    src.Dtime.AsTime().Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/XD/ScholarNet/cmd/bff/transfer"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize 上传的导出包的大小上限
+const maxImportSize = 32 << 20
+
+var _ handler = (*ArticleTransferHandler)(nil)
+
+// ArticleTransferHandler 批量导出和导入帖子，都是异步任务，拿着任务 ID 轮询状态
+type ArticleTransferHandler struct {
+	svc *transfer.Service
+	l   logger.LoggerV1
+}
+
+func NewArticleTransferHandler(svc *transfer.Service, l logger.LoggerV1) *ArticleTransferHandler {
+	return &ArticleTransferHandler{svc: svc, l: l}
+}
+
+func (h *ArticleTransferHandler) RegisterRoutes(s *gin.Engine) {
+	g := s.Group("/articles/transfer")
+	g.POST("/export", ginx.WrapClaims(h.Export))
+	g.POST("/import", ginx.WrapClaimsAndReq[ImportReq](h.Import))
+	g.POST("/job", ginx.WrapClaimsAndReq[TransferJobReq](h.Job))
+	g.GET("/download/:id", h.Download)
+}
+
+type ImportReq struct {
+	File *multipart.FileHeader `form:"file"`
+}
+
+type TransferJobReq struct {
+	Id string `json:"id"`
+}
+
+func (h *ArticleTransferHandler) Export(ctx *gin.Context, usr jwt.UserClaims) (ginx.Result, error) {
+	job, err := h.svc.StartExport(ctx, usr.Id)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Data: job}, nil
+}
+
+func (h *ArticleTransferHandler) Import(ctx *gin.Context, req ImportReq, usr jwt.UserClaims) (ginx.Result, error) {
+	if req.File == nil {
+		return ginx.Result{Code: 4, Msg: "请上传导出的 zip 文件"}, nil
+	}
+	if req.File.Size > maxImportSize {
+		return ginx.Result{Code: 4, Msg: "文件太大了"}, nil
+	}
+	f, err := req.File.Open()
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxImportSize))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	job, err := h.svc.StartImport(ctx, usr.Id, data)
+	if errors.Is(err, transfer.ErrInvalidBundle) {
+		return ginx.Result{Code: 4, Msg: "文件格式不对"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Data: job}, nil
+}
+
+func (h *ArticleTransferHandler) Job(ctx *gin.Context, req TransferJobReq, usr jwt.UserClaims) (ginx.Result, error) {
+	job, err := h.svc.Job(ctx, usr.Id, req.Id)
+	if errors.Is(err, transfer.ErrJobNotFound) {
+		return ginx.Result{Code: 4, Msg: "任务不存在"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{Data: job}, nil
+}
+
+// Download 直接返回 zip 文件，不走 Result
+func (h *ArticleTransferHandler) Download(ctx *gin.Context) {
+	usr, ok := ctx.MustGet("user").(jwt.UserClaims)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	data, err := h.svc.Download(ctx, usr.Id, ctx.Param("id"))
+	if errors.Is(err, transfer.ErrJobNotFound) {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.l.Error("下载导出的帖子失败", logger.Error(err))
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="articles.zip"`)
+	ctx.Data(http.StatusOK, "application/zip", data)
+}
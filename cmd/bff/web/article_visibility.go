@@ -0,0 +1,83 @@
+package web
+
+import (
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ArticleVisibilityHandler)(nil)
+
+// ArticleVisibilityHandler 帖子的可见范围，检查是 article 服务在查询的时候做的
+type ArticleVisibilityHandler struct {
+	svc articlev1.VisibilityServiceClient
+	l   logger.LoggerV1
+}
+
+func NewArticleVisibilityHandler(svc articlev1.VisibilityServiceClient, l logger.LoggerV1) *ArticleVisibilityHandler {
+	return &ArticleVisibilityHandler{svc: svc, l: l}
+}
+
+func (h *ArticleVisibilityHandler) RegisterRoutes(s *gin.Engine) {
+	g := s.Group("/articles/visibility")
+	g.POST("/set", ginx.WrapClaimsAndReq[SetVisibilityReq](h.SetVisibility))
+	g.POST("/get", ginx.WrapClaimsAndReq[GetVisibilityReq](h.GetVisibility))
+}
+
+type SetVisibilityReq struct {
+	Id int64 `json:"id"`
+	// 0 公开，1 关注者可见，2 互相关注可见，3 白名单可见
+	Visibility int32 `json:"visibility"`
+	// AllowList 白名单可见的时候哪些人能看，整体替换
+	AllowList []int64 `json:"allowList"`
+}
+
+type GetVisibilityReq struct {
+	Id int64 `json:"id"`
+}
+
+type VisibilityVo struct {
+	Visibility int32   `json:"visibility"`
+	AllowList  []int64 `json:"allowList,omitempty"`
+}
+
+func (h *ArticleVisibilityHandler) SetVisibility(ctx *gin.Context, req SetVisibilityReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.SetVisibility(ctx, &articlev1.SetVisibilityRequest{
+		Uid:        usr.Id,
+		ArticleId:  req.Id,
+		Visibility: req.Visibility,
+		AllowList:  req.AllowList,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "帖子不存在或者你不是作者"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (h *ArticleVisibilityHandler) GetVisibility(ctx *gin.Context, req GetVisibilityReq, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.GetVisibility(ctx, &articlev1.GetVisibilityRequest{
+		Uid:       usr.Id,
+		ArticleId: req.Id,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Data: VisibilityVo{
+			Visibility: resp.GetVisibility(),
+			AllowList:  resp.GetAllowList(),
+		}}, nil
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "帖子不存在或者你不是作者"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
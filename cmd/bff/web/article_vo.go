@@ -2,6 +2,8 @@ package web
 
 import (
 	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
 )
 
 type RewardReq struct {
@@ -14,6 +16,39 @@ type LikeReq struct {
 	Like bool  `json:"like"`
 }
 
+type ReactReq struct {
+	Id int64 `json:"id"`
+	// 比如说 like，insightful，为空就是取消表态
+	Reaction string `json:"reaction"`
+}
+
+type ReadProgressReq struct {
+	Id int64 `json:"id"`
+	// Progress 读到了哪里，百分比，0 - 100
+	Progress int32 `json:"progress"`
+}
+
+type ResumeReq struct {
+	Id int64 `json:"id"`
+}
+
+type PauseHistoryReq struct {
+	// Paused false 就是恢复记录
+	Paused bool `json:"paused"`
+}
+
+type DailyReadersReq struct {
+	Id int64 `json:"id"`
+	// Days 最近多少天，包括今天，最多 30 天
+	Days int32 `json:"days"`
+}
+
+type DailyReadersVo struct {
+	Date string `json:"date"`
+	// Cnt 那一天的独立读者数，估算值
+	Cnt int64 `json:"cnt"`
+}
+
 type CollectReq struct {
 	Id  int64 `json:"id"`
 	Cid int64 `json:"cid"`
@@ -28,23 +63,147 @@ type ArticleVo struct {
 	Content string `json:"content"`
 	Status  int32  `json:"status"`
 	Author  string `json:"author"`
-	Ctime   string `json:"ctime"`
-	Utime   string `json:"utime"`
+	// Authors 所有的作者，按照署名顺序排列，只有读者端才有
+	Authors []AuthorVo `json:"authors,omitempty"`
+	Ctime   string     `json:"ctime"`
+	Utime   string     `json:"utime"`
+	// 定时发表的时间
+	PublishAt string `json:"publishAt,omitempty"`
+	// 乐观锁的版本号，编辑的时候要带回来
+	Version int64 `json:"version,omitempty"`
+	// 删除的时间，只有回收站才有
+	Dtime string `json:"dtime,omitempty"`
+	// 渲染之后的内容，只有读者端才有
+	HTML           string      `json:"html,omitempty"`
+	TOC            []TocItemVo `json:"toc,omitempty"`
+	ReadingMinutes int32       `json:"readingMinutes,omitempty"`
+	// 所在系列的上一篇和下一篇
+	SeriesNav *SeriesNavVo `json:"seriesNav,omitempty"`
+	// 可见范围，0 公开，1 关注者可见，2 互相关注可见，3 白名单可见
+	Visibility int32 `json:"visibility"`
 
 	// 点赞之类的信息
 	LikeCnt    int64 `json:"likeCnt"`
 	CollectCnt int64 `json:"collectCnt"`
 	ReadCnt    int64 `json:"readCnt"`
+	// 被多少篇帖子引用了
+	CiteCnt int64 `json:"citeCnt"`
+	// 独立读者数，估算值
+	ReaderCnt int64 `json:"readerCnt"`
+	// 每种表态的数量，包括点赞
+	Reactions map[string]int64 `json:"reactions,omitempty"`
 
 	// 个人是否点赞的信息
 	Liked     bool `json:"liked"`
 	Collected bool `json:"collected"`
+	// 个人的表态，没有表态就是空的
+	Reaction string `json:"reaction,omitempty"`
+}
+
+type AuthorVo struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	// 1 是创建帖子的人，2 是共同作者
+	Role int32 `json:"role"`
+}
+
+func newAuthorVos(authors []*articlev1.Author) []AuthorVo {
+	return slice.Map[*articlev1.Author, AuthorVo](authors,
+		func(idx int, src *articlev1.Author) AuthorVo {
+			return AuthorVo{Id: src.Id, Name: src.Name, Role: src.Role}
+		})
+}
+
+type CoAuthorReq struct {
+	// Id 帖子 ID
+	Id int64 `json:"id"`
+	// Uid 被邀请或者被移除的人，列表的时候不需要
+	Uid int64 `json:"uid"`
+}
+
+type RespondInvitationReq struct {
+	Id     int64 `json:"id"`
+	Accept bool  `json:"accept"`
+}
+
+type CoAuthorVo struct {
+	ArticleId int64    `json:"articleId"`
+	Author    AuthorVo `json:"author"`
+	// 1 是等待接受，2 是已经接受
+	Status int32  `json:"status"`
+	Ctime  string `json:"ctime"`
+}
+
+func newCoAuthorVos(cs []*articlev1.CoAuthor) []CoAuthorVo {
+	return slice.Map[*articlev1.CoAuthor, CoAuthorVo](cs,
+		func(idx int, src *articlev1.CoAuthor) CoAuthorVo {
+			return CoAuthorVo{
+				ArticleId: src.ArticleId,
+				Author: AuthorVo{
+					Id:   src.GetAuthor().GetId(),
+					Role: src.GetAuthor().GetRole(),
+				},
+				Status: src.Status,
+				Ctime:  src.Ctime.AsTime().Format(time.DateTime),
+			}
+		})
+}
+
+// TocItemVo 目录中的一项
+type TocItemVo struct {
+	Level  int32  `json:"level"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+}
+
+type SeriesNavVo struct {
+	SeriesId    int64  `json:"seriesId"`
+	SeriesTitle string `json:"seriesTitle"`
+	// 没有上一篇或者下一篇的时候 id 为 0
+	PrevId    int64  `json:"prevId"`
+	PrevTitle string `json:"prevTitle"`
+	NextId    int64  `json:"nextId"`
+	NextTitle string `json:"nextTitle"`
+}
+
+func newSeriesNavVo(nav *articlev1.SeriesNav) *SeriesNavVo {
+	if nav == nil {
+		return nil
+	}
+	return &SeriesNavVo{
+		SeriesId:    nav.SeriesId,
+		SeriesTitle: nav.SeriesTitle,
+		PrevId:      nav.GetPrev().GetId(),
+		PrevTitle:   nav.GetPrev().GetTitle(),
+		NextId:      nav.GetNext().GetId(),
+		NextTitle:   nav.GetNext().GetTitle(),
+	}
 }
 
 type ArticleReq struct {
 	Id      int64  `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	// PublishAt 定时发表的时间，毫秒数，只有发表的时候才有用
+	PublishAt int64 `json:"publishAt"`
+	// Version 最后看到的版本号，编辑和发表已有的帖子的时候必须带上
+	Version int64 `json:"version"`
+}
+
+// VersionConflictVo 编辑冲突的时候返回服务端现在的版本号
+type VersionConflictVo struct {
+	CurrentVersion int64 `json:"currentVersion"`
+}
+
+// RecycleReq 删除和从回收站恢复
+type RecycleReq struct {
+	Id int64 `json:"id"`
+}
+
+type ScheduleReq struct {
+	Id int64 `json:"id"`
+	// PublishAt 新的定时发表的时间，毫秒数
+	PublishAt int64 `json:"publishAt"`
 }
 
 func (req ArticleReq) toDTO(uid int64) *articlev1.Article {
@@ -57,3 +216,11 @@ func (req ArticleReq) toDTO(uid int64) *articlev1.Article {
 		},
 	}
 }
+
+// formatPublishAt 不是定时发表的帖子，返回空字符串
+func formatPublishAt(art *articlev1.Article) string {
+	if art.PublishAt == nil {
+		return ""
+	}
+	return art.PublishAt.AsTime().Format(time.DateTime)
+}
@@ -39,6 +39,42 @@ func (i *InteractiveClient) CancelLike(ctx context.Context, in *intrv1.CancelLik
 	return i.selectClient().CancelLike(ctx, in)
 }
 
+func (i *InteractiveClient) React(ctx context.Context, in *intrv1.ReactRequest, opts ...grpc.CallOption) (*intrv1.ReactResponse, error) {
+	return i.selectClient().React(ctx, in)
+}
+
+func (i *InteractiveClient) ListLiked(ctx context.Context, in *intrv1.ListLikedRequest, opts ...grpc.CallOption) (*intrv1.ListLikedResponse, error) {
+	return i.selectClient().ListLiked(ctx, in)
+}
+
+func (i *InteractiveClient) ListCollected(ctx context.Context, in *intrv1.ListCollectedRequest, opts ...grpc.CallOption) (*intrv1.ListCollectedResponse, error) {
+	return i.selectClient().ListCollected(ctx, in)
+}
+
+func (i *InteractiveClient) ReportReadProgress(ctx context.Context, in *intrv1.ReportReadProgressRequest, opts ...grpc.CallOption) (*intrv1.ReportReadProgressResponse, error) {
+	return i.selectClient().ReportReadProgress(ctx, in)
+}
+
+func (i *InteractiveClient) GetReadProgress(ctx context.Context, in *intrv1.GetReadProgressRequest, opts ...grpc.CallOption) (*intrv1.GetReadProgressResponse, error) {
+	return i.selectClient().GetReadProgress(ctx, in)
+}
+
+func (i *InteractiveClient) ListReadHistory(ctx context.Context, in *intrv1.ListReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ListReadHistoryResponse, error) {
+	return i.selectClient().ListReadHistory(ctx, in)
+}
+
+func (i *InteractiveClient) ClearReadHistory(ctx context.Context, in *intrv1.ClearReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ClearReadHistoryResponse, error) {
+	return i.selectClient().ClearReadHistory(ctx, in)
+}
+
+func (i *InteractiveClient) PauseReadHistory(ctx context.Context, in *intrv1.PauseReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.PauseReadHistoryResponse, error) {
+	return i.selectClient().PauseReadHistory(ctx, in)
+}
+
+func (i *InteractiveClient) GetDailyReaders(ctx context.Context, in *intrv1.GetDailyReadersRequest, opts ...grpc.CallOption) (*intrv1.GetDailyReadersResponse, error) {
+	return i.selectClient().GetDailyReaders(ctx, in)
+}
+
 func (i *InteractiveClient) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
 	return i.selectClient().Collect(ctx, in)
 }
@@ -51,6 +87,34 @@ func (i *InteractiveClient) GetByIds(ctx context.Context, in *intrv1.GetByIdsReq
 	return i.selectClient().GetByIds(ctx, in)
 }
 
+func (i *InteractiveClient) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	return i.selectClient().CancelCollect(ctx, in)
+}
+
+func (i *InteractiveClient) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	return i.selectClient().MoveCollectionItem(ctx, in)
+}
+
+func (i *InteractiveClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	return i.selectClient().CreateCollection(ctx, in)
+}
+
+func (i *InteractiveClient) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	return i.selectClient().RenameCollection(ctx, in)
+}
+
+func (i *InteractiveClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	return i.selectClient().DeleteCollection(ctx, in)
+}
+
+func (i *InteractiveClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	return i.selectClient().ListCollections(ctx, in)
+}
+
+func (i *InteractiveClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	return i.selectClient().ListCollectionItems(ctx, in)
+}
+
 func (i *InteractiveClient) selectClient() intrv1.InteractiveServiceClient {
 	num := rand.Int31n(100)
 	if num < i.threshold.Load() {
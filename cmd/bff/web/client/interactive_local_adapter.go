@@ -6,6 +6,7 @@ import (
 	"github.com/XD/ScholarNet/cmd/interactive/domain"
 	"github.com/XD/ScholarNet/cmd/interactive/service"
 	"google.golang.org/grpc"
+	"time"
 )
 
 type InteractiveLocalAdapter struct {
@@ -31,6 +32,11 @@ func (i *InteractiveLocalAdapter) CancelLike(ctx context.Context, in *intrv1.Can
 	return &intrv1.CancelLikeResponse{}, err
 }
 
+func (i *InteractiveLocalAdapter) React(ctx context.Context, in *intrv1.ReactRequest, opts ...grpc.CallOption) (*intrv1.ReactResponse, error) {
+	err := i.svc.React(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetReaction())
+	return &intrv1.ReactResponse{}, err
+}
+
 func (i *InteractiveLocalAdapter) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, in.GetBiz(), in.GetBizId(), in.GetCid(), in.GetUid())
 	return &intrv1.CollectResponse{}, err
@@ -50,7 +56,7 @@ func (i *InteractiveLocalAdapter) GetByIds(ctx context.Context, in *intrv1.GetBy
 	if len(in.Ids) == 0 {
 		return &intrv1.GetByIdsResponse{}, nil
 	}
-	data, err := i.svc.GetByIds(ctx, in.GetBiz(), in.GetIds())
+	data, err := i.svc.GetByIds(ctx, in.GetBiz(), in.GetIds(), in.GetUid())
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +70,179 @@ func (i *InteractiveLocalAdapter) GetByIds(ctx context.Context, in *intrv1.GetBy
 	}, nil
 }
 
+func (i *InteractiveLocalAdapter) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetCid())
+	return &intrv1.MoveCollectionItemResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, in.GetUid(), in.GetName())
+	return &intrv1.CreateCollectionResponse{Id: id}, err
+}
+
+func (i *InteractiveLocalAdapter) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, in.GetUid(), in.GetCid(), in.GetName())
+	return &intrv1.RenameCollectionResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, in.GetUid(), in.GetCid())
+	return &intrv1.DeleteCollectionResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	cs, err := i.svc.ListCollections(ctx, in.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.Collection, 0, len(cs))
+	for _, c := range cs {
+		res = append(res, &intrv1.Collection{
+			Id:      c.Id,
+			Name:    c.Name,
+			ItemCnt: c.ItemCnt,
+			Ctime:   c.Ctime.UnixMilli(),
+			Utime:   c.Utime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionsResponse{Collections: res}, nil
+}
+
+func (i *InteractiveLocalAdapter) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, in.GetUid(), in.GetCid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: res}, nil
+}
+
+func (i *InteractiveLocalAdapter) ListLiked(ctx context.Context, in *intrv1.ListLikedRequest, opts ...grpc.CallOption) (*intrv1.ListLikedResponse, error) {
+	cursor, err := domain.DecodeCursor(in.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	items, next, err := i.svc.ListLiked(ctx, in.GetUid(), in.GetBiz(), cursor, int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.LikedItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.LikedItem{
+			Biz:      item.Biz,
+			BizId:    item.BizId,
+			Reaction: item.Reaction,
+			Utime:    item.Utime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListLikedResponse{Items: res, NextCursor: next.Encode()}, nil
+}
+
+func (i *InteractiveLocalAdapter) ListCollected(ctx context.Context, in *intrv1.ListCollectedRequest, opts ...grpc.CallOption) (*intrv1.ListCollectedResponse, error) {
+	cursor, err := domain.DecodeCursor(in.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	items, next, err := i.svc.ListCollected(ctx, in.GetUid(), in.GetBiz(), cursor, int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectedResponse{Items: res, NextCursor: next.Encode()}, nil
+}
+
+func (i *InteractiveLocalAdapter) ReportReadProgress(ctx context.Context, in *intrv1.ReportReadProgressRequest, opts ...grpc.CallOption) (*intrv1.ReportReadProgressResponse, error) {
+	err := i.svc.ReportReadProgress(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetProgress())
+	return &intrv1.ReportReadProgressResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) GetReadProgress(ctx context.Context, in *intrv1.GetReadProgressRequest, opts ...grpc.CallOption) (*intrv1.GetReadProgressResponse, error) {
+	record, err := i.svc.GetReadProgress(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
+	switch err {
+	case nil:
+		return &intrv1.GetReadProgressResponse{Record: i.readRecordToDTO(record)}, nil
+	case service.ErrReadRecordNotFound:
+		return &intrv1.GetReadProgressResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (i *InteractiveLocalAdapter) ListReadHistory(ctx context.Context, in *intrv1.ListReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ListReadHistoryResponse, error) {
+	cursor, err := domain.DecodeCursor(in.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	records, next, err := i.svc.ListReadHistory(ctx, in.GetUid(), in.GetBiz(), cursor, int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	paused, err := i.svc.ReadHistoryPaused(ctx, in.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.ReadRecord, 0, len(records))
+	for _, r := range records {
+		res = append(res, i.readRecordToDTO(r))
+	}
+	return &intrv1.ListReadHistoryResponse{Records: res, NextCursor: next.Encode(), Paused: paused}, nil
+}
+
+func (i *InteractiveLocalAdapter) ClearReadHistory(ctx context.Context, in *intrv1.ClearReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ClearReadHistoryResponse, error) {
+	err := i.svc.ClearReadHistory(ctx, in.GetUid(), in.GetBiz())
+	return &intrv1.ClearReadHistoryResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) PauseReadHistory(ctx context.Context, in *intrv1.PauseReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.PauseReadHistoryResponse, error) {
+	err := i.svc.PauseReadHistory(ctx, in.GetUid(), in.GetPaused())
+	return &intrv1.PauseReadHistoryResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) readRecordToDTO(r domain.ReadRecord) *intrv1.ReadRecord {
+	return &intrv1.ReadRecord{
+		Biz:      r.Biz,
+		BizId:    r.BizId,
+		Progress: r.Progress,
+		ReadTime: r.Utime.UnixMilli(),
+	}
+}
+
+func (i *InteractiveLocalAdapter) GetDailyReaders(ctx context.Context, in *intrv1.GetDailyReadersRequest, opts ...grpc.CallOption) (*intrv1.GetDailyReadersResponse, error) {
+	cnts, err := i.svc.GetDailyReaders(ctx, in.GetBiz(), in.GetBizId(), int(in.GetDays()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.DailyReaders, 0, len(cnts))
+	for _, c := range cnts {
+		res = append(res, &intrv1.DailyReaders{
+			Date: c.Date.Format(time.DateOnly),
+			Cnt:  c.Cnt,
+		})
+	}
+	return &intrv1.GetDailyReadersResponse{Days: res}, nil
+}
+
 func (i *InteractiveLocalAdapter) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		Biz:        intr.Biz,
@@ -71,7 +250,11 @@ func (i *InteractiveLocalAdapter) toDTO(intr domain.Interactive) *intrv1.Interac
 		ReadCnt:    intr.ReadCnt,
 		LikeCnt:    intr.LikeCnt,
 		CollectCnt: intr.CollectCnt,
+		CiteCnt:    intr.CiteCnt,
+		ReaderCnt:  intr.ReaderCnt,
 		Liked:      intr.Liked,
 		Collected:  intr.Collected,
+		Reactions:  intr.Reactions,
+		Reaction:   intr.Reaction,
 	}
 }
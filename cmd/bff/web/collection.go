@@ -0,0 +1,156 @@
+package web
+
+import (
+	"time"
+
+	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
+	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
+	"github.com/XD/ScholarNet/cmd/pkg/ginx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*CollectionHandler)(nil)
+
+// CollectionHandler 收藏夹，往收藏夹里面放东西是在各个业务自己的接口里面，比如说 /articles/pub/collect
+type CollectionHandler struct {
+	intrSvc intrv1.InteractiveServiceClient
+	l       logger.LoggerV1
+}
+
+func NewCollectionHandler(intrSvc intrv1.InteractiveServiceClient, l logger.LoggerV1) *CollectionHandler {
+	return &CollectionHandler{intrSvc: intrSvc, l: l}
+}
+
+func (h *CollectionHandler) RegisterRoutes(s *gin.Engine) {
+	g := s.Group("/collections")
+	g.POST("/create", ginx.WrapClaimsAndReq[CollectionReq](h.Create))
+	g.POST("/rename", ginx.WrapClaimsAndReq[CollectionReq](h.Rename))
+	g.POST("/delete", ginx.WrapClaimsAndReq[CollectionReq](h.Delete))
+	g.POST("/list", ginx.WrapClaims(h.List))
+	g.POST("/items", ginx.WrapClaimsAndReq[CollectionItemsReq](h.ListItems))
+}
+
+type CollectionReq struct {
+	// Id 0 是默认收藏夹
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CollectionItemsReq struct {
+	Id int64 `json:"id"`
+	Page
+}
+
+type CollectionVo struct {
+	Id      int64  `json:"id"`
+	Name    string `json:"name"`
+	ItemCnt int64  `json:"itemCnt"`
+	Ctime   string `json:"ctime,omitempty"`
+	Utime   string `json:"utime,omitempty"`
+}
+
+type CollectionItemVo struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// Ctime 收藏的时间
+	Ctime string `json:"ctime"`
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context, req CollectionReq, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.intrSvc.CreateCollection(ctx, &intrv1.CreateCollectionRequest{
+		Uid:  usr.Id,
+		Name: req.Name,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Data: resp.GetId()}, nil
+	case codes.InvalidArgument, codes.ResourceExhausted:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (h *CollectionHandler) Rename(ctx *gin.Context, req CollectionReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := h.intrSvc.RenameCollection(ctx, &intrv1.RenameCollectionRequest{
+		Uid:  usr.Id,
+		Cid:  req.Id,
+		Name: req.Name,
+	})
+	return h.result(err)
+}
+
+// Delete 收藏夹里面的东西会挪到默认收藏夹，不会取消收藏
+func (h *CollectionHandler) Delete(ctx *gin.Context, req CollectionReq, usr jwt.UserClaims) (ginx.Result, error) {
+	_, err := h.intrSvc.DeleteCollection(ctx, &intrv1.DeleteCollectionRequest{
+		Uid: usr.Id,
+		Cid: req.Id,
+	})
+	return h.result(err)
+}
+
+func (h *CollectionHandler) List(ctx *gin.Context, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.intrSvc.ListCollections(ctx, &intrv1.ListCollectionsRequest{Uid: usr.Id})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*intrv1.Collection, CollectionVo](resp.GetCollections(),
+			func(idx int, src *intrv1.Collection) CollectionVo {
+				vo := CollectionVo{
+					Id:      src.GetId(),
+					Name:    src.GetName(),
+					ItemCnt: src.GetItemCnt(),
+				}
+				// 默认收藏夹没有时间
+				if src.GetCtime() > 0 {
+					vo.Ctime = time.UnixMilli(src.GetCtime()).Format(time.DateTime)
+					vo.Utime = time.UnixMilli(src.GetUtime()).Format(time.DateTime)
+				}
+				return vo
+			}),
+	}, nil
+}
+
+func (h *CollectionHandler) ListItems(ctx *gin.Context, req CollectionItemsReq, usr jwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.intrSvc.ListCollectionItems(ctx, &intrv1.ListCollectionItemsRequest{
+		Uid:    usr.Id,
+		Cid:    req.Id,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*intrv1.CollectionItem, CollectionItemVo](resp.GetItems(),
+			func(idx int, src *intrv1.CollectionItem) CollectionItemVo {
+				return CollectionItemVo{
+					Biz:   src.GetBiz(),
+					BizId: src.GetBizId(),
+					Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (h *CollectionHandler) result(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "收藏夹不存在"}, nil
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
@@ -12,6 +12,18 @@ type Page struct {
 	Limit  int `json:"limit"`
 }
 
+// CursorPage 按照游标翻页，第一页 cursor 不传，之后用上一页返回的 nextCursor
+type CursorPage struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+// CursorList 为空的 nextCursor 说明没有下一页了
+type CursorList[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
 type handler interface {
 	RegisterRoutes(s *gin.Engine)
 }
@@ -4,6 +4,7 @@ package main
 
 import (
 	"github.com/XD/ScholarNet/cmd/bff/ioc"
+	"github.com/XD/ScholarNet/cmd/bff/transfer"
 	"github.com/XD/ScholarNet/cmd/bff/web"
 	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
 	"github.com/XD/ScholarNet/cmd/pkg/wego"
@@ -17,6 +18,13 @@ func InitApp() *wego.App {
 		ioc.InitEtcdClient,
 
 		web.NewArticleHandler,
+		web.NewArticleTransferHandler,
+		web.NewArticleCitationHandler,
+		web.NewArticleVisibilityHandler,
+		web.NewCollectionHandler,
+		transfer.NewService,
+		transfer.NewRedisJobStore,
+		ioc.InitTransferFileStore,
 		web.NewUserHandler,
 		web.NewRewardHandler,
 		jwt.NewRedisHandler,
@@ -26,6 +34,10 @@ func InitApp() *wego.App {
 		ioc.InitRewardClient,
 		ioc.InitCodeClient,
 		ioc.InitArticleClient,
+		ioc.InitCitationClient,
+		ioc.InitArticleCiteHandler,
+		ioc.InitVisibilityClient,
+		ioc.InitTagClient,
 		ioc.InitGinServer,
 		wire.Struct(new(wego.App), "WebServer"),
 	)
@@ -8,6 +8,7 @@ package main
 
 import (
 	"github.com/XD/ScholarNet/cmd/bff/ioc"
+	"github.com/XD/ScholarNet/cmd/bff/transfer"
 	"github.com/XD/ScholarNet/cmd/bff/web"
 	"github.com/XD/ScholarNet/cmd/bff/web/jwt"
 	"github.com/XD/ScholarNet/cmd/pkg/wego"
@@ -27,8 +28,19 @@ func InitApp() *wego.App {
 	interactiveServiceClient := ioc.InitIntrClient(client)
 	rewardServiceClient := ioc.InitRewardClient(client)
 	articleHandler := web.NewArticleHandler(articleServiceClient, interactiveServiceClient, rewardServiceClient, loggerV1)
+	tagServiceClient := ioc.InitTagClient(client)
+	jobStore := transfer.NewRedisJobStore(cmdable)
+	fileStore := ioc.InitTransferFileStore()
+	service := transfer.NewService(articleServiceClient, tagServiceClient, jobStore, fileStore, loggerV1)
+	articleTransferHandler := web.NewArticleTransferHandler(service, loggerV1)
+	citationServiceClient := ioc.InitCitationClient(client)
+	articleCitationHandler := web.NewArticleCitationHandler(citationServiceClient, loggerV1)
+	articleCiteHandler := ioc.InitArticleCiteHandler(articleServiceClient, loggerV1)
+	visibilityServiceClient := ioc.InitVisibilityClient(client)
+	articleVisibilityHandler := web.NewArticleVisibilityHandler(visibilityServiceClient, loggerV1)
+	collectionHandler := web.NewCollectionHandler(interactiveServiceClient, loggerV1)
 	rewardHandler := web.NewRewardHandler(rewardServiceClient, articleServiceClient)
-	server := ioc.InitGinServer(loggerV1, handler, userHandler, articleHandler, rewardHandler)
+	server := ioc.InitGinServer(loggerV1, handler, userHandler, articleHandler, articleTransferHandler, articleCitationHandler, articleCiteHandler, articleVisibilityHandler, collectionHandler, rewardHandler)
 	app := &wego.App{
 		WebServer: server,
 	}
@@ -62,7 +62,8 @@ func (r *ArticleEventConsumer) Consume(msg *sarama.ConsumerMessage,
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	return r.svc.CreateFeedEvent(ctx, domain.FeedEvent{
-		Type: service.FollowEventName,
+		// 删除的时候按照这个类型去找，不能写错
+		Type: service.ArticleEventName,
 		Ext: map[string]string{
 			"uid": strconv.FormatInt(evt.Uid, 10),
 			"aid": strconv.FormatInt(evt.Aid, 10),
@@ -74,3 +75,53 @@ func (r *ArticleEventConsumer) Consume(msg *sarama.ConsumerMessage,
 // json 序列化与反序列化的问题
 // uid: 123
 // 反序列化 map[string]any => uid: 123, 但是 123 是 float64
+
+const topicArticleDeleteEvent = "article_delete_event"
+
+// ArticleDeleteEvent 帖子进了回收站
+type ArticleDeleteEvent struct {
+	Uid int64
+	Aid int64
+}
+
+type ArticleDeleteEventConsumer struct {
+	client sarama.Client
+	l      logger.LoggerV1
+	svc    service.FeedService
+}
+
+func NewArticleDeleteEventConsumer(
+	client sarama.Client,
+	l logger.LoggerV1,
+	svc service.FeedService) *ArticleDeleteEventConsumer {
+	return &ArticleDeleteEventConsumer{
+		svc:    svc,
+		client: client,
+		l:      l,
+	}
+}
+
+func (r *ArticleDeleteEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("articleFeedDelete",
+		r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicArticleDeleteEvent},
+			saramax.NewHandler[ArticleDeleteEvent](r.l, r.Consume))
+		if err != nil {
+			r.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (r *ArticleDeleteEventConsumer) Consume(msg *sarama.ConsumerMessage,
+	evt ArticleDeleteEvent) error {
+	// 收件箱要扫全表，给长一点的时间
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return r.svc.RemoveArticle(ctx, evt.Uid, evt.Aid)
+}
@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func InitArticleClient() articlev1.ArticleServiceClient {
+	return articlev1.NewArticleServiceClient(dialArticle())
+}
+
+// InitVisibilityClient 可见范围和帖子是同一个服务
+func InitVisibilityClient() articlev1.VisibilityServiceClient {
+	return articlev1.NewVisibilityServiceClient(dialArticle())
+}
+
+func dialArticle() *grpc.ClientConn {
+	type config struct {
+		Target string `yaml:"target"`
+	}
+	var cfg config
+	err := viper.UnmarshalKey("grpc.client.article", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	conn, err := grpc.Dial(
+		cfg.Target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return conn
+}
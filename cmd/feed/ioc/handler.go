@@ -1,13 +1,17 @@
 package ioc
 
 import (
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
 	followv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1"
 	"github.com/XD/ScholarNet/cmd/feed/repository"
 	"github.com/XD/ScholarNet/cmd/feed/service"
 )
 
-func RegisterHandler(repo repository.FeedEventRepo, followClient followv1.FollowServiceClient) map[string]service.Handler {
-	articleHandler := service.NewArticleEventHandler(repo, followClient)
+func RegisterHandler(repo repository.FeedEventRepo,
+	followClient followv1.FollowServiceClient,
+	articleClient articlev1.ArticleServiceClient,
+	visibilityClient articlev1.VisibilityServiceClient) map[string]service.Handler {
+	articleHandler := service.NewArticleEventHandler(repo, followClient, articleClient, visibilityClient)
 	followHanlder := service.NewFollowEventHandler(repo)
 	likeHandler := service.NewLikeEventHandler(repo)
 	return map[string]service.Handler{
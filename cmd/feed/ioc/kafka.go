@@ -25,9 +25,12 @@ func InitKafka() sarama.Client {
 }
 
 // 组装 []consumer
-func NewConsumers(article *events.ArticleEventConsumer, feed *events.FeedEventConsumer) []events.Consumer {
+func NewConsumers(article *events.ArticleEventConsumer,
+	articleDelete *events.ArticleDeleteEventConsumer,
+	feed *events.FeedEventConsumer) []events.Consumer {
 	return []events.Consumer{
 		article,
+		articleDelete,
 		feed,
 	}
 }
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"gorm.io/gorm"
 )
 
@@ -9,6 +10,8 @@ type FeedPullEventDAO interface {
 	CreatePullEvent(ctx context.Context, event FeedPullEvent) error
 	FindPullEventList(ctx context.Context, uids []int64, timestamp, limit int64) ([]FeedPullEvent, error)
 	FindPullEventListWithType(ctx context.Context, typ string, uids []int64, timestamp, limit int64) ([]FeedPullEvent, error)
+	// DeleteByContent 删除 uid 发出来的、内容里面 key 的值是 val 的事件
+	DeleteByContent(ctx context.Context, typ string, uid int64, key, val string) error
 }
 
 // FeedPullEvent 拉模型
@@ -43,6 +46,21 @@ func (dao *GORMFeedPullEventDAO) FindPullEventListWithType(ctx context.Context,
 	return events, err
 }
 
+func (dao *GORMFeedPullEventDAO) DeleteByContent(ctx context.Context, typ string,
+	uid int64, key, val string) error {
+	return dao.db.WithContext(ctx).
+		Where("type = ? AND uid = ? AND content LIKE ?", typ, uid, contentPattern(key, val)).
+		Delete(&FeedPullEvent{}).Error
+}
+
+// contentPattern Content 是 map[string]string 序列化出来的，
+// 所以一个键值对一定是 "key":"val" 这个样子
+func contentPattern(key, val string) string {
+	k, _ := json.Marshal(key)
+	v, _ := json.Marshal(val)
+	return "%" + string(k) + ":" + string(v) + "%"
+}
+
 func (dao *GORMFeedPullEventDAO) CreatePullEvent(ctx context.Context, event FeedPullEvent) error {
 	return dao.db.WithContext(ctx).Create(&event).Error
 }
@@ -9,6 +9,9 @@ type FeedPushEventDAO interface {
 	CreatePushEvents(ctx context.Context, events []FeedPushEvent) error
 	GetPushEvents(ctx context.Context, uid int64, timestamp, limit int64) ([]FeedPushEvent, error)
 	GetPushEventsWithTyp(ctx context.Context, typ string, uid int64, timestamp, limit int64) ([]FeedPushEvent, error)
+	// DeleteByContent 删除所有收件箱里面内容中 key 的值是 val 的事件
+	// 收件箱是按照收件人建的索引，这里只能扫 type 下面的全部数据，不能频繁调用
+	DeleteByContent(ctx context.Context, typ string, key, val string) error
 }
 
 // FeedPushEvent 写扩散，推模型，收件箱
@@ -31,6 +34,12 @@ type feedPushEventDAO struct {
 	db *gorm.DB
 }
 
+func (dao *feedPushEventDAO) DeleteByContent(ctx context.Context, typ string, key, val string) error {
+	return dao.db.WithContext(ctx).
+		Where("type = ? AND content LIKE ?", typ, contentPattern(key, val)).
+		Delete(&FeedPushEvent{}).Error
+}
+
 func (dao *feedPushEventDAO) GetPushEventsWithTyp(ctx context.Context,
 	typ string, uid int64, timestamp, limit int64) ([]FeedPushEvent, error) {
 	var res []FeedPushEvent
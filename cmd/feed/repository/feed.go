@@ -21,6 +21,8 @@ type FeedEventRepo interface {
 	FindPullEventsWithTyp(ctx context.Context, typ string, uids []int64, timestamp, limit int64) ([]domain.FeedEvent, error)
 	// FindPushEvents 获取某个类型的推事件，也就是发件箱里面的事件
 	FindPushEventsWithTyp(ctx context.Context, typ string, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	// DeleteEventsWithExt 删除 sender 发出来的，Ext 里面 key 的值是 val 的事件，收件箱和发件箱都删
+	DeleteEventsWithExt(ctx context.Context, typ string, sender int64, key, val string) error
 }
 
 type feedEventRepo struct {
@@ -50,6 +52,15 @@ func (f *feedEventRepo) FindPullEventsWithTyp(ctx context.Context,
 	}), nil
 }
 
+func (f *feedEventRepo) DeleteEventsWithExt(ctx context.Context,
+	typ string, sender int64, key, val string) error {
+	err := f.pullDao.DeleteByContent(ctx, typ, sender, key, val)
+	if err != nil {
+		return err
+	}
+	return f.pushDao.DeleteByContent(ctx, typ, key, val)
+}
+
 func (f *feedEventRepo) CreatePushEvents(ctx context.Context, events []domain.FeedEvent) error {
 	return f.pushDao.CreatePushEvents(ctx, slice.Map(events, func(idx int, src domain.FeedEvent) dao.FeedPushEvent {
 		return convertToPushEvent(src)
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
 	followv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1"
 	"github.com/XD/ScholarNet/cmd/feed/domain"
 	"github.com/XD/ScholarNet/cmd/feed/repository"
@@ -20,12 +21,19 @@ const (
 )
 
 type ArticleEventHandler struct {
-	repo         repository.FeedEventRepo
-	followClient followv1.FollowServiceClient
+	repo          repository.FeedEventRepo
+	followClient  followv1.FollowServiceClient
+	articleClient articlev1.ArticleServiceClient
+	// 不公开的帖子只能推给能看的人
+	visibilityClient articlev1.VisibilityServiceClient
 }
 
-func NewArticleEventHandler(repo repository.FeedEventRepo, followClient followv1.FollowServiceClient) Handler {
-	return &ArticleEventHandler{repo: repo, followClient: followClient}
+func NewArticleEventHandler(repo repository.FeedEventRepo,
+	followClient followv1.FollowServiceClient,
+	articleClient articlev1.ArticleServiceClient,
+	visibilityClient articlev1.VisibilityServiceClient) Handler {
+	return &ArticleEventHandler{repo: repo, followClient: followClient,
+		articleClient: articleClient, visibilityClient: visibilityClient}
 }
 
 func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.ExtendFields) error {
@@ -33,6 +41,10 @@ func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.Ex
 	if err != nil {
 		return err
 	}
+	aid, err := ext.Get("aid").AsInt64()
+	if err != nil {
+		return err
+	}
 	resp, err := a.followClient.GetFollowStatics(ctx, &followv1.GetFollowStaticsRequest{
 		Uid: authorId,
 	})
@@ -48,18 +60,31 @@ func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.Ex
 		if err != nil {
 			return err
 		}
+		uids := slice.Map(followers.GetFollowRelations(),
+			func(idx int, src *followv1.FollowRelation) int64 {
+				return src.Follower
+			})
+		// 不公开的帖子只推给能看的粉丝，公开的帖子原样返回
+		audience, err := a.visibilityClient.FilterAudience(ctx, &articlev1.FilterAudienceRequest{
+			ArticleId: aid,
+			Uids:      uids,
+		})
+		if err != nil {
+			return err
+		}
 		// 要综合考虑什么活跃用户，是不是铁粉，
 		// 在这里判定
-		events := slice.Map(followers.GetFollowRelations(),
-			func(idx int, src *followv1.FollowRelation) domain.FeedEvent {
+		events := slice.Map(audience.GetUids(),
+			func(idx int, src int64) domain.FeedEvent {
 				return domain.FeedEvent{
-					Uid:  src.Follower,
+					Uid:  src,
 					Type: ArticleEventName,
 					Ext:  ext,
 				}
 			})
 		return a.repo.CreatePushEvents(ctx, events)
 	} else {
+		// 发件箱是所有粉丝共用的，可见范围在 hydrate 的时候再按照读者检查
 		return a.repo.CreatePullEvent(ctx, domain.FeedEvent{
 			Uid:  authorId,
 			Type: ArticleEventName,
@@ -117,5 +142,65 @@ func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp
 	sort.Slice(events, func(i, j int) bool {
 		return events[i].Ctime.Unix() > events[j].Ctime.Unix()
 	})
-	return events[:min[int](int(limit), len(events))], nil
+	return a.hydrate(ctx, uid, events[:min[int](int(limit), len(events))])
+}
+
+// hydrate 事件里面只有 aid，展示用的标题和摘要一次批量查出来，
+// 已经撤回或者删除了的帖子就不展示了，uid 看不到的帖子也不展示，
+// 推送之后作者可能又改了可见范围，所以收件箱里面的也要检查
+func (a *ArticleEventHandler) hydrate(ctx context.Context, uid int64, events []domain.FeedEvent) ([]domain.FeedEvent, error) {
+	if len(events) == 0 {
+		return events, nil
+	}
+	ids := make([]int64, 0, len(events))
+	for _, evt := range events {
+		aid, err := evt.Ext.Get("aid").AsInt64()
+		if err != nil {
+			continue
+		}
+		ids = append(ids, aid)
+	}
+	resp, err := a.articleClient.BatchGetPublished(ctx, &articlev1.BatchGetPublishedRequest{
+		Ids: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	arts := make(map[int64]*articlev1.Article, len(resp.GetArticles()))
+	for _, art := range resp.GetArticles() {
+		// 不公开的帖子不多，一篇一篇检查
+		if art.GetVisibility() != 0 {
+			audience, err := a.visibilityClient.FilterAudience(ctx, &articlev1.FilterAudienceRequest{
+				ArticleId: art.GetId(),
+				Uids:      []int64{uid},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(audience.GetUids()) == 0 {
+				continue
+			}
+		}
+		arts[art.GetId()] = art
+	}
+	res := make([]domain.FeedEvent, 0, len(events))
+	for _, evt := range events {
+		aid, err := evt.Ext.Get("aid").AsInt64()
+		if err != nil {
+			continue
+		}
+		art, ok := arts[aid]
+		if !ok {
+			continue
+		}
+		ext := make(domain.ExtendFields, len(evt.Ext)+2)
+		for k, v := range evt.Ext {
+			ext[k] = v
+		}
+		ext["title"] = art.GetTitle()
+		ext["abstract"] = art.GetAbstract()
+		evt.Ext = ext
+		res = append(res, evt)
+	}
+	return res, nil
 }
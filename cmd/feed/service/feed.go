@@ -9,6 +9,7 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
 	"sort"
+	"strconv"
 	"sync"
 )
 
@@ -38,6 +39,11 @@ func (s *feedService) CreateFeedEvent(ctx context.Context, feed domain.FeedEvent
 	return handler.CreateFeedEvent(ctx, feed.Ext)
 }
 
+// RemoveArticle 发帖子的事件 Ext 里面有 uid 和 aid，见 ArticleEventHandler
+func (f *feedService) RemoveArticle(ctx context.Context, uid, aid int64) error {
+	return f.repo.DeleteEventsWithExt(ctx, ArticleEventName, uid, "aid", strconv.FormatInt(aid, 10))
+}
+
 // GetFeedEventListV1 不依赖于 Handler 的直接查询
 // service 层面上的统一实现
 // 基本思路就是，收件箱查一下，发件箱查一下，合并结果（排序，分页），返回结果。
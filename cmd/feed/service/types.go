@@ -10,6 +10,8 @@ import (
 type FeedService interface {
 	CreateFeedEvent(ctx context.Context, feed domain.FeedEvent) error
 	GetFeedEventList(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	// RemoveArticle 帖子删除之后，从所有人的 feed 里面去掉
+	RemoveArticle(ctx context.Context, uid, aid int64) error
 }
 
 // Handler 具体业务处理逻辑
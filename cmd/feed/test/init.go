@@ -1,6 +1,9 @@
 package test
 
 import (
+	"context"
+	articlev1 "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1"
+	artmocks "github.com/XD/ScholarNet/cmd/api/proto/gen/article/v1/mocks"
 	feedv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/feed/v1"
 	followMocks "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1/mocks"
 	"github.com/XD/ScholarNet/cmd/feed/grpc"
@@ -10,6 +13,7 @@ import (
 	"github.com/XD/ScholarNet/cmd/feed/repository/dao"
 	"github.com/XD/ScholarNet/cmd/feed/service"
 	"go.uber.org/mock/gomock"
+	grpc2 "google.golang.org/grpc"
 	"gorm.io/gorm"
 	"testing"
 )
@@ -24,8 +28,24 @@ func InitGrpcServer(t *testing.T) (feedv1.FeedSvcServer, *followMocks.MockFollow
 	feedEventRepo := repository.NewFeedEventRepo(feedPullEventDAO, feedPushEventDAO, feedEventCache)
 	mockCtrl := gomock.NewController(t)
 	followClient := followMocks.NewMockFollowServiceClient(mockCtrl)
-	v := ioc.RegisterHandler(feedEventRepo, followClient)
+	articleClient := NewPublishedArticleClient(mockCtrl)
+	v := ioc.RegisterHandler(feedEventRepo, followClient, articleClient)
 	feedService := service.NewFeedService(feedEventRepo, v)
 	feedEventGrpcSvc := grpc.NewFeedEventGrpcSvc(feedService)
 	return feedEventGrpcSvc, followClient, db
 }
+
+// NewPublishedArticleClient 所有的帖子都当作是已经发表了的
+func NewPublishedArticleClient(ctrl *gomock.Controller) *artmocks.MockArticleServiceClient {
+	client := artmocks.NewMockArticleServiceClient(ctrl)
+	client.EXPECT().BatchGetPublished(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *articlev1.BatchGetPublishedRequest,
+			opts ...grpc2.CallOption) (*articlev1.BatchGetPublishedResponse, error) {
+			arts := make([]*articlev1.Article, 0, len(req.GetIds()))
+			for _, id := range req.GetIds() {
+				arts = append(arts, &articlev1.Article{Id: id})
+			}
+			return &articlev1.BatchGetPublishedResponse{Articles: arts}, nil
+		}).AnyTimes()
+	return client
+}
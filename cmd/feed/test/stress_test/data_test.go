@@ -138,7 +138,8 @@ func Test_Feed(t *testing.T) {
 	// 不想用 mock，你就用真实的 follow rpc client
 	// 我想要模拟降级怎么办，你在 follow 加上降级的逻辑
 	followClient := followMocks.NewMockFollowServiceClient(mockCtrl)
-	v := ioc.RegisterHandler(feedEventRepo, followClient)
+	articleClient := test.NewPublishedArticleClient(mockCtrl)
+	v := ioc.RegisterHandler(feedEventRepo, followClient, articleClient)
 	feedService := service.NewFeedService(feedEventRepo, v)
 	engine := gin.Default()
 	handler := web.NewFeedHandler(feedService)
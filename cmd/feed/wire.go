@@ -27,6 +27,8 @@ var thirdProvider = wire.NewSet(
 	ioc.InitKafka,
 	ioc.InitDB,
 	ioc.InitFollowClient,
+	ioc.InitArticleClient,
+	ioc.InitVisibilityClient,
 )
 
 func Init() *App {
@@ -37,6 +39,7 @@ func Init() *App {
 		service.NewFeedService,
 		grpc.NewFeedEventGrpcSvc,
 		events.NewArticleEventConsumer,
+		events.NewArticleDeleteEventConsumer,
 		events.NewFeedEventConsumer,
 		ioc.InitGRPCxServer,
 		ioc.NewConsumers,
@@ -29,14 +29,17 @@ func Init() *App {
 	feedEventCache := cache.NewFeedEventCache(cmdable)
 	feedEventRepo := repository.NewFeedEventRepo(feedPullEventDAO, feedPushEventDAO, feedEventCache)
 	followServiceClient := ioc.InitFollowClient()
-	v := ioc.RegisterHandler(feedEventRepo, followServiceClient)
+	articleServiceClient := ioc.InitArticleClient()
+	visibilityServiceClient := ioc.InitVisibilityClient()
+	v := ioc.RegisterHandler(feedEventRepo, followServiceClient, articleServiceClient, visibilityServiceClient)
 	feedService := service.NewFeedService(feedEventRepo, v)
 	feedEventGrpcSvc := grpc.NewFeedEventGrpcSvc(feedService)
 	server := ioc.InitGRPCxServer(loggerV1, client, feedEventGrpcSvc)
 	saramaClient := ioc.InitKafka()
 	articleEventConsumer := events.NewArticleEventConsumer(saramaClient, loggerV1, feedService)
+	articleDeleteEventConsumer := events.NewArticleDeleteEventConsumer(saramaClient, loggerV1, feedService)
 	feedEventConsumer := events.NewFeedEventConsumer(saramaClient, loggerV1, feedService)
-	v2 := ioc.NewConsumers(articleEventConsumer, feedEventConsumer)
+	v2 := ioc.NewConsumers(articleEventConsumer, articleDeleteEventConsumer, feedEventConsumer)
 	app := &App{
 		server:    server,
 		consumers: v2,
@@ -48,4 +51,4 @@ func Init() *App {
 
 var serviceProviderSet = wire.NewSet(dao.NewFeedPushEventDAO, dao.NewFeedPullEventDAO, cache.NewFeedEventCache, repository.NewFeedEventRepo)
 
-var thirdProvider = wire.NewSet(ioc.InitEtcdClient, ioc.InitLogger, ioc.InitRedis, ioc.InitKafka, ioc.InitDB, ioc.InitFollowClient)
+var thirdProvider = wire.NewSet(ioc.InitEtcdClient, ioc.InitLogger, ioc.InitRedis, ioc.InitKafka, ioc.InitDB, ioc.InitFollowClient, ioc.InitArticleClient, ioc.InitVisibilityClient)
@@ -2,10 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	followv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/follow/v1"
 	"github.com/XD/ScholarNet/cmd/follow/domain"
 	"github.com/XD/ScholarNet/cmd/follow/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FollowServiceServer struct {
@@ -49,6 +52,10 @@ func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1
 
 func (f *FollowServiceServer) FollowInfo(ctx context.Context, request *followv1.FollowInfoRequest) (*followv1.FollowInfoResponse, error) {
 	relation, err := f.svc.FollowInfo(ctx, request.GetFollower(), request.GetFollowee())
+	if errors.Is(err, service.ErrFollowRelationNotFound) {
+		// 调用方靠 NotFound 判断有没有关注，不要当成系统错误
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -8,10 +8,12 @@ import (
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
 )
 
+var ErrFollowRelationNotFound = dao.ErrFollowRelationNotFound
+
 type FollowRepository interface {
 	// GetFollowee 获取某人的关注列表
 	GetFollowee(ctx context.Context, follower, offset, limit int64) ([]domain.FollowRelation, error)
-	// FollowInfo 查看关注的详情
+	// FollowInfo 查看关注的详情，没有关注返回 ErrFollowRelationNotFound
 	FollowInfo(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error)
 	// AddFollowRelation 创建关注关系
 	AddFollowRelation(ctx context.Context, f domain.FollowRelation) error
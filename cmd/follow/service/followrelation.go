@@ -6,10 +6,13 @@ import (
 	"github.com/XD/ScholarNet/cmd/follow/repository"
 )
 
+var ErrFollowRelationNotFound = repository.ErrFollowRelationNotFound
+
 type FollowRelationService interface {
 	Follow(ctx context.Context, follower, followee int64) error
 	CancelFollow(ctx context.Context, follower, followee int64) error
 	GetFollowee(ctx context.Context, follower, offset, limit int64) ([]domain.FollowRelation, error)
+	// FollowInfo follower 没有关注 followee 的时候返回 ErrFollowRelationNotFound
 	FollowInfo(ctx context.Context, follower, followee int64) (domain.FollowRelation, error)
 	GetFollowStatus(ctx context.Context, uid int64) (domain.FollowStatics, error)
 }
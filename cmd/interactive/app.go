@@ -4,6 +4,7 @@ import (
 	"github.com/XD/ScholarNet/cmd/pkg/ginx"
 	"github.com/XD/ScholarNet/cmd/pkg/grpcx"
 	"github.com/XD/ScholarNet/cmd/pkg/saramax"
+	"github.com/robfig/cron/v3"
 )
 
 type App struct {
@@ -12,4 +13,6 @@ type App struct {
 	server    *grpcx.Server
 	consumers []saramax.Consumer
 	webAdmin  *ginx.Server
+	// 合并计数增量的定时任务
+	cron *cron.Cron
 }
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// DefaultCollectionName cid 是 0 的默认收藏夹，数据库里面没有这个收藏夹
+const DefaultCollectionName = "默认收藏夹"
+
+// Collection 收藏夹
+type Collection struct {
+	Id   int64
+	Uid  int64
+	Name string
+	// ItemCnt 收藏夹里面有多少个东西
+	ItemCnt int64
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// CollectionItem 收藏夹里面的一个东西
+type CollectionItem struct {
+	// Id 收藏记录的 ID，翻页用
+	Id    int64
+	Cid   int64
+	Biz   string
+	BizId int64
+	// Ctime 收藏的时间
+	Ctime time.Time
+}
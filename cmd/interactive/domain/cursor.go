@@ -0,0 +1,17 @@
+package domain
+
+import "github.com/XD/ScholarNet/cmd/pkg/cursorx"
+
+var ErrInvalidCursor = cursorx.ErrInvalidCursor
+
+// Cursor 按照 (时间, id) 倒序翻页
+// 点赞列表的时间是表态的时间，收藏列表的时间是收藏的时间
+type Cursor = cursorx.Cursor
+
+// FirstPage 从最新的数据开始
+var FirstPage = cursorx.FirstPage
+
+// DecodeCursor 空字符串就是第一页
+func DecodeCursor(s string) (Cursor, error) {
+	return cursorx.Decode(s)
+}
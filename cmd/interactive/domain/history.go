@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// ReadRecord 一条阅读记录，同一个东西看多次也只有一条
+type ReadRecord struct {
+	// Id 阅读记录的 ID，翻页用
+	Id    int64
+	Biz   string
+	BizId int64
+	// Progress 读到了哪里，百分比，0 - 100
+	Progress int32
+	// Utime 最后一次阅读的时间
+	Utime time.Time
+}
@@ -1,5 +1,10 @@
 package domain
 
+import "time"
+
+// ReactionLike 点赞，每个 biz 都支持的表态
+const ReactionLike = "like"
+
 // Interactive 这个是总体交互的计数
 type Interactive struct {
 	Biz   string
@@ -8,8 +13,46 @@ type Interactive struct {
 	ReadCnt    int64 `json:"read_cnt"`
 	LikeCnt    int64 `json:"like_cnt"`
 	CollectCnt int64 `json:"collect_cnt"`
+	// CiteCnt 被多少篇帖子引用了，由 article 那边算好了发过来
+	CiteCnt int64 `json:"cite_cnt"`
+	// ReaderCnt 独立读者数，HyperLogLog 的估算值，定时写回数据库的，会比 ReadCnt 慢一点
+	ReaderCnt int64 `json:"reader_cnt"`
+	// Reactions 每种表态的数量，包括点赞，没有人表态的不在里面
+	Reactions map[string]int64 `json:"reactions"`
 	// 这个是当下这个资源，你有没有点赞或者收集
 	// 你也可以考虑把这两个字段分离出去，作为一个单独的结构体
 	Liked     bool `json:"liked"`
 	Collected bool `json:"collected"`
+	// Reaction 当下这个用户的表态，没有表态就是空字符串
+	Reaction string `json:"reaction"`
+}
+
+// CntMismatch 计数和点赞、收藏记录对不上
+// Expected 是按照记录算出来的应该存的值
+type CntMismatch struct {
+	// Id 存计数的那一行的 id
+	Id       int64
+	Biz      string
+	BizId    int64
+	CntType  string
+	Stored   int64
+	Expected int64
+}
+
+// DailyReaderCnt 某一天的独立读者数，估算值
+type DailyReaderCnt struct {
+	// Date 那一天的零点
+	Date time.Time
+	Cnt  int64
+}
+
+// LikedItem 用户点赞过的一个东西
+type LikedItem struct {
+	// Id 表态记录的 ID，翻页用
+	Id       int64
+	Biz      string
+	BizId    int64
+	Reaction string
+	// Utime 表态的时间，切换表态也会更新
+	Utime time.Time
 }
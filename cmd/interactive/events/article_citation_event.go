@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/saramax"
+)
+
+const topicArticleCitation = "article_citation_event"
+
+// CitationEvent 帖子的引用关系变了之后 article 发过来的
+type CitationEvent struct {
+	// CiteCnts 被引用的帖子 => 被多少篇帖子引用了
+	CiteCnts map[int64]int64
+}
+
+// ArticleCitationEventConsumer 更新被引用的次数
+type ArticleCitationEventConsumer struct {
+	client sarama.Client
+	repo   repository.InteractiveRepository
+	l      logger.LoggerV1
+}
+
+func NewArticleCitationEventConsumer(client sarama.Client,
+	repo repository.InteractiveRepository,
+	l logger.LoggerV1) *ArticleCitationEventConsumer {
+	return &ArticleCitationEventConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (r *ArticleCitationEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_citation", r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicArticleCitation},
+			saramax.NewHandler[CitationEvent](r.l, r.Consume))
+		if err != nil {
+			r.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Consume 次数是全量的，所以是幂等的
+func (r *ArticleCitationEventConsumer) Consume(msg *sarama.ConsumerMessage, evt CitationEvent) error {
+	if len(evt.CiteCnts) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return r.repo.BatchSetCiteCnt(ctx, "article", evt.CiteCnts)
+}
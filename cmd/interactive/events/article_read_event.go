@@ -1,6 +1,46 @@
 package events
 
+import (
+	"context"
+	"strconv"
+
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+// ReadEvent 打开一篇文章就会有一个，Uid 是 0 的是没有登录的
 type ReadEvent struct {
-	uid int64
+	Uid int64
 	Aid int64
+	// DeviceId 没有登录的用户用设备 ID 来区分，发送方拿得到的话就带上
+	DeviceId string
+}
+
+// reader 独立读者的标识，登录了就是 uid，没有登录就是设备 ID，都没有就是空字符串
+func (e ReadEvent) reader() string {
+	if e.Uid > 0 {
+		return "u:" + strconv.FormatInt(e.Uid, 10)
+	}
+	if e.DeviceId != "" {
+		return "d:" + e.DeviceId
+	}
+	return ""
+}
+
+// countRead 记独立读者，返回这一次要不要加阅读计数，去重窗口内重复打开的不加
+// 记不上的时候还是加，宁可多算也不要少算
+func countRead(ctx context.Context, repo repository.InteractiveRepository, l logger.LoggerV1, t ReadEvent) bool {
+	reader := t.reader()
+	if reader == "" {
+		return true
+	}
+	ok, err := repo.AddReader(ctx, "article", t.Aid, reader)
+	if err != nil {
+		l.Error("记录独立读者失败",
+			logger.Int64("aid", t.Aid),
+			logger.String("reader", reader),
+			logger.Error(err))
+		return true
+	}
+	return ok
 }
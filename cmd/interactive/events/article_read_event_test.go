@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// readerRepo 只实现 AddReader，别的方法调到了就 panic
+type readerRepo struct {
+	repository.InteractiveRepository
+	readers []string
+	res     bool
+	err     error
+}
+
+func (r *readerRepo) AddReader(ctx context.Context, biz string, bizId int64, reader string) (bool, error) {
+	r.readers = append(r.readers, reader)
+	return r.res, r.err
+}
+
+func TestCountRead(t *testing.T) {
+	testCases := []struct {
+		name string
+		evt  ReadEvent
+		res  bool
+		err  error
+
+		wantReaders []string
+		want        bool
+	}{
+		{
+			name:        "登录了用 uid",
+			evt:         ReadEvent{Uid: 1, Aid: 2, DeviceId: "abc"},
+			res:         true,
+			wantReaders: []string{"u:1"},
+			want:        true,
+		},
+		{
+			name:        "没有登录用设备 ID",
+			evt:         ReadEvent{Aid: 2, DeviceId: "abc"},
+			res:         true,
+			wantReaders: []string{"d:abc"},
+			want:        true,
+		},
+		{
+			name:        "窗口内重复打开不加",
+			evt:         ReadEvent{Uid: 1, Aid: 2},
+			res:         false,
+			wantReaders: []string{"u:1"},
+			want:        false,
+		},
+		{
+			name: "什么都没有的不去重",
+			evt:  ReadEvent{Aid: 2},
+			want: true,
+		},
+		{
+			name:        "Redis 出错了还是加",
+			evt:         ReadEvent{Uid: 1, Aid: 2},
+			err:         errors.New("mock redis error"),
+			wantReaders: []string{"u:1"},
+			want:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &readerRepo{res: tc.res, err: tc.err}
+			got := countRead(context.Background(), repo, logger.NewNoOpLogger(), tc.evt)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantReaders, repo.readers)
+		})
+	}
+}
@@ -49,13 +49,17 @@ func (r *InteractiveReadEventBatchConsumer) Start() error {
 func (r *InteractiveReadEventBatchConsumer) Consume(ctx context.Context, msgs []*sarama.ConsumerMessage, ts []ReadEvent) error {
 	ids := make([]int64, 0, len(ts))
 	bizs := make([]string, 0, len(ts))
+	c, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
 	// 第一个参数是索引
 	for _, evt := range ts {
+		// 去重窗口内重复打开的不加阅读计数
+		if !countRead(c, r.repo, r.l, evt) {
+			continue
+		}
 		ids = append(ids, evt.Aid)
 		bizs = append(bizs, "article")
 	}
-	c, cancel := context.WithTimeout(ctx, time.Second)
-	defer cancel()
 	err := r.repo.BatchIncrReadCnt(c, bizs, ids)
 	if err != nil {
 		r.l.Error("批量增加阅读计数失败",
@@ -64,5 +68,20 @@ func (r *InteractiveReadEventBatchConsumer) Consume(ctx context.Context, msgs []
 	} else {
 		r.l.Info("消费成功")
 	}
+	// 阅读历史一条一条记，记不上不影响阅读计数
+	hctx, hcancel := context.WithTimeout(ctx, time.Second)
+	defer hcancel()
+	for _, evt := range ts {
+		if evt.Uid <= 0 {
+			continue
+		}
+		er := r.repo.AddRecord(hctx, "article", evt.Aid, evt.Uid)
+		if er != nil {
+			r.l.Error("记录阅读历史失败",
+				logger.Int64("uid", evt.Uid),
+				logger.Int64("aid", evt.Aid),
+				logger.Error(er))
+		}
+	}
 	return nil
 }
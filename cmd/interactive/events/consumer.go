@@ -61,5 +61,20 @@ func (r *InteractiveReadEventConsumer) AsyncStart() error {
 func (r *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, t ReadEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return r.repo.IncrReadCnt(ctx, "article", t.Aid)
+	if countRead(ctx, r.repo, r.l, t) {
+		if err := r.repo.IncrReadCnt(ctx, "article", t.Aid); err != nil {
+			return err
+		}
+	}
+	if t.Uid <= 0 {
+		return nil
+	}
+	// 阅读历史记不上不影响阅读计数，只记录日志
+	if er := r.repo.AddRecord(ctx, "article", t.Aid, t.Uid); er != nil {
+		r.l.Error("记录阅读历史失败",
+			logger.Int64("uid", t.Uid),
+			logger.Int64("aid", t.Aid),
+			logger.Error(er))
+	}
+	return nil
 }
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+
+	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxListLimit 收藏、点赞和阅读历史的列表一页最多查多少个
+const maxListLimit = 100
+
+func (i *InteractiveServiceServer) CancelCollect(ctx context.Context, request *intrv1.CancelCollectRequest) (*intrv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractiveServiceServer) MoveCollectionItem(ctx context.Context, request *intrv1.MoveCollectionItemRequest) (*intrv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
+	switch err {
+	case nil:
+		return &intrv1.MoveCollectionItemResponse{}, nil
+	case service.ErrCollectionNotFound:
+		return nil, status.Error(codes.NotFound, "收藏夹不存在")
+	case service.ErrNotCollected:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, err
+	}
+}
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *intrv1.CreateCollectionRequest) (*intrv1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, request.GetUid(), request.GetName())
+	switch err {
+	case nil:
+		return &intrv1.CreateCollectionResponse{Id: id}, nil
+	case service.ErrInvalidCollectionName:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case service.ErrTooManyCollections:
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return nil, err
+	}
+}
+
+func (i *InteractiveServiceServer) RenameCollection(ctx context.Context, request *intrv1.RenameCollectionRequest) (*intrv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, request.GetUid(), request.GetCid(), request.GetName())
+	if err != nil {
+		return nil, i.collectionErr(err)
+	}
+	return &intrv1.RenameCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *intrv1.DeleteCollectionRequest) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.GetUid(), request.GetCid())
+	if err != nil {
+		return nil, i.collectionErr(err)
+	}
+	return &intrv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *intrv1.ListCollectionsRequest) (*intrv1.ListCollectionsResponse, error) {
+	cs, err := i.svc.ListCollections(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListCollectionsResponse{
+		Collections: slice.Map[domain.Collection, *intrv1.Collection](cs,
+			func(idx int, src domain.Collection) *intrv1.Collection {
+				c := &intrv1.Collection{
+					Id:      src.Id,
+					Name:    src.Name,
+					ItemCnt: src.ItemCnt,
+				}
+				// 默认收藏夹没有创建时间
+				if src.Id > 0 {
+					c.Ctime = src.Ctime.UnixMilli()
+					c.Utime = src.Utime.UnixMilli()
+				}
+				return c
+			}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *intrv1.ListCollectionItemsRequest) (*intrv1.ListCollectionItemsResponse, error) {
+	limit := int(request.GetLimit())
+	if request.GetOffset() < 0 || limit <= 0 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit 必须在 1 到 %d 之间", maxListLimit)
+	}
+	items, err := i.svc.ListCollectionItems(ctx, request.GetUid(), request.GetCid(),
+		int(request.GetOffset()), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListCollectionItemsResponse{
+		Items: slice.Map[domain.CollectionItem, *intrv1.CollectionItem](items, i.collectionItemToDTO),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollected(ctx context.Context, request *intrv1.ListCollectedRequest) (*intrv1.ListCollectedResponse, error) {
+	limit := int(request.GetLimit())
+	if limit <= 0 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit 必须在 1 到 %d 之间", maxListLimit)
+	}
+	cursor, err := domain.DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, next, err := i.svc.ListCollected(ctx, request.GetUid(), request.GetBiz(), cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListCollectedResponse{
+		Items:      slice.Map[domain.CollectionItem, *intrv1.CollectionItem](items, i.collectionItemToDTO),
+		NextCursor: next.Encode(),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) collectionItemToDTO(idx int, src domain.CollectionItem) *intrv1.CollectionItem {
+	return &intrv1.CollectionItem{
+		Cid:   src.Cid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Ctime: src.Ctime.UnixMilli(),
+	}
+}
+
+// collectionErr 改名和删除收藏夹共用的错误码
+func (i *InteractiveServiceServer) collectionErr(err error) error {
+	switch err {
+	case service.ErrCollectionNotFound:
+		return status.Error(codes.NotFound, "收藏夹不存在")
+	case service.ErrInvalidCollectionName:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.ErrDefaultCollectionFrozen:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *InteractiveServiceServer) ReportReadProgress(ctx context.Context, request *intrv1.ReportReadProgressRequest) (*intrv1.ReportReadProgressResponse, error) {
+	err := i.svc.ReportReadProgress(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetProgress())
+	if err == service.ErrInvalidProgress {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &intrv1.ReportReadProgressResponse{}, err
+}
+
+func (i *InteractiveServiceServer) GetReadProgress(ctx context.Context, request *intrv1.GetReadProgressRequest) (*intrv1.GetReadProgressResponse, error) {
+	record, err := i.svc.GetReadProgress(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	switch err {
+	case nil:
+		return &intrv1.GetReadProgressResponse{Record: readRecordToDTO(record)}, nil
+	case service.ErrReadRecordNotFound:
+		// 没有看过不算错误，从头开始看就可以
+		return &intrv1.GetReadProgressResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (i *InteractiveServiceServer) ListReadHistory(ctx context.Context, request *intrv1.ListReadHistoryRequest) (*intrv1.ListReadHistoryResponse, error) {
+	limit := int(request.GetLimit())
+	if limit <= 0 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit 必须在 1 到 %d 之间", maxListLimit)
+	}
+	cursor, err := domain.DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	records, next, err := i.svc.ListReadHistory(ctx, request.GetUid(), request.GetBiz(), cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	paused, err := i.svc.ReadHistoryPaused(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListReadHistoryResponse{
+		Records: slice.Map[domain.ReadRecord, *intrv1.ReadRecord](records,
+			func(idx int, src domain.ReadRecord) *intrv1.ReadRecord {
+				return readRecordToDTO(src)
+			}),
+		NextCursor: next.Encode(),
+		Paused:     paused,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ClearReadHistory(ctx context.Context, request *intrv1.ClearReadHistoryRequest) (*intrv1.ClearReadHistoryResponse, error) {
+	err := i.svc.ClearReadHistory(ctx, request.GetUid(), request.GetBiz())
+	return &intrv1.ClearReadHistoryResponse{}, err
+}
+
+func (i *InteractiveServiceServer) PauseReadHistory(ctx context.Context, request *intrv1.PauseReadHistoryRequest) (*intrv1.PauseReadHistoryResponse, error) {
+	err := i.svc.PauseReadHistory(ctx, request.GetUid(), request.GetPaused())
+	return &intrv1.PauseReadHistoryResponse{}, err
+}
+
+func readRecordToDTO(r domain.ReadRecord) *intrv1.ReadRecord {
+	return &intrv1.ReadRecord{
+		Biz:      r.Biz,
+		BizId:    r.BizId,
+		Progress: r.Progress,
+		ReadTime: r.Utime.UnixMilli(),
+	}
+}
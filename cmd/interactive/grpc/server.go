@@ -5,7 +5,11 @@ import (
 	intrv1 "github.com/XD/ScholarNet/cmd/api/proto/gen/intr/v1"
 	"github.com/XD/ScholarNet/cmd/interactive/domain"
 	"github.com/XD/ScholarNet/cmd/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
 )
 
 // InteractiveServiceServer 我这里只是把 service 包装成一个 grpc 而已
@@ -39,8 +43,49 @@ func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *intr
 	return &intrv1.CancelLikeResponse{}, err
 }
 
+func (i *InteractiveServiceServer) React(ctx context.Context, request *intrv1.ReactRequest) (*intrv1.ReactResponse, error) {
+	err := i.svc.React(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetReaction())
+	if err == service.ErrUnknownReaction {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &intrv1.ReactResponse{}, err
+}
+
+func (i *InteractiveServiceServer) ListLiked(ctx context.Context, request *intrv1.ListLikedRequest) (*intrv1.ListLikedResponse, error) {
+	limit := int(request.GetLimit())
+	if limit <= 0 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit 必须在 1 到 %d 之间", maxListLimit)
+	}
+	cursor, err := domain.DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, next, err := i.svc.ListLiked(ctx, request.GetUid(), request.GetBiz(), cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListLikedResponse{
+		Items: slice.Map[domain.LikedItem, *intrv1.LikedItem](items,
+			func(idx int, src domain.LikedItem) *intrv1.LikedItem {
+				return &intrv1.LikedItem{
+					Biz:      src.Biz,
+					BizId:    src.BizId,
+					Reaction: src.Reaction,
+					Utime:    src.Utime.UnixMilli(),
+				}
+			}),
+		NextCursor: next.Encode(),
+	}, nil
+}
+
 func (i *InteractiveServiceServer) Collect(ctx context.Context, request *intrv1.CollectRequest) (*intrv1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetCid(), request.GetUid())
+	switch err {
+	case service.ErrCollectionNotFound:
+		return nil, status.Error(codes.NotFound, "收藏夹不存在")
+	case service.ErrAlreadyCollected:
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
 	return &intrv1.CollectResponse{}, err
 }
 
@@ -52,7 +97,7 @@ func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetR
 }
 
 func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1.GetByIdsRequest) (*intrv1.GetByIdsResponse, error) {
-	mp, err := i.svc.GetByIds(ctx, request.GetBiz(), request.GetBizIds())
+	mp, err := i.svc.GetByIds(ctx, request.GetBiz(), request.GetBizIds(), request.GetUid())
 	if err != nil {
 		return nil, err
 	}
@@ -75,5 +120,29 @@ func (i *InteractiveServiceServer) toDTO(interactive domain.Interactive) *intrv1
 		LikeCnt:    interactive.LikeCnt,
 		Liked:      interactive.Liked,
 		Collected:  interactive.Collected,
+		CiteCnt:    interactive.CiteCnt,
+		ReaderCnt:  interactive.ReaderCnt,
+		Reactions:  interactive.Reactions,
+		Reaction:   interactive.Reaction,
 	}
 }
+
+func (i *InteractiveServiceServer) GetDailyReaders(ctx context.Context, request *intrv1.GetDailyReadersRequest) (*intrv1.GetDailyReadersResponse, error) {
+	cnts, err := i.svc.GetDailyReaders(ctx, request.GetBiz(), request.GetBizId(), int(request.GetDays()))
+	switch err {
+	case nil:
+	case service.ErrInvalidDays:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, err
+	}
+	return &intrv1.GetDailyReadersResponse{
+		Days: slice.Map[domain.DailyReaderCnt, *intrv1.DailyReaders](cnts,
+			func(idx int, src domain.DailyReaderCnt) *intrv1.DailyReaders {
+				return &intrv1.DailyReaders{
+					Date: src.Date.Format(time.DateOnly),
+					Cnt:  src.Cnt,
+				}
+			}),
+	}, nil
+}
@@ -244,10 +244,11 @@ func (s *InteractiveTestSuite) TestLike() {
 				likeBiz.Ctime = 0
 				likeBiz.Utime = 0
 				assert.Equal(t, dao.UserLikeBiz{
-					Biz:    "test",
-					BizId:  2,
-					Uid:    123,
-					Status: 1,
+					Biz:      "test",
+					BizId:    2,
+					Uid:      123,
+					Status:   1,
+					Reaction: "like",
 				}, likeBiz)
 
 				cnt, err := s.rdb.HGet(ctx, "interactive:test:2", "like_cnt").Int()
@@ -292,10 +293,11 @@ func (s *InteractiveTestSuite) TestLike() {
 				likeBiz.Ctime = 0
 				likeBiz.Utime = 0
 				assert.Equal(t, dao.UserLikeBiz{
-					Biz:    "test",
-					BizId:  3,
-					Uid:    123,
-					Status: 1,
+					Biz:      "test",
+					BizId:    3,
+					Uid:      123,
+					Status:   1,
+					Reaction: "like",
 				}, likeBiz)
 
 				cnt, err := s.rdb.Exists(ctx, "interactive:test:2").Result()
@@ -386,12 +388,13 @@ func (s *InteractiveTestSuite) TestDislike() {
 				assert.True(t, likeBiz.Utime > 7)
 				likeBiz.Utime = 0
 				assert.Equal(t, dao.UserLikeBiz{
-					Id:     1,
-					Biz:    "test",
-					BizId:  2,
-					Uid:    123,
-					Ctime:  6,
-					Status: 0,
+					Id:       1,
+					Biz:      "test",
+					BizId:    2,
+					Uid:      123,
+					Ctime:    6,
+					Status:   0,
+					Reaction: "like",
 				}, likeBiz)
 
 				cnt, err := s.rdb.HGet(ctx, "interactive:test:2", "like_cnt").Int()
@@ -663,8 +666,10 @@ func (s *InteractiveTestSuite) TestGet() {
 				ReadCnt:    100,
 				CollectCnt: 200,
 				LikeCnt:    300,
+				Reactions:  map[string]int64{"like": 300},
 				Liked:      true,
 				Collected:  true,
+				Reaction:   "like",
 			},
 		},
 		{
@@ -705,6 +710,7 @@ func (s *InteractiveTestSuite) TestGet() {
 				CollectCnt: 1,
 				Collected:  true,
 				Liked:      true,
+				Reaction:   "like",
 			},
 		},
 	}
@@ -760,6 +766,7 @@ func (s *InteractiveTestSuite) TestGetByIds() {
 					ReadCnt:    1,
 					CollectCnt: 2,
 					LikeCnt:    3,
+					Reactions:  map[string]int64{"like": 3},
 				},
 				2: {
 					Biz:        "test",
@@ -767,6 +774,7 @@ func (s *InteractiveTestSuite) TestGetByIds() {
 					ReadCnt:    2,
 					CollectCnt: 3,
 					LikeCnt:    4,
+					Reactions:  map[string]int64{"like": 4},
 				},
 			},
 		},
@@ -781,7 +789,7 @@ func (s *InteractiveTestSuite) TestGetByIds() {
 	svc := startup.InitInteractiveService()
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			res, err := svc.GetByIds(context.Background(), tc.biz, tc.ids)
+			res, err := svc.GetByIds(context.Background(), tc.biz, tc.ids, 0)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantRes, res)
 		})
@@ -0,0 +1,10 @@
+package startup
+
+import "github.com/XD/ScholarNet/cmd/interactive/service"
+
+// InitReactions 测试里面 article 支持所有的表态
+func InitReactions() service.Reactions {
+	return service.Reactions{
+		"article": {"like", "insightful", "needs_citation", "confused"},
+	}
+}
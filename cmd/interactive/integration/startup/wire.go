@@ -11,7 +11,7 @@ import (
 )
 
 var thirdProvider = wire.NewSet(InitRedis,
-	InitTestDB, InitLogger)
+	InitTestDB, InitLogger, InitReactions)
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
@@ -22,5 +22,5 @@ var interactiveSvcProvider = wire.NewSet(
 
 func InitInteractiveService() service.InteractiveService {
 	wire.Build(thirdProvider, interactiveSvcProvider)
-	return service.NewInteractiveService(nil, nil)
+	return service.NewInteractiveService(nil, nil, nil)
 }
@@ -23,13 +23,14 @@ func InitInteractiveService() service.InteractiveService {
 	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB)
 	loggerV1 := InitLogger()
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveCache, interactiveDAO, loggerV1)
-	interactiveService := service.NewInteractiveService(interactiveRepository, loggerV1)
+	reactions := InitReactions()
+	interactiveService := service.NewInteractiveService(interactiveRepository, reactions, loggerV1)
 	return interactiveService
 }
 
 // wire.go:
 
 var thirdProvider = wire.NewSet(InitRedis,
-	InitTestDB, InitLogger)
+	InitTestDB, InitLogger, InitReactions)
 
 var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepository, dao.NewGORMInteractiveDAO, cache.NewRedisInteractiveCache)
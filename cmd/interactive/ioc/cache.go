@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/interactive/repository/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// InitInteractiveCache uniqueReader.dedupWindow 内同一个人重复打开只算一次阅读，改了配置立刻生效
+func InitInteractiveCache(client redis.Cmdable) cache.InteractiveCache {
+	res := cache.NewRedisInteractiveCache(client).(*cache.RedisInteractiveCache)
+	res.UpdateReadDedupWindow(viper.GetDuration("uniqueReader.dedupWindow"))
+	onConfigChange(func() {
+		res.UpdateReadDedupWindow(viper.GetDuration("uniqueReader.dedupWindow"))
+	})
+	return res
+}
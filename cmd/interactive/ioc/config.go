@@ -0,0 +1,28 @@
+package ioc
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+var (
+	configMu       sync.Mutex
+	configHandlers []func()
+)
+
+// onConfigChange viper 只会保留最后一次 OnConfigChange 注册的回调，
+// 所以要热更新的配置都在这里注册，改了配置之后挨个调用
+func onConfigChange(fn func()) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	configHandlers = append(configHandlers, fn)
+	handlers := slices.Clone(configHandlers)
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		for _, h := range handlers {
+			h()
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// InitWriteBehindDAO writeBehind.bizs 里面的 biz 计数先写增量再合并，改了配置立刻生效
+func InitWriteBehindDAO(db *gorm.DB) *dao.WriteBehindInteractiveDAO {
+	res := dao.NewWriteBehindInteractiveDAO(dao.NewGORMInteractiveDAO(db), db,
+		viper.GetStringSlice("writeBehind.bizs"))
+	onConfigChange(func() {
+		res.UpdateBizs(viper.GetStringSlice("writeBehind.bizs"))
+	})
+	return res
+}
@@ -0,0 +1,73 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/interactive/job"
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/cronjobx"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+)
+
+func InitFlushDeltaJob(flusher *dao.WriteBehindInteractiveDAO, rlockClient *rlock.Client, l logger.LoggerV1) *job.FlushDeltaJob {
+	batchSize := viper.GetInt("writeBehind.batchSize")
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return job.NewFlushDeltaJob(flusher, rlockClient, l, batchSize, time.Second*30)
+}
+
+func InitPersistReaderCntJob(repo repository.InteractiveRepository, rlockClient *rlock.Client, l logger.LoggerV1) *job.PersistReaderCntJob {
+	batchSize := viper.GetInt("uniqueReader.batchSize")
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return job.NewPersistReaderCntJob(repo, rlockClient, l, batchSize, time.Second*30)
+}
+
+// InitReconcileCntJob reconcile.fix 打开之后才会修，改了配置立刻生效
+func InitReconcileCntJob(repo repository.InteractiveRepository, rlockClient *rlock.Client,
+	cmd redis.Cmdable, l logger.LoggerV1) *job.ReconcileCntJob {
+	batchSize := viper.GetInt("reconcile.batchSize")
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	interval := viper.GetDuration("reconcile.interval")
+	if interval <= 0 {
+		interval = time.Second
+	}
+	res := job.NewReconcileCntJob(repo, rlockClient, cmd, l, batchSize, interval, time.Minute*5)
+	res.UpdateFix(viper.GetBool("reconcile.fix"))
+	onConfigChange(func() {
+		res.UpdateFix(viper.GetBool("reconcile.fix"))
+	})
+	return res
+}
+
+// InitJobs 所有定时任务都在这里初始化
+func InitJobs(l logger.LoggerV1, flushJob *job.FlushDeltaJob, readerJob *job.PersistReaderCntJob,
+	reconcileJob *job.ReconcileCntJob) *cron.Cron {
+	res := cron.New(cron.WithSeconds())
+	cbd := cronjobx.NewCronJobBuilder(l)
+	// 合并得越勤，数据库里面的计数越新，查询的时候不会再去加没合并的增量
+	_, err := res.AddJob("*/5 * * * * ?", cbd.Build(flushJob))
+	if err != nil {
+		panic(err)
+	}
+	// 独立读者数本来就是估算值，晚一点写回也没关系
+	_, err = res.AddJob("0 * * * * ?", cbd.Build(readerJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每次最多跑五分钟，跑不完下一次接着来
+	_, err = res.AddJob("0 */10 * * * ?", cbd.Build(reconcileJob))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
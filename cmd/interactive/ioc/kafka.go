@@ -38,6 +38,7 @@ func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
 // NewConsumers 面临的问题依旧是所有的 Consumer 在这里注册一下
 // 加上fix.Consumer
 func NewConsumers(c1 *events2.InteractiveReadEventConsumer,
+	citation *events2.ArticleCitationEventConsumer,
 	fix *fixer.Consumer[dao.Interactive]) []saramax.Consumer {
-	return []saramax.Consumer{c1, fix}
+	return []saramax.Consumer{c1, citation, fix}
 }
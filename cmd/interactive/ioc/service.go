@@ -0,0 +1,15 @@
+package ioc
+
+import (
+	"github.com/XD/ScholarNet/cmd/interactive/service"
+	"github.com/spf13/viper"
+)
+
+// InitReactions reactions 下面是每个 biz 支持的表态，没有配置的 biz 只能点赞
+func InitReactions() service.Reactions {
+	var res service.Reactions
+	if err := viper.UnmarshalKey("reactions", &res); err != nil {
+		panic(err)
+	}
+	return res
+}
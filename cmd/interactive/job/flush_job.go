@@ -0,0 +1,64 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+)
+
+// DeltaFlusher 就是 dao.WriteBehindInteractiveDAO
+type DeltaFlusher interface {
+	// Flush 合并最早的 batchSize 个增量，返回合并了多少个
+	Flush(ctx context.Context, batchSize int) (int, error)
+}
+
+// FlushDeltaJob 把先写后合并的计数增量合并到 Interactive 里面
+// 合并本身是事务，多个实例同时合并也不会出错，分布式锁只是为了不做无用功
+type FlushDeltaJob struct {
+	flusher   DeltaFlusher
+	timeout   time.Duration
+	client    *rlock.Client
+	key       string
+	l         logger.LoggerV1
+	batchSize int
+}
+
+func NewFlushDeltaJob(flusher DeltaFlusher,
+	client *rlock.Client,
+	l logger.LoggerV1,
+	batchSize int,
+	timeout time.Duration) *FlushDeltaJob {
+	return &FlushDeltaJob{
+		flusher:   flusher,
+		timeout:   timeout,
+		client:    client,
+		key:       "rlock:cron_job:flush_interactive_delta",
+		l:         l,
+		batchSize: batchSize,
+	}
+}
+
+func (j *FlushDeltaJob) Name() string { return "flush_interactive_delta" }
+
+func (j *FlushDeltaJob) Run() error {
+	return runWithLock(j.client, j.key, j.timeout, j.l, func(ctx context.Context) error {
+		total := 0
+		// 一直合并到积压的增量不满一批，下一次再来
+		for ctx.Err() == nil {
+			n, err := j.flusher.Flush(ctx, j.batchSize)
+			if err != nil {
+				return err
+			}
+			total += n
+			if n < j.batchSize {
+				break
+			}
+		}
+		if total > 0 {
+			j.l.Debug("合并计数增量", logger.Int("cnt", total))
+		}
+		return nil
+	})
+}
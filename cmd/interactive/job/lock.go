@@ -0,0 +1,35 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+)
+
+// runWithLock 拿到分布式锁才执行 fn，没拿到说明别的实例在执行，直接返回
+// fn 拿到的 ctx 在 timeout 之后就过期了，锁也是这么久
+func runWithLock(client *rlock.Client, key string, timeout time.Duration,
+	l logger.LoggerV1, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	lock, err := client.Lock(ctx, key, timeout, &rlock.FixIntervalRetry{
+		Interval: time.Millisecond * 100,
+		Max:      0,
+	}, time.Second)
+	cancel()
+	if err != nil {
+		// 没拿到锁，说明别的实例在执行
+		return nil
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if er := lock.Unlock(ctx); er != nil {
+			l.Error("释放分布式锁失败", logger.String("key", key), logger.Error(er))
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+)
+
+// ReaderCntPersister 就是 repository.InteractiveRepository
+type ReaderCntPersister interface {
+	// PersistReaderCnts 把最多 batchSize 个变了的独立读者数写回数据库，返回写了多少个
+	PersistReaderCnts(ctx context.Context, batchSize int) (int, error)
+}
+
+// PersistReaderCntJob 把 Redis 里面 HyperLogLog 估算的独立读者数写回 Interactive
+// 写回是直接覆盖，多个实例同时写也不会出错，分布式锁只是为了不做无用功
+type PersistReaderCntJob struct {
+	persister ReaderCntPersister
+	timeout   time.Duration
+	client    *rlock.Client
+	key       string
+	l         logger.LoggerV1
+	batchSize int
+}
+
+func NewPersistReaderCntJob(persister ReaderCntPersister,
+	client *rlock.Client,
+	l logger.LoggerV1,
+	batchSize int,
+	timeout time.Duration) *PersistReaderCntJob {
+	return &PersistReaderCntJob{
+		persister: persister,
+		timeout:   timeout,
+		client:    client,
+		key:       "rlock:cron_job:persist_reader_cnt",
+		l:         l,
+		batchSize: batchSize,
+	}
+}
+
+func (j *PersistReaderCntJob) Name() string { return "persist_reader_cnt" }
+
+func (j *PersistReaderCntJob) Run() error {
+	return runWithLock(j.client, j.key, j.timeout, j.l, func(ctx context.Context) error {
+		total := 0
+		for ctx.Err() == nil {
+			n, err := j.persister.PersistReaderCnts(ctx, j.batchSize)
+			if err != nil {
+				return err
+			}
+			total += n
+			if n < j.batchSize {
+				break
+			}
+		}
+		if total > 0 {
+			j.l.Debug("写回独立读者数", logger.Int("cnt", total))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,149 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/syncx/atomicx"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// CntReconciler 就是 repository.InteractiveRepository，修了之后缓存也要跟着删掉
+type CntReconciler interface {
+	FindCntMismatches(ctx context.Context, startId int64, limit int) ([]domain.CntMismatch, int64, error)
+	FixCnt(ctx context.Context, m domain.CntMismatch) (bool, error)
+}
+
+// ReconcileCntJob 按照点赞、收藏记录校验 Interactive 里面的点赞数和收藏数
+// 一次跑不完就把校验到哪里了记在 Redis 里面，下一次接着来，重启了或者换了实例也一样，
+// 校验完一遍再从头开始
+// 每一批之间停 interval，免得线上数据库扛不住；对不上的都会上报，打开了 fix 才会修
+type ReconcileCntJob struct {
+	reconciler CntReconciler
+	timeout    time.Duration
+	client     *rlock.Client
+	key        string
+	// cmd 和 cursorKey 记录校验到的最后一个 Interactive 的 id
+	cmd       redis.Cmdable
+	cursorKey string
+	l         logger.LoggerV1
+	batchSize int
+	interval  time.Duration
+	fix       *atomicx.Value[bool]
+	mismatch  *prometheus.CounterVec
+}
+
+func NewReconcileCntJob(reconciler CntReconciler,
+	client *rlock.Client,
+	cmd redis.Cmdable,
+	l logger.LoggerV1,
+	batchSize int,
+	interval time.Duration,
+	timeout time.Duration) *ReconcileCntJob {
+	mismatch := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "basic_go",
+		Subsystem: "webook",
+		Name:      "interactive_cnt_mismatch",
+		Help:      "Interactive 里面的计数和记录对不上的次数",
+	}, []string{"biz", "cnt_type", "fixed"})
+	prometheus.MustRegister(mismatch)
+	return &ReconcileCntJob{
+		reconciler: reconciler,
+		timeout:    timeout,
+		client:     client,
+		key:        "rlock:cron_job:reconcile_interactive_cnt",
+		cmd:        cmd,
+		cursorKey:  "interactive:reconcile_cnt:last_id",
+		l:          l,
+		batchSize:  batchSize,
+		interval:   interval,
+		fix:        atomicx.NewValueOf(false),
+		mismatch:   mismatch,
+	}
+}
+
+// UpdateFix false 就是只上报不修，先观察一下误报多不多
+func (j *ReconcileCntJob) UpdateFix(fix bool) {
+	j.fix.Store(fix)
+}
+
+func (j *ReconcileCntJob) Name() string { return "reconcile_interactive_cnt" }
+
+func (j *ReconcileCntJob) Run() error {
+	return runWithLock(j.client, j.key, j.timeout, j.l, func(ctx context.Context) error {
+		startId, err := j.loadCursor(ctx)
+		if err != nil {
+			return err
+		}
+		for ctx.Err() == nil {
+			mismatches, lastId, err := j.reconciler.FindCntMismatches(ctx, startId, j.batchSize)
+			if err != nil {
+				return err
+			}
+			if lastId == startId {
+				// 校验完一遍了，下一次从头开始
+				return j.saveCursor(ctx, 0)
+			}
+			j.reconcile(ctx, mismatches)
+			// 先修再记，中途挂了最多重新校验一批
+			if err = j.saveCursor(ctx, lastId); err != nil {
+				return err
+			}
+			startId = lastId
+			select {
+			case <-ctx.Done():
+			case <-time.After(j.interval):
+			}
+		}
+		// 这一次的时间用完了，下一次接着校验
+		return nil
+	})
+}
+
+// loadCursor 上一次校验到哪里了，没有记录就从头开始
+func (j *ReconcileCntJob) loadCursor(ctx context.Context) (int64, error) {
+	res, err := j.cmd.Get(ctx, j.cursorKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return res, err
+}
+
+func (j *ReconcileCntJob) saveCursor(ctx context.Context, lastId int64) error {
+	// 时间用完了 ctx 也过期了，换一个 ctx 把进度记下来
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	return j.cmd.Set(ctx, j.cursorKey, lastId, 0).Err()
+}
+
+func (j *ReconcileCntJob) reconcile(ctx context.Context, mismatches []domain.CntMismatch) {
+	fix := j.fix.Load()
+	// 点赞数和收藏数都对不上的时候，一次就都修好了，修的结果两个都用
+	results := make(map[int64]bool, len(mismatches))
+	for _, m := range mismatches {
+		fields := []logger.Field{
+			logger.String("biz", m.Biz),
+			logger.Int64("bizId", m.BizId),
+			logger.String("cntType", m.CntType),
+			logger.Int64("stored", m.Stored),
+			logger.Int64("expected", m.Expected),
+		}
+		fixed, ok := results[m.Id]
+		if fix && !ok {
+			var err error
+			fixed, err = j.reconciler.FixCnt(ctx, m)
+			if err != nil {
+				j.l.Error("修复计数失败", append(fields, logger.Error(err))...)
+			}
+			results[m.Id] = fixed
+		}
+		j.mismatch.WithLabelValues(m.Biz, m.CntType, strconv.FormatBool(fixed)).Inc()
+		j.l.Warn("计数对不上", append(fields, logger.Bool("fixed", fixed))...)
+	}
+}
@@ -17,6 +17,11 @@ func main() {
 			panic(err)
 		}
 	}
+	app.cron.Start()
+	defer func() {
+		// 等待正在执行的任务结束
+		<-app.cron.Stop().Done()
+	}()
 	go func() {
 		// http，8081 端口
 		err := app.webAdmin.Start()
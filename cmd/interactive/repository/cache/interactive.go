@@ -6,20 +6,30 @@ import (
 	"errors"
 	"fmt"
 	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/ecodeclub/ekit/syncx/atomicx"
 	"github.com/redis/go-redis/v9"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var (
 	//go:embed lua/interactive_incr_cnt.lua
 	luaIncrCnt string
+	//go:embed lua/interactive_set_cnt.lua
+	luaSetCnt string
+	//go:embed lua/interactive_incr_cnts.lua
+	luaIncrCnts string
 )
 
 const (
 	fieldReadCnt    = "read_cnt"
 	fieldCollectCnt = "collect_cnt"
 	fieldLikeCnt    = "like_cnt"
+	fieldCiteCnt    = "cite_cnt"
+	// fieldReactionPrefix 除了点赞之外的表态，field 是 reaction:表态
+	// 点赞的计数还是 like_cnt
+	fieldReactionPrefix = "reaction:"
 )
 
 type InteractiveCache interface {
@@ -29,9 +39,26 @@ type InteractiveCache interface {
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// IncrReactionCntIfPresent deltas 是表态 => 增量，换表态的时候一个 -1 一个 +1
+	IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, deltas map[string]int64) error
+	// SetCiteCntIfPresent 被引用次数是算好了的，直接覆盖
+	SetCiteCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error
+
+	// AddReader 把 reader 加到这个东西的 HyperLogLog 里面，总的和当天的各一个
+	// 返回这一次要不要算一次阅读，reader 在去重窗口内看过的话就不算
+	AddReader(ctx context.Context, biz string, bizId int64, reader string) (bool, error)
+	// DailyReaderCnts 每一天的独立读者数的估算值，和 days 一一对应
+	DailyReaderCnts(ctx context.Context, biz string, bizId int64, days []time.Time) ([]int64, error)
+	// PopReaderCnts 拿出最多 limit 个估算值变了的东西，biz => bizId => 独立读者数
+	PopReaderCnts(ctx context.Context, limit int) (map[string]map[int64]int64, error)
+	// PushBackReaderDirty 拿出来了但是没有写进数据库的，放回去等下一次
+	PushBackReaderDirty(ctx context.Context, biz string, bizIds []int64) error
+	SetReaderCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error
 
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error
+	// Del 数据库里面的计数被直接改掉了的时候用，下一次查的时候再从数据库加载
+	Del(ctx context.Context, biz string, bizId int64) error
 }
 
 // 方案1
@@ -63,6 +90,18 @@ func (r *RedisInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz
 	return r.client.Eval(ctx, luaIncrCnt, []string{r.key(biz, bizId)}, fieldCollectCnt, -1).Err()
 }
 
+func (r *RedisInteractiveCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, deltas map[string]int64) error {
+	args := make([]any, 0, len(deltas)*2)
+	for reaction, delta := range deltas {
+		args = append(args, r.reactionField(reaction), delta)
+	}
+	return r.client.Eval(ctx, luaIncrCnts, []string{r.key(biz, bizId)}, args...).Err()
+}
+
+func (r *RedisInteractiveCache) SetCiteCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	return r.client.Eval(ctx, luaSetCnt, []string{r.key(biz, bizId)}, fieldCiteCnt, cnt).Err()
+}
+
 func (r *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	// 直接使用 HMGet，即便缓存中没有对应的 key，也不会返回 error
 	//r.client.HMGet(ctx, r.key(biz, bizId),
@@ -84,39 +123,83 @@ func (r *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64
 	readCnt, _ := strconv.ParseInt(data[fieldReadCnt], 10, 64)
 	collectCnt, _ := strconv.ParseInt(data[fieldCollectCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(data[fieldLikeCnt], 10, 64)
+	citeCnt, _ := strconv.ParseInt(data[fieldCiteCnt], 10, 64)
+	readerCnt, _ := strconv.ParseInt(data[fieldReaderCnt], 10, 64)
+	var reactions map[string]int64
+	if likeCnt > 0 {
+		reactions = map[string]int64{domain.ReactionLike: likeCnt}
+	}
+	for field, val := range data {
+		reaction, ok := strings.CutPrefix(field, fieldReactionPrefix)
+		if !ok {
+			continue
+		}
+		cnt, _ := strconv.ParseInt(val, 10, 64)
+		if cnt <= 0 {
+			continue
+		}
+		if reactions == nil {
+			reactions = make(map[string]int64)
+		}
+		reactions[reaction] = cnt
+	}
 	return domain.Interactive{
 		Biz:        biz,
 		BizId:      bizId,
 		ReadCnt:    readCnt,
 		LikeCnt:    likeCnt,
 		CollectCnt: collectCnt,
+		CiteCnt:    citeCnt,
+		ReaderCnt:  readerCnt,
+		Reactions:  reactions,
 	}, nil
 }
 
 func (r *RedisInteractiveCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
 	key := r.key(biz, bizId)
-	err := r.client.HMSet(ctx, key, map[string]any{
+	vals := map[string]any{
 		fieldReadCnt:    intr.ReadCnt,
 		fieldLikeCnt:    intr.LikeCnt,
 		fieldCollectCnt: intr.CollectCnt,
-	}).Err()
+		fieldCiteCnt:    intr.CiteCnt,
+		fieldReaderCnt:  intr.ReaderCnt,
+	}
+	for reaction, cnt := range intr.Reactions {
+		if reaction != domain.ReactionLike {
+			vals[r.reactionField(reaction)] = cnt
+		}
+	}
+	err := r.client.HMSet(ctx, key, vals).Err()
 	if err != nil {
 		return err
 	}
 	return r.client.Expire(ctx, key, time.Minute*15).Err()
 }
 
+func (r *RedisInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Del(ctx, r.key(biz, bizId)).Err()
+}
+
 type RedisInteractiveCache struct {
-	client     redis.Cmdable
-	expiration time.Duration
+	client      redis.Cmdable
+	expiration  time.Duration
+	dedupWindow *atomicx.Value[time.Duration]
 }
 
 func (r *RedisInteractiveCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
 }
 
+func (r *RedisInteractiveCache) reactionField(reaction string) string {
+	if reaction == domain.ReactionLike {
+		return fieldLikeCnt
+	}
+	return fieldReactionPrefix + reaction
+}
+
 func NewRedisInteractiveCache(client redis.Cmdable) InteractiveCache {
 	return &RedisInteractiveCache{
-		client: client,
+		client:      client,
+		dedupWindow: atomicx.NewValueOf(time.Duration(0)),
 	}
 }
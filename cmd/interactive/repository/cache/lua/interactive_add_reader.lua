@@ -0,0 +1,28 @@
+-- 总的 HyperLogLog
+local total = KEYS[1]
+-- 当天的 HyperLogLog
+local daily = KEYS[2]
+-- 估算值变了的 biz:bizId，等着定时任务写回数据库
+local dirty = KEYS[3]
+-- 去重用的 key，窗口内存在说明这个人刚看过
+local dedup = KEYS[4]
+local reader = ARGV[1]
+local dailyTTL = tonumber(ARGV[2])
+local member = ARGV[3]
+-- 毫秒数，0 就是不去重
+local window = tonumber(ARGV[4])
+
+if redis.call("PFADD", total, reader) == 1 then
+    redis.call("SADD", dirty, member)
+end
+redis.call("PFADD", daily, reader)
+redis.call("EXPIRE", daily, dailyTTL)
+
+if window <= 0 then
+    return 1
+end
+if redis.call("SET", dedup, "1", "NX", "PX", window) then
+    return 1
+end
+-- 窗口内重复打开，阅读计数不加
+return 0
@@ -0,0 +1,12 @@
+local key = KEYS[1]
+-- ARGV 是 field1, delta1, field2, delta2 ... 这样成对的
+local exists = redis.call("EXISTS", key)
+if exists == 1 then
+    -- 换表态的时候一个减一个加，要一起改
+    for i = 1, #ARGV, 2 do
+        redis.call("HINCRBY", key, ARGV[i], tonumber(ARGV[i + 1]))
+    end
+    return 1
+else
+    return 0
+end
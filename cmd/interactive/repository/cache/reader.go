@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/interactive_add_reader.lua
+var luaAddReader string
+
+const (
+	fieldReaderCnt = "reader_cnt"
+	// keyReaderDirty 估算值变了的 biz:bizId，写回数据库之后就拿掉
+	keyReaderDirty = "interactive:reader:dirty"
+	// dailyReaderTTL 按天的 HyperLogLog 留多久，趋势最多也就查这么多天
+	dailyReaderTTL = time.Hour * 24 * 31
+	dailyLayout    = "20060102"
+)
+
+func (r *RedisInteractiveCache) AddReader(ctx context.Context, biz string, bizId int64, reader string) (bool, error) {
+	keys := []string{
+		r.readerKey(biz, bizId),
+		r.dailyReaderKey(biz, bizId, time.Now()),
+		keyReaderDirty,
+		r.dedupKey(biz, bizId, reader),
+	}
+	res, err := r.client.Eval(ctx, luaAddReader, keys,
+		reader, int64(dailyReaderTTL/time.Second),
+		r.dirtyMember(biz, bizId), r.dedupWindow.Load().Milliseconds()).Int()
+	return res == 1, err
+}
+
+func (r *RedisInteractiveCache) DailyReaderCnts(ctx context.Context, biz string, bizId int64, days []time.Time) ([]int64, error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(days))
+	for _, day := range days {
+		cmds = append(cmds, pipe.PFCount(ctx, r.dailyReaderKey(biz, bizId, day)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(days))
+	for _, cmd := range cmds {
+		// 过期了或者那天没有人看，PFCOUNT 都是 0
+		cnt, _ := cmd.Result()
+		res = append(res, cnt)
+	}
+	return res, nil
+}
+
+func (r *RedisInteractiveCache) PopReaderCnts(ctx context.Context, limit int) (map[string]map[int64]int64, error) {
+	members, err := r.client.SPopN(ctx, keyReaderDirty, int64(limit)).Result()
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(members))
+	for _, member := range members {
+		biz, bizId, _ := r.parseDirtyMember(member)
+		cmds = append(cmds, pipe.PFCount(ctx, r.readerKey(biz, bizId)))
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		// 放回去，下一次再写
+		r.client.SAdd(ctx, keyReaderDirty, members)
+		return nil, err
+	}
+	res := make(map[string]map[int64]int64, 1)
+	for i, member := range members {
+		biz, bizId, ok := r.parseDirtyMember(member)
+		if !ok {
+			continue
+		}
+		cnts, ok := res[biz]
+		if !ok {
+			cnts = make(map[int64]int64)
+			res[biz] = cnts
+		}
+		cnts[bizId] = cmds[i].Val()
+	}
+	return res, nil
+}
+
+func (r *RedisInteractiveCache) PushBackReaderDirty(ctx context.Context, biz string, bizIds []int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		members = append(members, r.dirtyMember(biz, bizId))
+	}
+	return r.client.SAdd(ctx, keyReaderDirty, members...).Err()
+}
+
+func (r *RedisInteractiveCache) SetReaderCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	return r.client.Eval(ctx, luaSetCnt, []string{r.key(biz, bizId)}, fieldReaderCnt, cnt).Err()
+}
+
+// UpdateReadDedupWindow 同一个人在窗口内重复打开只算一次阅读，0 就是每次都算
+func (r *RedisInteractiveCache) UpdateReadDedupWindow(window time.Duration) {
+	r.dedupWindow.Store(window)
+}
+
+func (r *RedisInteractiveCache) readerKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:reader:%s:%d", biz, bizId)
+}
+
+func (r *RedisInteractiveCache) dailyReaderKey(biz string, bizId int64, day time.Time) string {
+	return fmt.Sprintf("interactive:reader_daily:%s:%d:%s", biz, bizId, day.Format(dailyLayout))
+}
+
+func (r *RedisInteractiveCache) dedupKey(biz string, bizId int64, reader string) string {
+	return fmt.Sprintf("interactive:read_dedup:%s:%d:%s", biz, bizId, reader)
+}
+
+func (r *RedisInteractiveCache) dirtyMember(biz string, bizId int64) string {
+	return biz + ":" + strconv.FormatInt(bizId, 10)
+}
+
+func (r *RedisInteractiveCache) parseDirtyMember(member string) (string, int64, bool) {
+	idx := strings.LastIndexByte(member, ':')
+	if idx < 0 {
+		return "", 0, false
+	}
+	bizId, err := strconv.ParseInt(member[idx+1:], 10, 64)
+	return member[:idx], bizId, err == nil
+}
@@ -0,0 +1,178 @@
+//go:build e2e
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisInteractiveCache_AddReader_e2e(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		t.Fatal(err)
+	}
+	c := NewRedisInteractiveCache(rdb).(*RedisInteractiveCache)
+
+	testCases := []struct {
+		name   string
+		window time.Duration
+		bizId  int64
+		// 依次调用 AddReader 的 reader
+		readers []string
+		// 每一次调用的返回值
+		wantRes []bool
+		// 验证 Redis 中的数据
+		after func(t *testing.T, bizId int64)
+	}{
+		{
+			name:    "窗口内重复打开只算一次",
+			window:  time.Minute,
+			bizId:   1,
+			readers: []string{"u:1", "u:1", "u:2"},
+			wantRes: []bool{true, false, true},
+			after: func(t *testing.T, bizId int64) {
+				ctx := context.Background()
+				ttl, err := rdb.PTTL(ctx, c.dedupKey("test", bizId, "u:1")).Result()
+				require.NoError(t, err)
+				assert.True(t, ttl > 0 && ttl <= time.Minute)
+				cnt, err := rdb.PFCount(ctx, c.readerKey("test", bizId)).Result()
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), cnt)
+			},
+		},
+		{
+			name:    "窗口是 0 的时候每次都算",
+			window:  0,
+			bizId:   2,
+			readers: []string{"d:abc", "d:abc"},
+			wantRes: []bool{true, true},
+			after: func(t *testing.T, bizId int64) {
+				ctx := context.Background()
+				// 不去重就不会有去重的 key
+				n, err := rdb.Exists(ctx, c.dedupKey("test", bizId, "d:abc")).Result()
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), n)
+				cnt, err := rdb.PFCount(ctx, c.readerKey("test", bizId)).Result()
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), cnt)
+			},
+		},
+		{
+			name:    "当天的 HyperLogLog 带过期时间",
+			window:  0,
+			bizId:   3,
+			readers: []string{"u:1"},
+			wantRes: []bool{true},
+			after: func(t *testing.T, bizId int64) {
+				ctx := context.Background()
+				key := c.dailyReaderKey("test", bizId, time.Now())
+				ttl, err := rdb.TTL(ctx, key).Result()
+				require.NoError(t, err)
+				assert.True(t, ttl > dailyReaderTTL-time.Minute && ttl <= dailyReaderTTL)
+				cnts, err := c.DailyReaderCnts(ctx, "test", bizId, []time.Time{time.Now()})
+				require.NoError(t, err)
+				assert.Equal(t, []int64{1}, cnts)
+			},
+		},
+		{
+			name:    "新的读者才标记成 dirty",
+			window:  0,
+			bizId:   4,
+			readers: []string{"u:1"},
+			wantRes: []bool{true},
+			after: func(t *testing.T, bizId int64) {
+				ctx := context.Background()
+				member := c.dirtyMember("test", bizId)
+				ok, err := rdb.SIsMember(ctx, keyReaderDirty, member).Result()
+				require.NoError(t, err)
+				assert.True(t, ok)
+
+				// 拿掉之后同一个人再看，估算值没变，不会再标记
+				require.NoError(t, rdb.SRem(ctx, keyReaderDirty, member).Err())
+				_, err = c.AddReader(ctx, "test", bizId, "u:1")
+				require.NoError(t, err)
+				ok, err = rdb.SIsMember(ctx, keyReaderDirty, member).Result()
+				require.NoError(t, err)
+				assert.False(t, ok)
+
+				_, err = c.AddReader(ctx, "test", bizId, "u:2")
+				require.NoError(t, err)
+				ok, err = rdb.SIsMember(ctx, keyReaderDirty, member).Result()
+				require.NoError(t, err)
+				assert.True(t, ok)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			t.Cleanup(func() {
+				keys := []string{
+					c.readerKey("test", tc.bizId),
+					c.dailyReaderKey("test", tc.bizId, time.Now()),
+				}
+				for _, reader := range tc.readers {
+					keys = append(keys, c.dedupKey("test", tc.bizId, reader))
+				}
+				rdb.Del(ctx, keys...)
+				rdb.SRem(ctx, keyReaderDirty, c.dirtyMember("test", tc.bizId))
+			})
+			c.UpdateReadDedupWindow(tc.window)
+			res := make([]bool, 0, len(tc.readers))
+			for _, reader := range tc.readers {
+				ok, err := c.AddReader(ctx, "test", tc.bizId, reader)
+				require.NoError(t, err)
+				res = append(res, ok)
+			}
+			assert.Equal(t, tc.wantRes, res)
+			tc.after(t, tc.bizId)
+		})
+	}
+}
+
+func TestRedisInteractiveCache_PopReaderCnts_e2e(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		t.Fatal(err)
+	}
+	c := NewRedisInteractiveCache(rdb).(*RedisInteractiveCache)
+	ctx := context.Background()
+	// dirty 集合是全局的，先清掉别的测试留下来的
+	require.NoError(t, rdb.Del(ctx, keyReaderDirty).Err())
+	t.Cleanup(func() {
+		rdb.Del(ctx, keyReaderDirty,
+			c.readerKey("test", 11), c.readerKey("test", 12),
+			c.dailyReaderKey("test", 11, time.Now()), c.dailyReaderKey("test", 12, time.Now()))
+	})
+	for i := 0; i < 3; i++ {
+		_, err := c.AddReader(ctx, "test", 11, fmt.Sprintf("u:%d", i))
+		require.NoError(t, err)
+	}
+	_, err := c.AddReader(ctx, "test", 12, "u:1")
+	require.NoError(t, err)
+
+	cnts, err := c.PopReaderCnts(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[int64]int64{"test": {11: 3, 12: 1}}, cnts)
+	n, err := rdb.SCard(ctx, keyReaderDirty).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	// 写数据库失败的时候放回去，下一次还能拿到
+	require.NoError(t, c.PushBackReaderDirty(ctx, "test", []int64{11, 12}))
+	cnts, err = c.PopReaderCnts(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[int64]int64{"test": {11: 3, 12: 1}}, cnts)
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+func (repo *CachedInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := repo.dao.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	if err == dao.ErrDataNotFound {
+		// 本来就没有收藏，收藏数没有变，缓存也不用动
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return repo.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+}
+
+func (repo *CachedInteractiveRepository) MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid, cid int64) error {
+	return repo.dao.UpdateCollectionBizCid(ctx, biz, bizId, uid, cid)
+}
+
+func (repo *CachedInteractiveRepository) ListCollectionItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	items, err := repo.dao.ListCollectionBiz(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items,
+		func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+			return repo.collectionItemToDomain(src)
+		}), nil
+}
+
+func (repo *CachedInteractiveRepository) ListCollected(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.CollectionItem, error) {
+	ctime, id := cursor.Keys()
+	items, err := repo.dao.ListCollectedBiz(ctx, uid, biz, ctime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items,
+		func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+			return repo.collectionItemToDomain(src)
+		}), nil
+}
+
+func (repo *CachedInteractiveRepository) collectionItemToDomain(item dao.UserCollectionBiz) domain.CollectionItem {
+	return domain.CollectionItem{
+		Id:    item.Id,
+		Cid:   item.Cid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: time.UnixMilli(item.Ctime),
+	}
+}
+
+func (repo *CachedInteractiveRepository) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	return repo.dao.InsertCollection(ctx, dao.Collection{
+		Name: c.Name,
+		Uid:  c.Uid,
+	})
+}
+
+func (repo *CachedInteractiveRepository) GetCollection(ctx context.Context, cid int64) (domain.Collection, error) {
+	c, err := repo.dao.GetCollection(ctx, cid)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return repo.collectionToDomain(c), nil
+}
+
+func (repo *CachedInteractiveRepository) RenameCollection(ctx context.Context, uid, cid int64, name string) error {
+	return repo.dao.UpdateCollectionName(ctx, uid, cid, name)
+}
+
+func (repo *CachedInteractiveRepository) DeleteCollection(ctx context.Context, uid, cid int64) error {
+	return repo.dao.DeleteCollection(ctx, uid, cid)
+}
+
+func (repo *CachedInteractiveRepository) ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	cs, err := repo.dao.ListCollections(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	cnts, err := repo.dao.CountCollectionBiz(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Collection, 0, len(cs)+1)
+	res = append(res, domain.Collection{
+		Uid:     uid,
+		Name:    domain.DefaultCollectionName,
+		ItemCnt: cnts[0],
+	})
+	for _, c := range cs {
+		dc := repo.collectionToDomain(c)
+		dc.ItemCnt = cnts[c.Id]
+		res = append(res, dc)
+	}
+	return res, nil
+}
+
+func (repo *CachedInteractiveRepository) collectionToDomain(c dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:    c.Id,
+		Uid:   c.Uid,
+		Name:  c.Name,
+		Ctime: time.UnixMilli(c.Ctime),
+		Utime: time.UnixMilli(c.Utime),
+	}
+}
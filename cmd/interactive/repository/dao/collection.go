@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (dao *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error {
+	// 收藏记录没有 status，直接删掉，和取消点赞一样，删掉了才减收藏数
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		return dao.decrCollectCnt(tx, biz, bizId)
+	})
+}
+
+func (dao *GORMInteractiveDAO) UpdateCollectionBizCid(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	res := dao.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+		Updates(map[string]any{
+			"cid":   cid,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMInteractiveDAO) ListCollectionBiz(ctx context.Context, uid, cid int64, offset, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	// 挪动收藏夹会改 utime，所以用 id 来排序
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND cid = ?", uid, cid).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) ListCollectedBiz(ctx context.Context, uid int64, biz string, ctime, id int64, limit int) ([]UserCollectionBiz, error) {
+	query := dao.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Where("ctime < ? OR (ctime = ? AND id < ?)", ctime, ctime, id)
+	if biz != "" {
+		query = query.Where("biz = ?", biz)
+	}
+	var res []UserCollectionBiz
+	err := query.Order("ctime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) InsertCollection(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (dao *GORMInteractiveDAO) GetCollection(ctx context.Context, cid int64) (Collection, error) {
+	var res Collection
+	err := dao.db.WithContext(ctx).
+		Where("id = ?", cid).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) UpdateCollectionName(ctx context.Context, uid, cid int64, name string) error {
+	res := dao.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", cid, uid).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMInteractiveDAO) DeleteCollection(ctx context.Context, uid, cid int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", cid, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		// 东西还是收藏着的，所以收藏数不用动
+		return tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND cid = ?", uid, cid).
+			Updates(map[string]any{
+				"cid":   0,
+				"utime": now,
+			}).Error
+	})
+}
+
+func (dao *GORMInteractiveDAO) ListCollections(ctx context.Context, uid int64) ([]Collection, error) {
+	var res []Collection
+	err := dao.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Order("id ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) CountCollectionBiz(ctx context.Context, uid int64) (map[int64]int64, error) {
+	var rows []struct {
+		Cid int64
+		Cnt int64
+	}
+	err := dao.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Select("cid, COUNT(*) AS cnt").
+		Where("uid = ?", uid).
+		Group("cid").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		res[row.Cid] = row.Cnt
+	}
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_Collection(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewGORMInteractiveDAO(db)
+	ctx := context.Background()
+	const uid int64 = 10
+
+	cid, err := dao.InsertCollection(ctx, Collection{Uid: uid, Name: "论文"})
+	require.NoError(t, err)
+	other, err := dao.InsertCollection(ctx, Collection{Uid: uid + 1, Name: "别人的"})
+	require.NoError(t, err)
+
+	// InsertCollectionBiz 的 upsert 是 MySQL 的写法，所以直接准备数据
+	require.NoError(t, db.Create([]UserCollectionBiz{
+		{Uid: uid, Biz: "article", BizId: 1},
+		{Uid: uid, Biz: "article", BizId: 2, Cid: cid},
+		{Uid: uid, Biz: "article", BizId: 3, Cid: cid},
+		{Uid: uid + 1, Biz: "article", BizId: 2},
+	}).Error)
+	require.NoError(t, db.Create(&Interactive{Biz: "article", BizId: 2, CollectCnt: 2}).Error)
+
+	cnts, err := dao.CountCollectionBiz(ctx, uid)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{0: 1, cid: 2}, cnts)
+
+	items, err := dao.ListCollectionBiz(ctx, uid, cid, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(3), items[0].BizId)
+	items, err = dao.ListCollectionBiz(ctx, uid, cid, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(2), items[0].BizId)
+
+	// 取消收藏，收藏数跟着减，第二次取消就找不到了
+	require.NoError(t, dao.DeleteCollectionBiz(ctx, "article", 2, uid))
+	intr, err := dao.Get(ctx, "article", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), intr.CollectCnt)
+	assert.Equal(t, ErrDataNotFound, dao.DeleteCollectionBiz(ctx, "article", 2, uid))
+
+	require.NoError(t, dao.UpdateCollectionBizCid(ctx, "article", 1, uid, cid))
+	assert.Equal(t, ErrDataNotFound, dao.UpdateCollectionBizCid(ctx, "article", 2, uid, cid))
+
+	assert.Equal(t, ErrDataNotFound, dao.UpdateCollectionName(ctx, uid, other, "抢过来"))
+	require.NoError(t, dao.UpdateCollectionName(ctx, uid, cid, "好论文"))
+	c, err := dao.GetCollection(ctx, cid)
+	require.NoError(t, err)
+	assert.Equal(t, "好论文", c.Name)
+
+	// 删掉收藏夹，里面的东西回到默认收藏夹
+	assert.Equal(t, ErrDataNotFound, dao.DeleteCollection(ctx, uid, other))
+	require.NoError(t, dao.DeleteCollection(ctx, uid, cid))
+	cs, err := dao.ListCollections(ctx, uid)
+	require.NoError(t, err)
+	assert.Empty(t, cs)
+	cnts, err = dao.CountCollectionBiz(ctx, uid)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{0: 2}, cnts)
+}
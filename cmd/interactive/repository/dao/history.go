@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ReadHistory 阅读记录，一个人对一个东西只有一条，再看一次就更新阅读时间
+type ReadHistory struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 1. 看过之后上报进度，要按照 uid, biz, biz_id 找到这一条
+	// 2. 用户看看自己最近看过什么，按照时间倒序，所以还有一个 uid, utime 的索引
+	Uid   int64  `gorm:"uniqueIndex:read_uid_biz_id;index:read_uid_utime,priority:1"`
+	Biz   string `gorm:"uniqueIndex:read_uid_biz_id;type:varchar(128)"`
+	BizId int64  `gorm:"uniqueIndex:read_uid_biz_id"`
+	// Progress 读到了哪里，百分比，0 - 100
+	Progress int32
+
+	Ctime int64
+	// Utime 最后一次阅读的时间
+	Utime int64 `gorm:"index:read_uid_utime,priority:2"`
+}
+
+// ReadHistorySetting 暂停过记录阅读历史的用户才有这一行，没有就是没有暂停
+type ReadHistorySetting struct {
+	Uid    int64 `gorm:"primaryKey;autoIncrement:false"`
+	Paused bool
+
+	Ctime int64
+	Utime int64
+}
+
+func (dao *GORMInteractiveDAO) InsertReadHistory(ctx context.Context, uid int64, biz string, bizId int64) error {
+	now := time.Now().UnixMilli()
+	// 再看一次只更新时间，进度要留着，方便接着往下看
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}, {Name: "biz"}, {Name: "biz_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"utime": now,
+		}),
+	}).Create(&ReadHistory{
+		Uid:   uid,
+		Biz:   biz,
+		BizId: bizId,
+		Ctime: now,
+		Utime: now,
+	}).Error
+}
+
+func (dao *GORMInteractiveDAO) UpsertReadProgress(ctx context.Context, uid int64, biz string, bizId int64, progress int32) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}, {Name: "biz"}, {Name: "biz_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"progress": progress,
+			"utime":    now,
+		}),
+	}).Create(&ReadHistory{
+		Uid:      uid,
+		Biz:      biz,
+		BizId:    bizId,
+		Progress: progress,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
+
+func (dao *GORMInteractiveDAO) GetReadHistory(ctx context.Context, uid int64, biz string, bizId int64) (ReadHistory, error) {
+	var res ReadHistory
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) ListReadHistory(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]ReadHistory, error) {
+	query := dao.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id)
+	if biz != "" {
+		query = query.Where("biz = ?", biz)
+	}
+	var res []ReadHistory
+	err := query.Order("utime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractiveDAO) DeleteReadHistory(ctx context.Context, uid int64, biz string) error {
+	// 用户要清掉的东西就真的删掉，不软删除
+	query := dao.db.WithContext(ctx).Where("uid = ?", uid)
+	if biz != "" {
+		query = query.Where("biz = ?", biz)
+	}
+	return query.Delete(&ReadHistory{}).Error
+}
+
+func (dao *GORMInteractiveDAO) SetReadHistoryPaused(ctx context.Context, uid int64, paused bool) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"paused": paused,
+			"utime":  now,
+		}),
+	}).Create(&ReadHistorySetting{
+		Uid:    uid,
+		Paused: paused,
+		Ctime:  now,
+		Utime:  now,
+	}).Error
+}
+
+func (dao *GORMInteractiveDAO) GetReadHistoryPaused(ctx context.Context, uid int64) (bool, error) {
+	var s ReadHistorySetting
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&s).Error
+	if err == ErrDataNotFound {
+		return false, nil
+	}
+	return s.Paused, err
+}
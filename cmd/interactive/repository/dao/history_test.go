@@ -0,0 +1,67 @@
+package dao
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_ReadHistory(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewGORMInteractiveDAO(db)
+	ctx := context.Background()
+	const uid int64 = 10
+
+	require.NoError(t, dao.InsertReadHistory(ctx, uid, "article", 1))
+	require.NoError(t, dao.UpsertReadProgress(ctx, uid, "article", 1, 40))
+	// 再看一次，进度要留着
+	require.NoError(t, dao.InsertReadHistory(ctx, uid, "article", 1))
+	h, err := dao.GetReadHistory(ctx, uid, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(40), h.Progress)
+	// 没有打开过也可以直接上报进度
+	require.NoError(t, dao.UpsertReadProgress(ctx, uid, "article", 2, 100))
+	_, err = dao.GetReadHistory(ctx, uid, "article", 3)
+	assert.Equal(t, ErrDataNotFound, err)
+
+	require.NoError(t, db.Create([]ReadHistory{
+		{Uid: uid, Biz: "comment", BizId: 4, Utime: math.MaxInt64 - 1},
+		{Uid: uid + 1, Biz: "article", BizId: 1, Utime: math.MaxInt64 - 1},
+	}).Error)
+	hs, err := dao.ListReadHistory(ctx, uid, "", math.MaxInt64, math.MaxInt64, 10)
+	require.NoError(t, err)
+	require.Len(t, hs, 3)
+	assert.Equal(t, int64(4), hs[0].BizId)
+	hs, err = dao.ListReadHistory(ctx, uid, "article", math.MaxInt64, math.MaxInt64, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2}, []int64{hs[0].BizId, hs[1].BizId})
+
+	// 只清掉 article 的，别人的不动
+	require.NoError(t, dao.DeleteReadHistory(ctx, uid, "article"))
+	hs, err = dao.ListReadHistory(ctx, uid, "", math.MaxInt64, math.MaxInt64, 10)
+	require.NoError(t, err)
+	require.Len(t, hs, 1)
+	assert.Equal(t, "comment", hs[0].Biz)
+	var cnt int64
+	require.NoError(t, db.Model(&ReadHistory{}).Where("uid = ?", uid+1).Count(&cnt).Error)
+	assert.Equal(t, int64(1), cnt)
+
+	paused, err := dao.GetReadHistoryPaused(ctx, uid)
+	require.NoError(t, err)
+	assert.False(t, paused)
+	require.NoError(t, dao.SetReadHistoryPaused(ctx, uid, true))
+	paused, err = dao.GetReadHistoryPaused(ctx, uid)
+	require.NoError(t, err)
+	assert.True(t, paused)
+	require.NoError(t, dao.SetReadHistoryPaused(ctx, uid, false))
+	paused, err = dao.GetReadHistoryPaused(ctx, uid)
+	require.NoError(t, err)
+	assert.False(t, paused)
+}
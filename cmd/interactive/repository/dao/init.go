@@ -8,5 +8,9 @@ func InitTable(db *gorm.DB) error {
 		&UserLikeBiz{},
 		&Collection{},
 		&UserCollectionBiz{},
+		&InteractiveV1{},
+		&ReactionCnt{},
+		&ReadHistory{},
+		&ReadHistorySetting{},
 	)
 }
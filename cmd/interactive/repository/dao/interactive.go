@@ -2,7 +2,9 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/XD/ScholarNet/cmd/pkg/migrator"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -10,17 +12,71 @@ import (
 
 var ErrDataNotFound = gorm.ErrRecordNotFound
 
+// ErrCollectionItemDuplicate 已经收藏过了，不管在哪个收藏夹里面
+var ErrCollectionItemDuplicate = errors.New("重复收藏")
+
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
 	InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) error
 	DeleteLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error
 	GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (UserLikeBiz, error)
+	// InsertReaction 表态，已经有别的表态的话就切换过去，返回之前的表态，之前没有表态就是空字符串
+	InsertReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (string, error)
+	// DeleteReaction 取消表态，返回取消掉的表态，没有表态过就是空字符串
+	DeleteReaction(ctx context.Context, biz string, bizId, uid int64) (string, error)
+	// GetReactionCnts 除了点赞之外别的表态的计数，bizId => 表态 => 数量
+	GetReactionCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]map[string]int64, error)
+	// GetReactions uid 对这些东西的表态，bizId => 表态
+	GetReactions(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]string, error)
+	// ListLikeBiz uid 点赞过的东西，别的表态不算，按照 (utime, id) 倒序，从严格排在 (utime, id) 后面的开始，biz 为空就是所有的 biz
+	ListLikeBiz(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, bizId, uid int64) (UserCollectionBiz, error)
 	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
+	// DeleteCollectionBiz 删除收藏记录并且减收藏数，没有收藏过返回 ErrDataNotFound
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error
+	// UpdateCollectionBizCid 挪到另外一个收藏夹，没有收藏过返回 ErrDataNotFound
+	UpdateCollectionBizCid(ctx context.Context, biz string, bizId, uid, cid int64) error
+	// ListCollectionBiz 按照收藏的时间倒序
+	ListCollectionBiz(ctx context.Context, uid, cid int64, offset, limit int) ([]UserCollectionBiz, error)
+	// ListCollectedBiz uid 收藏的所有东西，不管在哪个收藏夹，按照 (ctime, id) 倒序，biz 为空就是所有的 biz
+	ListCollectedBiz(ctx context.Context, uid int64, biz string, ctime, id int64, limit int) ([]UserCollectionBiz, error)
 	DecrCollectCnt(ctx context.Context, biz string, bizId int64) error
+
+	InsertCollection(ctx context.Context, c Collection) (int64, error)
+	GetCollection(ctx context.Context, cid int64) (Collection, error)
+	// UpdateCollectionName 不是 uid 的收藏夹返回 ErrDataNotFound
+	UpdateCollectionName(ctx context.Context, uid, cid int64, name string) error
+	// DeleteCollection 收藏夹里面的东西挪到默认收藏夹，不是 uid 的收藏夹返回 ErrDataNotFound
+	DeleteCollection(ctx context.Context, uid, cid int64) error
+	ListCollections(ctx context.Context, uid int64) ([]Collection, error)
+	// CountCollectionBiz 每个收藏夹里面有多少个东西，cid => 数量，包括默认收藏夹
+	CountCollectionBiz(ctx context.Context, uid int64) (map[int64]int64, error)
+
+	// InsertReadHistory 记录一次阅读，已经有记录的话只更新阅读时间，不动进度
+	InsertReadHistory(ctx context.Context, uid int64, biz string, bizId int64) error
+	// UpsertReadProgress 更新读到了哪里，没有记录的话就插入一条
+	UpsertReadProgress(ctx context.Context, uid int64, biz string, bizId int64, progress int32) error
+	// GetReadHistory 没有看过返回 ErrDataNotFound
+	GetReadHistory(ctx context.Context, uid int64, biz string, bizId int64) (ReadHistory, error)
+	// ListReadHistory 按照 (utime, id) 倒序，biz 为空就是所有的 biz
+	ListReadHistory(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]ReadHistory, error)
+	// DeleteReadHistory biz 为空就是全部清掉
+	DeleteReadHistory(ctx context.Context, uid int64, biz string) error
+	SetReadHistoryPaused(ctx context.Context, uid int64, paused bool) error
+	GetReadHistoryPaused(ctx context.Context, uid int64) (bool, error)
+
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) ([]Interactive, error)
+	// BatchSetCiteCnt 被引用次数是算好了的，直接覆盖
+	BatchSetCiteCnt(ctx context.Context, biz string, cnts map[int64]int64) error
+	// BatchSetReaderCnt 独立读者数是估算好了的，直接覆盖
+	BatchSetReaderCnt(ctx context.Context, biz string, cnts map[int64]int64) error
+
+	// FindCntMismatches 校验一批 Interactive 的点赞数和收藏数，返回对不上的和这一批最后一行的 id
+	FindCntMismatches(ctx context.Context, startId int64, limit int) ([]CntMismatch, int64, error)
+	// FixCnt 重新算一遍 id 这一行的点赞数和收藏数并且覆盖，返回有没有改
+	FixCnt(ctx context.Context, id int64) (bool, error)
 }
 
 type GORMInteractiveDAO struct {
@@ -98,78 +154,16 @@ func (dao *GORMInteractiveDAO) incrReadCnt(tx *gorm.DB, biz string, bizId int64)
 	}).Error
 }
 
+// InsertLikeInfo 点赞就是 ReactionLike 这种表态，之前是别的表态的话会切换成点赞
 func (dao *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error {
-	// 一把梭
-	// 同时记录点赞，以及更新点赞计数
-	// 首先你需要一张表来记录，谁点给什么资源点了赞
-	now := time.Now().UnixMilli()
-	// 带上 ctx 的作用：控制事务超时
-	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 先准备插入点赞记录
-		// 有没有可能已经点赞过了？
-		// 我要不要校验一下，这里必须是没有点赞过
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"utime":  now,
-				"status": 1,
-			}),
-		}).Create(&UserLikeBiz{
-			Biz:    biz,
-			BizId:  bizId,
-			Uid:    uid,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
-		}).Error
-		if err != nil {
-			return err
-		}
-		return tx.Clauses(clause.OnConflict{
-			// MySQL 可以不指定哪些列冲突，默认主键和唯一索引
-			//Columns:
-			DoUpdates: clause.Assignments(map[string]any{
-				"like_cnt": gorm.Expr("`like_cnt`+1"),
-				"utime":    now,
-			}),
-		}).Create(&Interactive{
-			Biz:     biz,
-			BizId:   bizId,
-			LikeCnt: 1,
-			Ctime:   now,
-			Utime:   now,
-		}).Error
-	})
+	_, err := dao.InsertReaction(ctx, biz, bizId, uid, ReactionLike)
+	return err
 }
 
+// DeleteLikeInfo 取消表态，不管是不是点赞
 func (dao *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error {
-	// 两个操作，事务
-	// 一个是软删除点赞记录
-	// 一个是减点赞数量
-	now := time.Now().UnixMilli()
-	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Model(&UserLikeBiz{}).
-			Where("biz = ? AND biz_id = ? AND uid = ? AND status = 1", biz, bizId, uid).
-			Updates(map[string]any{
-				"status": 0,
-				"utime":  now,
-			})
-		if result.Error != nil {
-			return result.Error
-		}
-
-		// 没有记录被更新 -> 表示用户根本没点过赞，不需要减 like_cnt
-		if result.RowsAffected == 0 {
-			return nil
-		}
-
-		// Step 2: 真的有点赞被删除，才去减 like_cnt
-		return tx.Model(&Interactive{}).
-			Where("biz = ? AND biz_id = ?", biz, bizId).
-			Updates(map[string]any{
-				"utime":    now,
-				"like_cnt": gorm.Expr("CASE WHEN like_cnt > 0 THEN like_cnt - 1 ELSE 0 END"),
-			}).Error
-	})
+	_, err := dao.DeleteReaction(ctx, biz, bizId, uid)
+	return err
 }
 
 func (dao *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
@@ -180,6 +174,12 @@ func (dao *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserC
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 插入收藏项目
 		err := tx.WithContext(ctx).Create(&cb).Error
+		if me, ok := err.(*mysql.MySQLError); ok {
+			const uniqueIndexErrNo uint16 = 1062
+			if me.Number == uniqueIndexErrNo {
+				return ErrCollectionItemDuplicate
+			}
+		}
 		if err != nil {
 			return err
 		}
@@ -202,16 +202,68 @@ func (dao *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserC
 }
 
 func (dao *GORMInteractiveDAO) DecrCollectCnt(ctx context.Context, biz string, bizId int64) error {
+	return dao.decrCollectCnt(dao.db.WithContext(ctx), biz, bizId)
+}
+
+func (dao *GORMInteractiveDAO) decrCollectCnt(tx *gorm.DB, biz string, bizId int64) error {
+	// 减的时候这一行肯定已经有了，不需要 upsert
+	return tx.Model(&Interactive{}).
+		Where("biz = ? AND biz_id = ?", biz, bizId).
+		Updates(map[string]any{
+			"utime":       time.Now().UnixMilli(),
+			"collect_cnt": gorm.Expr("CASE WHEN collect_cnt > 0 THEN collect_cnt - 1 ELSE 0 END"),
+		}).Error
+}
+
+func (dao *GORMInteractiveDAO) BatchSetCiteCnt(ctx context.Context, biz string, cnts map[int64]int64) error {
 	now := time.Now().UnixMilli()
-	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
-		// MySQL 不写
-		//Columns:
-		DoUpdates: clause.Assignments(map[string]any{
-			"collect_cnt": gorm.Expr("`collct_cnt` - 1"),
-			"utime":       now,
-		}),
-	}).Error
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for bizId, cnt := range cnts {
+			err := tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]any{
+					"cite_cnt": cnt,
+					"utime":    now,
+				}),
+			}).Create(&Interactive{
+				Biz:     biz,
+				BizId:   bizId,
+				CiteCnt: cnt,
+				Ctime:   now,
+				Utime:   now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
+
+func (dao *GORMInteractiveDAO) BatchSetReaderCnt(ctx context.Context, biz string, cnts map[int64]int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for bizId, cnt := range cnts {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"reader_cnt": cnt,
+					"utime":      now,
+				}),
+			}).Create(&Interactive{
+				Biz:       biz,
+				BizId:     bizId,
+				ReaderCnt: cnt,
+				Ctime:     now,
+				Utime:     now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (dao *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, bizIds []int64) ([]Interactive, error) {
 	var res []Interactive
 	err := dao.db.WithContext(ctx).Where("biz = ? AND biz_id IN ?", biz, bizIds).Find(&res).Error
@@ -276,8 +328,12 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
-	Ctime      int64
-	Utime      int64
+	// 被引用的次数
+	CiteCnt int64
+	// 独立读者数，Redis 里面 HyperLogLog 的估算值，定时写回来的
+	ReaderCnt int64
+	Ctime     int64
+	Utime     int64
 }
 
 func (i Interactive) ID() int64 {
@@ -292,11 +348,13 @@ func (i Interactive) Compareto(dst migrator.Entity) bool {
 
 // InteractiveV1 对写更友好，避免行锁竞争，支持高并发写入
 // Interactive 对读更加友好，单行查询高效
+// 现在用来存放 WriteBehindInteractiveDAO 还没有合并的增量
 type InteractiveV1 struct {
-	Id    int64 `gorm:"primaryKey,autoIncrement"`
-	BizId int64
-	Biz   string
-	// 计数
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 查询某个资源还没有合并的增量
+	BizId int64  `gorm:"index:pending_biz_id_type"`
+	Biz   string `gorm:"index:pending_biz_id_type;type:varchar(128)"`
+	// 计数，写后合并的时候是增量，可以是负数
 	Cnt int64
 	// 阅读数/点赞数/收藏数
 	CntType string
@@ -320,7 +378,8 @@ type UserLikeBiz struct {
 	// biz_id 和 biz 在前
 	// select count(*) where biz = ? and biz_id = ?
 	// 谁的操作
-	Uid   int64  `gorm:"uniqueIndex:uid_biz_id_type"`
+	// 3. 用户看看自己点赞过哪些，按照时间倒序，所以还有一个 uid, utime 的索引
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_id_type;index:like_uid_utime,priority:1"`
 	Biz   string `gorm:"uniqueIndex:uid_biz_id_type;type:varchar(128)"`
 	BizId int64  `gorm:"uniqueIndex:uid_biz_id_type"`
 
@@ -330,9 +389,12 @@ type UserLikeBiz struct {
 	// 这个状态是存储状态，纯纯用于软删除的，业务层面上没有感知
 	// 0-代表删除，1 代表有效
 	Status uint8
+	// 表态，点赞只是其中一种
+	// 加这一列之前的记录都是点赞，所以默认值是 like
+	Reaction string `gorm:"type:varchar(32);default:like"`
 
 	Ctime int64
-	Utime int64
+	Utime int64 `gorm:"index:like_uid_utime,priority:2"`
 	// 有效/无效
 	//Type string
 }
@@ -341,7 +403,8 @@ type UserLikeBiz struct {
 type Collection struct {
 	Id   int64  `gorm:"primaryKey,autoIncrement"`
 	Name string `gorm:"type=varchar(1024)"`
-	Uid  int64  `gorm:""`
+	// 查某个人的所有收藏夹
+	Uid int64 `gorm:"index"`
 
 	Ctime int64
 	Utime int64
@@ -356,11 +419,12 @@ type UserCollectionBiz struct {
 	// 这算是一个冗余，因为正常来说，
 	// 只需要在 Collection 中维持住 Uid 就可以
 
-	Uid   int64  `gorm:"uniqueIndex:biz_type_id_uid"`
+	// 用户看看自己收藏过哪些，按照时间倒序
+	Uid   int64  `gorm:"uniqueIndex:biz_type_id_uid;index:collect_uid_ctime,priority:1"`
 	BizId int64  `gorm:"uniqueIndex:biz_type_id_uid"`
 	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_uid"`
 
-	Ctime int64
+	Ctime int64 `gorm:"index:collect_uid_ctime,priority:2"`
 	Utime int64
 }
 
@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_ListLikedAndCollected(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewGORMInteractiveDAO(db)
+	ctx := context.Background()
+	const uid int64 = 10
+
+	// 2 和 3 的时间一样，要靠 id 来区分先后
+	require.NoError(t, db.Create([]UserLikeBiz{
+		{Uid: uid, Biz: "article", BizId: 1, Reaction: ReactionLike, Status: 1, Utime: 100},
+		{Uid: uid, Biz: "article", BizId: 2, Reaction: ReactionLike, Status: 1, Utime: 200},
+		{Uid: uid, Biz: "article", BizId: 3, Reaction: ReactionLike, Status: 1, Utime: 200},
+		{Uid: uid, Biz: "comment", BizId: 4, Reaction: ReactionLike, Status: 1, Utime: 300},
+		// 别的表态不算点赞
+		{Uid: uid, Biz: "article", BizId: 6, Reaction: "insightful", Status: 1, Utime: 250},
+		// 取消了的和别人的
+		{Uid: uid, Biz: "article", BizId: 5, Reaction: ReactionLike, Status: 0, Utime: 400},
+		{Uid: uid + 1, Biz: "article", BizId: 1, Reaction: ReactionLike, Status: 1, Utime: 500},
+	}).Error)
+
+	likes, err := dao.ListLikeBiz(ctx, uid, "", math.MaxInt64, math.MaxInt64, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 3, 2, 1}, likeBizIds(likes))
+
+	likes, err = dao.ListLikeBiz(ctx, uid, "article", math.MaxInt64, math.MaxInt64, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, likeBizIds(likes))
+	last := likes[len(likes)-1]
+	likes, err = dao.ListLikeBiz(ctx, uid, "article", last.Utime, last.Id, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, likeBizIds(likes))
+
+	require.NoError(t, db.Create([]UserCollectionBiz{
+		{Uid: uid, Biz: "article", BizId: 1, Ctime: 100},
+		{Uid: uid, Biz: "article", BizId: 2, Cid: 1, Ctime: 200},
+		{Uid: uid, Biz: "comment", BizId: 3, Ctime: 300},
+		{Uid: uid + 1, Biz: "article", BizId: 4, Ctime: 400},
+	}).Error)
+	items, err := dao.ListCollectedBiz(ctx, uid, "article", math.MaxInt64, math.MaxInt64, 1)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(2), items[0].BizId)
+	items, err = dao.ListCollectedBiz(ctx, uid, "", items[0].Ctime, items[0].Id, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(1), items[0].BizId)
+}
+
+func likeBizIds(likes []UserLikeBiz) []int64 {
+	return slice.Map[UserLikeBiz, int64](likes, func(idx int, src UserLikeBiz) int64 {
+		return src.BizId
+	})
+}
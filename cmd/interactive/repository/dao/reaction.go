@@ -0,0 +1,218 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReactionLike 点赞也是一种表态，它的计数还是放在 Interactive.LikeCnt 里面，
+// 排行榜之类的都依赖这个字段，别的表态的计数放在 ReactionCnt 里面
+const ReactionLike = "like"
+
+// reactionCntIncr 更新某种表态的计数，delta 是 +1 或者 -1
+type reactionCntIncr func(tx *gorm.DB, biz string, bizId int64, reaction string, delta int64) error
+
+// ReactionCnt 除了点赞之外，别的表态的计数
+type ReactionCnt struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	BizId    int64  `gorm:"uniqueIndex:reaction_biz_id_type"`
+	Biz      string `gorm:"uniqueIndex:reaction_biz_id_type;type:varchar(128)"`
+	Reaction string `gorm:"uniqueIndex:reaction_biz_id_type;type:varchar(32)"`
+	Cnt      int64
+	Ctime    int64
+	Utime    int64
+}
+
+func (dao *GORMInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (string, error) {
+	var old string
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		old, err = upsertReaction(tx, biz, bizId, uid, reaction, incrReactionCnt)
+		return err
+	})
+	return old, err
+}
+
+func (dao *GORMInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64) (string, error) {
+	var old string
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		old, err = deleteReaction(tx, biz, bizId, uid, incrReactionCnt)
+		return err
+	})
+	return old, err
+}
+
+func (dao *GORMInteractiveDAO) GetReactionCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]map[string]int64, error) {
+	var cnts []ReactionCnt
+	err := dao.db.WithContext(ctx).
+		Where("biz = ? AND biz_id IN ? AND cnt > 0", biz, bizIds).
+		Find(&cnts).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]map[string]int64, len(bizIds))
+	for _, c := range cnts {
+		m, ok := res[c.BizId]
+		if !ok {
+			m = make(map[string]int64)
+			res[c.BizId] = m
+		}
+		m[c.Reaction] = c.Cnt
+	}
+	return res, nil
+}
+
+func (dao *GORMInteractiveDAO) GetReactions(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]string, error) {
+	var likes []UserLikeBiz
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id IN ? AND status = ?", uid, biz, bizIds, 1).
+		Find(&likes).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]string, len(likes))
+	for _, like := range likes {
+		res[like.BizId] = like.Reaction
+	}
+	return res, nil
+}
+
+func (dao *GORMInteractiveDAO) ListLikeBiz(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]UserLikeBiz, error) {
+	query := dao.db.WithContext(ctx).
+		Where("uid = ? AND status = ? AND reaction = ?", uid, 1, ReactionLike).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id)
+	if biz != "" {
+		query = query.Where("biz = ?", biz)
+	}
+	var res []UserLikeBiz
+	err := query.Order("utime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// upsertReaction 一个人对一个东西只能有一种表态，换一种表态的话旧的计数要减掉
+// 返回之前的表态，之前没有表态就是空字符串
+func upsertReaction(tx *gorm.DB, biz string, bizId, uid int64, reaction string, incr reactionCntIncr) (string, error) {
+	now := time.Now().UnixMilli()
+	var like UserLikeBiz
+	// 锁住这一行，同一个人并发切换表态的时候计数才不会乱
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+		First(&like).Error
+	var old string
+	switch {
+	case err == ErrDataNotFound:
+		err = tx.Create(&UserLikeBiz{
+			Biz:      biz,
+			BizId:    bizId,
+			Uid:      uid,
+			Reaction: reaction,
+			Status:   1,
+			Ctime:    now,
+			Utime:    now,
+		}).Error
+	case err != nil:
+		return "", err
+	case like.Status == 1 && like.Reaction == reaction:
+		// 没有变化，计数不能再加
+		return reaction, nil
+	default:
+		if like.Status == 1 {
+			old = like.Reaction
+		}
+		err = tx.Model(&UserLikeBiz{}).
+			Where("id = ?", like.Id).
+			Updates(map[string]any{
+				"reaction": reaction,
+				"status":   1,
+				"utime":    now,
+			}).Error
+	}
+	if err != nil {
+		return "", err
+	}
+	if old != "" {
+		if err = incr(tx, biz, bizId, old, -1); err != nil {
+			return "", err
+		}
+	}
+	return old, incr(tx, biz, bizId, reaction, 1)
+}
+
+// deleteReaction 软删除，返回取消掉的表态，没有表态过就是空字符串
+func deleteReaction(tx *gorm.DB, biz string, bizId, uid int64, incr reactionCntIncr) (string, error) {
+	var like UserLikeBiz
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("biz = ? AND biz_id = ? AND uid = ? AND status = 1", biz, bizId, uid).
+		First(&like).Error
+	if err == ErrDataNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	err = tx.Model(&UserLikeBiz{}).
+		Where("id = ?", like.Id).
+		Updates(map[string]any{
+			"status": 0,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+	if err != nil {
+		return "", err
+	}
+	return like.Reaction, incr(tx, biz, bizId, like.Reaction, -1)
+}
+
+// incrReactionCnt 直接更新计数，点赞更新 Interactive，别的更新 ReactionCnt
+func incrReactionCnt(tx *gorm.DB, biz string, bizId int64, reaction string, delta int64) error {
+	now := time.Now().UnixMilli()
+	if delta < 0 {
+		// 减的时候这一行肯定已经有了
+		if reaction == ReactionLike {
+			return tx.Model(&Interactive{}).
+				Where("biz = ? AND biz_id = ?", biz, bizId).
+				Updates(map[string]any{
+					"utime":    now,
+					"like_cnt": gorm.Expr("CASE WHEN like_cnt > 0 THEN like_cnt - 1 ELSE 0 END"),
+				}).Error
+		}
+		return tx.Model(&ReactionCnt{}).
+			Where("biz = ? AND biz_id = ? AND reaction = ?", biz, bizId, reaction).
+			Updates(map[string]any{
+				"utime": now,
+				"cnt":   gorm.Expr("CASE WHEN cnt > 0 THEN cnt - 1 ELSE 0 END"),
+			}).Error
+	}
+	if reaction == ReactionLike {
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"like_cnt": gorm.Expr("`like_cnt` + 1"),
+				"utime":    now,
+			}),
+		}).Create(&Interactive{
+			Biz:     biz,
+			BizId:   bizId,
+			LikeCnt: 1,
+			Ctime:   now,
+			Utime:   now,
+		}).Error
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}, {Name: "reaction"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"cnt":   gorm.Expr("`cnt` + 1"),
+			"utime": now,
+		}),
+	}).Create(&ReactionCnt{
+		Biz:      biz,
+		BizId:    bizId,
+		Reaction: reaction,
+		Cnt:      1,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
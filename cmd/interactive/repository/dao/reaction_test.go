@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_Reaction(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	ctx := context.Background()
+
+	// 加表态之前的点赞记录
+	require.NoError(t, db.Create(&UserLikeBiz{Uid: 100, Biz: "article", BizId: 1, Status: 1}).Error)
+	require.NoError(t, db.Create(&Interactive{Biz: "article", BizId: 1, LikeCnt: 1}).Error)
+
+	for _, dao := range []InteractiveDAO{
+		NewGORMInteractiveDAO(db),
+		NewWriteBehindInteractiveDAO(NewGORMInteractiveDAO(db), db, []string{"article"}),
+	} {
+		like, err := dao.GetLikeInfo(ctx, "article", 1, 100)
+		require.NoError(t, err)
+		assert.Equal(t, ReactionLike, like.Reaction)
+
+		// 切换成别的表态，点赞数要减掉
+		old, err := dao.InsertReaction(ctx, "article", 1, 100, "insightful")
+		require.NoError(t, err)
+		assert.Equal(t, ReactionLike, old)
+		old, err = dao.InsertReaction(ctx, "article", 1, 100, "insightful")
+		require.NoError(t, err)
+		assert.Equal(t, "insightful", old)
+		old, err = dao.InsertReaction(ctx, "article", 1, 101, "insightful")
+		require.NoError(t, err)
+		assert.Empty(t, old)
+		require.NoError(t, dao.InsertLikeInfo(ctx, "article", 2, 100))
+
+		flushIfBuffered(t, dao)
+		intr, err := dao.Get(ctx, "article", 1)
+		require.NoError(t, err)
+		assert.Zero(t, intr.LikeCnt)
+		cnts, err := dao.GetReactionCnts(ctx, "article", []int64{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]map[string]int64{1: {"insightful": 2}}, cnts)
+		reactions, err := dao.GetReactions(ctx, "article", 100, []int64{1, 2, 3})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]string{1: "insightful", 2: ReactionLike}, reactions)
+
+		// 取消表态，第二次取消什么都不做
+		old, err = dao.DeleteReaction(ctx, "article", 1, 101)
+		require.NoError(t, err)
+		assert.Equal(t, "insightful", old)
+		old, err = dao.DeleteReaction(ctx, "article", 1, 101)
+		require.NoError(t, err)
+		assert.Empty(t, old)
+		require.NoError(t, dao.DeleteLikeInfo(ctx, "article", 2, 100))
+		cnts, err = dao.GetReactionCnts(ctx, "article", []int64{1})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]map[string]int64{1: {"insightful": 1}}, cnts)
+
+		// 恢复成一开始的样子，另外一个 DAO 再来一遍
+		require.NoError(t, dao.InsertLikeInfo(ctx, "article", 1, 100))
+		flushIfBuffered(t, dao)
+		intr, err = dao.Get(ctx, "article", 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), intr.LikeCnt)
+		cnts, err = dao.GetReactionCnts(ctx, "article", []int64{1})
+		require.NoError(t, err)
+		assert.Empty(t, cnts)
+	}
+}
+
+// flushIfBuffered 先写后合并的 DAO 要合并了才能查到计数
+func flushIfBuffered(t *testing.T, dao InteractiveDAO) {
+	if wb, ok := dao.(*WriteBehindInteractiveDAO); ok {
+		flushAll(t, wb)
+	}
+}
@@ -0,0 +1,30 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_BatchSetReaderCnt(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewGORMInteractiveDAO(db)
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&Interactive{Biz: "article", BizId: 1, ReadCnt: 10, ReaderCnt: 3}).Error)
+	// 已经有的直接覆盖，别的计数不动，没有的插入一行
+	require.NoError(t, dao.BatchSetReaderCnt(ctx, "article", map[int64]int64{1: 5, 2: 1}))
+	intr, err := dao.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), intr.ReadCnt)
+	assert.Equal(t, int64(5), intr.ReaderCnt)
+	intr, err = dao.Get(ctx, "article", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), intr.ReaderCnt)
+}
@@ -0,0 +1,123 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/migrator/fixer"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CntMismatch Interactive 里面的计数和点赞、收藏记录对不上
+// Expected 是按照记录算出来的、Interactive 里面应该存的值，已经减掉了还没合并的增量
+type CntMismatch struct {
+	// Id Interactive 的 id，修复的时候用
+	Id       int64
+	Biz      string
+	BizId    int64
+	CntType  string
+	Stored   int64
+	Expected int64
+}
+
+// FindCntMismatches 校验 id 大于 startId 的 limit 行 Interactive，返回这一批最后一行的 id
+// 这一批一行都没有的话返回的还是 startId，说明校验完了
+// 校验的时候不加锁，并发点赞的时候可能会误报，FixCnt 会重新算一遍
+func (dao *GORMInteractiveDAO) FindCntMismatches(ctx context.Context, startId int64, limit int) ([]CntMismatch, int64, error) {
+	db := dao.db.WithContext(ctx)
+	var stored []Interactive
+	err := db.Select("id, biz, biz_id, like_cnt, collect_cnt").
+		Where("id > ?", startId).
+		Order("id").Limit(limit).
+		Find(&stored).Error
+	if err != nil || len(stored) == 0 {
+		return nil, startId, err
+	}
+	lastId := stored[len(stored)-1].Id
+	var expected []Interactive
+	err = expectedInteractives(db, time.Now().UnixMilli()).
+		Where("id > ? AND id <= ?", startId, lastId).
+		Find(&expected).Error
+	if err != nil {
+		return nil, startId, err
+	}
+	expectedMap := make(map[int64]Interactive, len(expected))
+	for _, intr := range expected {
+		expectedMap[intr.Id] = intr
+	}
+	var res []CntMismatch
+	for _, intr := range stored {
+		exp, ok := expectedMap[intr.Id]
+		if !ok {
+			// 刚好被删掉了
+			continue
+		}
+		if exp.LikeCnt != intr.LikeCnt {
+			res = append(res, CntMismatch{Id: intr.Id, Biz: intr.Biz, BizId: intr.BizId, CntType: CntTypeLike,
+				Stored: intr.LikeCnt, Expected: exp.LikeCnt})
+		}
+		if exp.CollectCnt != intr.CollectCnt {
+			res = append(res, CntMismatch{Id: intr.Id, Biz: intr.Biz, BizId: intr.BizId, CntType: CntTypeCollect,
+				Stored: intr.CollectCnt, Expected: exp.CollectCnt})
+		}
+	}
+	return res, lastId, nil
+}
+
+// FixCnt 用 fixer.OverrideFixer 修复 id 这一行 Interactive 的点赞数和收藏数，返回有没有改
+// 按照点赞、收藏记录算出来的 expectedInteractives 就是 base，Interactive 就是 target，
+// 不信校验的时候查到的值，修的时候重新算一遍，已经对了就什么都不做
+func (dao *GORMInteractiveDAO) FixCnt(ctx context.Context, id int64) (bool, error) {
+	var fixed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先锁住这一行，点赞和合并增量都要更新这一行，所以会等我们改完
+		// 锁住之后再去数记录，和还没合并的增量在同一个快照里面，加起来才是对的
+		var stored Interactive
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&stored).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		var expected Interactive
+		err = expectedInteractives(tx, now).Where("id = ?", id).First(&expected).Error
+		if err != nil {
+			return err
+		}
+		if expected.LikeCnt == stored.LikeCnt && expected.CollectCnt == stored.CollectCnt {
+			return nil
+		}
+		f, err := fixer.NewOverrideFixer[Interactive](expectedInteractives(tx, now), tx)
+		if err != nil {
+			return err
+		}
+		fixed = true
+		return f.Fix(ctx, id)
+	})
+	return fixed, err
+}
+
+// expectedInteractives Interactive 应该是什么样的，只有点赞数和收藏数是按照记录算出来的，
+// 别的列和 Interactive 里面的一样，utime 换成修复的时间
+// 写后合并的 biz 还有没合并的增量，合并之后才会加到 Interactive 里面，所以要减掉
+func expectedInteractives(db *gorm.DB, utime int64) *gorm.DB {
+	// 只有点赞计入 like_cnt，别的表态的计数在 ReactionCnt 里面
+	likes := db.Session(&gorm.Session{NewDB: true}).Model(&UserLikeBiz{}).
+		Select("COUNT(*)").
+		Where("biz = interactives.biz AND biz_id = interactives.biz_id AND status = ? AND reaction = ?", 1, ReactionLike)
+	collects := db.Session(&gorm.Session{NewDB: true}).Model(&UserCollectionBiz{}).
+		Select("COUNT(*)").
+		Where("biz = interactives.biz AND biz_id = interactives.biz_id")
+	pending := func(cntType string) *gorm.DB {
+		return db.Session(&gorm.Session{NewDB: true}).Model(&InteractiveV1{}).
+			Select("COALESCE(SUM(cnt), 0)").
+			Where("biz = interactives.biz AND biz_id = interactives.biz_id AND cnt_type = ?", cntType)
+	}
+	expected := db.Session(&gorm.Session{NewDB: true}).Model(&Interactive{}).
+		Select("id, biz_id, biz, read_cnt, (?) - (?) AS like_cnt, (?) - (?) AS collect_cnt, "+
+			"cite_cnt, reader_cnt, ctime, ? AS utime",
+			likes, pending(CntTypeLike), collects, pending(CntTypeCollect), utime)
+	return db.Session(&gorm.Session{NewDB: true}).Table("(?) AS interactives", expected)
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMInteractiveDAO_Reconcile(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewGORMInteractiveDAO(db)
+	ctx := context.Background()
+
+	require.NoError(t, db.Create([]UserLikeBiz{
+		{Uid: 1, Biz: "article", BizId: 1, Reaction: ReactionLike, Status: 1},
+		{Uid: 2, Biz: "article", BizId: 1, Reaction: ReactionLike, Status: 1},
+		// 取消了的和别的表态都不算点赞
+		{Uid: 3, Biz: "article", BizId: 1, Reaction: ReactionLike, Status: 0},
+		{Uid: 4, Biz: "article", BizId: 1, Reaction: "insightful", Status: 1},
+		{Uid: 1, Biz: "article", BizId: 2, Reaction: ReactionLike, Status: 1},
+		{Uid: 1, Biz: "article", BizId: 3, Reaction: ReactionLike, Status: 1},
+	}).Error)
+	require.NoError(t, db.Create([]UserCollectionBiz{
+		{Uid: 1, Biz: "article", BizId: 1},
+		{Uid: 1, Biz: "article", BizId: 2},
+	}).Error)
+	// 3 的点赞还没有合并
+	require.NoError(t, db.Create(&InteractiveV1{Biz: "article", BizId: 3, CntType: CntTypeLike, Cnt: 1}).Error)
+	require.NoError(t, db.Create([]Interactive{
+		// 点赞数多了，收藏数是对的，别的计数修的时候不会动
+		{Biz: "article", BizId: 1, ReadCnt: 7, LikeCnt: 3, CollectCnt: 1, CiteCnt: 2, ReaderCnt: 5},
+		// 收藏数少了
+		{Biz: "article", BizId: 2, LikeCnt: 1, CollectCnt: 0},
+		// 加上没合并的增量是对的
+		{Biz: "article", BizId: 3, LikeCnt: 0},
+	}).Error)
+
+	mismatches, lastId, err := dao.FindCntMismatches(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), lastId)
+	assert.ElementsMatch(t, []CntMismatch{
+		{Id: 1, Biz: "article", BizId: 1, CntType: CntTypeLike, Stored: 3, Expected: 2},
+		{Id: 2, Biz: "article", BizId: 2, CntType: CntTypeCollect, Stored: 0, Expected: 1},
+	}, mismatches)
+	mismatches, lastId, err = dao.FindCntMismatches(ctx, lastId, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lastId)
+	assert.Empty(t, mismatches)
+	_, lastId, err = dao.FindCntMismatches(ctx, lastId, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lastId)
+
+	fixed, err := dao.FixCnt(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, fixed)
+	fixed, err = dao.FixCnt(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, fixed)
+	// 已经对了就不改
+	fixed, err = dao.FixCnt(ctx, 3)
+	require.NoError(t, err)
+	assert.False(t, fixed)
+	fixed, err = dao.FixCnt(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, fixed)
+	_, err = dao.FixCnt(ctx, 4)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mismatches, _, err = dao.FindCntMismatches(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+	intr, err := dao.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), intr.LikeCnt)
+	assert.Equal(t, int64(1), intr.CollectCnt)
+	assert.Equal(t, int64(7), intr.ReadCnt)
+	assert.Equal(t, int64(2), intr.CiteCnt)
+	assert.Equal(t, int64(5), intr.ReaderCnt)
+	intr, err = dao.Get(ctx, "article", 3)
+	require.NoError(t, err)
+	assert.Zero(t, intr.LikeCnt)
+}
@@ -0,0 +1,287 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ecodeclub/ekit/syncx/atomicx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InteractiveV1 里面 CntType 的取值，和 Interactive 的列名一致
+const (
+	CntTypeRead    = "read_cnt"
+	CntTypeLike    = "like_cnt"
+	CntTypeCollect = "collect_cnt"
+)
+
+// errConcurrentFlush 别的实例正在合并同一批增量
+var errConcurrentFlush = errors.New("增量已经被别的实例合并了")
+
+// WriteBehindInteractiveDAO 先写后合并
+// 配置了的 biz，阅读、点赞和收藏的计数不直接更新 Interactive，
+// 而是往 InteractiveV1 里面插入一行增量，插入没有行锁竞争，热门帖子也扛得住，
+// 再由 Flush 定时把增量合并到 Interactive 里面。
+// 点赞和收藏记录本身还是同步写的，和增量在同一个事务里面，
+// 合并的时候删除增量和更新计数也在同一个事务里面，所以中途崩溃既不会丢也不会重复。
+// 查询不会去加没有合并的增量，读多写少，不能每次读都多查一次数据库，
+// 所以计数最多落后一个合并周期，缓存没有命中的时候加载到的也是这个落后的计数。
+// 没有配置的 biz 还是走原本的逻辑，方便对比
+type WriteBehindInteractiveDAO struct {
+	InteractiveDAO
+	db   *gorm.DB
+	bizs *atomicx.Value[map[string]struct{}]
+}
+
+func NewWriteBehindInteractiveDAO(base InteractiveDAO, db *gorm.DB, bizs []string) *WriteBehindInteractiveDAO {
+	res := &WriteBehindInteractiveDAO{
+		InteractiveDAO: base,
+		db:             db,
+		bizs:           atomicx.NewValue[map[string]struct{}](),
+	}
+	res.UpdateBizs(bizs)
+	return res
+}
+
+// UpdateBizs 切换哪些 biz 走先写后合并，已经写进去的增量还是会被合并
+func (dao *WriteBehindInteractiveDAO) UpdateBizs(bizs []string) {
+	m := make(map[string]struct{}, len(bizs))
+	for _, biz := range bizs {
+		m[biz] = struct{}{}
+	}
+	dao.bizs.Store(m)
+}
+
+func (dao *WriteBehindInteractiveDAO) buffered(biz string) bool {
+	_, ok := dao.bizs.Load()[biz]
+	return ok
+}
+
+func (dao *WriteBehindInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	if !dao.buffered(biz) {
+		return dao.InteractiveDAO.IncrReadCnt(ctx, biz, bizId)
+	}
+	return dao.insertDelta(dao.db.WithContext(ctx), biz, bizId, CntTypeRead, 1)
+}
+
+func (dao *WriteBehindInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
+	now := time.Now().UnixMilli()
+	deltas := make([]InteractiveV1, 0, len(bizs))
+	var restBizs []string
+	var restIds []int64
+	for i, biz := range bizs {
+		if !dao.buffered(biz) {
+			restBizs = append(restBizs, biz)
+			restIds = append(restIds, ids[i])
+			continue
+		}
+		deltas = append(deltas, InteractiveV1{
+			Biz:     biz,
+			BizId:   ids[i],
+			Cnt:     1,
+			CntType: CntTypeRead,
+			Ctime:   now,
+			Utime:   now,
+		})
+	}
+	if len(deltas) > 0 {
+		// 一条 INSERT 语句插入多行
+		if err := dao.db.WithContext(ctx).Create(&deltas).Error; err != nil {
+			return err
+		}
+	}
+	if len(restBizs) == 0 {
+		return nil
+	}
+	return dao.InteractiveDAO.BatchIncrReadCnt(ctx, restBizs, restIds)
+}
+
+func (dao *WriteBehindInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
+	_, err := dao.InsertReaction(ctx, biz, bizId, uid, ReactionLike)
+	return err
+}
+
+func (dao *WriteBehindInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error {
+	_, err := dao.DeleteReaction(ctx, biz, bizId, uid)
+	return err
+}
+
+func (dao *WriteBehindInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (string, error) {
+	if !dao.buffered(biz) {
+		return dao.InteractiveDAO.InsertReaction(ctx, biz, bizId, uid, reaction)
+	}
+	var old string
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		old, err = upsertReaction(tx, biz, bizId, uid, reaction, dao.incrReactionCnt)
+		return err
+	})
+	return old, err
+}
+
+func (dao *WriteBehindInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64) (string, error) {
+	if !dao.buffered(biz) {
+		return dao.InteractiveDAO.DeleteReaction(ctx, biz, bizId, uid)
+	}
+	var old string
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		old, err = deleteReaction(tx, biz, bizId, uid, dao.incrReactionCnt)
+		return err
+	})
+	return old, err
+}
+
+// incrReactionCnt 只有点赞写增量，别的表态量不大，还是直接更新 ReactionCnt
+func (dao *WriteBehindInteractiveDAO) incrReactionCnt(tx *gorm.DB, biz string, bizId int64, reaction string, delta int64) error {
+	if reaction != ReactionLike {
+		return incrReactionCnt(tx, biz, bizId, reaction, delta)
+	}
+	return dao.insertDelta(tx, biz, bizId, CntTypeLike, delta)
+}
+
+func (dao *WriteBehindInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+	if !dao.buffered(cb.Biz) {
+		return dao.InteractiveDAO.InsertCollectionBiz(ctx, cb)
+	}
+	now := time.Now().UnixMilli()
+	cb.Ctime = now
+	cb.Utime = now
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&UserCollectionBiz{}).
+			Where("biz = ? AND biz_id = ? AND uid = ?", cb.Biz, cb.BizId, cb.Uid).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrCollectionItemDuplicate
+		}
+		if err = tx.Create(&cb).Error; err != nil {
+			return err
+		}
+		return dao.insertDelta(tx, cb.Biz, cb.BizId, CntTypeCollect, 1)
+	})
+}
+
+func (dao *WriteBehindInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error {
+	if !dao.buffered(biz) {
+		return dao.InteractiveDAO.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	}
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		return dao.insertDelta(tx, biz, bizId, CntTypeCollect, -1)
+	})
+}
+
+// Flush 按照 id 的顺序合并最早的 batchSize 个增量，返回合并了多少个
+// 多个实例同时合并的时候，删除的行数对不上的那个会回滚，返回 0
+func (dao *WriteBehindInteractiveDAO) Flush(ctx context.Context, batchSize int) (int, error) {
+	var n int
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deltas []InteractiveV1
+		err := tx.Order("id ASC").Limit(batchSize).Find(&deltas).Error
+		if err != nil || len(deltas) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(deltas))
+		for _, d := range deltas {
+			ids = append(ids, d.Id)
+		}
+		res := tx.Where("id IN ?", ids).Delete(&InteractiveV1{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(ids)) {
+			return errConcurrentFlush
+		}
+		merged := mergeDeltas(deltas)
+		// 固定更新的顺序，避免和别的事务互相等待行锁
+		sort.Slice(merged, func(i, j int) bool {
+			if merged[i].Biz != merged[j].Biz {
+				return merged[i].Biz < merged[j].Biz
+			}
+			return merged[i].BizId < merged[j].BizId
+		})
+		now := time.Now().UnixMilli()
+		for _, intr := range merged {
+			err = tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"read_cnt":    gorm.Expr("`read_cnt` + ?", intr.ReadCnt),
+					"like_cnt":    gorm.Expr("`like_cnt` + ?", intr.LikeCnt),
+					"collect_cnt": gorm.Expr("`collect_cnt` + ?", intr.CollectCnt),
+					"utime":       now,
+				}),
+			}).Create(&Interactive{
+				Biz:        intr.Biz,
+				BizId:      intr.BizId,
+				ReadCnt:    max(intr.ReadCnt, 0),
+				LikeCnt:    max(intr.LikeCnt, 0),
+				CollectCnt: max(intr.CollectCnt, 0),
+				Ctime:      now,
+				Utime:      now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		n = len(deltas)
+		return nil
+	})
+	if err == errConcurrentFlush {
+		return 0, nil
+	}
+	return n, err
+}
+
+func (dao *WriteBehindInteractiveDAO) insertDelta(tx *gorm.DB, biz string, bizId int64, cntType string, delta int64) error {
+	now := time.Now().UnixMilli()
+	return tx.Create(&InteractiveV1{
+		Biz:     biz,
+		BizId:   bizId,
+		Cnt:     delta,
+		CntType: cntType,
+		Ctime:   now,
+		Utime:   now,
+	}).Error
+}
+
+// mergeDeltas 按照 biz 和 bizId 把增量加起来
+func mergeDeltas(deltas []InteractiveV1) []Interactive {
+	type key struct {
+		biz   string
+		bizId int64
+	}
+	idx := make(map[key]int, len(deltas))
+	res := make([]Interactive, 0, len(deltas))
+	for _, d := range deltas {
+		k := key{biz: d.Biz, bizId: d.BizId}
+		i, ok := idx[k]
+		if !ok {
+			i = len(res)
+			idx[k] = i
+			res = append(res, Interactive{Biz: d.Biz, BizId: d.BizId})
+		}
+		switch d.CntType {
+		case CntTypeRead:
+			res[i].ReadCnt += d.Cnt
+		case CntTypeLike:
+			res[i].LikeCnt += d.Cnt
+		case CntTypeCollect:
+			res[i].CollectCnt += d.Cnt
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestWriteBehindInteractiveDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/intr.db"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, InitTable(db))
+	dao := NewWriteBehindInteractiveDAO(NewGORMInteractiveDAO(db), db, []string{"article"})
+	ctx := context.Background()
+
+	// 已经合并过一部分了
+	require.NoError(t, db.Create(&Interactive{Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 3}).Error)
+
+	require.NoError(t, dao.IncrReadCnt(ctx, "article", 1))
+	require.NoError(t, dao.BatchIncrReadCnt(ctx, []string{"article", "article"}, []int64{1, 2}))
+	require.NoError(t, dao.InsertLikeInfo(ctx, "article", 1, 100))
+	// 重复点赞不会重复计数
+	require.NoError(t, dao.InsertLikeInfo(ctx, "article", 1, 100))
+	require.NoError(t, dao.InsertLikeInfo(ctx, "article", 2, 100))
+	require.NoError(t, dao.DeleteLikeInfo(ctx, "article", 2, 100))
+	require.NoError(t, dao.DeleteLikeInfo(ctx, "article", 2, 100))
+	require.NoError(t, dao.InsertCollectionBiz(ctx, UserCollectionBiz{Biz: "article", BizId: 2, Uid: 100}))
+	assert.Equal(t, ErrCollectionItemDuplicate,
+		dao.InsertCollectionBiz(ctx, UserCollectionBiz{Biz: "article", BizId: 2, Uid: 100}))
+
+	wantArt1 := Interactive{Biz: "article", BizId: 1, ReadCnt: 12, LikeCnt: 4}
+	wantArt2 := Interactive{Biz: "article", BizId: 2, ReadCnt: 1, CollectCnt: 1}
+	// 还没有合并，查询的时候不会加上增量
+	intr, err := dao.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assertCnt(t, Interactive{Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 3}, intr)
+	_, err = dao.Get(ctx, "article", 2)
+	assert.Equal(t, ErrDataNotFound, err)
+	intrs, err := dao.GetByIds(ctx, "article", []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, intrs, 1)
+
+	// 3 次阅读，3 次点赞和取消点赞，1 次收藏
+	assert.Equal(t, 7, flushAll(t, dao))
+	var pending int64
+	require.NoError(t, db.Model(&InteractiveV1{}).Count(&pending).Error)
+	assert.Zero(t, pending)
+
+	// 合并之后就能查到准确的计数了
+	intr, err = dao.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assertCnt(t, wantArt1, intr)
+	intr, err = dao.Get(ctx, "article", 2)
+	require.NoError(t, err)
+	assertCnt(t, wantArt2, intr)
+	intrs, err = dao.GetByIds(ctx, "article", []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, intrs, 2)
+
+	// 取消收藏也是先写增量
+	require.NoError(t, dao.DeleteCollectionBiz(ctx, "article", 2, 100))
+	assert.Equal(t, ErrDataNotFound, dao.DeleteCollectionBiz(ctx, "article", 2, 100))
+	assert.Equal(t, 1, flushAll(t, dao))
+	intr, err = dao.Get(ctx, "article", 2)
+	require.NoError(t, err)
+	assert.Zero(t, intr.CollectCnt)
+}
+
+// flushAll 一直合并到没有增量，返回一共合并了多少个
+func flushAll(t *testing.T, dao *WriteBehindInteractiveDAO) int {
+	total := 0
+	for {
+		n, err := dao.Flush(context.Background(), 3)
+		require.NoError(t, err)
+		total += n
+		if n < 3 {
+			return total
+		}
+	}
+}
+
+func assertCnt(t *testing.T, want, actual Interactive) {
+	assert.Equal(t, want.Biz, actual.Biz)
+	assert.Equal(t, want.BizId, actual.BizId)
+	assert.Equal(t, want.ReadCnt, actual.ReadCnt)
+	assert.Equal(t, want.LikeCnt, actual.LikeCnt)
+	assert.Equal(t, want.CollectCnt, actual.CollectCnt)
+}
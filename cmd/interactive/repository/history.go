@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+func (repo *CachedInteractiveRepository) AddRecord(ctx context.Context, biz string, bizId, uid int64) error {
+	paused, err := repo.dao.GetReadHistoryPaused(ctx, uid)
+	if err != nil || paused {
+		return err
+	}
+	return repo.dao.InsertReadHistory(ctx, uid, biz, bizId)
+}
+
+func (repo *CachedInteractiveRepository) UpdateReadProgress(ctx context.Context, biz string, bizId, uid int64, progress int32) error {
+	paused, err := repo.dao.GetReadHistoryPaused(ctx, uid)
+	if err != nil || paused {
+		return err
+	}
+	return repo.dao.UpsertReadProgress(ctx, uid, biz, bizId, progress)
+}
+
+func (repo *CachedInteractiveRepository) GetReadRecord(ctx context.Context, biz string, bizId, uid int64) (domain.ReadRecord, error) {
+	h, err := repo.dao.GetReadHistory(ctx, uid, biz, bizId)
+	if err != nil {
+		return domain.ReadRecord{}, err
+	}
+	return readRecordToDomain(h), nil
+}
+
+func (repo *CachedInteractiveRepository) ListReadRecords(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error) {
+	utime, id := cursor.Keys()
+	hs, err := repo.dao.ListReadHistory(ctx, uid, biz, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ReadHistory, domain.ReadRecord](hs,
+		func(idx int, src dao.ReadHistory) domain.ReadRecord {
+			return readRecordToDomain(src)
+		}), nil
+}
+
+func (repo *CachedInteractiveRepository) ClearReadRecords(ctx context.Context, uid int64, biz string) error {
+	return repo.dao.DeleteReadHistory(ctx, uid, biz)
+}
+
+func (repo *CachedInteractiveRepository) SetReadHistoryPaused(ctx context.Context, uid int64, paused bool) error {
+	return repo.dao.SetReadHistoryPaused(ctx, uid, paused)
+}
+
+func (repo *CachedInteractiveRepository) ReadHistoryPaused(ctx context.Context, uid int64) (bool, error) {
+	return repo.dao.GetReadHistoryPaused(ctx, uid)
+}
+
+func readRecordToDomain(h dao.ReadHistory) domain.ReadRecord {
+	return domain.ReadRecord{
+		Id:       h.Id,
+		Biz:      h.Biz,
+		BizId:    h.BizId,
+		Progress: h.Progress,
+		Utime:    time.UnixMilli(h.Utime),
+	}
+}
@@ -7,6 +7,14 @@ import (
 	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
 	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var (
+	ErrCollectionNotFound      = dao.ErrDataNotFound
+	ErrCollectionItemNotFound  = dao.ErrDataNotFound
+	ErrCollectionItemDuplicate = dao.ErrCollectionItemDuplicate
+	ErrReadRecordNotFound      = dao.ErrDataNotFound
 )
 
 //go:generate mockgen -source=./interactive.go -package=repomocks -destination=mocks/interactive.mock.go InteractiveRepository
@@ -15,12 +23,64 @@ type InteractiveRepository interface {
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
 	IncrLike(ctx context.Context, biz string, bizId int64, uid int64) error
 	DecrLike(ctx context.Context, biz string, bizId int64, uid int64) error
+	// React 表态，已经有别的表态的话就切换过去
+	React(ctx context.Context, biz string, bizId, uid int64, reaction string) error
+	// CancelReaction 取消表态，不管是什么表态，没有表态过的话什么都不做
+	CancelReaction(ctx context.Context, biz string, bizId, uid int64) error
 	AddCollectionItem(ctx context.Context, biz string, bizId int64, cid, uid int64) error
+	// DeleteCollectionItem 取消收藏，没有收藏过的话什么都不做
+	DeleteCollectionItem(ctx context.Context, biz string, bizId int64, uid int64) error
+	// MoveCollectionItem 没有收藏过返回 ErrCollectionItemNotFound
+	MoveCollectionItem(ctx context.Context, biz string, bizId int64, uid, cid int64) error
+	ListCollectionItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error)
+	CreateCollection(ctx context.Context, c domain.Collection) (int64, error)
+	// GetCollection 不包含 ItemCnt
+	GetCollection(ctx context.Context, cid int64) (domain.Collection, error)
+	RenameCollection(ctx context.Context, uid, cid int64, name string) error
+	DeleteCollection(ctx context.Context, uid, cid int64) error
+	// ListCollections 第一个是默认收藏夹，ItemCnt 是填好了的
+	ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) ([]domain.Interactive, error)
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	// Reaction uid 的表态，没有表态就是空字符串
+	Reaction(ctx context.Context, biz string, bizId, uid int64) (string, error)
+	// Reactions uid 对这些东西的表态，bizId => 表态，没有表态的不在里面
+	Reactions(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]string, error)
+	// ListLiked uid 点赞过的东西，别的表态不算，按照点赞的时间倒序，biz 为空就是所有的 biz
+	ListLiked(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.LikedItem, error)
+	// ListCollected uid 收藏的所有东西，按照收藏的时间倒序，biz 为空就是所有的 biz
+	ListCollected(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.CollectionItem, error)
 	Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
-	AddRecord(ctx context.Context, aid int64, uid int64) error
+	// AddRecord 记录一次阅读，暂停了记录阅读历史的用户什么都不做
+	AddRecord(ctx context.Context, biz string, bizId, uid int64) error
+	// UpdateReadProgress 更新读到了哪里，暂停了记录阅读历史的用户什么都不做
+	UpdateReadProgress(ctx context.Context, biz string, bizId, uid int64, progress int32) error
+	// GetReadRecord 没有看过返回 ErrReadRecordNotFound
+	GetReadRecord(ctx context.Context, biz string, bizId, uid int64) (domain.ReadRecord, error)
+	// ListReadRecords 按照阅读的时间倒序，biz 为空就是所有的 biz
+	ListReadRecords(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error)
+	// ClearReadRecords biz 为空就是全部清掉
+	ClearReadRecords(ctx context.Context, uid int64, biz string) error
+	SetReadHistoryPaused(ctx context.Context, uid int64, paused bool) error
+	ReadHistoryPaused(ctx context.Context, uid int64) (bool, error)
+	// BatchSetCiteCnt cnts 是 bizId => 被引用的次数
+	BatchSetCiteCnt(ctx context.Context, biz string, cnts map[int64]int64) error
+
+	// AddReader 记一个独立读者，返回这一次要不要算一次阅读，在去重窗口内重复打开的不算
+	// reader 是 uid 或者没有登录的用户的设备 ID
+	AddReader(ctx context.Context, biz string, bizId int64, reader string) (bool, error)
+	// DailyReaderCnts 最近 days 天每一天的独立读者数，按照日期升序，最后一个是今天
+	DailyReaderCnts(ctx context.Context, biz string, bizId int64, days int) ([]domain.DailyReaderCnt, error)
+	// PersistReaderCnts 把最多 batchSize 个变了的独立读者数写回数据库，返回写了多少个
+	PersistReaderCnts(ctx context.Context, batchSize int) (int, error)
+
+	// FindCntMismatches 校验 id 大于 startId 的一批计数，返回对不上的和这一批最后一个的 id
+	// 校验完了返回的还是 startId
+	FindCntMismatches(ctx context.Context, startId int64, limit int) ([]domain.CntMismatch, int64, error)
+	// FixCnt 按照记录重新算一遍对不上的那一行的点赞数和收藏数并且覆盖，返回有没有改
+	// 改了的话缓存也会删掉
+	FixCnt(ctx context.Context, m domain.CntMismatch) (bool, error)
 }
 
 type CachedInteractiveRepository struct {
@@ -37,11 +97,6 @@ func NewCachedInteractiveRepository(cache cache.InteractiveCache, dao dao.Intera
 	}
 }
 
-func (c *CachedInteractiveRepository) AddRecord(ctx context.Context, aid int64, uid int64) error {
-	//TODO implement me
-	panic("implement me")
-}
-
 // BatchIncrReadCnt bizs 和 ids 的长度必须相等
 func (repo *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
 	err := repo.dao.BatchIncrReadCnt(ctx, bizs, bizIds)
@@ -67,22 +122,35 @@ func (repo *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz st
 }
 
 func (repo *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	// 先插入点赞，然后更新点赞计数，更新缓存
-	err := repo.dao.InsertLikeInfo(ctx, biz, bizId, uid)
-	if err != nil {
-		return err
-	}
-	// 这种做法，你需要在 repository 层面上维持住事务，来保证插入点赞和更新点赞计数同时成功或失败
+	// 点赞就是一种表态
+	// 以前是这个顺序：先插入点赞，然后更新点赞计数，更新缓存
+	// 你需要在 repository 层面上维持住事务，来保证插入点赞和更新点赞计数同时成功或失败
 	//c.dao.IncrLikeCnt()
-	return repo.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+	return repo.React(ctx, biz, bizId, uid, domain.ReactionLike)
 }
 
 func (repo *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	err := repo.dao.DeleteLikeInfo(ctx, biz, bizId, uid)
-	if err != nil {
+	return repo.CancelReaction(ctx, biz, bizId, uid)
+}
+
+func (repo *CachedInteractiveRepository) React(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	old, err := repo.dao.InsertReaction(ctx, biz, bizId, uid, reaction)
+	if err != nil || old == reaction {
 		return err
 	}
-	return repo.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+	deltas := map[string]int64{reaction: 1}
+	if old != "" {
+		deltas[old] = -1
+	}
+	return repo.cache.IncrReactionCntIfPresent(ctx, biz, bizId, deltas)
+}
+
+func (repo *CachedInteractiveRepository) CancelReaction(ctx context.Context, biz string, bizId, uid int64) error {
+	old, err := repo.dao.DeleteReaction(ctx, biz, bizId, uid)
+	if err != nil || old == "" {
+		return err
+	}
+	return repo.cache.IncrReactionCntIfPresent(ctx, biz, bizId, map[string]int64{old: -1})
 }
 
 func (repo *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) error {
@@ -102,13 +170,40 @@ func (repo *CachedInteractiveRepository) AddCollectionItem(ctx context.Context,
 	return repo.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
 }
 
+func (repo *CachedInteractiveRepository) BatchSetCiteCnt(ctx context.Context, biz string, cnts map[int64]int64) error {
+	err := repo.dao.BatchSetCiteCnt(ctx, biz, cnts)
+	if err != nil {
+		return err
+	}
+	for bizId, cnt := range cnts {
+		err = repo.cache.SetCiteCntIfPresent(ctx, biz, bizId, cnt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (repo *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string, bizIds []int64) ([]domain.Interactive, error) {
 	vals, err := repo.dao.GetByIds(ctx, biz, bizIds)
 	if err != nil {
 		return nil, err
 	}
-	return slice.Map[dao.Interactive, domain.Interactive](vals,
-		func(idx int, src dao.Interactive) domain.Interactive { return repo.toDomain(src) }), nil
+	cnts, err := repo.dao.GetReactionCnts(ctx, biz, bizIds)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map[dao.Interactive, domain.Interactive](vals,
+		func(idx int, src dao.Interactive) domain.Interactive {
+			intr := repo.toDomain(src, cnts[src.BizId])
+			delete(cnts, src.BizId)
+			return intr
+		})
+	// 只有别的表态，Interactive 里面还没有这一行
+	for bizId, cnt := range cnts {
+		res = append(res, repo.toDomain(dao.Interactive{Biz: biz, BizId: bizId}, cnt))
+	}
+	return res, nil
 }
 
 func (repo *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
@@ -125,10 +220,21 @@ func (repo *CachedInteractiveRepository) Get(ctx context.Context, biz string, bi
 
 	// 缓存没有，去数据库拿，并写回缓存
 	daoIntr, err := repo.dao.Get(ctx, biz, bizId)
-	if err != nil {
+	if err != nil && err != dao.ErrDataNotFound {
 		return domain.Interactive{}, err
 	}
-	intr = repo.toDomain(daoIntr)
+	cnts, er := repo.dao.GetReactionCnts(ctx, biz, []int64{bizId})
+	if er != nil {
+		return domain.Interactive{}, er
+	}
+	if err == dao.ErrDataNotFound {
+		if len(cnts[bizId]) == 0 {
+			return domain.Interactive{}, err
+		}
+		// 只有别的表态，Interactive 里面还没有这一行
+		daoIntr = dao.Interactive{Biz: biz, BizId: bizId}
+	}
+	intr = repo.toDomain(daoIntr, cnts[bizId])
 	go func() {
 		er := repo.cache.Set(ctx, biz, bizId, intr)
 		if er != nil {
@@ -141,18 +247,45 @@ func (repo *CachedInteractiveRepository) Get(ctx context.Context, biz string, bi
 }
 
 func (repo *CachedInteractiveRepository) Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
-	_, err := repo.dao.GetLikeInfo(ctx, biz, bizId, uid)
+	reaction, err := repo.Reaction(ctx, biz, bizId, uid)
+	return reaction == domain.ReactionLike, err
+}
+
+func (repo *CachedInteractiveRepository) Reaction(ctx context.Context, biz string, bizId, uid int64) (string, error) {
+	like, err := repo.dao.GetLikeInfo(ctx, biz, bizId, uid)
 	switch err {
 	case nil:
-		return true, nil
+		return like.Reaction, nil
 	case dao.ErrDataNotFound:
-		return false, nil // 从来没点赞过，或者软删除了（取消点赞）
+		return "", nil // 从来没表态过，或者软删除了（取消表态）
 	// 这才是真正的 错误
 	default:
-		return false, err
+		return "", err
 	}
 }
 
+func (repo *CachedInteractiveRepository) Reactions(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]string, error) {
+	return repo.dao.GetReactions(ctx, biz, uid, bizIds)
+}
+
+func (repo *CachedInteractiveRepository) ListLiked(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.LikedItem, error) {
+	utime, id := cursor.Keys()
+	likes, err := repo.dao.ListLikeBiz(ctx, uid, biz, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserLikeBiz, domain.LikedItem](likes,
+		func(idx int, src dao.UserLikeBiz) domain.LikedItem {
+			return domain.LikedItem{
+				Id:       src.Id,
+				Biz:      src.Biz,
+				BizId:    src.BizId,
+				Reaction: src.Reaction,
+				Utime:    time.UnixMilli(src.Utime),
+			}
+		}), nil
+}
+
 func (repo *CachedInteractiveRepository) Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
 	_, err := repo.dao.GetCollectInfo(ctx, biz, bizId, uid)
 	switch err {
@@ -169,12 +302,22 @@ func (repo *CachedInteractiveRepository) Collected(ctx context.Context, biz stri
 // 最简原则：
 // 1. 接收器永远用指针
 // 2. 输入输出都用结构体
-func (repo *CachedInteractiveRepository) toDomain(intr dao.Interactive) domain.Interactive {
+// reactions 是除了点赞之外别的表态的计数
+func (repo *CachedInteractiveRepository) toDomain(intr dao.Interactive, reactions map[string]int64) domain.Interactive {
+	if intr.LikeCnt > 0 {
+		if reactions == nil {
+			reactions = make(map[string]int64, 1)
+		}
+		reactions[domain.ReactionLike] = intr.LikeCnt
+	}
 	return domain.Interactive{
 		Biz:        intr.Biz,
 		BizId:      intr.BizId,
 		LikeCnt:    intr.LikeCnt,
 		CollectCnt: intr.CollectCnt,
 		ReadCnt:    intr.ReadCnt,
+		CiteCnt:    intr.CiteCnt,
+		ReaderCnt:  intr.ReaderCnt,
+		Reactions:  reactions,
 	}
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/mapx"
+)
+
+func (repo *CachedInteractiveRepository) AddReader(ctx context.Context, biz string, bizId int64, reader string) (bool, error) {
+	return repo.cache.AddReader(ctx, biz, bizId, reader)
+}
+
+func (repo *CachedInteractiveRepository) DailyReaderCnts(ctx context.Context, biz string, bizId int64, days int) ([]domain.DailyReaderCnt, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dates := make([]time.Time, 0, days)
+	for i := days - 1; i >= 0; i-- {
+		dates = append(dates, today.AddDate(0, 0, -i))
+	}
+	cnts, err := repo.cache.DailyReaderCnts(ctx, biz, bizId, dates)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.DailyReaderCnt, 0, len(dates))
+	for i, date := range dates {
+		res = append(res, domain.DailyReaderCnt{Date: date, Cnt: cnts[i]})
+	}
+	return res, nil
+}
+
+func (repo *CachedInteractiveRepository) PersistReaderCnts(ctx context.Context, batchSize int) (int, error) {
+	bizCnts, err := repo.cache.PopReaderCnts(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for biz, cnts := range bizCnts {
+		if er := repo.dao.BatchSetReaderCnt(ctx, biz, cnts); er != nil {
+			// 放回去，下一次再写，其它 biz 的接着写
+			err = er
+			if er = repo.cache.PushBackReaderDirty(ctx, biz, mapx.Keys(cnts)); er != nil {
+				repo.l.Error("放回没有写进数据库的独立读者数失败",
+					logger.String("biz", biz),
+					logger.Int("cnt", len(cnts)),
+					logger.Error(er))
+			}
+			continue
+		}
+		total += len(cnts)
+		for bizId, cnt := range cnts {
+			if er := repo.cache.SetReaderCntIfPresent(ctx, biz, bizId, cnt); er != nil {
+				repo.l.Error("更新缓存的独立读者数失败",
+					logger.String("biz", biz),
+					logger.Int64("bizId", bizId),
+					logger.Error(er))
+			}
+		}
+	}
+	return total, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+func (repo *CachedInteractiveRepository) FindCntMismatches(ctx context.Context, startId int64, limit int) ([]domain.CntMismatch, int64, error) {
+	mismatches, lastId, err := repo.dao.FindCntMismatches(ctx, startId, limit)
+	if err != nil {
+		return nil, startId, err
+	}
+	return slice.Map(mismatches, func(idx int, src dao.CntMismatch) domain.CntMismatch {
+		return domain.CntMismatch{
+			Id:       src.Id,
+			Biz:      src.Biz,
+			BizId:    src.BizId,
+			CntType:  src.CntType,
+			Stored:   src.Stored,
+			Expected: src.Expected,
+		}
+	}), lastId, nil
+}
+
+func (repo *CachedInteractiveRepository) FixCnt(ctx context.Context, m domain.CntMismatch) (bool, error) {
+	fixed, err := repo.dao.FixCnt(ctx, m.Id)
+	if err != nil || !fixed {
+		return fixed, err
+	}
+	// 缓存里面的也是错的，而且后面的点赞只会在错的值上面加减，所以要删掉
+	// 不直接覆盖，是因为覆盖之前可能已经有人点赞了，删掉让下一次查的时候从数据库加载
+	if er := repo.cache.Del(ctx, m.Biz, m.BizId); er != nil {
+		// 数据库已经修好了，缓存过期之后也会对上
+		repo.l.Error("修复计数之后删除缓存失败",
+			logger.String("biz", m.Biz),
+			logger.Int64("bizId", m.BizId),
+			logger.String("cntType", m.CntType),
+			logger.Error(er))
+	}
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+)
+
+var (
+	ErrCollectionNotFound      = repository.ErrCollectionNotFound
+	ErrAlreadyCollected        = repository.ErrCollectionItemDuplicate
+	ErrNotCollected            = errors.New("还没有收藏")
+	ErrInvalidCollectionName   = errors.New("收藏夹的名字不能为空，也不能太长")
+	ErrTooManyCollections      = errors.New("收藏夹太多了")
+	ErrDefaultCollectionFrozen = errors.New("默认收藏夹不能改名也不能删除")
+)
+
+const (
+	// maxCollections 每个人最多建多少个收藏夹，不算默认收藏夹
+	maxCollections = 100
+	// maxCollectionNameLen 收藏夹名字最多多少个字
+	maxCollectionNameLen = 64
+)
+
+func (s *interactiveService) CancelCollect(ctx context.Context, biz string, bizId, uid int64) error {
+	return s.repo.DeleteCollectionItem(ctx, biz, bizId, uid)
+}
+
+func (s *interactiveService) MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	if err := s.checkCollection(ctx, uid, cid); err != nil {
+		return err
+	}
+	err := s.repo.MoveCollectionItem(ctx, biz, bizId, uid, cid)
+	if err == repository.ErrCollectionItemNotFound {
+		return ErrNotCollected
+	}
+	return err
+}
+
+func (s *interactiveService) ListCollectionItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	// 查询条件里面带了 uid，别人的收藏夹只会查出来空的
+	return s.repo.ListCollectionItems(ctx, uid, cid, offset, limit)
+}
+
+func (s *interactiveService) ListCollected(ctx context.Context, uid int64, biz string,
+	cursor domain.Cursor, limit int) ([]domain.CollectionItem, domain.Cursor, error) {
+	items, err := s.repo.ListCollected(ctx, uid, biz, cursor, limit)
+	if err != nil || len(items) == 0 || len(items) < limit {
+		// 不满一页说明已经没有了
+		return items, domain.FirstPage, err
+	}
+	last := items[len(items)-1]
+	return items, domain.Cursor{Time: last.Ctime, Id: last.Id}, nil
+}
+
+func (s *interactiveService) CreateCollection(ctx context.Context, uid int64, name string) (int64, error) {
+	name, err := s.collectionName(name)
+	if err != nil {
+		return 0, err
+	}
+	cs, err := s.repo.ListCollections(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	// 第一个是默认收藏夹
+	if len(cs)-1 >= maxCollections {
+		return 0, ErrTooManyCollections
+	}
+	return s.repo.CreateCollection(ctx, domain.Collection{Uid: uid, Name: name})
+}
+
+func (s *interactiveService) RenameCollection(ctx context.Context, uid, cid int64, name string) error {
+	if cid == 0 {
+		return ErrDefaultCollectionFrozen
+	}
+	name, err := s.collectionName(name)
+	if err != nil {
+		return err
+	}
+	return s.repo.RenameCollection(ctx, uid, cid, name)
+}
+
+func (s *interactiveService) DeleteCollection(ctx context.Context, uid, cid int64) error {
+	if cid == 0 {
+		return ErrDefaultCollectionFrozen
+	}
+	return s.repo.DeleteCollection(ctx, uid, cid)
+}
+
+func (s *interactiveService) ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	return s.repo.ListCollections(ctx, uid)
+}
+
+// checkCollection cid 必须是 uid 自己的收藏夹，0 是默认收藏夹
+func (s *interactiveService) checkCollection(ctx context.Context, uid, cid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	c, err := s.repo.GetCollection(ctx, cid)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (s *interactiveService) collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLen {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
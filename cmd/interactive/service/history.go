@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+	"github.com/XD/ScholarNet/cmd/interactive/repository"
+)
+
+var (
+	ErrReadRecordNotFound = repository.ErrReadRecordNotFound
+	ErrInvalidProgress    = errors.New("阅读进度必须在 0 到 100 之间")
+)
+
+func (s *interactiveService) ReportReadProgress(ctx context.Context, biz string, bizId, uid int64, progress int32) error {
+	if progress < 0 || progress > 100 {
+		return ErrInvalidProgress
+	}
+	return s.repo.UpdateReadProgress(ctx, biz, bizId, uid, progress)
+}
+
+func (s *interactiveService) GetReadProgress(ctx context.Context, biz string, bizId, uid int64) (domain.ReadRecord, error) {
+	return s.repo.GetReadRecord(ctx, biz, bizId, uid)
+}
+
+func (s *interactiveService) ListReadHistory(ctx context.Context, uid int64, biz string,
+	cursor domain.Cursor, limit int) ([]domain.ReadRecord, domain.Cursor, error) {
+	records, err := s.repo.ListReadRecords(ctx, uid, biz, cursor, limit)
+	if err != nil || len(records) == 0 || len(records) < limit {
+		// 不满一页说明已经没有了
+		return records, domain.FirstPage, err
+	}
+	last := records[len(records)-1]
+	return records, domain.Cursor{Time: last.Utime, Id: last.Id}, nil
+}
+
+func (s *interactiveService) ClearReadHistory(ctx context.Context, uid int64, biz string) error {
+	return s.repo.ClearReadRecords(ctx, uid, biz)
+}
+
+func (s *interactiveService) PauseReadHistory(ctx context.Context, uid int64, paused bool) error {
+	return s.repo.SetReadHistoryPaused(ctx, uid, paused)
+}
+
+func (s *interactiveService) ReadHistoryPaused(ctx context.Context, uid int64) (bool, error) {
+	return s.repo.ReadHistoryPaused(ctx, uid)
+}
@@ -11,34 +11,89 @@ import (
 type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	Like(ctx context.Context, biz string, bizId int64, uid int64) error
+	// CancelLike 取消表态，不管是点赞还是别的表态
 	CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error
+	// React 表态，一个人对一个东西只能有一种表态，已经有别的表态的话就切换过去
+	// biz 不支持这种表态返回 ErrUnknownReaction
+	React(ctx context.Context, biz string, bizId, uid int64, reaction string) error
 	// Collect 收藏, cid 是收藏夹的 ID
 	// cid 不一定有，或者说 0 对应的是该用户的默认收藏夹
 	Collect(ctx context.Context, biz string, bizId, cid, uid int64) error
+	// CancelCollect 取消收藏，不管在哪个收藏夹里面，没有收藏过也算成功
+	CancelCollect(ctx context.Context, biz string, bizId, uid int64) error
+	// MoveCollectionItem 把收藏的东西挪到 cid 这个收藏夹
+	MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error
+	// ListCollectionItems 按照收藏的时间倒序
+	ListCollectionItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error)
+	// ListCollected uid 收藏的所有东西，不管在哪个收藏夹，按照收藏的时间倒序
+	// biz 为空就是所有的 biz，返回的游标是下一页的，没有下一页就是 domain.FirstPage
+	ListCollected(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.CollectionItem, domain.Cursor, error)
+	// ListLiked uid 点赞过的东西，别的表态不算，按照点赞的时间倒序，其余和 ListCollected 一样
+	ListLiked(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.LikedItem, domain.Cursor, error)
+
+	// ReportReadProgress 阅读的时候上报读到了哪里，progress 是百分比，不在 0 到 100 之间返回 ErrInvalidProgress
+	// 暂停了记录阅读历史的话什么都不做
+	ReportReadProgress(ctx context.Context, biz string, bizId, uid int64, progress int32) error
+	// GetReadProgress 上次读到了哪里，方便接着往下看，没有看过返回 ErrReadRecordNotFound
+	GetReadProgress(ctx context.Context, biz string, bizId, uid int64) (domain.ReadRecord, error)
+	// ListReadHistory 最近看过的东西，按照最后一次阅读的时间倒序，其余和 ListCollected 一样
+	ListReadHistory(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.ReadRecord, domain.Cursor, error)
+	// ClearReadHistory biz 为空就是全部清掉
+	ClearReadHistory(ctx context.Context, uid int64, biz string) error
+	// PauseReadHistory 暂停之后不再记录阅读历史，已经有的记录不动
+	PauseReadHistory(ctx context.Context, uid int64, paused bool) error
+	ReadHistoryPaused(ctx context.Context, uid int64) (bool, error)
+	// GetDailyReaders 最近 days 天每一天的独立读者数，包括今天，不在 1 到 30 之间返回 ErrInvalidDays
+	GetDailyReaders(ctx context.Context, biz string, bizId int64, days int) ([]domain.DailyReaderCnt, error)
+
+	// CreateCollection 返回收藏夹的 ID
+	CreateCollection(ctx context.Context, uid int64, name string) (int64, error)
+	// RenameCollection 默认收藏夹不能改名
+	RenameCollection(ctx context.Context, uid, cid int64, name string) error
+	// DeleteCollection 默认收藏夹不能删除，收藏夹里面的东西会挪到默认收藏夹
+	DeleteCollection(ctx context.Context, uid, cid int64) error
+	// ListCollections 第一个是默认收藏夹
+	ListCollections(ctx context.Context, uid int64) ([]domain.Collection, error)
 	// 获取与交互相关的全部信息：点赞收藏浏览数量，以及某用户是否点赞收藏
 	Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error)
-	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// GetByIds uid 大于 0 的时候补充这个用户的表态
+	GetByIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
-	l    logger.LoggerV1
+	repo      repository.InteractiveRepository
+	reactions Reactions
+	l         logger.LoggerV1
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository, l logger.LoggerV1) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository, reactions Reactions, l logger.LoggerV1) InteractiveService {
 	return &interactiveService{
-		repo: repo,
-		l:    l,
+		repo:      repo,
+		reactions: reactions,
+		l:         l,
 	}
 }
 
-func (s *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+func (s *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
 	intrs, err := s.repo.GetByIds(ctx, biz, bizIds)
 	if err != nil {
 		return nil, err
 	}
+	var reactions map[int64]string
+	if uid > 0 {
+		reactions, err = s.repo.Reactions(ctx, biz, uid, bizIds)
+		if err != nil {
+			// 和 Get 一样，只记录日志
+			s.l.Error("查询用户的表态失败",
+				logger.String("biz", biz),
+				logger.Int64("uid", uid),
+				logger.Error(err))
+		}
+	}
 	res := make(map[int64]domain.Interactive, len(intrs))
 	for _, intr := range intrs {
+		intr.Reaction = reactions[intr.BizId]
+		intr.Liked = intr.Reaction == domain.ReactionLike
 		res[intr.BizId] = intr
 	}
 	return res, nil
@@ -57,6 +112,9 @@ func (s *interactiveService) CancelLike(ctx context.Context, biz string, bizId i
 }
 
 func (s *interactiveService) Collect(ctx context.Context, biz string, bizId int64, cid, uid int64) error {
+	if err := s.checkCollection(ctx, uid, cid); err != nil {
+		return err
+	}
 	return s.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
 }
 
@@ -101,12 +159,15 @@ func (s *interactiveService) Get(
 	}
 	var eg errgroup.Group
 	eg.Go(func() error {
-		intr.Liked, err = s.repo.Liked(ctx, biz, bizId, uid)
-		return err
+		var er error
+		intr.Reaction, er = s.repo.Reaction(ctx, biz, bizId, uid)
+		intr.Liked = intr.Reaction == domain.ReactionLike
+		return er
 	})
 	eg.Go(func() error {
-		intr.Collected, err = s.repo.Collected(ctx, biz, bizId, uid)
-		return err
+		var er error
+		intr.Collected, er = s.repo.Collected(ctx, biz, bizId, uid)
+		return er
 	})
 	// 说明是登录过的，补充用户是否点赞或者
 	// 新的打印日志的形态 zap 本身就有这种用法
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+)
+
+var ErrUnknownReaction = errors.New("不支持这种表态")
+
+// Reactions 每个 biz 支持哪些表态，biz => 表态
+// 没有配置的 biz 只能点赞
+type Reactions map[string][]string
+
+// Support 点赞是每个 biz 都支持的
+func (r Reactions) Support(biz, reaction string) bool {
+	if reaction == domain.ReactionLike {
+		return true
+	}
+	return slices.Contains(r[biz], reaction)
+}
+
+func (s *interactiveService) React(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	if !s.reactions.Support(biz, reaction) {
+		return ErrUnknownReaction
+	}
+	return s.repo.React(ctx, biz, bizId, uid, reaction)
+}
+
+func (s *interactiveService) ListLiked(ctx context.Context, uid int64, biz string,
+	cursor domain.Cursor, limit int) ([]domain.LikedItem, domain.Cursor, error) {
+	items, err := s.repo.ListLiked(ctx, uid, biz, cursor, limit)
+	if err != nil || len(items) == 0 || len(items) < limit {
+		// 不满一页说明已经没有了
+		return items, domain.FirstPage, err
+	}
+	last := items[len(items)-1]
+	return items, domain.Cursor{Time: last.Utime, Id: last.Id}, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/XD/ScholarNet/cmd/interactive/domain"
+)
+
+// maxDailyReaderDays 按天的独立读者数最多查多少天，再早的已经过期了
+const maxDailyReaderDays = 30
+
+var ErrInvalidDays = errors.New("天数必须在 1 到 30 之间")
+
+func (s *interactiveService) GetDailyReaders(ctx context.Context, biz string, bizId int64, days int) ([]domain.DailyReaderCnt, error) {
+	if days <= 0 || days > maxDailyReaderDays {
+		return nil, ErrInvalidDays
+	}
+	return s.repo.DailyReaderCnts(ctx, biz, bizId, days)
+}
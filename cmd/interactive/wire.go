@@ -7,10 +7,10 @@ import (
 	"github.com/XD/ScholarNet/cmd/interactive/grpc"
 	"github.com/XD/ScholarNet/cmd/interactive/ioc"
 	"github.com/XD/ScholarNet/cmd/interactive/repository"
-	"github.com/XD/ScholarNet/cmd/interactive/repository/cache"
 	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
 	"github.com/XD/ScholarNet/cmd/interactive/service"
 	"github.com/google/wire"
+	rlock "github.com/gotomicro/redis-lock"
 )
 
 var thirdPartySet = wire.NewSet(
@@ -21,13 +21,16 @@ var thirdPartySet = wire.NewSet(
 	ioc.InitRedis,
 	ioc.InitKafka,
 	ioc.InitSyncProducer,
-	ioc.InitLogger)
+	ioc.InitLogger,
+	rlock.NewClient)
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
+	ioc.InitReactions,
 	repository.NewCachedInteractiveRepository,
-	cache.NewRedisInteractiveCache,
-	dao.NewGORMInteractiveDAO,
+	ioc.InitInteractiveCache,
+	ioc.InitWriteBehindDAO,
+	wire.Bind(new(dao.InteractiveDAO), new(*dao.WriteBehindInteractiveDAO)),
 )
 
 var migratorProvider = wire.NewSet(
@@ -40,7 +43,12 @@ func InitApp() *App {
 		thirdPartySet,
 		migratorProvider,
 		events.NewInteractiveReadEventConsumer,
+		events.NewArticleCitationEventConsumer,
 		ioc.NewConsumers,
+		ioc.InitFlushDeltaJob,
+		ioc.InitPersistReaderCntJob,
+		ioc.InitReconcileCntJob,
+		ioc.InitJobs,
 
 		grpc.NewInteractiveServiceServer,
 		ioc.InitGRPCxServer,
@@ -11,46 +11,54 @@ import (
 	"github.com/XD/ScholarNet/cmd/interactive/grpc"
 	"github.com/XD/ScholarNet/cmd/interactive/ioc"
 	"github.com/XD/ScholarNet/cmd/interactive/repository"
-	"github.com/XD/ScholarNet/cmd/interactive/repository/cache"
 	"github.com/XD/ScholarNet/cmd/interactive/repository/dao"
 	"github.com/XD/ScholarNet/cmd/interactive/service"
 	"github.com/google/wire"
+	rlock "github.com/gotomicro/redis-lock"
 )
 
 // Injectors from wire.go:
 
 func InitApp() *App {
 	cmdable := ioc.InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	interactiveCache := ioc.InitInteractiveCache(cmdable)
 	srcDB := ioc.InitSRC()
 	dstDB := ioc.InitDST()
 	doubleWritePool := ioc.InitDoubleWritePool(srcDB, dstDB)
 	db := ioc.InitBizDB(doubleWritePool)
-	interactiveDAO := dao.NewGORMInteractiveDAO(db)
+	writeBehindInteractiveDAO := ioc.InitWriteBehindDAO(db)
 	loggerV1 := ioc.InitLogger()
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveCache, interactiveDAO, loggerV1)
-	interactiveService := service.NewInteractiveService(interactiveRepository, loggerV1)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveCache, writeBehindInteractiveDAO, loggerV1)
+	reactions := ioc.InitReactions()
+	interactiveService := service.NewInteractiveService(interactiveRepository, reactions, loggerV1)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.InitGRPCxServer(interactiveServiceServer)
 	client := ioc.InitKafka()
 	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(client, interactiveRepository, loggerV1)
+	articleCitationEventConsumer := events.NewArticleCitationEventConsumer(client, interactiveRepository, loggerV1)
 	consumer := ioc.InitFixDataConsumer(loggerV1, srcDB, dstDB, client)
-	v := ioc.NewConsumers(interactiveReadEventConsumer, consumer)
+	v := ioc.NewConsumers(interactiveReadEventConsumer, articleCitationEventConsumer, consumer)
 	syncProducer := ioc.InitSyncProducer(client)
 	producer := ioc.InitMigratorProducer(syncProducer)
 	ginxServer := ioc.InitMigratorWeb(srcDB, dstDB, loggerV1, doubleWritePool, producer)
+	client2 := rlock.NewClient(cmdable)
+	flushDeltaJob := ioc.InitFlushDeltaJob(writeBehindInteractiveDAO, client2, loggerV1)
+	persistReaderCntJob := ioc.InitPersistReaderCntJob(interactiveRepository, client2, loggerV1)
+	reconcileCntJob := ioc.InitReconcileCntJob(interactiveRepository, client2, cmdable, loggerV1)
+	cron := ioc.InitJobs(loggerV1, flushDeltaJob, persistReaderCntJob, reconcileCntJob)
 	app := &App{
 		server:    server,
 		consumers: v,
 		webAdmin:  ginxServer,
+		cron:      cron,
 	}
 	return app
 }
 
 // wire.go:
 
-var thirdPartySet = wire.NewSet(ioc.InitSRC, ioc.InitDST, ioc.InitBizDB, ioc.InitDoubleWritePool, ioc.InitRedis, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitLogger)
+var thirdPartySet = wire.NewSet(ioc.InitSRC, ioc.InitDST, ioc.InitBizDB, ioc.InitDoubleWritePool, ioc.InitRedis, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitLogger, rlock.NewClient)
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepository, cache.NewRedisInteractiveCache, dao.NewGORMInteractiveDAO)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, ioc.InitReactions, repository.NewCachedInteractiveRepository, ioc.InitInteractiveCache, ioc.InitWriteBehindDAO, wire.Bind(new(dao.InteractiveDAO), new(*dao.WriteBehindInteractiveDAO)))
 
 var migratorProvider = wire.NewSet(ioc.InitMigratorProducer, ioc.InitFixDataConsumer, ioc.InitMigratorWeb)
@@ -36,6 +36,42 @@ func (g *GreyScaleInteractiveServiceClient) CancelLike(ctx context.Context, in *
 	return g.client().CancelLike(ctx, in, opts...)
 }
 
+func (g *GreyScaleInteractiveServiceClient) React(ctx context.Context, in *intrv1.ReactRequest, opts ...grpc.CallOption) (*intrv1.ReactResponse, error) {
+	return g.client().React(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListLiked(ctx context.Context, in *intrv1.ListLikedRequest, opts ...grpc.CallOption) (*intrv1.ListLikedResponse, error) {
+	return g.client().ListLiked(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollected(ctx context.Context, in *intrv1.ListCollectedRequest, opts ...grpc.CallOption) (*intrv1.ListCollectedResponse, error) {
+	return g.client().ListCollected(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ReportReadProgress(ctx context.Context, in *intrv1.ReportReadProgressRequest, opts ...grpc.CallOption) (*intrv1.ReportReadProgressResponse, error) {
+	return g.client().ReportReadProgress(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) GetReadProgress(ctx context.Context, in *intrv1.GetReadProgressRequest, opts ...grpc.CallOption) (*intrv1.GetReadProgressResponse, error) {
+	return g.client().GetReadProgress(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListReadHistory(ctx context.Context, in *intrv1.ListReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ListReadHistoryResponse, error) {
+	return g.client().ListReadHistory(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ClearReadHistory(ctx context.Context, in *intrv1.ClearReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.ClearReadHistoryResponse, error) {
+	return g.client().ClearReadHistory(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) PauseReadHistory(ctx context.Context, in *intrv1.PauseReadHistoryRequest, opts ...grpc.CallOption) (*intrv1.PauseReadHistoryResponse, error) {
+	return g.client().PauseReadHistory(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) GetDailyReaders(ctx context.Context, in *intrv1.GetDailyReadersRequest, opts ...grpc.CallOption) (*intrv1.GetDailyReadersResponse, error) {
+	return g.client().GetDailyReaders(ctx, in, opts...)
+}
+
 func (g *GreyScaleInteractiveServiceClient) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
 	return g.client().Collect(ctx, in, opts...)
 }
@@ -48,6 +84,34 @@ func (g *GreyScaleInteractiveServiceClient) GetByIds(ctx context.Context, in *in
 	return g.client().GetByIds(ctx, in, opts...)
 }
 
+func (g *GreyScaleInteractiveServiceClient) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	return g.client().CancelCollect(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	return g.client().MoveCollectionItem(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	return g.client().CreateCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	return g.client().RenameCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	return g.client().DeleteCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	return g.client().ListCollections(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	return g.client().ListCollectionItems(ctx, in, opts...)
+}
+
 func (g *GreyScaleInteractiveServiceClient) UpdataThreshhold(newThreshhold int32) {
 	g.threshold.Store(newThreshhold)
 }
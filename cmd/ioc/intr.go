@@ -11,6 +11,15 @@ import (
 	"log"
 )
 
+// InitIntrReactions 和 interactive 服务的配置一样，reactions 下面是每个 biz 支持的表态
+func InitIntrReactions() service.Reactions {
+	var res service.Reactions
+	if err := viper.UnmarshalKey("reactions", &res); err != nil {
+		panic(err)
+	}
+	return res
+}
+
 func InitIntrGRPCClient(svc service.InteractiveService) intrv1.InteractiveServiceClient {
 	type Config struct {
 		Addr      string
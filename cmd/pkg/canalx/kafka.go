@@ -1,9 +1,19 @@
 package canalx
 
+const (
+	TypeInsert = "INSERT"
+	TypeUpdate = "UPDATE"
+	TypeDelete = "DELETE"
+)
+
 // Message 可以根据需要把其它字段也加入进来。
 type Message[T any] struct {
 	Data     []T    `json:"data"`
 	Database string `json:"database"`
 	Table    string `json:"table"`
 	Type     string `json:"type"`
+	// IsDdl 建表、改表之类的语句，Data 是空的
+	IsDdl bool `json:"isDdl"`
+	// Es binlog 里面记录的执行时间，毫秒
+	Es int64 `json:"es"`
 }
@@ -0,0 +1,24 @@
+package canalx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Int64 canal 发到 Kafka 里面的列的值都是字符串，
+// 这个类型 "123" 和 123 都能解析，NULL 当作 0
+type Int64 int64
+
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "" || str == "null" {
+		*i = 0
+		return nil
+	}
+	val, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = Int64(val)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Package cursorx 按照 (时间, id) 倒序翻页的游标
+// 各个服务的列表用的都是这一个，时间是什么由各自的列表决定
+package cursorx
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("非法的翻页游标")
+
+// Cursor 下一页就是严格排在它后面的数据
+// 对外是不透明的字符串，调用方不应该解析它
+type Cursor struct {
+	Time time.Time
+	Id   int64
+}
+
+// FirstPage 从最新的数据开始
+var FirstPage = Cursor{}
+
+func (c Cursor) IsFirstPage() bool {
+	return c.Time.IsZero() && c.Id == 0
+}
+
+// Keys 返回时间的毫秒数和 id，第一页会返回最大值
+func (c Cursor) Keys() (int64, int64) {
+	if c.IsFirstPage() {
+		return math.MaxInt64, math.MaxInt64
+	}
+	return c.Time.UnixMilli(), c.Id
+}
+
+// Encode 第一页返回空字符串
+func (c Cursor) Encode() string {
+	if c.IsFirstPage() {
+		return ""
+	}
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(c.Time.UnixMilli()))
+	binary.BigEndian.PutUint64(buf[8:], uint64(c.Id))
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+// Decode 空字符串就是第一页
+func Decode(s string) (Cursor, error) {
+	if s == "" {
+		return FirstPage, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(buf) != 16 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	t := int64(binary.BigEndian.Uint64(buf[:8]))
+	id := int64(binary.BigEndian.Uint64(buf[8:]))
+	if t < 0 || id < 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Time: time.UnixMilli(t), Id: id}, nil
+}
@@ -0,0 +1,54 @@
+package cursorx
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor Cursor
+
+		wantKeys [2]int64
+	}{
+		{
+			name:     "第一页",
+			cursor:   FirstPage,
+			wantKeys: [2]int64{math.MaxInt64, math.MaxInt64},
+		},
+		{
+			name:     "中间的一页",
+			cursor:   Cursor{Time: time.UnixMilli(1700000000123), Id: 42},
+			wantKeys: [2]int64{1700000000123, 42},
+		},
+		{
+			name:     "只有时间",
+			cursor:   Cursor{Time: time.UnixMilli(1700000000123)},
+			wantKeys: [2]int64{1700000000123, 0},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tm, id := tc.cursor.Keys()
+			assert.Equal(t, tc.wantKeys, [2]int64{tm, id})
+			c, err := Decode(tc.cursor.Encode())
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantKeys, func() [2]int64 {
+				tm, id := c.Keys()
+				return [2]int64{tm, id}
+			}())
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, s := range []string{"!!!", "AAAA", "__________________________________________"} {
+		_, err := Decode(s)
+		assert.Equal(t, ErrInvalidCursor, err, s)
+	}
+}
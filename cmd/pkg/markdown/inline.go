@@ -0,0 +1,487 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// renderInline 渲染行内元素，返回 HTML 和纯文本
+//
+// 思路和 CommonMark 的参考实现一样，只从头到尾扫一遍：
+// 行内代码、链接、自动链接扫到的时候就处理掉，
+// *、_、~ 先当做分隔符放到栈里面，遇到 ] 或者扫完了再配对，
+// 这样不管怎么写，耗时都和长度成正比
+func renderInline(s string) (string, string) {
+	ip := &inlineParser{s: s, last: -1}
+	ip.parse()
+	ip.processEmphasis(-1)
+	var h, p strings.Builder
+	for i := range ip.nodes {
+		ip.nodes[i].render(&h, &p)
+	}
+	return h.String(), p.String()
+}
+
+// inlineNode 行内的一段，要么是已经渲染好的内容，要么是强调的分隔符
+type inlineNode struct {
+	html, plain string
+
+	// delim 不为 0 的是 *、_、~ 的分隔符
+	delim byte
+	// n 还没有配对的分隔符个数，origN 原本的个数
+	n, origN          int
+	canOpen, canClose bool
+	// closeTags 在剩下的分隔符前面，openTags 在后面
+	openTags, closeTags string
+	// prev、next 分隔符栈里面的前一个和后一个，-1 表示没有
+	prev, next int
+}
+
+func (n *inlineNode) render(h, p *strings.Builder) {
+	if n.delim == 0 {
+		h.WriteString(n.html)
+		p.WriteString(n.plain)
+		return
+	}
+	lit := strings.Repeat(string(n.delim), n.n)
+	h.WriteString(n.closeTags)
+	h.WriteString(lit)
+	h.WriteString(n.openTags)
+	p.WriteString(lit)
+}
+
+// bracket 还没有闭合的 [ 或者 ![
+type bracket struct {
+	node  int
+	image bool
+	// delim 遇到 [ 的时候分隔符栈的栈顶，链接文字里面的分隔符只在这之后配对
+	delim int
+}
+
+type inlineParser struct {
+	s     string
+	nodes []inlineNode
+	// text 还没有放进 nodes 的普通文本
+	text strings.Builder
+	// last 分隔符栈的栈顶
+	last     int
+	brackets []bracket
+	// inactive 下标比它小的 [ 不能再构成链接了，链接里面不能套链接
+	inactive int
+	// parens ( 的位置到配对的 ) 的位置，第一次用到的时候才算
+	parens map[int]int
+	// noCodeEnd 找不到结束标记的反引号个数，后面同样个数的反引号也不用再找了
+	noCodeEnd map[int]bool
+}
+
+func (ip *inlineParser) parse() {
+	s := ip.s
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			ip.text.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '`':
+			n := runLen(s, i)
+			if end := ip.codeSpan(i, n); end > 0 {
+				i = end
+				continue
+			}
+			// 没有闭合，整段反引号当做普通文本
+			ip.text.WriteString(s[i : i+n])
+			i += n
+			continue
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			ip.openBracket("![", true)
+			i += 2
+			continue
+		case c == '[':
+			ip.openBracket("[", false)
+			i++
+			continue
+		case c == ']':
+			if n := ip.closeBracket(i); n > 0 {
+				i += n
+				continue
+			}
+		case c == '<':
+			if n := ip.autolink(i); n > 0 {
+				i += n
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			i += ip.delimiter(i)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		ip.text.WriteString(s[i : i+size])
+		i += size
+	}
+	ip.flush()
+}
+
+// flush 把攒着的普通文本放进 nodes
+func (ip *inlineParser) flush() {
+	if ip.text.Len() == 0 {
+		return
+	}
+	t := ip.text.String()
+	ip.text.Reset()
+	ip.nodes = append(ip.nodes, inlineNode{html: escape(t), plain: t, prev: -1, next: -1})
+}
+
+func (ip *inlineParser) push(n inlineNode) int {
+	ip.flush()
+	n.prev, n.next = -1, -1
+	ip.nodes = append(ip.nodes, n)
+	return len(ip.nodes) - 1
+}
+
+// codeSpan 处理 `code`，返回结束之后的位置，0 表示不是行内代码
+func (ip *inlineParser) codeSpan(i, n int) int {
+	if ip.noCodeEnd[n] {
+		return 0
+	}
+	s := ip.s
+	for j := i + n; j < len(s); {
+		k := strings.IndexByte(s[j:], '`')
+		if k < 0 {
+			break
+		}
+		j += k
+		m := runLen(s, j)
+		if m != n {
+			j += m
+			continue
+		}
+		code := s[i+n : j]
+		if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+			code = code[1 : len(code)-1]
+		}
+		ip.push(inlineNode{html: "<code>" + escape(code) + "</code>", plain: code})
+		return j + m
+	}
+	if ip.noCodeEnd == nil {
+		ip.noCodeEnd = make(map[int]bool, 1)
+	}
+	ip.noCodeEnd[n] = true
+	return 0
+}
+
+func (ip *inlineParser) openBracket(lit string, image bool) {
+	idx := ip.push(inlineNode{html: lit, plain: lit})
+	ip.brackets = append(ip.brackets, bracket{node: idx, image: image, delim: ip.last})
+}
+
+// closeBracket 处理 [text](url "title") 的 ]，i 指向 ]，返回消耗的字节数，0 表示不是链接
+func (ip *inlineParser) closeBracket(i int) int {
+	if len(ip.brackets) == 0 {
+		return 0
+	}
+	top := len(ip.brackets) - 1
+	b := ip.brackets[top]
+	ip.brackets = ip.brackets[:top]
+	if !b.image && top < ip.inactive {
+		return 0
+	}
+	s := ip.s
+	if i+1 >= len(s) || s[i+1] != '(' {
+		return 0
+	}
+	closing, ok := ip.matchParen(i + 1)
+	if !ok {
+		return 0
+	}
+	dest, title := splitDest(s[i+2 : closing])
+	href, ok := safeURL(dest)
+
+	ip.flush()
+	ip.processEmphasis(b.delim)
+	var textHTML, textPlain strings.Builder
+	for k := b.node + 1; k < len(ip.nodes); k++ {
+		ip.nodes[k].render(&textHTML, &textPlain)
+	}
+	// 链接文字里面的分隔符都处理完了，从栈里面拿掉
+	ip.nodes = ip.nodes[:b.node]
+	ip.last = b.delim
+	if b.delim >= 0 {
+		ip.nodes[b.delim].next = -1
+	}
+
+	var h strings.Builder
+	switch {
+	case !ok:
+		// 不安全的链接，只保留文字
+		if b.image {
+			h.WriteString(escape(textPlain.String()))
+		} else {
+			h.WriteString(textHTML.String())
+		}
+	case b.image:
+		h.WriteString(`<img src="` + escape(href) + `" alt="` + escape(textPlain.String()) + `"`)
+		if title != "" {
+			h.WriteString(` title="` + escape(title) + `"`)
+		}
+		h.WriteString(">")
+	default:
+		h.WriteString(`<a href="` + escape(href) + `"`)
+		if title != "" {
+			h.WriteString(` title="` + escape(title) + `"`)
+		}
+		h.WriteString(` rel="nofollow noopener noreferrer">`)
+		h.WriteString(textHTML.String())
+		h.WriteString("</a>")
+	}
+	ip.push(inlineNode{html: h.String(), plain: textPlain.String()})
+	if !b.image {
+		ip.inactive = len(ip.brackets)
+	}
+	return closing + 1 - i
+}
+
+// matchParen 找到和 s[i] 的 ( 配对的 )，所有的括号扫一遍一起算好
+func (ip *inlineParser) matchParen(i int) (int, bool) {
+	if ip.parens == nil {
+		ip.parens = make(map[int]int)
+		var stack []int
+		s := ip.s
+		for j := 0; j < len(s); j++ {
+			switch s[j] {
+			case '\\':
+				j++
+			case '(':
+				stack = append(stack, j)
+			case ')':
+				if len(stack) > 0 {
+					ip.parens[stack[len(stack)-1]] = j
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+	end, ok := ip.parens[i]
+	return end, ok
+}
+
+// splitDest 把 url "title" 拆开
+func splitDest(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "<") {
+		if end := strings.IndexByte(s, '>'); end > 0 {
+			return s[1:end], unquoteTitle(s[end+1:])
+		}
+	}
+	if idx := strings.IndexAny(s, " \t"); idx >= 0 {
+		return s[:idx], unquoteTitle(s[idx+1:])
+	}
+	return s, ""
+}
+
+func unquoteTitle(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return ""
+}
+
+// autolink 处理 <https://example.com>
+func (ip *inlineParser) autolink(i int) int {
+	s := ip.s
+	// 地址里面不能有空白和 <，遇到了就不用往后找了
+	end := i + 1
+	for end < len(s) && strings.IndexByte(" \t<>", s[end]) < 0 {
+		end++
+	}
+	if end >= len(s) || s[end] != '>' || end == i+1 {
+		return 0
+	}
+	raw := s[i+1 : end]
+	href, ok := safeURL(raw)
+	if !ok || !strings.Contains(raw, ":") {
+		return 0
+	}
+	ip.push(inlineNode{
+		html:  `<a href="` + escape(href) + `" rel="nofollow noopener noreferrer">` + escape(raw) + "</a>",
+		plain: raw,
+	})
+	return end + 1 - i
+}
+
+// delimiter 把 *、_、~ 放进分隔符栈，返回消耗的字节数
+// 标记后面不是空白才能开始，前面不是空白才能结束，_ 不能出现在单词中间
+func (ip *inlineParser) delimiter(i int) int {
+	s := ip.s
+	c := s[i]
+	n := runLen(s, i)
+	before, after := ' ', ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(s[:i])
+	}
+	if i+n < len(s) {
+		after, _ = utf8.DecodeRuneInString(s[i+n:])
+	}
+	canOpen := !unicode.IsSpace(after)
+	canClose := !unicode.IsSpace(before)
+	if c == '_' {
+		canOpen = canOpen && !isWord(before)
+		canClose = canClose && !isWord(after)
+	}
+	// 删除线只认 ~~
+	if (c == '~' && n != 2) || (!canOpen && !canClose) {
+		ip.text.WriteString(s[i : i+n])
+		return n
+	}
+	idx := ip.push(inlineNode{delim: c, n: n, origN: n, canOpen: canOpen, canClose: canClose})
+	ip.nodes[idx].prev = ip.last
+	if ip.last >= 0 {
+		ip.nodes[ip.last].next = idx
+	}
+	ip.last = idx
+	return n
+}
+
+// processEmphasis 给 bottom 之后的分隔符配对，bottom 为 -1 就是全部
+func (ip *inlineParser) processEmphasis(bottom int) {
+	// openersBottom 往前找开始标记的下限，
+	// 某一类结束标记找不到开始标记，后面同一类的结束标记也不用再往前找了
+	var openersBottom [3][3][2]int
+	for i := range openersBottom {
+		for j := range openersBottom[i] {
+			openersBottom[i][j] = [2]int{bottom, bottom}
+		}
+	}
+	closer := ip.last
+	for closer != bottom && ip.nodes[closer].prev != bottom {
+		closer = ip.nodes[closer].prev
+	}
+	if closer == bottom {
+		return
+	}
+	for closer >= 0 {
+		c := &ip.nodes[closer]
+		if !c.canClose {
+			closer = c.next
+			continue
+		}
+		limit := &openersBottom[strings.IndexByte("*_~", c.delim)][c.origN%3][boolIdx(c.canOpen)]
+		o := c.prev
+		for o > *limit && !ip.matches(o, closer) {
+			o = ip.nodes[o].prev
+		}
+		if o <= *limit {
+			*limit = c.prev
+			next := c.next
+			if !c.canOpen {
+				ip.remove(closer)
+			}
+			closer = next
+			continue
+		}
+		op := &ip.nodes[o]
+		use, tag := 1, "em"
+		switch {
+		case c.delim == '~':
+			use, tag = 2, "del"
+		case op.n >= 2 && c.n >= 2:
+			use, tag = 2, "strong"
+		}
+		op.n -= use
+		c.n -= use
+		op.openTags = "<" + tag + ">" + op.openTags
+		c.closeTags += "</" + tag + ">"
+		// 中间的分隔符都不能再配对了
+		op.next, c.prev = closer, o
+		if op.n == 0 {
+			ip.remove(o)
+		}
+		if c.n == 0 {
+			next := c.next
+			ip.remove(closer)
+			closer = next
+		}
+	}
+}
+
+func (ip *inlineParser) matches(o, closer int) bool {
+	op, c := &ip.nodes[o], &ip.nodes[closer]
+	if op.delim != c.delim || !op.canOpen {
+		return false
+	}
+	// 既能开始又能结束的标记，加起来是 3 的倍数的不配对，不然 *a**b* 会配错
+	if c.delim != '~' && (op.canClose || c.canOpen) &&
+		(op.origN+c.origN)%3 == 0 && (op.origN%3 != 0 || c.origN%3 != 0) {
+		return false
+	}
+	return true
+}
+
+// remove 把分隔符从栈里面拿掉，剩下的标记当做普通文本输出
+func (ip *inlineParser) remove(i int) {
+	prev, next := ip.nodes[i].prev, ip.nodes[i].next
+	if prev >= 0 {
+		ip.nodes[prev].next = next
+	}
+	if next >= 0 {
+		ip.nodes[next].prev = prev
+	} else {
+		ip.last = prev
+	}
+}
+
+// safeURL 只允许 http、https、mailto 和相对路径，避免 javascript: 之类的 XSS
+func safeURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		// 相对路径的第一段里面不能有冒号，避免 java&#x09;script: 这种浏览器解码之后变成协议的写法
+		first := raw
+		if idx := strings.IndexAny(raw, "/?#"); idx >= 0 {
+			first = raw[:idx]
+		}
+		return raw, !strings.ContainsAny(first, ":&")
+	case "http", "https", "mailto":
+		return raw, true
+	default:
+		return "", false
+	}
+}
+
+func runLen(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func boolIdx(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func escape(s string) string {
+	return html.EscapeString(s)
+}
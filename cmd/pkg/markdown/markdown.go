@@ -0,0 +1,365 @@
+// Package markdown 把 Markdown 渲染成可以直接输出给浏览器的 HTML
+//
+// 这里只支持常用的语法：标题、段落、引用、列表、代码块、分割线，
+// 以及行内的强调、删除线、行内代码、链接、图片。
+// 安全性是靠构造保证的：所有的文本都会被转义，原始 HTML 不会被透传，
+// 只会输出白名单里面的标签，链接只允许 http、https、mailto 和相对路径。
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading 目录中的一项
+type Heading struct {
+	Level int
+	Title string
+	// Anchor 对应标题的 id 属性，可以用来跳转
+	Anchor string
+}
+
+type Result struct {
+	HTML string
+	TOC  []Heading
+	// PlainText 去掉了所有标记的纯文本，块之间用换行分隔
+	PlainText string
+	// Links 正文里面所有链接的地址，按照出现的顺序，不包括图片
+	Links []string
+}
+
+// Render 渲染 Markdown
+func Render(src string) Result {
+	r := &renderer{anchors: map[string]int{}}
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	r.blocks(strings.Split(src, "\n"), false)
+	res := Result{
+		HTML:      r.html.String(),
+		TOC:       r.toc,
+		PlainText: strings.TrimSpace(r.plain.String()),
+	}
+	res.Links = links(res.HTML)
+	return res
+}
+
+var linkRegexp = regexp.MustCompile(`<a href="([^"]*)"`)
+
+// links 从渲染之后的 HTML 里面取链接，
+// 代码块里面的内容都被转义过了，不会被当成链接
+func links(h string) []string {
+	matches := linkRegexp.FindAllStringSubmatch(h, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(matches))
+	for _, m := range matches {
+		res = append(res, html.UnescapeString(m[1]))
+	}
+	return res
+}
+
+var (
+	headingRegexp = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	hrRegexp      = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRegexp   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	listRegexp    = regexp.MustCompile(`^( {0,3})([-*+]|(\d{1,9})[.)])([ \t]+|$)`)
+	quoteRegexp   = regexp.MustCompile(`^ {0,3}> ?`)
+	langRegexp    = regexp.MustCompile(`^[A-Za-z0-9_+#-]+$`)
+)
+
+type renderer struct {
+	html    strings.Builder
+	plain   strings.Builder
+	toc     []Heading
+	anchors map[string]int
+}
+
+// blocks 渲染一组行。tight 为 true 的时候是紧凑列表里面的内容，段落不加 <p>
+func (r *renderer) blocks(lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fenceRegexp.MatchString(line):
+			i = r.fence(lines, i)
+		case headingRegexp.MatchString(line):
+			r.heading(line)
+			i++
+		case hrRegexp.MatchString(line):
+			r.html.WriteString("<hr>\n")
+			i++
+		case quoteRegexp.MatchString(line):
+			i = r.quote(lines, i)
+		case listRegexp.MatchString(line):
+			i = r.list(lines, i)
+		default:
+			i = r.paragraph(lines, i, tight)
+		}
+	}
+}
+
+// interrupts 判断这一行是否会打断段落
+func interrupts(line string) bool {
+	if fenceRegexp.MatchString(line) || headingRegexp.MatchString(line) ||
+		hrRegexp.MatchString(line) || quoteRegexp.MatchString(line) {
+		return true
+	}
+	m := listRegexp.FindStringSubmatch(line)
+	// 和 CommonMark 一样，有序列表只有从 1 开始才能打断段落，避免 "2024. 年" 这种误判
+	return m != nil && strings.TrimSpace(line[len(m[0]):]) != "" && (m[3] == "" || m[3] == "1")
+}
+
+func (r *renderer) fence(lines []string, i int) int {
+	m := fenceRegexp.FindStringSubmatch(lines[i])
+	indent, marker, lang := len(m[1]), m[2], m[3]
+	var code []string
+	i++
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, marker) && strings.Trim(trimmed, marker[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, trimLeftSpaces(lines[i], indent))
+	}
+	content := strings.Join(code, "\n")
+	r.html.WriteString("<pre><code")
+	if langRegexp.MatchString(lang) {
+		r.html.WriteString(` class="language-`)
+		r.html.WriteString(escape(lang))
+		r.html.WriteString(`"`)
+	}
+	r.html.WriteString(">")
+	r.html.WriteString(escape(content))
+	if content != "" {
+		r.html.WriteString("\n")
+	}
+	r.html.WriteString("</code></pre>\n")
+	r.writePlain(content)
+	return i
+}
+
+func (r *renderer) heading(line string) {
+	m := headingRegexp.FindStringSubmatch(line)
+	level := len(m[1])
+	html, plain := renderInline(strings.TrimSpace(m[2]))
+	anchor := r.anchor(plain)
+	tag := "h" + strconv.Itoa(level)
+	r.html.WriteString("<" + tag + ` id="` + escape(anchor) + `">`)
+	r.html.WriteString(html)
+	r.html.WriteString("</" + tag + ">\n")
+	r.toc = append(r.toc, Heading{Level: level, Title: plain, Anchor: anchor})
+	r.writePlain(plain)
+}
+
+// anchor 生成标题的 id，重复的标题会加上 -1、-2 这样的后缀
+func (r *renderer) anchor(title string) string {
+	var sb strings.Builder
+	lastDash := false
+	for _, c := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_':
+			sb.WriteRune(c)
+			lastDash = false
+		case unicode.IsSpace(c) || c == '-':
+			if !lastDash && sb.Len() > 0 {
+				sb.WriteByte('-')
+				lastDash = true
+			}
+		}
+	}
+	base := strings.TrimRight(sb.String(), "-")
+	if base == "" {
+		base = "section"
+	}
+	cnt := r.anchors[base]
+	r.anchors[base] = cnt + 1
+	if cnt == 0 {
+		return base
+	}
+	return base + "-" + strconv.Itoa(cnt)
+}
+
+func (r *renderer) quote(lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if loc := quoteRegexp.FindStringIndex(line); loc != nil {
+			inner = append(inner, line[loc[1]:])
+			continue
+		}
+		// 懒惰续行：引用里面的段落可以不写 >
+		if strings.TrimSpace(line) == "" || interrupts(line) ||
+			len(inner) == 0 || strings.TrimSpace(inner[len(inner)-1]) == "" {
+			break
+		}
+		inner = append(inner, line)
+	}
+	r.html.WriteString("<blockquote>\n")
+	r.blocks(inner, false)
+	r.html.WriteString("</blockquote>\n")
+	return i
+}
+
+func (r *renderer) list(lines []string, i int) int {
+	first := listRegexp.FindStringSubmatch(lines[i])
+	ordered := first[3] != ""
+	// 用最后一个字符区分不同的列表，比如说 - 和 * 是两个列表
+	delim := first[2][len(first[2])-1:]
+	var items [][]string
+	tight := true
+	for i < len(lines) {
+		m := listRegexp.FindStringSubmatch(lines[i])
+		if m == nil || (m[3] != "") != ordered || !strings.HasSuffix(m[2], delim) ||
+			hrRegexp.MatchString(lines[i]) {
+			break
+		}
+		// 内容的缩进，后续行缩进不少于这个就属于这一项
+		width := len(m[1]) + len(m[2]) + 1
+		item := []string{lines[i][len(m[0]):]}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				// 空行之后还有缩进的内容，那么还是属于这一项
+				j := i + 1
+				for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+					j++
+				}
+				if j < len(lines) && indentOf(lines[j]) >= width {
+					item = append(item, lines[i:j]...)
+					i = j
+					tight = false
+					continue
+				}
+				break
+			}
+			if indentOf(line) >= width {
+				item = append(item, trimLeftSpaces(line, width))
+				i++
+				continue
+			}
+			// 懒惰续行，遇到新的列表项就结束
+			if interrupts(line) || listRegexp.MatchString(line) {
+				break
+			}
+			item = append(item, line)
+			i++
+		}
+		items = append(items, item)
+		// 列表项之间的空行
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+			j++
+		}
+		if j > i && j < len(lines) {
+			if next := listRegexp.FindStringSubmatch(lines[j]); next != nil &&
+				(next[3] != "") == ordered && strings.HasSuffix(next[2], delim) {
+				tight = false
+				i = j
+			}
+		}
+	}
+	if ordered {
+		start, _ := strconv.Atoi(first[3])
+		if start == 1 {
+			r.html.WriteString("<ol>\n")
+		} else {
+			r.html.WriteString(`<ol start="` + strconv.Itoa(start) + `">` + "\n")
+		}
+	} else {
+		r.html.WriteString("<ul>\n")
+	}
+	for _, item := range items {
+		r.html.WriteString("<li>")
+		r.blocks(item, tight)
+		r.html.WriteString("</li>\n")
+	}
+	if ordered {
+		r.html.WriteString("</ol>\n")
+	} else {
+		r.html.WriteString("</ul>\n")
+	}
+	return i
+}
+
+func (r *renderer) paragraph(lines []string, i int, tight bool) int {
+	var para []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || (len(para) > 0 && interrupts(line)) {
+			break
+		}
+		para = append(para, line)
+	}
+	var html, plain strings.Builder
+	for j, line := range para {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		line = strings.TrimSpace(line)
+		if hardBreak && strings.HasSuffix(line, "\\") {
+			line = line[:len(line)-1]
+		}
+		h, p := renderInline(line)
+		html.WriteString(h)
+		plain.WriteString(p)
+		if j < len(para)-1 {
+			if hardBreak {
+				html.WriteString("<br>")
+			}
+			html.WriteString("\n")
+			plain.WriteString("\n")
+		}
+	}
+	if tight {
+		r.html.WriteString(html.String())
+	} else {
+		r.html.WriteString("<p>")
+		r.html.WriteString(html.String())
+		r.html.WriteString("</p>\n")
+	}
+	r.writePlain(plain.String())
+	return i
+}
+
+func (r *renderer) writePlain(s string) {
+	if s == "" {
+		return
+	}
+	if r.plain.Len() > 0 {
+		r.plain.WriteString("\n")
+	}
+	r.plain.WriteString(s)
+}
+
+// indentOf 计算缩进，tab 算 4 个空格
+func indentOf(line string) int {
+	n := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// trimLeftSpaces 去掉最多 n 个空格的缩进
+func trimLeftSpaces(line string, n int) string {
+	i := 0
+	for i < len(line) && i < n && line[i] == ' ' {
+		i++
+	}
+	if i < n && i < len(line) && line[i] == '\t' {
+		i++
+	}
+	return line[i:]
+}
@@ -0,0 +1,226 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		wantHTML string
+		wantTOC  []Heading
+		// 不校验就留空
+		wantPlain string
+	}{
+		{
+			name:      "段落和强调",
+			src:       "这是 **粗体**、*斜体*、~~删除~~ 和 `code`",
+			wantHTML:  "<p>这是 <strong>粗体</strong>、<em>斜体</em>、<del>删除</del> 和 <code>code</code></p>\n",
+			wantPlain: "这是 粗体、斜体、删除 和 code",
+		},
+		{
+			name:     "嵌套的强调",
+			src:      "***全部*** 和 *外面 **里面** 外面* 和 **没有闭合",
+			wantHTML: "<p><em><strong>全部</strong></em> 和 <em>外面 <strong>里面</strong> 外面</em> 和 **没有闭合</p>\n",
+		},
+		{
+			name:     "链接里面的强调",
+			src:      "[**论文** *a](/a) b*",
+			wantHTML: "<p><a href=\"/a\" rel=\"nofollow noopener noreferrer\"><strong>论文</strong> *a</a> b*</p>\n",
+		},
+		{
+			name:     "单词中间的下划线",
+			src:      "snake_case_name 和 _强调_",
+			wantHTML: "<p>snake_case_name 和 <em>强调</em></p>\n",
+		},
+		{
+			name:     "标题和目录",
+			src:      "# 简介\n\n正文\n\n## Related Work\n\n## 简介",
+			wantHTML: "<h1 id=\"简介\">简介</h1>\n<p>正文</p>\n<h2 id=\"related-work\">Related Work</h2>\n<h2 id=\"简介-1\">简介</h2>\n",
+			wantTOC: []Heading{
+				{Level: 1, Title: "简介", Anchor: "简介"},
+				{Level: 2, Title: "Related Work", Anchor: "related-work"},
+				{Level: 2, Title: "简介", Anchor: "简介-1"},
+			},
+			wantPlain: "简介\n正文\nRelated Work\n简介",
+		},
+		{
+			name:      "代码块",
+			src:       "```go\nfmt.Println(\"<b>\")\n```",
+			wantHTML:  "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)\n</code></pre>\n",
+			wantPlain: "fmt.Println(\"<b>\")",
+		},
+		{
+			name:     "代码块的语言不合法",
+			src:      "```\"><script>\nx\n```",
+			wantHTML: "<pre><code>x\n</code></pre>\n",
+		},
+		{
+			name:     "无序列表",
+			src:      "- 第一项\n- 第二项\n  继续\n- 第三项",
+			wantHTML: "<ul>\n<li>第一项</li>\n<li>第二项\n继续</li>\n<li>第三项</li>\n</ul>\n",
+		},
+		{
+			name:     "有序列表和嵌套",
+			src:      "3. 第三\n4. 第四\n   - 子项",
+			wantHTML: "<ol start=\"3\">\n<li>第三</li>\n<li>第四<ul>\n<li>子项</li>\n</ul>\n</li>\n</ol>\n",
+		},
+		{
+			name:     "松散列表",
+			src:      "- 第一项\n\n- 第二项",
+			wantHTML: "<ul>\n<li><p>第一项</p>\n</li>\n<li><p>第二项</p>\n</li>\n</ul>\n",
+		},
+		{
+			name:     "引用和分割线",
+			src:      "> 引用\n懒惰续行\n\n---",
+			wantHTML: "<blockquote>\n<p>引用\n懒惰续行</p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name:     "链接和图片",
+			src:      "[论文](https://example.com/a?b=1&c=2 \"标题\") ![图](/img/a.png)",
+			wantHTML: "<p><a href=\"https://example.com/a?b=1&amp;c=2\" title=\"标题\" rel=\"nofollow noopener noreferrer\">论文</a> <img src=\"/img/a.png\" alt=\"图\"></p>\n",
+		},
+		{
+			name:     "自动链接",
+			src:      "<https://example.com>",
+			wantHTML: "<p><a href=\"https://example.com\" rel=\"nofollow noopener noreferrer\">https://example.com</a></p>\n",
+		},
+		{
+			name:     "强制换行",
+			src:      "第一行  \n第二行",
+			wantHTML: "<p>第一行<br>\n第二行</p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Render(tc.src)
+			assert.Equal(t, tc.wantHTML, res.HTML)
+			assert.Equal(t, tc.wantTOC, res.TOC)
+			if tc.wantPlain != "" {
+				assert.Equal(t, tc.wantPlain, res.PlainText)
+			}
+		})
+	}
+}
+
+// TestRenderXSS 各种常见的 XSS 写法都不能输出可执行的内容
+func TestRenderXSS(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		wantHTML string
+	}{
+		{
+			name:     "原始 HTML",
+			src:      "<script>alert(1)</script>",
+			wantHTML: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		},
+		{
+			name:     "事件属性",
+			src:      "<img src=x onerror=alert(1)>",
+			wantHTML: "<p>&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "javascript 链接",
+			src:      "[点我](javascript:alert(1))",
+			wantHTML: "<p>点我</p>\n",
+		},
+		{
+			name:     "大小写混合的 javascript 链接",
+			src:      "[点我](JaVaScRiPt:alert(1))",
+			wantHTML: "<p>点我</p>\n",
+		},
+		{
+			name:     "实体编码的 javascript 链接",
+			src:      "[点我](java&#x09;script:alert(1))",
+			wantHTML: "<p>点我</p>\n",
+		},
+		{
+			name:     "data 图片",
+			src:      "![x](data:text/html;base64,PHNjcmlwdD4=)",
+			wantHTML: "<p>x</p>\n",
+		},
+		{
+			name:     "javascript 自动链接",
+			src:      "<javascript:alert(1)>",
+			wantHTML: "<p>&lt;javascript:alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "属性逃逸",
+			src:      "[x](https://a.com/\"onmouseover=\"alert(1))",
+			wantHTML: "<p><a href=\"https://a.com/&#34;onmouseover=&#34;alert(1)\" rel=\"nofollow noopener noreferrer\">x</a></p>\n",
+		},
+		{
+			name:     "标题里面的 HTML",
+			src:      "# <b onclick=x>标题</b>",
+			wantHTML: "<h1 id=\"b-onclickx标题b\">&lt;b onclick=x&gt;标题&lt;/b&gt;</h1>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Render(tc.src)
+			assert.Equal(t, tc.wantHTML, res.HTML)
+		})
+	}
+}
+
+func TestRenderLinks(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       string
+		wantLinks []string
+	}{
+		{
+			name: "链接和自动链接",
+			src: "见 [上一篇](/articles/pub/12) 和 <https://doi.org/10.1000/xyz?a=1&b=2>\n\n" +
+				"![图](/img/a.png)",
+			wantLinks: []string{"/articles/pub/12", "https://doi.org/10.1000/xyz?a=1&b=2"},
+		},
+		{
+			name: "代码里面的不算",
+			src:  "`<a href=\"/articles/pub/1\">`\n\n```\n[x](/articles/pub/2)\n```",
+		},
+		{
+			name: "不安全的链接",
+			src:  "[点我](javascript:alert(1))",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Render(tc.src)
+			assert.Equal(t, tc.wantLinks, res.Links)
+		})
+	}
+}
+
+// TestRenderPathological 恶意构造的输入渲染的耗时也要和长度成正比
+func TestRenderPathological(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+	}{
+		{name: "没有闭合的强调", src: strings.Repeat("*a ", 20000)},
+		{name: "没有闭合的下划线", src: strings.Repeat("_a ", 20000)},
+		{name: "交错的强调", src: strings.Repeat("*a **b ", 10000)},
+		{name: "没有闭合的删除线", src: strings.Repeat("~~a ", 20000)},
+		{name: "没有闭合的反引号", src: strings.Repeat("`a ``b ", 10000)},
+		{name: "没有闭合的方括号", src: strings.Repeat("[a ", 20000)},
+		{name: "没有闭合的链接", src: strings.Repeat("[a](b ", 10000)},
+		{name: "没有闭合的尖括号", src: strings.Repeat("<a ", 20000) + ">"},
+		{name: "嵌套的图片", src: strings.Repeat("![", 10000) + "a" + strings.Repeat("](b)", 10000)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			res := Render(tc.src)
+			assert.NotEmpty(t, res.HTML)
+			// 平方级别的实现要好几秒
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hit 命中的敏感词，Start 和 End 是在原文中的字节偏移量
+// 因为匹配的时候会跳过空白和标点，所以 text[Start:End] 不一定和 Word 完全一样
+type Hit struct {
+	Word  string
+	Start int
+	End   int
+}
+
+// Matcher 基于 Aho-Corasick 自动机的多模式匹配，构造完成之后是只读的，可以并发使用
+//
+// 匹配之前会统一处理原文和敏感词：忽略大小写，全角转半角，
+// 并且跳过空白、标点和符号，避免 "赌 博"、"赌*博" 这种简单的绕过
+type Matcher struct {
+	root *acNode
+}
+
+type acNode struct {
+	children map[rune]*acNode
+	fail     *acNode
+	// outputs 以这个节点结尾的所有敏感词，包括 fail 链上的
+	outputs []output
+}
+
+type output struct {
+	word string
+	// length 规范化之后的字符数
+	length int
+}
+
+func NewMatcher(words []string) *Matcher {
+	root := &acNode{children: map[rune]*acNode{}}
+	for _, word := range words {
+		rs := normalize(word)
+		if len(rs) == 0 {
+			continue
+		}
+		cur := root
+		for _, r := range rs {
+			next, ok := cur.children[r]
+			if !ok {
+				next = &acNode{children: map[rune]*acNode{}}
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		cur.outputs = append(cur.outputs, output{word: strings.TrimSpace(word), length: len(rs)})
+	}
+	// 按照层次遍历构造 fail 指针，父节点的 fail 一定比子节点先处理
+	queue := make([]*acNode, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			fail := cur.fail
+			for fail != nil && fail.children[r] == nil {
+				fail = fail.fail
+			}
+			if fail == nil {
+				child.fail = root
+			} else {
+				child.fail = fail.children[r]
+			}
+			child.outputs = append(child.outputs, child.fail.outputs...)
+			queue = append(queue, child)
+		}
+	}
+	return &Matcher{root: root}
+}
+
+// FindAll 找出所有命中的敏感词，按照结束的位置排列，重叠的也会返回
+func (m *Matcher) FindAll(text string) []Hit {
+	var hits []Hit
+	m.scan(text, func(h Hit) bool {
+		hits = append(hits, h)
+		return true
+	})
+	return hits
+}
+
+// Contains 只要命中一个就返回
+func (m *Matcher) Contains(text string) bool {
+	found := false
+	m.scan(text, func(h Hit) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// scan fn 返回 false 的时候停止
+func (m *Matcher) scan(text string, fn func(h Hit) bool) {
+	if m == nil || len(m.root.children) == 0 {
+		return
+	}
+	// starts 记录参与匹配的每一个字符在原文中的起始位置
+	var starts []int
+	cur := m.root
+	for i := 0; i < len(text); {
+		c, size := utf8.DecodeRuneInString(text[i:])
+		r, ok := normalizeRune(c)
+		if !ok {
+			i += size
+			continue
+		}
+		starts = append(starts, i)
+		for cur != m.root && cur.children[r] == nil {
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		}
+		i += size
+		for _, o := range cur.outputs {
+			h := Hit{Word: o.word, Start: starts[len(starts)-o.length], End: i}
+			if !fn(h) {
+				return
+			}
+		}
+	}
+}
+
+// Mask 把命中的部分替换成 mask，昵称、评论这种可以直接打码展示的场景用
+func Mask(text string, hits []Hit, mask rune) string {
+	if len(hits) == 0 {
+		return text
+	}
+	masked := make([]bool, len(text))
+	for _, h := range hits {
+		for i := h.Start; i < h.End && i < len(text); i++ {
+			masked[i] = true
+		}
+	}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for i := 0; i < len(text); {
+		c, size := utf8.DecodeRuneInString(text[i:])
+		if masked[i] {
+			if _, ok := normalizeRune(c); ok {
+				sb.WriteRune(mask)
+			} else {
+				sb.WriteString(text[i : i+size])
+			}
+		} else {
+			sb.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	return sb.String()
+}
+
+func normalize(s string) []rune {
+	res := make([]rune, 0, len(s))
+	for _, c := range s {
+		if r, ok := normalizeRune(c); ok {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// normalizeRune 返回 false 说明这个字符不参与匹配
+func normalizeRune(c rune) (rune, bool) {
+	// 全角的 ASCII 字符转成半角
+	if c >= 0xFF01 && c <= 0xFF5E {
+		c -= 0xFEE0
+	}
+	if unicode.IsSpace(c) || unicode.IsPunct(c) || unicode.IsSymbol(c) ||
+		unicode.Is(unicode.Cf, c) {
+		return 0, false
+	}
+	return unicode.ToLower(c), true
+}
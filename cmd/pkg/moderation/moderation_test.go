@@ -0,0 +1,135 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]string{"赌博", "博彩", "he", "she", "hers", "  ", "Spam"})
+	testCases := []struct {
+		name     string
+		text     string
+		wantHits []Hit
+	}{
+		{
+			name: "没有命中",
+			text: "今天天气不错",
+		},
+		{
+			name: "重叠的敏感词",
+			text: "网络赌博彩票",
+			wantHits: []Hit{
+				{Word: "赌博", Start: 6, End: 12},
+				{Word: "博彩", Start: 9, End: 15},
+			},
+		},
+		{
+			name: "经典的 ushers",
+			text: "ushers",
+			wantHits: []Hit{
+				{Word: "she", Start: 1, End: 4},
+				{Word: "he", Start: 2, End: 4},
+				{Word: "hers", Start: 2, End: 6},
+			},
+		},
+		{
+			name: "跳过空白和标点",
+			text: "赌 *博",
+			wantHits: []Hit{
+				{Word: "赌博", Start: 0, End: 8},
+			},
+		},
+		{
+			name: "忽略大小写和全角",
+			text: "ＳＰＡＭ",
+			wantHits: []Hit{
+				{Word: "Spam", Start: 0, End: 12},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits := m.FindAll(tc.text)
+			assert.Equal(t, tc.wantHits, hits)
+			assert.Equal(t, len(tc.wantHits) > 0, m.Contains(tc.text))
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	m := NewMatcher([]string{"赌博"})
+	text := "不要赌-博"
+	assert.Equal(t, "不要*-*", Mask(text, m.FindAll(text), '*'))
+	assert.Equal(t, "正常昵称", Mask("正常昵称", nil, '*'))
+}
+
+func TestWordList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# 注释\n赌博\n\n"), 0644))
+	w := NewWordList(FileLoader(path), VerdictReview, logger.NewNoOpLogger())
+
+	// 还没有加载
+	res, err := w.Moderate(context.Background(), "赌博")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictPass, res.Verdict)
+
+	require.NoError(t, w.Reload(context.Background()))
+	res, err = w.Moderate(context.Background(), "赌博")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictReview, res.Verdict)
+	assert.Len(t, res.Hits, 1)
+
+	// 热更新
+	require.NoError(t, os.WriteFile(path, []byte("代开发票\n"), 0644))
+	require.NoError(t, w.Reload(context.Background()))
+	res, err = w.Moderate(context.Background(), "赌博")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictPass, res.Verdict)
+
+	// 加载失败的时候继续用旧的
+	require.NoError(t, os.Remove(path))
+	assert.Error(t, w.Reload(context.Background()))
+	assert.True(t, w.Matcher().Contains("代开发票"))
+}
+
+func TestPipeline(t *testing.T) {
+	words := NewWordList(func(ctx context.Context) ([]string, error) {
+		return []string{"赌博"}, nil
+	}, VerdictReview, logger.NewNoOpLogger())
+	require.NoError(t, words.Reload(context.Background()))
+	called := 0
+	classifier := ModeratorFunc(func(ctx context.Context, text string) (Result, error) {
+		called++
+		if text == "违法内容" {
+			return Result{Verdict: VerdictReject, Reasons: []string{"illegal"}}, nil
+		}
+		return Result{Verdict: VerdictPass}, nil
+	})
+
+	p := NewPipeline(words, classifier)
+	res, err := p.Moderate(context.Background(), "赌博")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictReview, res.Verdict)
+
+	res, err = p.Moderate(context.Background(), "违法内容")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictReject, res.Verdict)
+	assert.Equal(t, []string{"illegal"}, res.Reasons)
+	assert.Equal(t, 2, called)
+
+	// 直接拒绝之后不再继续
+	p = NewPipeline(classifier, ModeratorFunc(func(ctx context.Context, text string) (Result, error) {
+		return Result{}, errors.New("不应该被调用")
+	}))
+	res, err = p.Moderate(context.Background(), "违法内容")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictReject, res.Verdict)
+}
@@ -0,0 +1,32 @@
+package moderation
+
+import "context"
+
+// Pipeline 按照顺序执行每一个 Moderator，结论取最严重的那个
+// 一般把便宜的敏感词匹配放在前面，调用外部服务的分类器放在后面
+type Pipeline struct {
+	stages []Moderator
+}
+
+func NewPipeline(stages ...Moderator) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Moderate 一旦有 Moderator 给出了 VerdictReject 就不再继续执行
+// 任何一个 Moderator 出错都直接返回，要不要放行由调用者决定
+func (p *Pipeline) Moderate(ctx context.Context, text string) (Result, error) {
+	var res Result
+	for _, stage := range p.stages {
+		r, err := stage.Moderate(ctx, text)
+		if err != nil {
+			return res, err
+		}
+		res.Verdict = max(res.Verdict, r.Verdict)
+		res.Hits = append(res.Hits, r.Hits...)
+		res.Reasons = append(res.Reasons, r.Reasons...)
+		if res.Verdict == VerdictReject {
+			break
+		}
+	}
+	return res, nil
+}
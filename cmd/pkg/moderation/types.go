@@ -0,0 +1,53 @@
+// Package moderation 敏感内容审核
+//
+// 帖子、评论、昵称这些用户输入的内容都可以用：
+// 敏感词用 WordList 匹配，外部的分类器（比如说第三方的内容安全服务）实现 Moderator 接入，
+// 再用 Pipeline 串起来
+package moderation
+
+import "context"
+
+// Verdict 审核结论，越大越严重
+type Verdict uint8
+
+const (
+	// VerdictPass 通过
+	VerdictPass Verdict = iota
+	// VerdictReview 需要人工审核
+	VerdictReview
+	// VerdictReject 直接拒绝
+	VerdictReject
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictPass:
+		return "pass"
+	case VerdictReview:
+		return "review"
+	case VerdictReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+type Result struct {
+	Verdict Verdict
+	// Hits 命中的敏感词，外部分类器一般没有
+	Hits []Hit
+	// Reasons 给人看的原因，比如说分类器给出的标签
+	Reasons []string
+}
+
+// Moderator 审核的扩展点，外部的分类器实现这个接口就可以接入 Pipeline
+type Moderator interface {
+	Moderate(ctx context.Context, text string) (Result, error)
+}
+
+// ModeratorFunc 方便用一个方法来实现 Moderator
+type ModeratorFunc func(ctx context.Context, text string) (Result, error)
+
+func (f ModeratorFunc) Moderate(ctx context.Context, text string) (Result, error) {
+	return f(ctx, text)
+}
@@ -0,0 +1,96 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+)
+
+// Loader 加载敏感词，可以是文件，也可以是数据库或者配置中心
+type Loader func(ctx context.Context) ([]string, error)
+
+// FileLoader 从文件中加载敏感词，一行一个，空行和 # 开头的行会被忽略
+func FileLoader(path string) Loader {
+	return func(ctx context.Context) ([]string, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		var words []string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			words = append(words, line)
+		}
+		return words, scanner.Err()
+	}
+}
+
+// WordList 可以热更新的敏感词表
+// 每次重新加载都是构造一个新的 Matcher 整体替换，正在进行的匹配不受影响
+type WordList struct {
+	load    Loader
+	matcher atomic.Pointer[Matcher]
+	// onHit 命中敏感词的时候给出的结论
+	onHit Verdict
+	l     logger.LoggerV1
+}
+
+// NewWordList 还没有加载之前什么都不会命中，要调用 Reload 或者 Watch
+func NewWordList(load Loader, onHit Verdict, l logger.LoggerV1) *WordList {
+	w := &WordList{
+		load:  load,
+		onHit: onHit,
+		l:     l,
+	}
+	w.matcher.Store(NewMatcher(nil))
+	return w
+}
+
+// Reload 重新加载敏感词，失败的时候继续使用旧的词表
+func (w *WordList) Reload(ctx context.Context) error {
+	words, err := w.load(ctx)
+	if err != nil {
+		return err
+	}
+	w.matcher.Store(NewMatcher(words))
+	return nil
+}
+
+// Watch 每隔 interval 重新加载一次，直到 ctx 被取消
+// 一般是用 go 启动
+func (w *WordList) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Reload(ctx); err != nil {
+				w.l.Error("重新加载敏感词失败", logger.Error(err))
+			}
+		}
+	}
+}
+
+func (w *WordList) Matcher() *Matcher {
+	return w.matcher.Load()
+}
+
+func (w *WordList) Moderate(ctx context.Context, text string) (Result, error) {
+	hits := w.Matcher().FindAll(text)
+	if len(hits) == 0 {
+		return Result{Verdict: VerdictPass}, nil
+	}
+	return Result{Verdict: w.onHit, Hits: hits}, nil
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/saramax"
+)
+
+const topicArticleDeleteEvent = "article_delete_event"
+
+// ArticleDeleteEvent 帖子进了回收站
+type ArticleDeleteEvent struct {
+	Aid int64
+	Uid int64
+}
+
+// ArticleRemover 从热榜里面去掉帖子，和 LocalCache 一样定义在这里，避免 events 依赖 service
+type ArticleRemover interface {
+	RemoveArticle(ctx context.Context, id int64) error
+}
+
+type ArticleDeleteConsumer struct {
+	client  sarama.Client
+	l       logger.LoggerV1
+	remover ArticleRemover
+}
+
+func NewArticleDeleteConsumer(client sarama.Client,
+	l logger.LoggerV1,
+	remover ArticleRemover) *ArticleDeleteConsumer {
+	return &ArticleDeleteConsumer{
+		client:  client,
+		l:       l,
+		remover: remover,
+	}
+}
+
+func (r *ArticleDeleteConsumer) Start() error {
+	// 只需要一个节点处理，别的节点的本地缓存通过 topicUpdateEvent 更新
+	cg, err := sarama.NewConsumerGroupFromClient("rankingArticleDelete",
+		r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicArticleDeleteEvent},
+			saramax.NewHandler[ArticleDeleteEvent](r.l, r.Consume))
+		if err != nil {
+			r.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (r *ArticleDeleteConsumer) Consume(msg *sarama.ConsumerMessage,
+	evt ArticleDeleteEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return r.remover.RemoveArticle(ctx, evt.Aid)
+}
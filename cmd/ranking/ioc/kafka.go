@@ -8,8 +8,10 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/XD/ScholarNet/cmd/pkg/logger"
+	"github.com/XD/ScholarNet/cmd/pkg/saramax"
 	"github.com/XD/ScholarNet/cmd/ranking/events"
 	"github.com/XD/ScholarNet/cmd/ranking/repository/cache"
+	"github.com/XD/ScholarNet/cmd/ranking/service"
 	"github.com/spf13/viper"
 )
 
@@ -63,6 +65,19 @@ func InitKafkaConsumer(
 	return consumer
 }
 
+func InitArticleDeleteConsumer(client sarama.Client,
+	l logger.LoggerV1,
+	svc service.RankingService) *events.ArticleDeleteConsumer {
+	return events.NewArticleDeleteConsumer(client, l, svc)
+}
+
+// NewConsumers 跟着 App 一起启动的 Consumer
+func NewConsumers(articleDelete *events.ArticleDeleteConsumer) []saramax.Consumer {
+	return []saramax.Consumer{
+		articleDelete,
+	}
+}
+
 // 供依赖注入使用：构建带 producer 和 logger 的本地缓存
 func InitRankingLocalCache(p events.Producer, l logger.LoggerV1) *cache.RankingLocalCache {
 	return cache.NewRankingLocalCache(p, l)
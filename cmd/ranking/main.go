@@ -8,6 +8,12 @@ import (
 func main() {
 	initViperV2Watch()
 	app := Init()
+	for _, c := range app.Consumers {
+		err := c.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
 	app.Cron.Start()
 }
 
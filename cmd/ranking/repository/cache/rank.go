@@ -136,6 +136,21 @@ func (r *RedisRankingCache) Set(ctx context.Context, arts []domain.Article) erro
 //	return err
 //}
 
+// Remove 成员是序列化之后的帖子，只能全部取出来找到对应的那一个再删
+func (r *RedisRankingCache) Remove(ctx context.Context, id int64) error {
+	vals, err := r.client.ZRange(ctx, r.key, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	for _, v := range vals {
+		var art domain.Article
+		if json.Unmarshal([]byte(v), &art) == nil && art.Id == id {
+			return r.client.ZRem(ctx, r.key, v).Err()
+		}
+	}
+	return nil
+}
+
 func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.Article, error) {
 	// 取前100名
 	vals, err := r.client.ZRevRange(ctx, r.key, 0, 99).Result()
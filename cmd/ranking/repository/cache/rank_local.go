@@ -63,6 +63,27 @@ func (r *RankingLocalCache) Set(_ context.Context, arts []domain.Article) error
 	return nil
 }
 
+// Remove 帖子删除之后从热榜里面去掉，和 Set 一样通知别的节点
+func (r *RankingLocalCache) Remove(ctx context.Context, id int64) error {
+	arts := r.topN.Load()
+	res := make([]domain.Article, 0, len(arts))
+	for _, art := range arts {
+		if art.Id != id {
+			res = append(res, art)
+		}
+	}
+	if len(res) == len(arts) {
+		return nil
+	}
+	if len(res) == 0 {
+		// 热榜只剩这一篇了，不能 Set 空的，直接让本地缓存失效
+		r.topN.Store(res)
+		r.hash.Store("")
+		return nil
+	}
+	return r.Set(ctx, res)
+}
+
 func (r *RankingLocalCache) Get(_ context.Context) ([]domain.Article, error) {
 	arts := r.topN.Load()
 	if len(arts) == 0 || r.ddl.Load().Before(time.Now()) {
@@ -12,6 +12,8 @@ type RankingRepository interface {
 	GetTopN(ctx context.Context) ([]domain.Article, error)
 	RefreshLocalCache(ctx context.Context) error
 	RefreshLocalCacheV1(ctx context.Context) error
+	// RemoveArticle 从热榜里面去掉，不会重新计算，空出来的位置等下一次计算补上
+	RemoveArticle(ctx context.Context, id int64) error
 }
 
 type CachedRankingRepository struct {
@@ -54,6 +56,14 @@ func (c *CachedRankingRepository) GetTopN(ctx context.Context) ([]domain.Article
 	return arts, err
 }
 
+func (c *CachedRankingRepository) RemoveArticle(ctx context.Context, id int64) error {
+	err := c.redisCache.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.localCache.Remove(ctx, id)
+}
+
 // 定时任务用的
 func (c *CachedRankingRepository) RefreshLocalCache(ctx context.Context) error {
 	arts, err := c.redisCache.Get(ctx)
@@ -53,6 +53,20 @@ func (mr *MockRankingServiceMockRecorder) RankTopN(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RankTopN", reflect.TypeOf((*MockRankingService)(nil).RankTopN), ctx)
 }
 
+// RemoveArticle mocks base method.
+func (m *MockRankingService) RemoveArticle(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveArticle", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveArticle indicates an expected call of RemoveArticle.
+func (mr *MockRankingServiceMockRecorder) RemoveArticle(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveArticle", reflect.TypeOf((*MockRankingService)(nil).RemoveArticle), ctx, id)
+}
+
 // TopN mocks base method.
 func (m *MockRankingService) TopN(ctx context.Context) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -19,6 +19,8 @@ type RankingService interface {
 	RankTopN(ctx context.Context) error
 	// 查询 TopN
 	TopN(ctx context.Context) ([]domain.Article, error)
+	// RemoveArticle 帖子删除之后马上从热榜里面去掉，不等下一次计算
+	RemoveArticle(ctx context.Context, id int64) error
 }
 
 type BatchRankingService struct {
@@ -28,13 +30,20 @@ type BatchRankingService struct {
 	batchSize int
 	n         int
 	// scoreFunc 不能返回负数
-	scoreFunc func(t time.Time, likeCnt int64) float64
+	scoreFunc func(t time.Time, likeCnt, citeCnt int64) float64
 }
 
+// citeWeight 一次被引用相当于多少个赞，被别的帖子引用要比点赞难得多
+const citeWeight = 5
+
 func (a *BatchRankingService) TopN(ctx context.Context) ([]domain.Article, error) {
 	return a.repo.GetTopN(ctx)
 }
 
+func (a *BatchRankingService) RemoveArticle(ctx context.Context, id int64) error {
+	return a.repo.RemoveArticle(ctx, id)
+}
+
 func NewBatchRankingService(
 	artSvc articlev1.ArticleServiceClient,
 	intrSvc intrv1.InteractiveServiceClient,
@@ -45,9 +54,9 @@ func NewBatchRankingService(
 		repo:      repo,
 		batchSize: 100,
 		n:         10000,
-		scoreFunc: func(t time.Time, likeCnt int64) float64 {
+		scoreFunc: func(t time.Time, likeCnt, citeCnt int64) float64 {
 			sec := time.Since(t).Seconds()
-			return float64(likeCnt-1) / math.Pow(float64(sec+2), 1.5)
+			return float64(likeCnt-1+citeWeight*citeCnt) / math.Pow(float64(sec+2), 1.5)
 		},
 	}
 }
@@ -57,6 +66,10 @@ func (s *BatchRankingService) RankTopN(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	arts, err = s.dropUnpublished(ctx, arts)
+	if err != nil {
+		return err
+	}
 	// 存缓存
 	return s.repo.ReplaceTopN(ctx, arts)
 }
@@ -65,7 +78,8 @@ func (s *BatchRankingService) RankTopN(ctx context.Context) error {
 func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, error) {
 	// 只取七天内的数据，超过七天的可以认为绝对不可能成为热榜
 	now := time.Now()
-	offset := 0
+	// 用游标翻页，扫描期间有帖子更新也不会重复或者遗漏
+	cursor := ""
 	type Score struct {
 		art   domain.Article
 		score float64
@@ -83,10 +97,10 @@ func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, e
 		})
 
 	for {
-		// 拿一批
+		// 拿一批，不传 uid，所以只有公开的帖子才会进热榜
 		arts, err := s.artSvc.ListPub(ctx, &articlev1.ListPubRequest{
 			StartTime: timestamppb.New(now),
-			Offset:    int32(offset),
+			Cursor:    cursor,
 			Limit:     int32(s.batchSize),
 		})
 		if err != nil {
@@ -97,6 +111,14 @@ func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, e
 		for _, art := range arts.Articles {
 			domianArts = append(domianArts, articleToDomain(art))
 		}
+		if len(domianArts) == 0 {
+			// 过滤掉看不了的之后可能一篇都不剩，有下一页就接着翻
+			if arts.NextCursor == "" {
+				break
+			}
+			cursor = arts.NextCursor
+			continue
+		}
 		ids := slice.Map[domain.Article, int64](domianArts, func(idx int, src domain.Article) int64 {
 			return src.Id
 		})
@@ -115,7 +137,7 @@ func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, e
 		// 并决定是否要放入优先队列，即topN
 		for _, art := range domianArts {
 			intr := resp.Intrs[art.Id]
-			score := s.scoreFunc(art.Utime, intr.LikeCnt)
+			score := s.scoreFunc(art.Utime, intr.GetLikeCnt(), intr.GetCiteCnt())
 			art.Score = score
 			// 我要考虑，我这个 score 在不在前一百名
 			// 两种情况：1、队列未满，直接入 2、队列满了，跟堆顶最小的比
@@ -136,13 +158,13 @@ func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, e
 			}
 		}
 		// 一批已经处理完了，问题来了，我要不要进入下一批？我怎么知道还有没有？
-		if len(domianArts) < s.batchSize || now.Sub(domianArts[len(domianArts)-1].Utime).Hours() > 7*24 {
+		if arts.NextCursor == "" || now.Sub(domianArts[len(domianArts)-1].Utime).Hours() > 7*24 {
 			// 我这一批都没取够，我当然没有下一批了
 			// 又或者已经取到了七天之前的数据了，说明可以中断了
 			break
 		}
 		// 下一批
-		offset += len(domianArts)
+		cursor = arts.NextCursor
 	}
 	res := make([]domain.Article, s.n)
 	for i := s.n - 1; i >= 0; i-- {
@@ -156,6 +178,41 @@ func (s *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, e
 	return res, nil
 }
 
+// dropUnpublished 扫描要花一段时间，期间被撤回或者删除的帖子不能上热榜
+// 不够 n 个的时候前面会有空的位置，也一起去掉
+func (s *BatchRankingService) dropUnpublished(ctx context.Context,
+	arts []domain.Article) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(arts))
+	for start := 0; start < len(arts); start += s.batchSize {
+		batch := arts[start:min(start+s.batchSize, len(arts))]
+		ids := make([]int64, 0, len(batch))
+		for _, art := range batch {
+			if art.Id > 0 {
+				ids = append(ids, art.Id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		resp, err := s.artSvc.BatchGetPublished(ctx, &articlev1.BatchGetPublishedRequest{
+			Ids: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		missing := make(map[int64]struct{}, len(resp.GetMissingIds()))
+		for _, id := range resp.GetMissingIds() {
+			missing[id] = struct{}{}
+		}
+		for _, art := range batch {
+			if _, ok := missing[art.Id]; art.Id > 0 && !ok {
+				res = append(res, art)
+			}
+		}
+	}
+	return res, nil
+}
+
 func articleToDomain(article *articlev1.Article) domain.Article {
 	domainArticle := domain.Article{}
 	if article != nil {
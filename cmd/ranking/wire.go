@@ -15,6 +15,7 @@ import (
 )
 
 var serviceProviderSet = wire.NewSet(
+	ioc.InitRankingLocalCache,
 	cache.NewRedisRankingCache,
 	repository.NewCachedRankingRepository,
 	service.NewBatchRankingService,
@@ -47,7 +48,9 @@ func Init() *wego.App {
 		cronJob,
 		grpc.NewRankingServiceServer,
 		ioc.InitGRPCxServer,
-		wire.Struct(new(wego.App), "GRPCServer", "Cron"),
+		ioc.InitArticleDeleteConsumer,
+		ioc.NewConsumers,
+		wire.Struct(new(wego.App), "GRPCServer", "Cron", "Consumers"),
 	)
 	return new(wego.App)
 }
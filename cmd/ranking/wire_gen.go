@@ -24,27 +24,34 @@ func Init() *wego.App {
 	interactiveServiceClient := ioc.InitInterActiveRpcClient()
 	cmdable := ioc.InitRedis()
 	redisRankingCache := cache.NewRedisRankingCache(cmdable)
-	rankingLocalCache := cache.NewRankingLocalCache()
+	client := ioc.InitKafkaClient()
+	producer := ioc.InitKafkaProducer(client)
+	loggerV1 := ioc.InitLogger()
+	rankingLocalCache := ioc.InitRankingLocalCache(producer, loggerV1)
 	rankingRepository := repository.NewCachedRankingRepository(redisRankingCache, rankingLocalCache)
 	rankingService := service.NewBatchRankingService(articleServiceClient, interactiveServiceClient, rankingRepository)
 	rankingServiceServer := grpc.NewRankingServiceServer(rankingService)
 	server := ioc.InitGRPCxServer(rankingServiceServer)
-	loggerV1 := ioc.InitLogger()
-	client := rlock.NewClient(cmdable)
-	rankingJob := ioc.InitRankingJob(rankingService, client, loggerV1)
+	rlockClient := rlock.NewClient(cmdable)
+	rankingJob := ioc.InitRankingJob(rankingService, rlockClient, loggerV1)
 	localCacheRefreshJob := ioc.InitLocalCacheRefreshJob(rankingRepository, loggerV1)
 	cron := ioc.InitJobs(loggerV1, rankingJob, localCacheRefreshJob)
+	articleDeleteConsumer := ioc.InitArticleDeleteConsumer(client, loggerV1, rankingService)
+	v := ioc.NewConsumers(articleDeleteConsumer)
 	app := &wego.App{
 		GRPCServer: server,
 		Cron:       cron,
+		Consumers:  v,
 	}
 	return app
 }
 
 // wire.go:
 
-var serviceProviderSet = wire.NewSet(cache.NewRankingLocalCache, cache.NewRedisRankingCache, repository.NewCachedRankingRepository, service.NewBatchRankingService)
+var serviceProviderSet = wire.NewSet(ioc.InitRankingLocalCache, cache.NewRedisRankingCache, repository.NewCachedRankingRepository, service.NewBatchRankingService)
 
 var thirdProvider = wire.NewSet(ioc.InitRedis, ioc.InitLogger, ioc.InitInterActiveRpcClient, ioc.InitArticleRpcClient, rlock.NewClient)
 
+var kafkaProvider = wire.NewSet(ioc.InitKafkaClient, ioc.InitKafkaProducer)
+
 var cronJob = wire.NewSet(ioc.InitRankingJob, ioc.InitLocalCacheRefreshJob, ioc.InitJobs)
@@ -67,3 +67,51 @@ func (a *ArticleConsumer) toDomain(article ArticleEvent) domain.Article {
 		Content: article.Content,
 	}
 }
+
+const topicDeleteArticle = "article_delete_event"
+
+// ArticleDeleteEvent 帖子进了回收站，从索引里面删掉
+type ArticleDeleteEvent struct {
+	Aid int64
+	Uid int64
+}
+
+type ArticleDeleteConsumer struct {
+	syncSvc service.SyncService
+	client  sarama.Client
+	l       logger.LoggerV1
+}
+
+func NewArticleDeleteConsumer(client sarama.Client,
+	l logger.LoggerV1,
+	svc service.SyncService) *ArticleDeleteConsumer {
+	return &ArticleDeleteConsumer{
+		syncSvc: svc,
+		client:  client,
+		l:       l,
+	}
+}
+
+func (a *ArticleDeleteConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("search_delete_article",
+		a.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicDeleteArticle},
+			saramax.NewHandler[ArticleDeleteEvent](a.l, a.Consume))
+		if err != nil {
+			a.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (a *ArticleDeleteConsumer) Consume(sg *sarama.ConsumerMessage,
+	evt ArticleDeleteEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return a.syncSvc.DeleteArticle(ctx, evt.Aid)
+}
@@ -26,11 +26,13 @@ func InitKafka() sarama.Client {
 
 // NewConsumers 面临的问题依旧是所有的 Consumer 在这里注册一下
 func NewConsumers(articleConsumer *events.ArticleConsumer,
+	articleDeleteConsumer *events.ArticleDeleteConsumer,
 	userConsumer *events.UserConsumer,
 	anyConsumer *events.AnyConsumer,
 ) []events.Consumer {
 	return []events.Consumer{
 		articleConsumer,
+		articleDeleteConsumer,
 		userConsumer,
 		anyConsumer,
 	}
@@ -26,6 +26,10 @@ func (repo *articleRepository) InputArticle(ctx context.Context, art domain.Arti
 	})
 }
 
+func (repo *articleRepository) DeleteArticle(ctx context.Context, id int64) error {
+	return repo.dao.DeleteArticle(ctx, id)
+}
+
 func (repo *articleRepository) SearchArticle(ctx context.Context, uid int64, keywords []string) ([]domain.Article, error) {
 	// keywords 命中文章的标签，传 uid 才知道哪些标签
 	ids, err := repo.tags.Search(ctx, uid, "article", keywords)
@@ -21,6 +21,15 @@ func (a *articleElasticDAO) InputArticle(ctx context.Context, art Article) error
 	return err
 }
 
+func (a *articleElasticDAO) DeleteArticle(ctx context.Context, id int64) error {
+	_, err := a.client.Delete().Index(ArticleIndexName).
+		Id(strconv.FormatInt(id, 10)).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (a *articleElasticDAO) Search(ctx context.Context, ids []int64, keywords []string) ([]Article, error) {
 	queryString := strings.Join(keywords, " ")
 	// 文章，标题或者内容任何一个匹配上
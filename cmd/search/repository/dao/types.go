@@ -15,6 +15,8 @@ type UserDAO interface {
 
 type ArticleDAO interface {
 	InputArticle(ctx context.Context, art Article) error
+	// DeleteArticle 文档本来就不存在也算成功
+	DeleteArticle(ctx context.Context, id int64) error
 	Search(ctx context.Context, ids []int64, keywords []string) ([]Article, error)
 }
 
@@ -12,6 +12,7 @@ type UserRepository interface {
 
 type ArticleRepository interface {
 	InputArticle(ctx context.Context, msg domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
 	SearchArticle(ctx context.Context, uid int64, keywords []string) ([]domain.Article, error)
 }
 
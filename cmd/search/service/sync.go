@@ -9,6 +9,8 @@ import (
 // 实际就是 写
 type SyncService interface {
 	InputArticle(ctx context.Context, article domain.Article) error
+	// DeleteArticle 帖子删除之后就不能再被搜到了
+	DeleteArticle(ctx context.Context, id int64) error
 	InputUser(ctx context.Context, user domain.User) error
 	// ...
 	// 通用的，考虑一些通用元素
@@ -31,6 +33,10 @@ func (s *syncService) InputArticle(ctx context.Context, article domain.Article)
 	return s.articleRepo.InputArticle(ctx, article)
 }
 
+func (s *syncService) DeleteArticle(ctx context.Context, id int64) error {
+	return s.articleRepo.DeleteArticle(ctx, id)
+}
+
 func (s *syncService) InputUser(ctx context.Context, user domain.User) error {
 	return s.userRepo.InputUser(ctx, user)
 }
@@ -41,6 +41,7 @@ func Init() *App {
 		ioc.InitGRPCxServer,
 		events.NewUserConsumer,
 		events.NewArticleConsumer,
+		events.NewArticleDeleteConsumer,
 		events.NewAnyConsumer,
 		ioc.NewConsumers,
 		wire.Struct(new(App), "*"),
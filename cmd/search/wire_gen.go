@@ -36,9 +36,10 @@ func Init() *App {
 	server := ioc.InitGRPCxServer(syncServiceServer, searchServiceServer, clientv3Client, loggerV1)
 	saramaClient := ioc.InitKafka()
 	articleConsumer := events.NewArticleConsumer(saramaClient, loggerV1, syncService)
+	articleDeleteConsumer := events.NewArticleDeleteConsumer(saramaClient, loggerV1, syncService)
 	userConsumer := events.NewUserConsumer(saramaClient, loggerV1, syncService)
 	anyConsumer := events.NewAnyConsumer(saramaClient, loggerV1, syncService)
-	v := ioc.NewConsumers(articleConsumer, userConsumer, anyConsumer)
+	v := ioc.NewConsumers(articleConsumer, articleDeleteConsumer, userConsumer, anyConsumer)
 	app := &App{
 		server:    server,
 		consumers: v,
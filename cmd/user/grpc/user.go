@@ -50,6 +50,21 @@ func (u *UserServiceServer) Profile(ctx context.Context, request *userv1.Profile
 	}, err
 }
 
+func (u *UserServiceServer) BatchProfile(ctx context.Context, request *userv1.BatchProfileRequest) (*userv1.BatchProfileResponse, error) {
+	users, err := u.service.BatchProfile(ctx, request.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]*userv1.User, len(users))
+	for _, user := range users {
+		vUser := convertToV(user)
+		// 批量查询一般是用来展示的，不需要密码
+		vUser.Password = ""
+		res[user.Id] = vUser
+	}
+	return &userv1.BatchProfileResponse{Users: res}, nil
+}
+
 func (u *UserServiceServer) UpdateNonSensitiveInfo(ctx context.Context, request *userv1.UpdateNonSensitiveInfoRequest) (*userv1.UpdateNonSensitiveInfoResponse, error) {
 	err := u.service.UpdateNonSensitiveInfo(ctx, convertToDomain(request.GetUser()))
 	return &userv1.UpdateNonSensitiveInfoResponse{}, err
@@ -23,6 +23,8 @@ type UserDAO interface {
 	FindByEmail(ctx context.Context, email string) (User, error)
 	FindByWechat(ctx context.Context, openId string) (User, error)
 	FindById(ctx context.Context, id int64) (User, error)
+	// FindByIds 批量查询，不存在的 id 会被忽略，不保证顺序
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 }
 
 type GORMUserDAO struct {
@@ -82,6 +84,15 @@ func (ud *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	return u, err
 }
 
+func (ud *GORMUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var res []User
+	if len(ids) == 0 {
+		return res, nil
+	}
+	err := ud.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
+	return res, err
+}
+
 type User struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// 设置为唯一索引
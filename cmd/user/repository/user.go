@@ -21,6 +21,8 @@ type UserRepository interface {
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindById(ctx context.Context, id int64) (domain.User, error)
+	// FindByIds 批量查询，不存在的 id 会被忽略，不保证顺序
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 	// FindByWechat 暂时可以认为按照 openId来查询
 	// 将来可能需要按照 unionId 来查询
 	FindByWechat(ctx context.Context, openId string) (domain.User, error)
@@ -112,6 +114,20 @@ func (ur *CachedUserRepository) FindById(ctx context.Context,
 	return u, nil
 }
 
+// FindByIds 批量查询直接走数据库，一条 SQL 比挨个查缓存还要快
+func (ur *CachedUserRepository) FindByIds(ctx context.Context,
+	ids []int64) ([]domain.User, error) {
+	ues, err := ur.dao.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.User, 0, len(ues))
+	for _, ue := range ues {
+		res = append(res, ur.entityToDomain(ue))
+	}
+	return res, nil
+}
+
 func (ur *CachedUserRepository) FindByIdV1(ctx context.Context,
 	id int64) (domain.User, error) {
 	u, err := ur.cache.Get(ctx, id)
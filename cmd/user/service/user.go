@@ -20,6 +20,8 @@ type UserService interface {
 	FindOrCreate(ctx context.Context, phone string) (domain.User, error)
 	Login(ctx context.Context, email, password string) (domain.User, error)
 	Profile(ctx context.Context, id int64) (domain.User, error)
+	// BatchProfile 批量查询用户信息，不存在的用户会被忽略
+	BatchProfile(ctx context.Context, ids []int64) ([]domain.User, error)
 	// UpdateNonSensitiveInfo 更新非敏感数据
 	// 你可以在这里进一步补充究竟哪些数据会被更新
 	UpdateNonSensitiveInfo(ctx context.Context, user domain.User) error
@@ -133,3 +135,8 @@ func (svc *userService) Profile(ctx context.Context,
 	// 有些人的系统比较复杂，有一个 GUID（global unique ID）
 	return svc.repo.FindById(ctx, id)
 }
+
+func (svc *userService) BatchProfile(ctx context.Context,
+	ids []int64) ([]domain.User, error) {
+	return svc.repo.FindByIds(ctx, ids)
+}
@@ -24,6 +24,7 @@ import (
 
 var interactiveSvcProvider = wire.NewSet(
 	service2.NewInteractiveService,
+	ioc.InitIntrReactions,
 	repository2.NewCachedInteractiveRepository,
 	dao2.NewGORMInteractiveDAO,
 	cache2.NewRedisInteractiveCache,
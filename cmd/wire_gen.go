@@ -55,7 +55,8 @@ func InitWebServer() *App {
 	interactiveCache := cache2.NewRedisInteractiveCache(cmdable)
 	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
 	interactiveRepository := repository2.NewCachedInteractiveRepository(interactiveCache, interactiveDAO, loggerV1)
-	interactiveService := service2.NewInteractiveService(interactiveRepository, loggerV1)
+	reactions := ioc.InitIntrReactions()
+	interactiveService := service2.NewInteractiveService(interactiveRepository, reactions, loggerV1)
 	interactiveServiceClient := ioc.InitIntrGRPCClient(interactiveService)
 	articleHandler := web.NewArticleHandler(articleService, loggerV1, interactiveServiceClient)
 	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
@@ -78,6 +79,6 @@ func InitWebServer() *App {
 
 // wire.go:
 
-var interactiveSvcProvider = wire.NewSet(service2.NewInteractiveService, repository2.NewCachedInteractiveRepository, dao2.NewGORMInteractiveDAO, cache2.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service2.NewInteractiveService, ioc.InitIntrReactions, repository2.NewCachedInteractiveRepository, dao2.NewGORMInteractiveDAO, cache2.NewRedisInteractiveCache)
 
 var rankingSvcProvider = wire.NewSet(service.NewBatchRankingService, repository.NewCachedRankingRepository, cache.NewRankingRedisCache, cache.NewRankingLocalCache)